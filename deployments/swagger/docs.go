@@ -20,6 +20,92 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/private/audit": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a page of the audit log, most recent first. Each entry records who created, updated, deleted\nor overwrote the associations of an entity, with the changed fields before and after the operation.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Find audit entries",
+                "operationId": "find-all-audit-entries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "maximum number of entries, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "wall",
+                            "block",
+                            "program",
+                            "episode",
+                            "media",
+                            "tag",
+                            "category"
+                        ],
+                        "type": "string",
+                        "description": "only keep entries about this kind of entity",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep entries about the entity with this UUID",
+                        "name": "entityID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep entries recorded for this actor",
+                        "name": "actor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_AuditEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
         "/private/blocks": {
             "get": {
                 "security": [
@@ -28,7 +114,7 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find all blocks",
+                "description": "Find a page of blocks, filtered and sorted by the query parameters",
                 "produces": [
                     "application/json"
                 ],
@@ -37,14 +123,63 @@ const docTemplate = `{
                 ],
                 "summary": "Find all blocks",
                 "operationId": "find-all-blocks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "maximum number of blocks, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of blocks to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt",
+                            "kind",
+                            "-kind"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep blocks whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep blocks created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep blocks created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.BlockResponse"
-                            }
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_BlockResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -89,6 +224,105 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/blocks/trash": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a page of the blocks in the trash, filtered and sorted by the query parameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocks"
+                ],
+                "summary": "Find deleted blocks",
+                "operationId": "find-deleted-blocks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "maximum number of blocks, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of blocks to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt",
+                            "kind",
+                            "-kind",
+                            "deletedAt",
+                            "-deletedAt"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, -deletedAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep blocks whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep blocks created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep blocks created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_BlockResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -131,6 +365,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg.BlockResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -180,6 +420,24 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -189,7 +447,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a block",
+                "description": "Move a block to the trash, refused while other entities still reference it unless cascade is set",
                 "produces": [
                     "application/json"
                 ],
@@ -205,6 +463,12 @@ const docTemplate = `{
                         "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "removes the block from the walls it is placed on instead of refusing the deletion",
+                        "name": "cascade",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -214,6 +478,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the dependents of the block",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -310,46 +586,22 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/pkg.ErrorJSON"
                         }
-                    }
-                }
-            }
-        },
-        "/private/categories": {
-            "get": {
-                "security": [
-                    {
-                        "Bearer-APIKey": [],
-                        "Bearer-JWT": []
-                    }
-                ],
-                "description": "Find all categories",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "categories"
-                ],
-                "summary": "Find all categories",
-                "operationId": "find-all-categories",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.CategoryResponse"
-                            }
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -359,31 +611,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/private/blocks/{uuid}/programs/reorder": {
+            "patch": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Create a new category",
+                "description": "Reorder the programs of a block, identified by their program ID, renumbering their positions from 1",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "blocks"
                 ],
-                "summary": "Create a new category",
-                "operationId": "create-category",
+                "summary": "Reorder programs of a block",
+                "operationId": "reorder-block-programs",
                 "parameters": [
                     {
-                        "description": "create request",
+                        "type": "string",
+                        "description": "UUID of the block",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new order, as a list of IDs or a move between two positions",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pkg.CreateCategoryRequestJSON"
+                            "$ref": "#/definitions/pkg.ReorderRequestJSON"
                         }
                     }
                 ],
@@ -394,6 +655,24 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request, when the IDs do not list every program exactly once or a position is out of range",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -403,37 +682,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/categories/{uuid}": {
-            "get": {
+        "/private/blocks/{uuid}/programs/{programUUID}": {
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find a category",
+                "description": "Append a program to a block, after its last program; adding a program the block already holds does nothing",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "blocks"
                 ],
-                "summary": "Find a category",
-                "operationId": "find-category",
+                "summary": "Add a program to a block",
+                "operationId": "add-block-program",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "uuid",
+                        "description": "UUID of the block",
                         "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the program",
+                        "name": "programUUID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "ok",
                         "schema": {
-                            "$ref": "#/definitions/pkg.CategoryResponse"
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, when the block or the program does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -444,38 +736,36 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Update category",
+                "description": "Remove a program from a block, renumbering the positions of the remaining programs from 1; removing a program the block does not hold does nothing",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "blocks"
                 ],
-                "summary": "Update category",
-                "operationId": "update-category",
+                "summary": "Remove a program from a block",
+                "operationId": "remove-block-program",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "uuid",
+                        "description": "UUID of the block",
                         "name": "uuid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/pkg.UpdateCategoryRequestJSON"
-                        }
+                        "type": "string",
+                        "description": "UUID of the program",
+                        "name": "programUUID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -485,6 +775,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found, when the block does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -492,23 +788,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/private/blocks/{uuid}/restore": {
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Delete a category",
+                "description": "Restore a block from the trash",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "blocks"
                 ],
-                "summary": "Delete a category",
-                "operationId": "delete-category",
+                "summary": "Restore a block",
+                "operationId": "restore-block",
                 "parameters": [
                     {
                         "type": "string",
@@ -520,11 +818,23 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "deleted",
+                        "description": "restored",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -534,7 +844,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/categories/{uuid}/programs": {
+        "/private/categories": {
             "get": {
                 "security": [
                     {
@@ -542,32 +852,70 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find all category's programs",
+                "description": "Find a page of categories, filtered and sorted by the query parameters",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "categories"
                 ],
-                "summary": "Find all category's programs",
-                "operationId": "find-category-programs",
+                "summary": "Find all categories",
+                "operationId": "find-all-categories",
                 "parameters": [
                     {
+                        "type": "integer",
+                        "description": "maximum number of categories, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of categories to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt"
+                        ],
                         "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "field to sort on, prefixed with - for descending order, createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep categories whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep categories created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep categories created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.ProgramResponse"
-                            }
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -577,33 +925,51 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/private/episodes": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find all episodes",
+                "description": "Create a new category",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "episodes"
+                    "categories"
+                ],
+                "summary": "Create a new category",
+                "operationId": "create-category",
+                "parameters": [
+                    {
+                        "description": "create request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.CreateCategoryRequestJSON"
+                        }
+                    }
                 ],
-                "summary": "Find all episodes",
-                "operationId": "find-all-episodes",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "ok",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.EpisodeResponse"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -613,39 +979,118 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/private/categories/trash": {
+            "get": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Create a new episode",
+                "description": "Find a page of the categories in the trash, filtered and sorted by the query parameters",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "episodes"
+                    "categories"
                 ],
-                "summary": "Create a new episode",
-                "operationId": "create-episode",
+                "summary": "Find deleted categories",
+                "operationId": "find-deleted-categories",
                 "parameters": [
                     {
-                        "description": "create request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "maximum number of categories, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of categories to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt",
+                            "deletedAt",
+                            "-deletedAt"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, -deletedAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep categories whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep categories created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep categories created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg.CreateEpisodeRequestJSON"
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     }
+                }
+            }
+        },
+        "/private/categories/tree": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
                 ],
+                "description": "Find the live categories nested under their parent, root categories first and ordered by name at every level",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Find the category tree",
+                "operationId": "find-category-tree",
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.CategoryTreeResponse"
+                            }
                         }
                     },
                     "500": {
@@ -657,7 +1102,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/episodes/{uuid}": {
+        "/private/categories/{uuid}": {
             "get": {
                 "security": [
                     {
@@ -665,15 +1110,15 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find an episode",
+                "description": "Find a category",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "episodes"
+                    "categories"
                 ],
-                "summary": "Find an episode",
-                "operationId": "find-episode",
+                "summary": "Find a category",
+                "operationId": "find-category",
                 "parameters": [
                     {
                         "type": "string",
@@ -687,7 +1132,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg.EpisodeResponse"
+                            "$ref": "#/definitions/pkg.CategoryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -705,15 +1156,15 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Update episode",
+                "description": "Update category",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "episodes"
+                    "categories"
                 ],
-                "summary": "Update episode",
-                "operationId": "update-episode",
+                "summary": "Update category",
+                "operationId": "update-category",
                 "parameters": [
                     {
                         "type": "string",
@@ -728,7 +1179,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pkg.UpdateEpisodeRequestJSON"
+                            "$ref": "#/definitions/pkg.UpdateCategoryRequestJSON"
                         }
                     }
                 ],
@@ -739,6 +1190,24 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -754,15 +1223,15 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Delete an episode",
+                "description": "Move a category to the trash, refused while other entities still reference it unless cascade is set",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "episodes"
+                    "categories"
                 ],
-                "summary": "Delete an episode",
-                "operationId": "delete-episode",
+                "summary": "Delete a category",
+                "operationId": "delete-category",
                 "parameters": [
                     {
                         "type": "string",
@@ -770,6 +1239,12 @@ const docTemplate = `{
                         "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "detaches the category from the programs it is attached to instead of refusing the deletion",
+                        "name": "cascade",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -779,6 +1254,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the dependents of the category",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -788,27 +1275,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/medias": {
+        "/private/categories/{uuid}/ancestors": {
             "get": {
-                "description": "Find all medias",
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find the ancestors of a category, from its root category down to its parent",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "medias"
+                    "categories"
+                ],
+                "summary": "Find a category's ancestors",
+                "operationId": "find-category-ancestors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Find all medias",
-                "operationId": "find-all-medias",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/pkg.MediaResponse"
+                                "$ref": "#/definitions/pkg.CategoryResponse"
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -816,33 +1324,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new media",
-                "produces": [
+            }
+        },
+        "/private/categories/{uuid}/descendants": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find the subcategories of a category at any depth, nested under their parent and ordered by name",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "medias"
+                    "categories"
                 ],
-                "summary": "Create a new media",
-                "operationId": "create-media",
+                "summary": "Find a category's descendants",
+                "operationId": "find-category-descendants",
                 "parameters": [
                     {
-                        "description": "create request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/pkg.CreateMediaRequestJSON"
-                        }
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.CategoryTreeResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -854,17 +1377,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/medias/{uuid}": {
-            "get": {
-                "description": "Find a media",
+        "/private/categories/{uuid}/move": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Move a category, along with its subcategories, under another parent, or to the root when the parent is empty",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "medias"
+                    "categories"
                 ],
-                "summary": "Find a media",
-                "operationId": "find-media",
+                "summary": "Move a category",
+                "operationId": "move-category",
                 "parameters": [
                     {
                         "type": "string",
@@ -872,13 +1401,34 @@ const docTemplate = `{
                         "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "move request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.MoveCategoryRequestJSON"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "ok",
                         "schema": {
-                            "$ref": "#/definitions/pkg.MediaResponse"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, when the parent does not exist, would create a cycle or nest categories too deep",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -888,17 +1438,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update media",
+            }
+        },
+        "/private/categories/{uuid}/programs": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find all category's programs, optionally including the programs of every subcategory",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "medias"
+                    "categories"
                 ],
-                "summary": "Update media",
-                "operationId": "update-media",
+                "summary": "Find all category's programs",
+                "operationId": "find-category-programs",
                 "parameters": [
                     {
                         "type": "string",
@@ -908,20 +1466,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/pkg.UpdateMediaRequestJSON"
-                        }
+                        "type": "boolean",
+                        "description": "includes the programs of the subcategories at any depth",
+                        "name": "descendants",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.ProgramResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -931,23 +1495,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/private/categories/{uuid}/restore": {
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Delete a media",
+                "description": "Restore a category from the trash",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "medias"
+                    "categories"
                 ],
-                "summary": "Delete a media",
-                "operationId": "delete-media",
+                "summary": "Restore a category",
+                "operationId": "restore-category",
                 "parameters": [
                     {
                         "type": "string",
@@ -959,11 +1525,29 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "deleted",
+                        "description": "restored",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request, when it would create a cycle or nest categories too deep",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -973,7 +1557,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/programs": {
+        "/private/episodes": {
             "get": {
                 "security": [
                     {
@@ -981,23 +1565,72 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find all programs",
+                "description": "Find a page of episodes, filtered and sorted by the query parameters",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "programs"
+                    "episodes"
+                ],
+                "summary": "Find all episodes",
+                "operationId": "find-all-episodes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "maximum number of episodes, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of episodes to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt",
+                            "position",
+                            "-position"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep episodes whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep episodes created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep episodes created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Find all programs",
-                "operationId": "find-all-programs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.ProgramResponse"
-                            }
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_EpisodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1015,15 +1648,15 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Create a new program",
+                "description": "Create a new episode",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "programs"
+                    "episodes"
                 ],
-                "summary": "Create a new program",
-                "operationId": "create-program",
+                "summary": "Create a new episode",
+                "operationId": "create-episode",
                 "parameters": [
                     {
                         "description": "create request",
@@ -1031,7 +1664,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pkg.CreateProgramRequestJSON"
+                            "$ref": "#/definitions/pkg.CreateEpisodeRequestJSON"
                         }
                     }
                 ],
@@ -1042,6 +1675,24 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1051,37 +1702,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/programs/{uuid}": {
-            "get": {
+        "/private/episodes/bulk": {
+            "put": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find a program",
+                "description": "Update up to 100 episodes, identified by their ID, in a single transaction: when an item fails, none is updated and the failed items are listed by index",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "programs"
+                    "episodes"
                 ],
-                "summary": "Find a program",
-                "operationId": "find-program",
+                "summary": "Update episodes in bulk",
+                "operationId": "bulk-update-episodes",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "update requests",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.BulkUpdateEpisodeRequestJSON"
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg.ProgramResponse"
+                            "$ref": "#/definitions/pkg.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1092,130 +1772,65 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Update program",
+                "description": "Create up to 100 episodes in a single transaction: when an item fails, none is created and the failed items are listed by index",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "programs"
+                    "episodes"
                 ],
-                "summary": "Update program",
-                "operationId": "update-program",
+                "summary": "Create episodes in bulk",
+                "operationId": "bulk-create-episodes",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "update request",
+                        "description": "create requests",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pkg.UpdateProgramRequestJSON"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.CreateEpisodeRequestJSON"
+                            }
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/pkg.BulkResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
                         "schema": {
                             "$ref": "#/definitions/pkg.ErrorJSON"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "Bearer-APIKey": [],
-                        "Bearer-JWT": []
-                    }
-                ],
-                "description": "Delete a program",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "programs"
-                ],
-                "summary": "Delete a program",
-                "operationId": "delete-program",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "deleted",
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
                         "schema": {
                             "$ref": "#/definitions/pkg.ErrorJSON"
                         }
-                    }
-                }
-            }
-        },
-        "/private/programs/{uuid}/categories": {
-            "get": {
-                "security": [
-                    {
-                        "Bearer-APIKey": [],
-                        "Bearer-JWT": []
-                    }
-                ],
-                "description": "Find a program's categories",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "programs"
-                ],
-                "summary": "Find a program's categories",
-                "operationId": "find-program-categories",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.CategoryResponse"
-                            }
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1225,35 +1840,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/private/programs/{uuid}/categories/overwrite": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Overwrite the categories of a specific program by replacing all existing categories with new ones",
+                "description": "Move up to 100 episodes to the trash in a single transaction: when an item fails, none is deleted and the failed items are listed by index",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "programs"
+                    "episodes"
                 ],
-                "summary": "Overwrite categories of a program",
-                "operationId": "overwrite-program-categories",
+                "summary": "Delete episodes in bulk",
+                "operationId": "bulk-delete-episodes",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "UUID of the program",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "List of categories' UUIDs to set",
+                        "description": "IDs of the episodes",
                         "name": "request",
                         "in": "body",
                         "required": true,
@@ -1267,9 +1873,27 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/pkg.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "422": {
@@ -1287,7 +1911,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/programs/{uuid}/episodes": {
+        "/private/episodes/trash": {
             "get": {
                 "security": [
                     {
@@ -1295,32 +1919,74 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find a program's episodes",
+                "description": "Find a page of the episodes in the trash, filtered and sorted by the query parameters",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "programs"
+                    "episodes"
                 ],
-                "summary": "Find a program's episodes",
-                "operationId": "find-program-episodes",
+                "summary": "Find deleted episodes",
+                "operationId": "find-deleted-episodes",
                 "parameters": [
                     {
+                        "type": "integer",
+                        "description": "maximum number of episodes, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of episodes to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt",
+                            "position",
+                            "-position",
+                            "deletedAt",
+                            "-deletedAt"
+                        ],
                         "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "field to sort on, prefixed with - for descending order, -deletedAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep episodes whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep episodes created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep episodes created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.EpisodeResponse"
-                            }
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_EpisodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1332,7 +1998,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/programs/{uuid}/tags": {
+        "/private/episodes/{uuid}": {
             "get": {
                 "security": [
                     {
@@ -1340,15 +2006,15 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find a program's tags",
+                "description": "Find an episode",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "programs"
+                    "episodes"
                 ],
-                "summary": "Find a program's tags",
-                "operationId": "find-program-tags",
+                "summary": "Find an episode",
+                "operationId": "find-episode",
                 "parameters": [
                     {
                         "type": "string",
@@ -1362,10 +2028,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.TagResponse"
-                            }
+                            "$ref": "#/definitions/pkg.EpisodeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1375,9 +2044,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/private/programs/{uuid}/tags/overwrite": {
+            },
             "put": {
                 "security": [
                     {
@@ -1385,33 +2052,30 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Overwrite the tags of a specific program by replacing all existing tags with new ones",
+                "description": "Update episode",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "programs"
+                    "episodes"
                 ],
-                "summary": "Overwrite tags of a program",
-                "operationId": "overwrite-program-tags",
+                "summary": "Update episode",
+                "operationId": "update-episode",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID of the program",
+                        "description": "uuid",
                         "name": "uuid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "List of tags UUIDs to set",
+                        "description": "update request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/pkg.UpdateEpisodeRequestJSON"
                         }
                     }
                 ],
@@ -1422,46 +2086,22 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/pkg.ErrorJSON"
                         }
-                    }
-                }
-            }
-        },
-        "/private/tags": {
-            "get": {
-                "security": [
-                    {
-                        "Bearer-APIKey": [],
-                        "Bearer-JWT": []
-                    }
-                ],
-                "description": "Find all tags",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "tags"
-                ],
-                "summary": "Find all tags",
-                "operationId": "find-all-tags",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.TagResponse"
-                            }
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1472,40 +2112,50 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Create a new tag",
+                "description": "Delete an episode",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "episodes"
                 ],
-                "summary": "Create a new tag",
-                "operationId": "create-tag",
+                "summary": "Delete an episode",
+                "operationId": "delete-episode",
                 "parameters": [
                     {
-                        "description": "create request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/pkg.CreateTagRequestJSON"
-                        }
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "deleted",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1515,23 +2165,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/tags/{uuid}": {
-            "get": {
+        "/private/episodes/{uuid}/draft": {
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find a tag",
+                "description": "Move a scheduled or unpublished episode back to draft, cancelling its scheduled publication. Fails with 409 when the episode is published or already a draft",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "episodes"
                 ],
-                "summary": "Find a tag",
-                "operationId": "find-tag",
+                "summary": "Move an episode back to draft",
+                "operationId": "draft-episode",
                 "parameters": [
                     {
                         "type": "string",
@@ -1543,35 +2193,49 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "draft",
                         "schema": {
-                            "$ref": "#/definitions/pkg.TagResponse"
+                            "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/pkg.ErrorJSON"
                         }
-                    }
-                }
-            },
-            "put": {
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/episodes/{uuid}/publish": {
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Update tag",
+                "description": "Publish a draft, scheduled or unpublished episode right away. Fails with 400 when the episode holds no media, and with 409 when it is already published",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "episodes"
                 ],
-                "summary": "Update tag",
-                "operationId": "update-tag",
+                "summary": "Publish an episode",
+                "operationId": "publish-episode",
                 "parameters": [
                     {
                         "type": "string",
@@ -1579,24 +2243,33 @@ const docTemplate = `{
                         "name": "uuid",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/pkg.UpdateTagRequestJSON"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "published",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1604,23 +2277,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/private/episodes/{uuid}/restore": {
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Delete a tag",
+                "description": "Restore an episode from the trash, along with the medias deleted with it. Fails with 409 while its program is in the trash",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "episodes"
                 ],
-                "summary": "Delete a tag",
-                "operationId": "delete-tag",
+                "summary": "Restore an episode",
+                "operationId": "restore-episode",
                 "parameters": [
                     {
                         "type": "string",
@@ -1632,11 +2307,23 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "deleted",
+                        "description": "restored",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1646,23 +2333,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/tags/{uuid}/programs": {
-            "get": {
+        "/private/episodes/{uuid}/schedule": {
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find all tag's programs",
+                "description": "Schedule the publication of a draft or unpublished episode at a time in the future, or move the publication of a scheduled episode. Fails with 400 when the episode holds no media, and with 409 when it is published",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "episodes"
                 ],
-                "summary": "Find all tag's programs",
-                "operationId": "find-tag-programs",
+                "summary": "Schedule an episode",
+                "operationId": "schedule-episode",
                 "parameters": [
                     {
                         "type": "string",
@@ -1670,16 +2357,40 @@ const docTemplate = `{
                         "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "schedule request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ScheduleEpisodeRequestJSON"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "scheduled",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.ProgramResponse"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1691,31 +2402,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/walls": {
-            "get": {
+        "/private/episodes/{uuid}/unpublish": {
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find all walls",
+                "description": "Withdraw a published episode from the listeners. Fails with 409 when the episode is not published",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "walls"
+                    "episodes"
+                ],
+                "summary": "Unpublish an episode",
+                "operationId": "unpublish-episode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Find all walls",
-                "operationId": "find-all-walls",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "unpublished",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.WallResponse"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1725,23 +2454,88 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
+            }
+        },
+        "/private/medias": {
+            "get": {
+                "description": "Find a page of medias, filtered and sorted by the query parameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medias"
+                ],
+                "summary": "Find all medias",
+                "operationId": "find-all-medias",
+                "parameters": [
                     {
-                        "Bearer-APIKey": [],
-                        "Bearer-JWT": []
+                        "type": "integer",
+                        "description": "maximum number of medias, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of medias to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "createdAt",
+                            "-createdAt",
+                            "kind",
+                            "-kind"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep medias created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep medias created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
                     }
                 ],
-                "description": "Create a new wall",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_MediaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new media",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "walls"
+                    "medias"
                 ],
-                "summary": "Create a new wall",
-                "operationId": "create-wall",
+                "summary": "Create a new media",
+                "operationId": "create-media",
                 "parameters": [
                     {
                         "description": "create request",
@@ -1749,7 +2543,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pkg.CreateWallRequestJSON"
+                            "$ref": "#/definitions/pkg.CreateMediaRequestJSON"
                         }
                     }
                 ],
@@ -1760,6 +2554,24 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1769,37 +2581,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/walls/{uuid}": {
-            "get": {
+        "/private/medias/bulk": {
+            "put": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find a wall",
+                "description": "Update up to 100 medias, identified by their ID, in a single transaction: when an item fails, none is updated and the failed items are listed by index",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "walls"
+                    "medias"
                 ],
-                "summary": "Find a wall",
-                "operationId": "find-wall",
+                "summary": "Update medias in bulk",
+                "operationId": "bulk-update-medias",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "update requests",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.BulkUpdateMediaRequestJSON"
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg.WallResponse"
+                            "$ref": "#/definitions/pkg.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1810,49 +2651,69 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "Bearer-APIKey": [],
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Update wall",
+                "description": "Create up to 100 medias in a single transaction: when an item fails, none is created and the failed items are listed by index",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "walls"
+                    "medias"
                 ],
-                "summary": "Update wall",
-                "operationId": "update-wall",
+                "summary": "Create medias in bulk",
+                "operationId": "bulk-create-medias",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "update request",
+                        "description": "create requests",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pkg.UpdateWallRequestJSON"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.CreateMediaRequestJSON"
+                            }
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/pkg.BulkResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/pkg.ErrorJSON"
                         }
@@ -1866,29 +2727,58 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Delete a wall",
+                "description": "Move up to 100 medias to the trash in a single transaction: when an item fails, none is deleted and the failed items are listed by index",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "walls"
+                    "medias"
                 ],
-                "summary": "Delete a wall",
-                "operationId": "delete-wall",
+                "summary": "Delete medias in bulk",
+                "operationId": "bulk-delete-medias",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "IDs of the medias",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "deleted",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/pkg.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1900,7 +2790,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/walls/{uuid}/blocks": {
+        "/private/medias/trash": {
             "get": {
                 "security": [
                     {
@@ -1908,21 +2798,111 @@ const docTemplate = `{
                         "Bearer-JWT": []
                     }
                 ],
-                "description": "Find all wall's blocks",
+                "description": "Find a page of the medias in the trash, filtered and sorted by the query parameters",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "walls"
+                    "medias"
                 ],
-                "summary": "Find all wall's blocks",
-                "operationId": "find-wall-block",
+                "summary": "Find deleted medias",
+                "operationId": "find-deleted-medias",
                 "parameters": [
                     {
+                        "type": "integer",
+                        "description": "maximum number of medias, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of medias to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "createdAt",
+                            "-createdAt",
+                            "kind",
+                            "-kind",
+                            "deletedAt",
+                            "-deletedAt"
+                        ],
                         "type": "string",
-                        "description": "uuid",
-                        "name": "uuid",
-                        "in": "path",
+                        "description": "field to sort on, prefixed with - for descending order, -deletedAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep medias created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep medias created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_MediaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/medias/upload": {
+            "post": {
+                "description": "Create a new media from a file streamed to the object store; its direct link, size, checksum and MIME type are filled in from the stored file\nThe kind and episodeID fields must be sent before the file, which is stored as it is received.\nThe new media is returned, with its UUID and the link of the stored file.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medias"
+                ],
+                "summary": "Upload a new media",
+                "operationId": "upload-media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kind of the media",
+                        "name": "kind",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the episode of the media",
+                        "name": "episodeID",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "media file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -1930,10 +2910,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/pkg.BlockResponse"
-                            }
+                            "$ref": "#/definitions/pkg.MediaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
                         }
                     },
                     "500": {
@@ -1945,38 +2940,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/private/walls/{uuid}/blocks/overwrite": {
-            "put": {
-                "security": [
+        "/private/medias/{uuid}": {
+            "get": {
+                "description": "Find a media",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medias"
+                ],
+                "summary": "Find a media",
+                "operationId": "find-media",
+                "parameters": [
                     {
-                        "Bearer-APIKey": [],
-                        "Bearer-JWT": []
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "Overwrite the blocks of a specific wall by replacing all existing blocks with new ones",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.MediaResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update media",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "walls"
+                    "medias"
                 ],
-                "summary": "Overwrite blocks of a wall",
-                "operationId": "overwrite-wall-blocks",
+                "summary": "Update media",
+                "operationId": "update-media",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID of the wall",
+                        "description": "uuid",
                         "name": "uuid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "List of blocks' UUIDs to set",
+                        "description": "update request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pkg.OverwriteBlocksRequestJSON"
+                            "$ref": "#/definitions/pkg.UpdateMediaRequestJSON"
                         }
                     }
                 ],
@@ -1987,8 +3016,72 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Delete a media",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medias"
+                ],
+                "summary": "Delete a media",
+                "operationId": "delete-media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/pkg.ErrorJSON"
                         }
@@ -2001,144 +3094,3700 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "pkg.BlockProgramsResponse": {
+        },
+        "/private/medias/{uuid}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Restore a media from the trash. Fails with 409 while its episode is in the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medias"
+                ],
+                "summary": "Restore a media",
+                "operationId": "restore-media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "restored",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a page of programs, filtered and sorted by the query parameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Find all programs",
+                "operationId": "find-all-programs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "maximum number of programs, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of programs to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep programs whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep programs created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep programs created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_ProgramResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Create a new program",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Create a new program",
+                "operationId": "create-program",
+                "parameters": [
+                    {
+                        "description": "create request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.CreateProgramRequestJSON"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/bulk": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Update up to 100 programs, identified by their ID, in a single transaction: when an item fails, none is updated and the failed items are listed by index",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Update programs in bulk",
+                "operationId": "bulk-update-programs",
+                "parameters": [
+                    {
+                        "description": "update requests",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.BulkUpdateProgramRequestJSON"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Create up to 100 programs in a single transaction: when an item fails, none is created and the failed items are listed by index",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Create programs in bulk",
+                "operationId": "bulk-create-programs",
+                "parameters": [
+                    {
+                        "description": "create requests",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.CreateProgramRequestJSON"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Move up to 100 programs to the trash in a single transaction: when an item fails, none is deleted and the failed items are listed by index",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Delete programs in bulk",
+                "operationId": "bulk-delete-programs",
+                "parameters": [
+                    {
+                        "description": "IDs of the programs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "moves the episodes of the programs and their medias to the trash too instead of refusing the deletion",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/import": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Import an RSS feed, downloaded from a URL or uploaded, as a program: its items become episodes positioned in publication order, their enclosures medias and its iTunes keywords tags.\nImporting a feed again updates the program and episodes imported from it, matched by GUID, instead of duplicating them.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Import a podcast feed",
+                "operationId": "import-program-feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "URL of the feed, when no file is sent",
+                        "name": "url",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "feed file, when no URL is sent",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/trash": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a page of the programs in the trash, filtered and sorted by the query parameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Find deleted programs",
+                "operationId": "find-deleted-programs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "maximum number of programs, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of programs to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt",
+                            "deletedAt",
+                            "-deletedAt"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, -deletedAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep programs whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep programs created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep programs created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_ProgramResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a program",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Find a program",
+                "operationId": "find-program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ProgramResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Update program",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Update program",
+                "operationId": "update-program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.UpdateProgramRequestJSON"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Move a program to the trash, refused while other entities still reference it unless cascade is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Delete a program",
+                "operationId": "delete-program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "moves the episodes of the program and their medias to the trash too instead of refusing the deletion",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the dependents of the program",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/{uuid}/categories": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a program's categories",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Find a program's categories",
+                "operationId": "find-program-categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.CategoryResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/{uuid}/categories/overwrite": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Overwrite the categories of a specific program by replacing all existing categories with new ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Overwrite categories of a program",
+                "operationId": "overwrite-program-categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the program",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List of categories' UUIDs to set",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/{uuid}/categories/{categoryUUID}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Associate a category with a program; adding a category the program already has does nothing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Add a category to a program",
+                "operationId": "add-program-category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the program",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the category",
+                        "name": "categoryUUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, when the program or the category does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Dissociate a category from a program; removing a category the program does not have does nothing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Remove a category from a program",
+                "operationId": "remove-program-category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the program",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the category",
+                        "name": "categoryUUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, when the program does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/{uuid}/episodes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a program's episodes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Find a program's episodes",
+                "operationId": "find-program-episodes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.EpisodeResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/{uuid}/episodes/reorder": {
+            "patch": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Reorder the episodes of a program, renumbering their positions from 1",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Reorder episodes of a program",
+                "operationId": "reorder-program-episodes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the program",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new order, as a list of IDs or a move between two positions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ReorderRequestJSON"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, when the IDs do not list every episode exactly once or a position is out of range",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/{uuid}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Restore a program from the trash, along with the episodes and medias deleted with it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Restore a program",
+                "operationId": "restore-program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "restored",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/{uuid}/tags": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a program's tags",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Find a program's tags",
+                "operationId": "find-program-tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.TagResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/{uuid}/tags/overwrite": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Overwrite the tags of a specific program by replacing all existing tags with new ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Overwrite tags of a program",
+                "operationId": "overwrite-program-tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the program",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List of tags UUIDs to set",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/programs/{uuid}/tags/{tagUUID}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Associate a tag with a program; adding a tag the program already has does nothing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Add a tag to a program",
+                "operationId": "add-program-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the program",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the tag",
+                        "name": "tagUUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, when the program or the tag does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Dissociate a tag from a program; removing a tag the program does not have does nothing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Remove a tag from a program",
+                "operationId": "remove-program-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the program",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the tag",
+                        "name": "tagUUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, when the program does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/search": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Full-text search over program and episode names and descriptions, and tag and category names.\nHits are grouped by kind and ranked from the most to the least relevant.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search the catalogue",
+                "operationId": "search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "text to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of hits of each kind, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/tags": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a page of tags, filtered and sorted by the query parameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Find all tags",
+                "operationId": "find-all-tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "maximum number of tags, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of tags to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep tags whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep tags created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep tags created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Create a new tag",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create a new tag",
+                "operationId": "create-tag",
+                "parameters": [
+                    {
+                        "description": "create request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.CreateTagRequestJSON"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/tags/bulk": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Update up to 100 tags, identified by their ID, in a single transaction: when an item fails, none is updated and the failed items are listed by index",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Update tags in bulk",
+                "operationId": "bulk-update-tags",
+                "parameters": [
+                    {
+                        "description": "update requests",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.BulkUpdateTagRequestJSON"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Create up to 100 tags in a single transaction: when an item fails, none is created and the failed items are listed by index",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create tags in bulk",
+                "operationId": "bulk-create-tags",
+                "parameters": [
+                    {
+                        "description": "create requests",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.CreateTagRequestJSON"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Move up to 100 tags to the trash in a single transaction: when an item fails, none is deleted and the failed items are listed by index",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete tags in bulk",
+                "operationId": "bulk-delete-tags",
+                "parameters": [
+                    {
+                        "description": "IDs of the tags",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "detaches the tags from the programs they are attached to instead of refusing the deletion",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, listing the items that failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, listing the items referencing an entity that does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the items conflicting with the stored data",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/tags/trash": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a page of the tags in the trash, filtered and sorted by the query parameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Find deleted tags",
+                "operationId": "find-deleted-tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "maximum number of tags, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of tags to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt",
+                            "deletedAt",
+                            "-deletedAt"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, -deletedAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep tags whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep tags created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep tags created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/tags/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a tag",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Find a tag",
+                "operationId": "find-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.TagResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Update tag",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Update tag",
+                "operationId": "update-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.UpdateTagRequestJSON"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Move a tag to the trash, refused while other entities still reference it unless cascade is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete a tag",
+                "operationId": "delete-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "detaches the tag from the programs it is attached to instead of refusing the deletion",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict, listing the dependents of the tag",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/tags/{uuid}/programs": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find all tag's programs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Find all tag's programs",
+                "operationId": "find-tag-programs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.ProgramResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/tags/{uuid}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Restore a tag from the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Restore a tag",
+                "operationId": "restore-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "restored",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/walls": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a page of walls, filtered and sorted by the query parameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Find all walls",
+                "operationId": "find-all-walls",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "maximum number of walls, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of walls to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep walls whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep walls created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep walls created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_WallResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Create a new wall",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Create a new wall",
+                "operationId": "create-wall",
+                "parameters": [
+                    {
+                        "description": "create request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.CreateWallRequestJSON"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/walls/trash": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a page of the walls in the trash, filtered and sorted by the query parameters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Find deleted walls",
+                "operationId": "find-deleted-walls",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "maximum number of walls, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of walls to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "createdAt",
+                            "-createdAt",
+                            "deletedAt",
+                            "-deletedAt"
+                        ],
+                        "type": "string",
+                        "description": "field to sort on, prefixed with - for descending order, -deletedAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep walls whose name contains this text",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep walls created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only keep walls created at or before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.PageResponse-pkg_WallResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/walls/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a wall",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Find a wall",
+                "operationId": "find-wall",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.WallResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Update wall",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Update wall",
+                "operationId": "update-wall",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.UpdateWallRequestJSON"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Delete a wall",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Delete a wall",
+                "operationId": "delete-wall",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/walls/{uuid}/blocks": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find all wall's blocks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Find all wall's blocks",
+                "operationId": "find-wall-block",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/pkg.BlockResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/walls/{uuid}/blocks/overwrite": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Overwrite the blocks of a specific wall by replacing all existing blocks with new ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Overwrite blocks of a wall",
+                "operationId": "overwrite-wall-blocks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the wall",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List of blocks' UUIDs to set",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.OverwriteBlocksRequestJSON"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/walls/{uuid}/blocks/reorder": {
+            "patch": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Reorder the blocks of a wall, identified by their block ID, renumbering their positions from 1",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Reorder blocks of a wall",
+                "operationId": "reorder-wall-blocks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the wall",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new order, as a list of IDs or a move between two positions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ReorderRequestJSON"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, when the IDs do not list every block exactly once or a position is out of range",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/walls/{uuid}/blocks/{blockUUID}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Append a block to a wall, after its last block; adding a block the wall already holds does nothing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Add a block to a wall",
+                "operationId": "add-wall-block",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the wall",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the block",
+                        "name": "blockUUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, when the wall or the block does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Remove a block from a wall, renumbering the positions of the remaining blocks from 1; removing a block the wall does not hold does nothing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Remove a block from a wall",
+                "operationId": "remove-wall-block",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the wall",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of the block",
+                        "name": "blockUUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found, when the wall does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/walls/{uuid}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Restore a wall from the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Restore a wall",
+                "operationId": "restore-wall",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "restored",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/private/walls/{uuid}/tree": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer-APIKey": [],
+                        "Bearer-JWT": []
+                    }
+                ],
+                "description": "Find a wall along with its ordered blocks, their ordered programs, the episodes of these programs and their medias",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "walls"
+                ],
+                "summary": "Find the tree of a wall",
+                "operationId": "find-wall-tree",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the wall",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.WallTreeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/programs/{uuid}/feed.xml": {
+            "get": {
+                "description": "Render a program as an RSS 2.0 feed with the iTunes and Podcasting 2.0 extensions, with an item per episode holding a media, in the order of the episodes.\nThis read-only endpoint requires no authentication and answers conditional requests with 304 Not Modified.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Find the podcast feed of a program",
+                "operationId": "find-program-feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the program",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "RSS feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/walls/{uuid}/tree": {
+            "get": {
+                "description": "Find a wall along with its ordered blocks, their ordered programs, the published episodes of these programs and their medias.\nThis read-only endpoint requires no authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Find the tree of a wall for the front-office",
+                "operationId": "find-public-wall-tree",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID of the wall",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.WallTreeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg.ErrorJSON"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "pkg.AuditEntryResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "entityID": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "requestID": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.BlockProgramsResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pkg.BlockResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.BulkItemResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.FieldErrorJSON"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pkg.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.BulkItemResponse"
+                    }
+                }
+            }
+        },
+        "pkg.BulkUpdateEpisodeRequestJSON": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "programID": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.BulkUpdateMediaRequestJSON": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "directLink": {
+                    "type": "string"
+                },
+                "episodeID": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.BulkUpdateProgramRequestJSON": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.BulkUpdateTagRequestJSON": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentID": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.CategoryTreeResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.CategoryTreeResponse"
+                    }
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentID": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.CreateBlockRequestJSON": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.CreateCategoryRequestJSON": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentID": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.CreateEpisodeRequestJSON": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "programID": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.CreateMediaRequestJSON": {
+            "type": "object",
+            "properties": {
+                "directLink": {
+                    "type": "string"
+                },
+                "episodeID": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.CreateProgramRequestJSON": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.CreateTagRequestJSON": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.CreateWallRequestJSON": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.DependentJSON": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.EpisodeResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "programID": {
+                    "type": "string"
+                },
+                "publishAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.ErrorJSON": {
+            "type": "object",
+            "properties": {
+                "dependents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.DependentJSON"
+                    }
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.FieldErrorJSON"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.BulkItemResponse"
+                    }
+                }
+            }
+        },
+        "pkg.FieldErrorJSON": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "episodesCreated": {
+                    "type": "integer"
+                },
+                "episodesUpdated": {
+                    "type": "integer"
+                },
+                "mediasCreated": {
+                    "type": "integer"
+                },
+                "programCreated": {
+                    "type": "boolean"
+                },
+                "programID": {
+                    "type": "string"
+                },
+                "tagsCreated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pkg.MediaResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "artwork": {
+                    "type": "string"
+                },
+                "bitrate": {
+                    "type": "integer"
+                },
+                "channels": {
+                    "type": "integer"
+                },
+                "checksum": {
+                    "type": "string"
+                },
+                "codec": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "directLink": {
+                    "type": "string"
+                },
+                "durationMs": {
+                    "type": "integer"
+                },
+                "episodeID": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "mimeType": {
+                    "type": "string"
+                },
+                "sampleRate": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.MoveCategoryRequestJSON": {
+            "type": "object",
+            "properties": {
+                "parentID": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.OverwriteBlocksRequestJSON": {
+            "type": "object",
+            "properties": {
+                "orderedBlocks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "wallID": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.OverwriteProgramsRequestJSON": {
+            "type": "object",
+            "properties": {
+                "blockID": {
+                    "type": "string"
+                },
+                "orderedPrograms": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "pkg.PageResponse-pkg_AuditEntryResponse": {
             "type": "object",
             "properties": {
-                "ID": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.AuditEntryResponse"
+                    }
                 },
-                "description": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
                     "type": "string"
                 },
-                "name": {
+                "offset": {
+                    "type": "integer"
+                },
+                "previous": {
                     "type": "string"
                 },
-                "position": {
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "pkg.BlockResponse": {
+        "pkg.PageResponse-pkg_BlockResponse": {
             "type": "object",
             "properties": {
-                "ID": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.BlockResponse"
+                    }
                 },
-                "description": {
-                    "type": "string"
+                "limit": {
+                    "type": "integer"
                 },
-                "kind": {
+                "next": {
                     "type": "string"
                 },
-                "name": {
+                "offset": {
+                    "type": "integer"
+                },
+                "previous": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "pkg.CategoryResponse": {
+        "pkg.PageResponse-pkg_CategoryResponse": {
             "type": "object",
             "properties": {
-                "ID": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.CategoryResponse"
+                    }
                 },
-                "description": {
-                    "type": "string"
+                "limit": {
+                    "type": "integer"
                 },
-                "name": {
+                "next": {
                     "type": "string"
                 },
-                "parentID": {
+                "offset": {
+                    "type": "integer"
+                },
+                "previous": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "pkg.CreateBlockRequestJSON": {
+        "pkg.PageResponse-pkg_EpisodeResponse": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.EpisodeResponse"
+                    }
                 },
-                "kind": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
                     "type": "string"
                 },
-                "name": {
+                "offset": {
+                    "type": "integer"
+                },
+                "previous": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "pkg.CreateCategoryRequestJSON": {
+        "pkg.PageResponse-pkg_MediaResponse": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.MediaResponse"
+                    }
                 },
-                "name": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
                     "type": "string"
                 },
-                "parentID": {
+                "offset": {
+                    "type": "integer"
+                },
+                "previous": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "pkg.CreateEpisodeRequestJSON": {
+        "pkg.PageResponse-pkg_ProgramResponse": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.ProgramResponse"
+                    }
                 },
-                "name": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
                     "type": "string"
                 },
-                "position": {
+                "offset": {
                     "type": "integer"
                 },
-                "programID": {
+                "previous": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "pkg.CreateMediaRequestJSON": {
+        "pkg.PageResponse-pkg_TagResponse": {
             "type": "object",
             "properties": {
-                "directLink": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.TagResponse"
+                    }
                 },
-                "episodeID": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
                     "type": "string"
                 },
-                "kind": {
+                "offset": {
+                    "type": "integer"
+                },
+                "previous": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "pkg.CreateProgramRequestJSON": {
+        "pkg.PageResponse-pkg_WallResponse": {
             "type": "object",
             "properties": {
-                "description": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.WallResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
                     "type": "string"
                 },
-                "name": {
+                "offset": {
+                    "type": "integer"
+                },
+                "previous": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "pkg.CreateTagRequestJSON": {
+        "pkg.ProgramResponse": {
             "type": "object",
             "properties": {
-                "description": {
+                "ID": {
                     "type": "string"
                 },
-                "name": {
+                "deletedAt": {
                     "type": "string"
-                }
-            }
-        },
-        "pkg.CreateWallRequestJSON": {
-            "type": "object",
-            "properties": {
+                },
                 "description": {
                     "type": "string"
                 },
@@ -2147,90 +6796,80 @@ const docTemplate = `{
                 }
             }
         },
-        "pkg.EpisodeResponse": {
+        "pkg.ReorderRequestJSON": {
             "type": "object",
             "properties": {
-                "ID": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
+                "from": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "position": {
+                "to": {
                     "type": "integer"
-                },
-                "programID": {
-                    "type": "string"
                 }
             }
         },
-        "pkg.ErrorJSON": {
+        "pkg.ScheduleEpisodeRequestJSON": {
             "type": "object",
             "properties": {
-                "error": {
+                "publishAt": {
                     "type": "string"
                 }
             }
         },
-        "pkg.MediaResponse": {
+        "pkg.SearchHitResponse": {
             "type": "object",
             "properties": {
                 "ID": {
                     "type": "string"
                 },
-                "directLink": {
+                "description": {
                     "type": "string"
                 },
-                "episodeID": {
+                "kind": {
                     "type": "string"
                 },
-                "kind": {
+                "name": {
                     "type": "string"
+                },
+                "score": {
+                    "type": "number"
                 }
             }
         },
-        "pkg.OverwriteBlocksRequestJSON": {
+        "pkg.SearchResponse": {
             "type": "object",
             "properties": {
-                "orderedBlocks": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "integer"
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.SearchHitResponse"
                     }
                 },
-                "wallID": {
-                    "type": "string"
-                }
-            }
-        },
-        "pkg.OverwriteProgramsRequestJSON": {
-            "type": "object",
-            "properties": {
-                "blockID": {
-                    "type": "string"
+                "episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.SearchHitResponse"
+                    }
                 },
-                "orderedPrograms": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "integer"
+                "programs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.SearchHitResponse"
                     }
-                }
-            }
-        },
-        "pkg.ProgramResponse": {
-            "type": "object",
-            "properties": {
-                "ID": {
-                    "type": "string"
                 },
-                "description": {
+                "query": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.SearchHitResponse"
+                    }
                 }
             }
         },
@@ -2240,6 +6879,9 @@ const docTemplate = `{
                 "ID": {
                     "type": "string"
                 },
+                "deletedAt": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -2346,6 +6988,122 @@ const docTemplate = `{
                 "ID": {
                     "type": "string"
                 },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.WallTreeBlockResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "programs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.WallTreeProgramResponse"
+                    }
+                }
+            }
+        },
+        "pkg.WallTreeEpisodeResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "medias": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.MediaResponse"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "programID": {
+                    "type": "string"
+                },
+                "publishAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg.WallTreeProgramResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.WallTreeEpisodeResponse"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pkg.WallTreeResponse": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "string"
+                },
+                "blocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg.WallTreeBlockResponse"
+                    }
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -1,20 +1,25 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/api"
-	"github.com/khedhrije/podcaster-backoffice-api/internal/infrastructure/mysql"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/infrastructure/auth"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/ui/gin/handlers"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/ui/gin/router"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/ui/jobs"
 	"github.com/rs/zerolog/log"
 )
 
 // Bootstrap struct encapsulates the configuration settings and the HTTP router necessary for the application to run.
 type Bootstrap struct {
-	Config *configuration.AppConfig // Application configuration settings
-	Router *gin.Engine              // HTTP router for handling web requests
+	Config    *configuration.AppConfig // Application configuration settings
+	Router    *gin.Engine              // HTTP router for handling web requests
+	Purge     *jobs.Purge              // Job emptying the trash, nil when disabled
+	Scheduler *jobs.Scheduler          // Job publishing the scheduled episodes, nil when disabled
 }
 
 // InitBootstrap initializes the bootstrap process and returns a Bootstrap instance.
@@ -33,30 +38,59 @@ func initBootstrap() Bootstrap {
 	app := Bootstrap{}
 	app.Config = configuration.Config
 
-	// Initialize MySQL client using application configuration
-	mysqlClient := mysql.NewClient(app.Config)
-
-	// Initialize MySQL adapters for different domain models, setting up the data access layer
-	wallAdapter := mysql.NewWallAdapter(mysqlClient)
-	wallBlockAdapter := mysql.NewWallBlockAdapter(mysqlClient)
-	blockAdapter := mysql.NewBlockAdapter(mysqlClient)
-	blockProgramAdapter := mysql.NewBlockProgramAdapter(mysqlClient)
-	programAdapter := mysql.NewProgramAdapter(mysqlClient)
-	episodeAdapter := mysql.NewEpisodeAdapter(mysqlClient)
-	mediaAdapter := mysql.NewMediaAdapter(mysqlClient)
-	tagAdapter := mysql.NewTagAdapter(mysqlClient)
-	programTagAdapter := mysql.NewProgramTagAdapter(mysqlClient)
-	categoryAdapter := mysql.NewCategoryAdapter(mysqlClient)
-	programCategoryAdapter := mysql.NewProgramCategoryAdapter(mysqlClient)
+	// Initialize the data access layer selected by the database driver
+	persisters := newPersisters(app.Config)
+
+	// Initialize the verifier and refresher of the access tokens issued by the account API
+	verifier, err := auth.NewVerifier(app.Config.AccountApi)
+	if err != nil {
+		log.Panic().Err(err).Msg("error while initializing the token verifier")
+	}
+	var refresher port.TokenRefresher
+	if app.Config.AccountApi.BaseURL != "" {
+		if refresher, err = auth.NewRefresher(app.Config.AccountApi); err != nil {
+			log.Panic().Err(err).Msg("error while initializing the token refresher")
+		}
+	}
+
+	// Initialize the object store the uploaded media files are kept in
+	objectStore := newObjectStore(app.Config.ObjectStore)
+	prober, fetcher := newMediaProbe(app.Config.MediaProbe)
 
 	// Initialize APIs for different domain models, enabling business logic operations
-	wallApi := api.NewWallApi(wallAdapter, wallBlockAdapter, blockAdapter)
-	blockApi := api.NewBlockApi(blockAdapter, blockProgramAdapter, programAdapter)
-	programApi := api.NewProgramApi(programAdapter, episodeAdapter, programTagAdapter, tagAdapter, programCategoryAdapter, categoryAdapter)
-	episodeApi := api.NewEpisodeApi(episodeAdapter)
-	mediaApi := api.NewMediaApi(mediaAdapter)
-	tagApi := api.NewTagApi(tagAdapter, programTagAdapter, programAdapter)
-	catApi := api.NewCategoryApi(categoryAdapter, programCategoryAdapter, programAdapter)
+	wallApi := api.NewWallApi(persisters.wall, persisters.wallBlock, persisters.block, persisters.tx)
+	blockApi := api.NewBlockApi(persisters.block, persisters.blockProgram, persisters.program, persisters.wallBlock, persisters.wall, persisters.tx)
+	programApi := api.NewProgramApi(persisters.program, persisters.episode, persisters.programTag, persisters.tag, persisters.programCategory, persisters.category, persisters.tx)
+	episodeApi := api.NewEpisodeApi(persisters.episode, persisters.media)
+	mediaApi := api.NewMediaApi(persisters.media, objectStore, prober, fetcher, app.Config.ObjectStore.MaxUploadSize)
+	tagApi := api.NewTagApi(persisters.tag, persisters.program, persisters.programTag, persisters.tx)
+	catApi := api.NewCategoryApi(persisters.category, persisters.program, persisters.programCategory, persisters.tx, app.Config.Categories.MaxDepth)
+	searchApi := api.NewSearchApi(persisters.search)
+	wallTreeApi := api.NewWallTreeApi(persisters.wall, persisters.wallBlock, persisters.block, persisters.blockProgram, persisters.program, persisters.episode, persisters.media)
+	auditApi := api.NewAuditApi(persisters.audit)
+	feedApi := api.NewFeedApi(persisters.program, persisters.episode, persisters.media, persisters.category, api.FeedSettings{
+		BaseURL:    app.Config.Feed.BaseURL,
+		Link:       app.Config.Feed.Link,
+		Language:   app.Config.Feed.Language,
+		Author:     app.Config.Feed.Author,
+		OwnerName:  app.Config.Feed.OwnerName,
+		OwnerEmail: app.Config.Feed.OwnerEmail,
+		Explicit:   app.Config.Feed.Explicit,
+	})
+	feedImportApi := newFeedImportApi(app.Config.FeedImport, persisters)
+	trashApi := api.NewTrashApi(persisters.wall, persisters.block, persisters.program, persisters.episode, persisters.media, persisters.tag, persisters.category, objectStore, persisters.tx)
+
+	// Record the mutating operations of the catalogue APIs in the audit log
+	wallApi = api.NewAuditedWallApi(wallApi, persisters.audit)
+	blockApi = api.NewAuditedBlockApi(blockApi, persisters.audit)
+	programApi = api.NewAuditedProgramApi(programApi, persisters.audit)
+	episodeApi = api.NewAuditedEpisodeApi(episodeApi, persisters.audit)
+	mediaApi = api.NewAuditedMediaApi(mediaApi, persisters.audit)
+	tagApi = api.NewAuditedTagApi(tagApi, persisters.audit)
+	catApi = api.NewAuditedCategoryApi(catApi, persisters.audit)
+
+	// Save bulk items through the audited APIs, so that each item is recorded as if it was sent alone
+	bulkApi := api.NewBulkApi(programApi, episodeApi, mediaApi, tagApi, persisters.tx)
 
 	// Initialize handlers for different APIs, setting up the presentation layer
 	wallHandler := handlers.NewWallHandler(wallApi)
@@ -66,6 +100,12 @@ func initBootstrap() Bootstrap {
 	mediaHandler := handlers.NewMediaHandler(mediaApi)
 	tagHandler := handlers.NewTagHandler(tagApi)
 	catHandler := handlers.NewCategoryHandler(catApi)
+	searchHandler := handlers.NewSearchHandler(searchApi)
+	wallTreeHandler := handlers.NewWallTreeHandler(wallTreeApi)
+	auditHandler := handlers.NewAuditHandler(auditApi)
+	feedHandler := handlers.NewFeedHandler(feedApi)
+	feedImportHandler := handlers.NewFeedImportHandler(feedImportApi)
+	bulkHandler := handlers.NewBulkHandler(bulkApi)
 
 	// Create the router with the initialized handlers, configuring the request handling
 	r := router.CreateRouter(
@@ -76,14 +116,35 @@ func initBootstrap() Bootstrap {
 		mediaHandler,
 		tagHandler,
 		catHandler,
+		searchHandler,
+		wallTreeHandler,
+		auditHandler,
+		feedHandler,
+		feedImportHandler,
+		bulkHandler,
+		verifier,
+		refresher,
 	)
 	app.Router = r
+	serveObjectStore(app.Router, app.Config.ObjectStore)
+
+	// Initialize the job purging the trash once the retention has elapsed
+	app.Purge = jobs.NewPurge(trashApi, app.Config.Trash)
+
+	// Initialize the job publishing the scheduled episodes once their publication time is reached
+	app.Scheduler = jobs.NewScheduler(episodeApi, app.Config.Publication)
 	return app
 }
 
-// Run starts the application by running the HTTP server on the configured host address and port.
+// Run starts the application by running the background jobs and the HTTP server on the configured host address and port.
 // It logs a fatal error if the server cannot be started, ensuring that the failure is captured and reported.
 func (b Bootstrap) Run() {
+	if b.Purge != nil {
+		go b.Purge.Run(context.Background())
+	}
+	if b.Scheduler != nil {
+		go b.Scheduler.Run(context.Background())
+	}
 	dsn := fmt.Sprintf("%s:%d", b.Config.HostAddress, b.Config.HostPort)
 	if errRun := b.Router.Run(dsn); errRun != nil {
 		log.Fatal().Msg("error during service instantiation")
@@ -0,0 +1,84 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/api"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/infrastructure/rss"
+	"github.com/rs/zerolog/log"
+)
+
+// Import runs the feed import command described by args against the configured database:
+// "import <url|path>" imports the RSS feed downloaded from the URL, or read from the file at the path, as a program.
+func Import(ctx context.Context, args []string) error {
+	if configuration.Config == nil {
+		return fmt.Errorf("configuration is nil")
+	}
+	if configuration.Config.DatabaseConfig.Driver == driverMemory {
+		return fmt.Errorf("the in-memory driver would lose the imported feed on exit")
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("expected the URL or the path of a single feed, got %d arguments", len(args))
+	}
+
+	persisters := newMySQLPersisters(configuration.Config)
+	feedImportApi := newFeedImportApi(configuration.Config.FeedImport, persisters)
+
+	result, err := feedImportApi.Import(ctx, newImportSource(args[0]))
+	if err != nil {
+		return err
+	}
+	log.Info().
+		Str("programID", result.ProgramID).
+		Bool("programCreated", result.ProgramCreated).
+		Int("episodesCreated", result.EpisodesCreated).
+		Int("episodesUpdated", result.EpisodesUpdated).
+		Int("mediasCreated", result.MediasCreated).
+		Int("tagsCreated", result.TagsCreated).
+		Msg("feed imported")
+	return nil
+}
+
+// newFeedImportApi initializes the api importing the podcast feeds, recording the imports in the audit log.
+func newFeedImportApi(config configuration.FeedImport, persisters persisters) api.FeedImport {
+	feedImportApi := api.NewFeedImportApi(rss.NewReader(config.FetchTimeout), persisters.program, persisters.episode, persisters.media, persisters.tag, persisters.programTag, persisters.tx)
+	return api.NewAuditedFeedImportApi(feedImportApi, persisters.audit)
+}
+
+// importSource is an ImportFeedRequest naming the feed to import on the command line, by URL or by path.
+type importSource struct {
+	url  string
+	path string
+}
+
+// newImportSource creates an importSource from a command line argument, a URL when it has an http or https scheme.
+func newImportSource(arg string) importSource {
+	if strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "https://") {
+		return importSource{url: arg}
+	}
+	return importSource{path: arg}
+}
+
+// URL returns the URL of the feed, empty when the feed is read from a file.
+func (source importSource) URL() string {
+	return source.url
+}
+
+// FileName returns the name of the file of the feed, empty when the feed is downloaded.
+func (source importSource) FileName() string {
+	if source.path == "" {
+		return ""
+	}
+	return filepath.Base(source.path)
+}
+
+// Open opens the file of the feed for reading.
+func (source importSource) Open() (io.ReadCloser, error) {
+	return os.Open(source.path)
+}
@@ -0,0 +1,69 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/infrastructure/mysql"
+	"github.com/rs/zerolog/log"
+)
+
+// Migrate runs the schema migration command described by args against the configured database.
+// Supported commands are "up" (the default), "down [steps]" which reverts one migration unless
+// a step count is given, and "status" which logs every migration and whether it is applied.
+func Migrate(ctx context.Context, args []string) error {
+	if configuration.Config == nil {
+		return fmt.Errorf("configuration is nil")
+	}
+	if configuration.Config.DatabaseConfig.Driver == driverMemory {
+		return fmt.Errorf("the in-memory driver has no schema to migrate")
+	}
+
+	migrator := mysql.NewMigrator(mysql.NewClient(configuration.Config))
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		log.Info().Int("applied", count).Msg("database is up to date")
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			parsed, err := strconv.Atoi(args[1])
+			if err != nil || parsed <= 0 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+			steps = parsed
+		}
+		count, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		log.Info().Int("reverted", count).Msg("migrations reverted")
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			event := log.Info().Int64("version", status.Version).Str("name", status.Name)
+			if status.AppliedAt != nil {
+				event.Time("appliedAt", *status.AppliedAt).Msg("applied")
+			} else {
+				event.Msg("pending")
+			}
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected up, down or status", command)
+	}
+	return nil
+}
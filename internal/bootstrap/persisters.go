@@ -0,0 +1,95 @@
+package bootstrap
+
+import (
+	"context"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/infrastructure/memory"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/infrastructure/mysql"
+	"github.com/rs/zerolog/log"
+)
+
+// driverMemory is the DatabaseConfig.Driver value selecting the in-memory adapters.
+// Any other value is handed to the MySQL client as the sql driver name.
+const driverMemory = "memory"
+
+// persisters groups the adapters implementing every port of the data access layer,
+// along with the transaction manager they take part in.
+type persisters struct {
+	wall            port.WallPersister
+	wallBlock       port.WallBlockPersister
+	block           port.BlockPersister
+	blockProgram    port.BlockProgramPersister
+	program         port.ProgramPersister
+	episode         port.EpisodePersister
+	media           port.MediaPersister
+	tag             port.TagPersister
+	programTag      port.ProgramTagPersister
+	category        port.CategoryPersister
+	programCategory port.ProgramCategoryPersister
+	search          port.Searcher
+	audit           port.AuditPersister
+	tx              port.TxManager
+}
+
+// newPersisters initializes the adapters matching the configured database driver.
+func newPersisters(config *configuration.AppConfig) persisters {
+	if config.DatabaseConfig.Driver == driverMemory {
+		log.Warn().Msg("using in-memory persistence, data will be lost on restart")
+		return newMemoryPersisters()
+	}
+	return newMySQLPersisters(config)
+}
+
+// newMySQLPersisters initializes the MySQL client and its adapters.
+// Pending schema migrations are applied first when DatabaseConfig.AutoMigrate is enabled.
+func newMySQLPersisters(config *configuration.AppConfig) persisters {
+	// Initialize MySQL client using application configuration
+	mysqlClient := mysql.NewClient(config)
+
+	// Apply pending schema migrations before any adapter touches the database, when enabled
+	if config.DatabaseConfig.AutoMigrate {
+		if _, err := mysql.NewMigrator(mysqlClient).Up(context.Background()); err != nil {
+			log.Panic().Err(err).Msg("could not apply database migrations")
+		}
+	}
+
+	return persisters{
+		wall:            mysql.NewWallAdapter(mysqlClient),
+		wallBlock:       mysql.NewWallBlockAdapter(mysqlClient),
+		block:           mysql.NewBlockAdapter(mysqlClient),
+		blockProgram:    mysql.NewBlockProgramAdapter(mysqlClient),
+		program:         mysql.NewProgramAdapter(mysqlClient),
+		episode:         mysql.NewEpisodeAdapter(mysqlClient),
+		media:           mysql.NewMediaAdapter(mysqlClient),
+		tag:             mysql.NewTagAdapter(mysqlClient),
+		programTag:      mysql.NewProgramTagAdapter(mysqlClient),
+		category:        mysql.NewCategoryAdapter(mysqlClient),
+		programCategory: mysql.NewProgramCategoryAdapter(mysqlClient),
+		search:          mysql.NewSearchAdapter(mysqlClient),
+		audit:           mysql.NewAuditAdapter(mysqlClient),
+		tx:              mysql.NewTxManager(mysqlClient),
+	}
+}
+
+// newMemoryPersisters initializes an empty in-memory store and its adapters.
+func newMemoryPersisters() persisters {
+	memoryClient := memory.NewClient()
+	return persisters{
+		wall:            memory.NewWallAdapter(memoryClient),
+		wallBlock:       memory.NewWallBlockAdapter(memoryClient),
+		block:           memory.NewBlockAdapter(memoryClient),
+		blockProgram:    memory.NewBlockProgramAdapter(memoryClient),
+		program:         memory.NewProgramAdapter(memoryClient),
+		episode:         memory.NewEpisodeAdapter(memoryClient),
+		media:           memory.NewMediaAdapter(memoryClient),
+		tag:             memory.NewTagAdapter(memoryClient),
+		programTag:      memory.NewProgramTagAdapter(memoryClient),
+		category:        memory.NewCategoryAdapter(memoryClient),
+		programCategory: memory.NewProgramCategoryAdapter(memoryClient),
+		search:          memory.NewSearchAdapter(memoryClient),
+		audit:           memory.NewAuditAdapter(memoryClient),
+		tx:              memory.NewTxManager(memoryClient),
+	}
+}
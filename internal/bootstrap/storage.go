@@ -0,0 +1,49 @@
+package bootstrap
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/infrastructure/audio"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/infrastructure/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// objectStoreLocal is the ObjectStore.Driver value selecting the local filesystem object store.
+const objectStoreLocal = "local"
+
+// newObjectStore initializes the object store matching the configured driver.
+// It panics on an unknown driver, so that uploads never fail only once the service is running.
+func newObjectStore(config configuration.ObjectStore) port.ObjectStore {
+	switch config.Driver {
+	case objectStoreLocal:
+		store, err := storage.NewLocalStore(config.LocalDir, config.PublicURL)
+		if err != nil {
+			log.Panic().Err(err).Msg("error while initializing the local object store")
+		}
+		return store
+	default:
+		log.Panic().Str("driver", config.Driver).Msg("unknown object store driver")
+		return nil
+	}
+}
+
+// serveObjectStore serves the files of the local object store when their public URL is a path of the service.
+// Files published under an absolute URL are left to the web server sharing the directory.
+func serveObjectStore(r *gin.Engine, config configuration.ObjectStore) {
+	if config.Driver != objectStoreLocal || !strings.HasPrefix(config.PublicURL, "/") {
+		return
+	}
+	r.Static(strings.TrimSuffix(config.PublicURL, "/"), config.LocalDir)
+}
+
+// newMediaProbe initializes the prober describing the media files, and the fetcher reading the files of the
+// medias registered by link, nil when linked files are left undescribed.
+func newMediaProbe(config configuration.MediaProbe) (port.AudioProber, port.MediaFetcher) {
+	if config.FetchTimeout <= 0 {
+		return audio.NewProber(), nil
+	}
+	return audio.NewProber(), storage.NewHTTPFetcher(config.FetchTimeout)
+}
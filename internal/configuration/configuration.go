@@ -29,17 +29,25 @@ type AppConfig struct {
 	AccountApi     AccountApi
 	DatabaseConfig DatabaseConfig // Configuration settings for the database
 	CacheConfig    CacheConfig
+	Trash          Trash       // Retention of the deleted catalogue entities
+	ObjectStore    ObjectStore // Storage of the uploaded media files
+	MediaProbe     MediaProbe  // Description of the audio stream of the media files
+	Feed           Feed        // Publisher of the podcast feeds of the programs
+	FeedImport     FeedImport  // Import of the podcast feeds published by other hosts
+	Publication    Publication // Publication of the scheduled episodes
+	Categories     Categories  // Hierarchy of the categories
 }
 
 // DatabaseConfig defines the configuration settings for the database connection.
 // It includes details like the database name, driver, DSN, and connection pool settings.
 type DatabaseConfig struct {
 	Name                   string        // Database name
-	Driver                 string        // Database driver type (e.g., mysql, postgres)
+	Driver                 string        // Database driver type (e.g., mysql, or memory for the in-memory adapters)
 	DSN                    string        // Data source name for the database connection
 	MaxConnections         int           // Maximum number of open connections to the database
 	MaxIdleConnections     int           // Maximum number of idle connections to the database
 	MaxLifetimeConnections time.Duration // Maximum amount of time a connection may be reused
+	AutoMigrate            bool          // Apply pending schema migrations when the service boots
 }
 
 // CacheConfig defines the configuration for cache connections.
@@ -48,8 +56,64 @@ type CacheConfig struct {
 	DSN string // Data source name for the cache
 }
 
+// AccountApi defines how the access tokens of the callers are verified.
+// Tokens are verified locally against PublicKey, or else the keys published at JWKSURL, and
+// handed to the account API at BaseURL when no key is configured or the token cannot be verified locally.
 type AccountApi struct {
-	BaseURL string
+	BaseURL        string        // Base URL of the account API
+	JWKSURL        string        // URL of the JSON Web Key Set the access tokens are signed with
+	PublicKey      string        // PEM encoded public key the access tokens are signed with, used instead of the JWKS
+	Issuer         string        // Expected issuer of the access tokens, not checked when empty
+	Audience       string        // Expected audience of the access tokens, not checked when empty
+	JWKSCacheTTL   time.Duration // How long the fetched JWKS is trusted before being fetched again
+	Timeout        time.Duration // Timeout of every call made to the account API
+	RemoteFallback bool          // Whether tokens that cannot be verified locally are validated by the account API
+}
+
+// Trash defines how long the deleted catalogue entities are kept before being permanently removed.
+type Trash struct {
+	Retention     time.Duration // How long an entity stays in the trash before being purged
+	PurgeInterval time.Duration // How often the trash is purged, 0 disabling the purge job
+}
+
+// ObjectStore defines where the uploaded media files are stored and served from.
+type ObjectStore struct {
+	Driver        string // Object store implementation (e.g., local)
+	LocalDir      string // Directory the local object store writes the files to
+	PublicURL     string // Base URL the stored files are served from, served by the service itself when it is a path
+	MaxUploadSize int64  // Maximum size of an uploaded file in bytes, 0 disabling the limit
+}
+
+// MediaProbe defines how the files of the medias are read to describe their audio stream.
+// Uploaded files are always described; the files of the medias registered by link are fetched over HTTP.
+type MediaProbe struct {
+	FetchTimeout time.Duration // Timeout of every request fetching a linked file, 0 leaving linked files undescribed
+}
+
+// Feed describes the publisher of the podcast feeds of the programs.
+type Feed struct {
+	BaseURL    string // Public base URL of the service, the feeds linking to themselves under it
+	Link       string // Website of the podcasts, the feed itself when empty
+	Language   string // Language of the podcasts (e.g., en, fr-FR)
+	Author     string // Author of the podcasts
+	OwnerName  string // Name of the contact of the podcasts
+	OwnerEmail string // Email of the contact of the podcasts
+	Explicit   bool   // Whether the podcasts contain explicit content
+}
+
+// FeedImport defines how the podcast feeds published by other hosts are downloaded to be imported.
+type FeedImport struct {
+	FetchTimeout time.Duration // Timeout of the request downloading a feed
+}
+
+// Publication defines how often the scheduled episodes are checked for publication.
+type Publication struct {
+	ScheduleInterval time.Duration // How often the due scheduled episodes are published, 0 disabling the scheduler
+}
+
+// Categories defines the limits of the category hierarchy.
+type Categories struct {
+	MaxDepth int // Maximum number of nested levels, a root category being on the first one, 0 disabling the limit
 }
 
 // loadFromEnv loads configuration settings from environment variables and returns an AppConfig instance.
@@ -57,6 +121,20 @@ type AccountApi struct {
 func loadFromEnv() *AppConfig {
 	viper.AutomaticEnv() // Automatically read environment variables
 	viper.SetDefault("APP_PODCASTER_BACKOFFICE_API_HOST_PORT", 8080)
+	viper.SetDefault("ACCOUNT_API_JWKS_CACHE_TTL", 15*time.Minute)
+	viper.SetDefault("ACCOUNT_API_TIMEOUT", 5*time.Second)
+	viper.SetDefault("ACCOUNT_API_REMOTE_FALLBACK", true)
+	viper.SetDefault("TRASH_RETENTION", 30*24*time.Hour)
+	viper.SetDefault("TRASH_PURGE_INTERVAL", time.Hour)
+	viper.SetDefault("OBJECT_STORE_DRIVER", "local")
+	viper.SetDefault("OBJECT_STORE_LOCAL_DIR", "data/files")
+	viper.SetDefault("OBJECT_STORE_PUBLIC_URL", "/files")
+	viper.SetDefault("MEDIA_MAX_UPLOAD_SIZE", 512<<20)
+	viper.SetDefault("MEDIA_PROBE_FETCH_TIMEOUT", 30*time.Second)
+	viper.SetDefault("FEED_LANGUAGE", "en")
+	viper.SetDefault("FEED_IMPORT_FETCH_TIMEOUT", 30*time.Second)
+	viper.SetDefault("PUBLICATION_SCHEDULE_INTERVAL", 30*time.Second)
+	viper.SetDefault("CATEGORY_MAX_DEPTH", 5)
 	return &AppConfig{
 		Name:        viper.GetString("APP_PODCASTER_BACKOFFICE_API_NAME"),              // Application name
 		Env:         viper.GetString("APP_PODCASTER_BACKOFFICE_API_ENV"),               // Application environment
@@ -64,7 +142,14 @@ func loadFromEnv() *AppConfig {
 		HostPort:    viper.GetInt("APP_PODCASTER_BACKOFFICE_API_HOST_PORT"),            // Server port number
 		DocsAddress: viper.GetString("APP_PODCASTER_BACKOFFICE_API_DOCS_HOST_ADDRESS"), // Address for API documentation
 		AccountApi: AccountApi{
-			BaseURL: viper.GetString("ACCOUNT-API-URL"),
+			BaseURL:        viper.GetString("ACCOUNT-API-URL"),              // Account API base URL
+			JWKSURL:        viper.GetString("ACCOUNT_API_JWKS_URL"),         // Signing keys of the access tokens
+			PublicKey:      viper.GetString("ACCOUNT_API_PUBLIC_KEY"),       // Signing key of the access tokens
+			Issuer:         viper.GetString("ACCOUNT_API_ISSUER"),           // Expected token issuer
+			Audience:       viper.GetString("ACCOUNT_API_AUDIENCE"),         // Expected token audience
+			JWKSCacheTTL:   viper.GetDuration("ACCOUNT_API_JWKS_CACHE_TTL"), // JWKS cache lifetime
+			Timeout:        viper.GetDuration("ACCOUNT_API_TIMEOUT"),        // Account API call timeout
+			RemoteFallback: viper.GetBool("ACCOUNT_API_REMOTE_FALLBACK"),    // Validate remotely when local verification cannot decide
 		},
 		DatabaseConfig: DatabaseConfig{
 			Driver:                 viper.GetString("MYSQL_DRIVER"),                    // Database driver
@@ -73,9 +158,41 @@ func loadFromEnv() *AppConfig {
 			MaxConnections:         viper.GetInt("MYSQL_MAX_CONNECTIONS"),              // Max open connections
 			MaxIdleConnections:     viper.GetInt("MYSQL_MAX_IDLE_CONNECTIONS"),         // Max idle connections
 			MaxLifetimeConnections: viper.GetDuration("MYSQL_MAX_CONNECTION_LIFETIME"), // Connection lifetime
+			AutoMigrate:            viper.GetBool("MYSQL_AUTO_MIGRATE"),                // Run migrations on boot
 		},
 		CacheConfig: CacheConfig{
 			DSN: viper.GetString("REDIS_URL"), // Data source name for Redis
 		},
+		Trash: Trash{
+			Retention:     viper.GetDuration("TRASH_RETENTION"),      // Time spent in the trash before purge
+			PurgeInterval: viper.GetDuration("TRASH_PURGE_INTERVAL"), // Time between two purges
+		},
+		ObjectStore: ObjectStore{
+			Driver:        viper.GetString("OBJECT_STORE_DRIVER"),     // Object store implementation
+			LocalDir:      viper.GetString("OBJECT_STORE_LOCAL_DIR"),  // Directory of the local object store
+			PublicURL:     viper.GetString("OBJECT_STORE_PUBLIC_URL"), // Base URL of the stored files
+			MaxUploadSize: viper.GetInt64("MEDIA_MAX_UPLOAD_SIZE"),    // Upload size limit in bytes
+		},
+		MediaProbe: MediaProbe{
+			FetchTimeout: viper.GetDuration("MEDIA_PROBE_FETCH_TIMEOUT"), // Linked file fetch timeout
+		},
+		Feed: Feed{
+			BaseURL:    viper.GetString("FEED_BASE_URL"),    // Public base URL of the service
+			Link:       viper.GetString("FEED_LINK"),        // Website of the podcasts
+			Language:   viper.GetString("FEED_LANGUAGE"),    // Language of the podcasts
+			Author:     viper.GetString("FEED_AUTHOR"),      // Author of the podcasts
+			OwnerName:  viper.GetString("FEED_OWNER_NAME"),  // Contact name
+			OwnerEmail: viper.GetString("FEED_OWNER_EMAIL"), // Contact email
+			Explicit:   viper.GetBool("FEED_EXPLICIT"),      // Explicit content flag
+		},
+		FeedImport: FeedImport{
+			FetchTimeout: viper.GetDuration("FEED_IMPORT_FETCH_TIMEOUT"), // Feed download timeout
+		},
+		Publication: Publication{
+			ScheduleInterval: viper.GetDuration("PUBLICATION_SCHEDULE_INTERVAL"), // Time between two publication runs
+		},
+		Categories: Categories{
+			MaxDepth: viper.GetInt("CATEGORY_MAX_DEPTH"), // Nesting limit of the categories
+		},
 	}
 }
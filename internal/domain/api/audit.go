@@ -0,0 +1,240 @@
+// Package api provides functionality for reading and recording the audit log.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"github.com/rs/zerolog/log"
+)
+
+// auditEntities lists the kinds of entities recorded in the audit log.
+var auditEntities = []string{
+	model.AuditEntityWall,
+	model.AuditEntityBlock,
+	model.AuditEntityProgram,
+	model.AuditEntityEpisode,
+	model.AuditEntityMedia,
+	model.AuditEntityTag,
+	model.AuditEntityCategory,
+}
+
+// AuditRequest represents the interface for listing the audit log one page at a time.
+type AuditRequest interface {
+	Limit() int
+	Offset() int
+	Entity() string
+	EntityID() string
+	Actor() string
+}
+
+// Audit represents the interface for reading the audit log.
+type Audit interface {
+	FindAll(ctx context.Context, req AuditRequest) (*pkg.PageResponse[*pkg.AuditEntryResponse], error)
+}
+
+// auditApi is an implementation of the Audit interface.
+type auditApi struct {
+	auditAdapter port.AuditPersister
+}
+
+// NewAuditApi creates a new instance of Audit.
+// It takes an adapter for audit log persistence as dependency.
+func NewAuditApi(auditAdapter port.AuditPersister) Audit {
+	return &auditApi{
+		auditAdapter: auditAdapter,
+	}
+}
+
+// FindAll finds a page of audit entries, most recent first.
+// It takes the context and AuditRequest, and returns a page of AuditEntryResponse or an error.
+func (api auditApi) FindAll(ctx context.Context, req AuditRequest) (*pkg.PageResponse[*pkg.AuditEntryResponse], error) {
+	// Validate request
+	vErrs := auditRequestValidation(ctx, req)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	filter := model.AuditFilter{
+		Limit:    req.Limit(),
+		Offset:   req.Offset(),
+		Entity:   req.Entity(),
+		EntityID: req.EntityID(),
+		Actor:    req.Actor(),
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageLimit
+	}
+
+	// Call adapter
+	entries, total, err := api.auditAdapter.FindAll(ctx, filter)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while finding audit entries")
+		return nil, fmt.Errorf("error occurred while finding audit entries: %w", err)
+	}
+
+	// Map to response
+	var response []*pkg.AuditEntryResponse
+	for _, entry := range entries {
+		response = append(response, &pkg.AuditEntryResponse{
+			ID:        entry.ID,
+			Actor:     entry.Actor,
+			Action:    entry.Action,
+			Entity:    entry.Entity,
+			EntityID:  entry.EntityID,
+			Field:     entry.Field,
+			Before:    rawJSON(entry.Before),
+			After:     rawJSON(entry.After),
+			RequestID: entry.RequestID,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+	// Return result
+	return newPage(response, total, model.ListOptions{Limit: filter.Limit, Offset: filter.Offset}), nil
+}
+
+// auditRequestValidation validates the audit request.
+// It takes the context and AuditRequest, and returns a slice of ValidationErrors.
+func auditRequestValidation(ctx context.Context, req AuditRequest) model.ValidationErrors {
+	var vErrs []model.ValidationError
+	if req.Limit() < 0 || req.Limit() > maxPageLimit {
+		vErrs = append(vErrs, model.ValidationError{Field: "limit", Message: fmt.Sprintf("must be between 0 and %d (0 for the default)", maxPageLimit)})
+	}
+	if req.Offset() < 0 {
+		vErrs = append(vErrs, model.ValidationError{Field: "offset", Message: "cannot be negative"})
+	}
+	if req.Entity() != "" && !slices.Contains(auditEntities, req.Entity()) {
+		vErrs = append(vErrs, model.ValidationError{Field: "entity", Message: "must be one of " + fmt.Sprint(auditEntities)})
+	}
+	if req.EntityID() != "" {
+		if _, err := uuid.Parse(req.EntityID()); err != nil {
+			vErrs = append(vErrs, model.ValidationError{Field: "entityID", Message: "must be a UUID"})
+		}
+	}
+	return vErrs
+}
+
+// rawJSON returns the JSON document stored in an audit entry, or nil when there is none.
+func rawJSON(document string) json.RawMessage {
+	if document == "" {
+		return nil
+	}
+	return json.RawMessage(document)
+}
+
+// auditor records the mutating operations on one kind of entity in the audit log.
+type auditor struct {
+	auditAdapter port.AuditPersister
+	entity       string
+}
+
+// record stores an audit entry for an operation on the entity identified by entityID,
+// keeping only the fields that differ between its state before and after the operation.
+// Recording failures are logged and not returned: the operation already happened and must not be reported as failed.
+func (a auditor) record(ctx context.Context, action, entityID, field string, before, after interface{}) {
+	entry := model.AuditEntry{
+		ID:        uuid.New().String(),
+		Action:    action,
+		Entity:    a.entity,
+		EntityID:  entityID,
+		Field:     field,
+		RequestID: model.RequestIDFromContext(ctx),
+	}
+	if claims, ok := model.ClaimsFromContext(ctx); ok {
+		entry.Actor = claims.Subject
+	}
+	var err error
+	entry.Before, entry.After, err = diff(before, after)
+	if err == nil {
+		err = a.auditAdapter.Create(ctx, entry)
+	}
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("entry", entry).Msg("error while recording audit entry")
+	}
+}
+
+// audited runs a mutation of the entity identified by id and records it, along with the state of the entity
+// read with find before and after it. Nothing is recorded when the mutation fails.
+func audited[T any](ctx context.Context, a auditor, action, id, field string, find func(context.Context, string) (T, error), mutate func() error) error {
+	before := state(ctx, find, id)
+	if err := mutate(); err != nil {
+		return err
+	}
+	var after interface{}
+	if action != model.AuditActionDelete {
+		after = state(ctx, find, id)
+	}
+	a.record(ctx, action, id, field, before, after)
+	return nil
+}
+
+// auditedCreate runs a creation and records it, along with the state of the new entity read with find.
+// Nothing is recorded when the creation fails.
+func auditedCreate[T any](ctx context.Context, a auditor, find func(context.Context, string) (T, error), create func() (string, error)) (string, error) {
+	id, err := create()
+	if err != nil {
+		return id, err
+	}
+	a.record(ctx, model.AuditActionCreate, id, "", nil, state(ctx, find, id))
+	return id, nil
+}
+
+// state reads the state of the entity identified by id with find, or returns nil when it cannot be read.
+func state[T any](ctx context.Context, find func(context.Context, string) (T, error), id string) interface{} {
+	value, err := find(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// diff returns the JSON documents of the state of an entity before and after an operation, restricted to
+// the fields that differ when both states are JSON objects. A missing state gives an empty document.
+func diff(before, after interface{}) (string, string, error) {
+	beforeJSON, err := marshalState(before)
+	if err != nil {
+		return "", "", err
+	}
+	afterJSON, err := marshalState(after)
+	if err != nil {
+		return "", "", err
+	}
+
+	var beforeFields, afterFields map[string]json.RawMessage
+	if json.Unmarshal(beforeJSON, &beforeFields) != nil || json.Unmarshal(afterJSON, &afterFields) != nil ||
+		beforeFields == nil || afterFields == nil {
+		return string(beforeJSON), string(afterJSON), nil
+	}
+	for field, value := range beforeFields {
+		if other, ok := afterFields[field]; ok && bytes.Equal(value, other) {
+			delete(beforeFields, field)
+			delete(afterFields, field)
+		}
+	}
+	if beforeJSON, err = json.Marshal(beforeFields); err != nil {
+		return "", "", err
+	}
+	if afterJSON, err = json.Marshal(afterFields); err != nil {
+		return "", "", err
+	}
+	return string(beforeJSON), string(afterJSON), nil
+}
+
+// marshalState encodes the state of an entity, a nil state giving no document.
+func marshalState(value interface{}) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil || bytes.Equal(data, []byte("null")) {
+		return nil, err
+	}
+	return data, nil
+}
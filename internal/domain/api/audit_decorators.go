@@ -0,0 +1,496 @@
+// Package api provides the decorators recording the mutating operations of the APIs in the audit log.
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+// auditedWallApi decorates a Wall api, recording its mutating operations in the audit log.
+type auditedWallApi struct {
+	Wall
+	auditor auditor
+}
+
+// NewAuditedWallApi creates a new instance of Wall recording the mutating operations of wallApi in the audit log.
+// It takes the decorated api and an adapter for audit log persistence as dependencies.
+func NewAuditedWallApi(wallApi Wall, auditAdapter port.AuditPersister) Wall {
+	return &auditedWallApi{
+		Wall:    wallApi,
+		auditor: auditor{auditAdapter: auditAdapter, entity: model.AuditEntityWall},
+	}
+}
+
+// Create creates a new wall and records its creation.
+func (api auditedWallApi) Create(ctx context.Context, req CreateWallRequest) (string, error) {
+	return auditedCreate(ctx, api.auditor, api.Wall.Find, func() (string, error) {
+		return api.Wall.Create(ctx, req)
+	})
+}
+
+// Update updates an existing wall and records the changed fields.
+func (api auditedWallApi) Update(ctx context.Context, uuid string, updates UpdateWallRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionUpdate, uuid, "", api.Wall.Find, func() error {
+		return api.Wall.Update(ctx, uuid, updates)
+	})
+}
+
+// Delete deletes a wall and records its last state.
+func (api auditedWallApi) Delete(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionDelete, uuid, "", api.Wall.Find, func() error {
+		return api.Wall.Delete(ctx, uuid)
+	})
+}
+
+// Restore restores a wall from the trash and records its restored state.
+func (api auditedWallApi) Restore(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionRestore, uuid, "", api.Wall.Find, func() error {
+		return api.Wall.Restore(ctx, uuid)
+	})
+}
+
+// OverwriteBlocks overwrites the blocks of a wall and records the blocks before and after.
+func (api auditedWallApi) OverwriteBlocks(ctx context.Context, wallID string, req OverwriteBlocksRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionOverwrite, wallID, "blocks", api.Wall.FindBlocks, func() error {
+		return api.Wall.OverwriteBlocks(ctx, wallID, req)
+	})
+}
+
+// ReorderBlocks reorders the blocks of a wall and records the blocks before and after.
+func (api auditedWallApi) ReorderBlocks(ctx context.Context, wallID string, req ReorderRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionReorder, wallID, "blocks", api.Wall.FindBlocks, func() error {
+		return api.Wall.ReorderBlocks(ctx, wallID, req)
+	})
+}
+
+// AddBlock adds a block to a wall and records the blocks before and after.
+func (api auditedWallApi) AddBlock(ctx context.Context, wallID string, blockID string) error {
+	return audited(ctx, api.auditor, model.AuditActionAttach, wallID, "blocks", api.Wall.FindBlocks, func() error {
+		return api.Wall.AddBlock(ctx, wallID, blockID)
+	})
+}
+
+// RemoveBlock removes a block from a wall and records the blocks before and after.
+func (api auditedWallApi) RemoveBlock(ctx context.Context, wallID string, blockID string) error {
+	return audited(ctx, api.auditor, model.AuditActionDetach, wallID, "blocks", api.Wall.FindBlocks, func() error {
+		return api.Wall.RemoveBlock(ctx, wallID, blockID)
+	})
+}
+
+// auditedBlockApi decorates a Block api, recording its mutating operations in the audit log.
+type auditedBlockApi struct {
+	Block
+	auditor auditor
+}
+
+// NewAuditedBlockApi creates a new instance of Block recording the mutating operations of blockApi in the audit log.
+// It takes the decorated api and an adapter for audit log persistence as dependencies.
+func NewAuditedBlockApi(blockApi Block, auditAdapter port.AuditPersister) Block {
+	return &auditedBlockApi{
+		Block:   blockApi,
+		auditor: auditor{auditAdapter: auditAdapter, entity: model.AuditEntityBlock},
+	}
+}
+
+// Create creates a new block and records its creation.
+func (api auditedBlockApi) Create(ctx context.Context, req CreateBlockRequest) (string, error) {
+	return auditedCreate(ctx, api.auditor, api.Block.Find, func() (string, error) {
+		return api.Block.Create(ctx, req)
+	})
+}
+
+// Update updates an existing block and records the changed fields.
+func (api auditedBlockApi) Update(ctx context.Context, uuid string, updates UpdateBlockRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionUpdate, uuid, "", api.Block.Find, func() error {
+		return api.Block.Update(ctx, uuid, updates)
+	})
+}
+
+// Delete deletes a block and records its last state.
+func (api auditedBlockApi) Delete(ctx context.Context, uuid string, req DeleteRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionDelete, uuid, "", api.Block.Find, func() error {
+		return api.Block.Delete(ctx, uuid, req)
+	})
+}
+
+// Restore restores a block from the trash and records its restored state.
+func (api auditedBlockApi) Restore(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionRestore, uuid, "", api.Block.Find, func() error {
+		return api.Block.Restore(ctx, uuid)
+	})
+}
+
+// OverwritePrograms overwrites the programs of a block and records the programs before and after.
+func (api auditedBlockApi) OverwritePrograms(ctx context.Context, blockID string, req OverwriteProgramsRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionOverwrite, blockID, "programs", api.Block.FindPrograms, func() error {
+		return api.Block.OverwritePrograms(ctx, blockID, req)
+	})
+}
+
+// ReorderPrograms reorders the programs of a block and records the programs before and after.
+func (api auditedBlockApi) ReorderPrograms(ctx context.Context, blockID string, req ReorderRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionReorder, blockID, "programs", api.Block.FindPrograms, func() error {
+		return api.Block.ReorderPrograms(ctx, blockID, req)
+	})
+}
+
+// AddProgram adds a program to a block and records the programs before and after.
+func (api auditedBlockApi) AddProgram(ctx context.Context, blockID string, programID string) error {
+	return audited(ctx, api.auditor, model.AuditActionAttach, blockID, "programs", api.Block.FindPrograms, func() error {
+		return api.Block.AddProgram(ctx, blockID, programID)
+	})
+}
+
+// RemoveProgram removes a program from a block and records the programs before and after.
+func (api auditedBlockApi) RemoveProgram(ctx context.Context, blockID string, programID string) error {
+	return audited(ctx, api.auditor, model.AuditActionDetach, blockID, "programs", api.Block.FindPrograms, func() error {
+		return api.Block.RemoveProgram(ctx, blockID, programID)
+	})
+}
+
+// auditedProgramApi decorates a Program api, recording its mutating operations in the audit log.
+type auditedProgramApi struct {
+	Program
+	auditor auditor
+}
+
+// NewAuditedProgramApi creates a new instance of Program recording the mutating operations of programApi in the audit log.
+// It takes the decorated api and an adapter for audit log persistence as dependencies.
+func NewAuditedProgramApi(programApi Program, auditAdapter port.AuditPersister) Program {
+	return &auditedProgramApi{
+		Program: programApi,
+		auditor: auditor{auditAdapter: auditAdapter, entity: model.AuditEntityProgram},
+	}
+}
+
+// Create creates a new program and records its creation.
+func (api auditedProgramApi) Create(ctx context.Context, req CreateProgramRequest) (string, error) {
+	return auditedCreate(ctx, api.auditor, api.Program.Find, func() (string, error) {
+		return api.Program.Create(ctx, req)
+	})
+}
+
+// Update updates an existing program and records the changed fields.
+func (api auditedProgramApi) Update(ctx context.Context, uuid string, updates UpdateProgramRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionUpdate, uuid, "", api.Program.Find, func() error {
+		return api.Program.Update(ctx, uuid, updates)
+	})
+}
+
+// Delete deletes a program and records its last state.
+func (api auditedProgramApi) Delete(ctx context.Context, uuid string, req DeleteRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionDelete, uuid, "", api.Program.Find, func() error {
+		return api.Program.Delete(ctx, uuid, req)
+	})
+}
+
+// Restore restores a program from the trash and records its restored state.
+func (api auditedProgramApi) Restore(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionRestore, uuid, "", api.Program.Find, func() error {
+		return api.Program.Restore(ctx, uuid)
+	})
+}
+
+// OverwriteCategories overwrites the categories of a program and records the categories before and after.
+func (api auditedProgramApi) OverwriteCategories(ctx context.Context, programID string, cats []string) error {
+	return audited(ctx, api.auditor, model.AuditActionOverwrite, programID, "categories", api.Program.FindCats, func() error {
+		return api.Program.OverwriteCategories(ctx, programID, cats)
+	})
+}
+
+// OverwriteTags overwrites the tags of a program and records the tags before and after.
+func (api auditedProgramApi) OverwriteTags(ctx context.Context, programID string, tags []string) error {
+	return audited(ctx, api.auditor, model.AuditActionOverwrite, programID, "tags", api.Program.FindTags, func() error {
+		return api.Program.OverwriteTags(ctx, programID, tags)
+	})
+}
+
+// ReorderEpisodes reorders the episodes of a program and records the episodes before and after.
+func (api auditedProgramApi) ReorderEpisodes(ctx context.Context, programID string, req ReorderRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionReorder, programID, "episodes", api.Program.FindEpisodes, func() error {
+		return api.Program.ReorderEpisodes(ctx, programID, req)
+	})
+}
+
+// AddTag adds a tag to a program and records the tags before and after.
+func (api auditedProgramApi) AddTag(ctx context.Context, programID string, tagID string) error {
+	return audited(ctx, api.auditor, model.AuditActionAttach, programID, "tags", api.Program.FindTags, func() error {
+		return api.Program.AddTag(ctx, programID, tagID)
+	})
+}
+
+// RemoveTag removes a tag from a program and records the tags before and after.
+func (api auditedProgramApi) RemoveTag(ctx context.Context, programID string, tagID string) error {
+	return audited(ctx, api.auditor, model.AuditActionDetach, programID, "tags", api.Program.FindTags, func() error {
+		return api.Program.RemoveTag(ctx, programID, tagID)
+	})
+}
+
+// AddCategory adds a category to a program and records the categories before and after.
+func (api auditedProgramApi) AddCategory(ctx context.Context, programID string, categoryID string) error {
+	return audited(ctx, api.auditor, model.AuditActionAttach, programID, "categories", api.Program.FindCats, func() error {
+		return api.Program.AddCategory(ctx, programID, categoryID)
+	})
+}
+
+// RemoveCategory removes a category from a program and records the categories before and after.
+func (api auditedProgramApi) RemoveCategory(ctx context.Context, programID string, categoryID string) error {
+	return audited(ctx, api.auditor, model.AuditActionDetach, programID, "categories", api.Program.FindCats, func() error {
+		return api.Program.RemoveCategory(ctx, programID, categoryID)
+	})
+}
+
+// auditedEpisodeApi decorates a Episode api, recording its mutating operations in the audit log.
+type auditedEpisodeApi struct {
+	Episode
+	auditor auditor
+}
+
+// NewAuditedEpisodeApi creates a new instance of Episode recording the mutating operations of episodeApi in the audit log.
+// It takes the decorated api and an adapter for audit log persistence as dependencies.
+func NewAuditedEpisodeApi(episodeApi Episode, auditAdapter port.AuditPersister) Episode {
+	return &auditedEpisodeApi{
+		Episode: episodeApi,
+		auditor: auditor{auditAdapter: auditAdapter, entity: model.AuditEntityEpisode},
+	}
+}
+
+// Create creates a new episode and records its creation.
+func (api auditedEpisodeApi) Create(ctx context.Context, req CreateEpisodeRequest) (string, error) {
+	return auditedCreate(ctx, api.auditor, api.Episode.Find, func() (string, error) {
+		return api.Episode.Create(ctx, req)
+	})
+}
+
+// Update updates an existing episode and records the changed fields.
+func (api auditedEpisodeApi) Update(ctx context.Context, uuid string, updates UpdateEpisodeRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionUpdate, uuid, "", api.Episode.Find, func() error {
+		return api.Episode.Update(ctx, uuid, updates)
+	})
+}
+
+// Delete deletes a episode and records its last state.
+func (api auditedEpisodeApi) Delete(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionDelete, uuid, "", api.Episode.Find, func() error {
+		return api.Episode.Delete(ctx, uuid)
+	})
+}
+
+// Restore restores an episode from the trash and records its restored state.
+func (api auditedEpisodeApi) Restore(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionRestore, uuid, "", api.Episode.Find, func() error {
+		return api.Episode.Restore(ctx, uuid)
+	})
+}
+
+// Publish publishes an episode and records its status before and after.
+func (api auditedEpisodeApi) Publish(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionPublish, uuid, "", api.Episode.Find, func() error {
+		return api.Episode.Publish(ctx, uuid)
+	})
+}
+
+// Schedule schedules the publication of an episode and records its status and publication time before and after.
+func (api auditedEpisodeApi) Schedule(ctx context.Context, uuid string, req ScheduleEpisodeRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionPublish, uuid, "", api.Episode.Find, func() error {
+		return api.Episode.Schedule(ctx, uuid, req)
+	})
+}
+
+// Unpublish unpublishes an episode and records its status before and after.
+func (api auditedEpisodeApi) Unpublish(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionPublish, uuid, "", api.Episode.Find, func() error {
+		return api.Episode.Unpublish(ctx, uuid)
+	})
+}
+
+// Draft moves an episode back to draft and records its status and publication time before and after.
+func (api auditedEpisodeApi) Draft(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionPublish, uuid, "", api.Episode.Find, func() error {
+		return api.Episode.Draft(ctx, uuid)
+	})
+}
+
+// PublishDue publishes the due scheduled episodes and records the status of each changed episode,
+// which was scheduled before the change.
+func (api auditedEpisodeApi) PublishDue(ctx context.Context, now time.Time) ([]*pkg.EpisodeResponse, error) {
+	changed, err := api.Episode.PublishDue(ctx, now)
+	for _, episode := range changed {
+		before := *episode
+		before.Status = string(model.EpisodeStatusScheduled)
+		api.auditor.record(ctx, model.AuditActionPublish, episode.ID, "", before, episode)
+	}
+	return changed, err
+}
+
+// auditedMediaApi decorates a Media api, recording its mutating operations in the audit log.
+type auditedMediaApi struct {
+	Media
+	auditor auditor
+}
+
+// NewAuditedMediaApi creates a new instance of Media recording the mutating operations of mediaApi in the audit log.
+// It takes the decorated api and an adapter for audit log persistence as dependencies.
+func NewAuditedMediaApi(mediaApi Media, auditAdapter port.AuditPersister) Media {
+	return &auditedMediaApi{
+		Media:   mediaApi,
+		auditor: auditor{auditAdapter: auditAdapter, entity: model.AuditEntityMedia},
+	}
+}
+
+// Create creates a new media and records its creation.
+func (api auditedMediaApi) Create(ctx context.Context, req CreateMediaRequest) (string, error) {
+	return auditedCreate(ctx, api.auditor, api.Media.Find, func() (string, error) {
+		return api.Media.Create(ctx, req)
+	})
+}
+
+// Upload creates a new media from an uploaded file and records its creation.
+func (api auditedMediaApi) Upload(ctx context.Context, req UploadMediaRequest) (string, error) {
+	return auditedCreate(ctx, api.auditor, api.Media.Find, func() (string, error) {
+		return api.Media.Upload(ctx, req)
+	})
+}
+
+// Update updates an existing media and records the changed fields.
+func (api auditedMediaApi) Update(ctx context.Context, uuid string, updates UpdateMediaRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionUpdate, uuid, "", api.Media.Find, func() error {
+		return api.Media.Update(ctx, uuid, updates)
+	})
+}
+
+// Delete deletes a media and records its last state.
+func (api auditedMediaApi) Delete(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionDelete, uuid, "", api.Media.Find, func() error {
+		return api.Media.Delete(ctx, uuid)
+	})
+}
+
+// Restore restores a media from the trash and records its restored state.
+func (api auditedMediaApi) Restore(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionRestore, uuid, "", api.Media.Find, func() error {
+		return api.Media.Restore(ctx, uuid)
+	})
+}
+
+// auditedTagApi decorates a Tag api, recording its mutating operations in the audit log.
+type auditedTagApi struct {
+	Tag
+	auditor auditor
+}
+
+// NewAuditedTagApi creates a new instance of Tag recording the mutating operations of tagApi in the audit log.
+// It takes the decorated api and an adapter for audit log persistence as dependencies.
+func NewAuditedTagApi(tagApi Tag, auditAdapter port.AuditPersister) Tag {
+	return &auditedTagApi{
+		Tag:     tagApi,
+		auditor: auditor{auditAdapter: auditAdapter, entity: model.AuditEntityTag},
+	}
+}
+
+// Create creates a new tag and records its creation.
+func (api auditedTagApi) Create(ctx context.Context, req CreateTagRequest) (string, error) {
+	return auditedCreate(ctx, api.auditor, api.Tag.Find, func() (string, error) {
+		return api.Tag.Create(ctx, req)
+	})
+}
+
+// Update updates an existing tag and records the changed fields.
+func (api auditedTagApi) Update(ctx context.Context, uuid string, updates UpdateTagRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionUpdate, uuid, "", api.Tag.Find, func() error {
+		return api.Tag.Update(ctx, uuid, updates)
+	})
+}
+
+// Delete deletes a tag and records its last state.
+func (api auditedTagApi) Delete(ctx context.Context, uuid string, req DeleteRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionDelete, uuid, "", api.Tag.Find, func() error {
+		return api.Tag.Delete(ctx, uuid, req)
+	})
+}
+
+// Restore restores a tag from the trash and records its restored state.
+func (api auditedTagApi) Restore(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionRestore, uuid, "", api.Tag.Find, func() error {
+		return api.Tag.Restore(ctx, uuid)
+	})
+}
+
+// auditedCategoryApi decorates a Category api, recording its mutating operations in the audit log.
+type auditedCategoryApi struct {
+	Category
+	auditor auditor
+}
+
+// NewAuditedCategoryApi creates a new instance of Category recording the mutating operations of categoryApi in the audit log.
+// It takes the decorated api and an adapter for audit log persistence as dependencies.
+func NewAuditedCategoryApi(categoryApi Category, auditAdapter port.AuditPersister) Category {
+	return &auditedCategoryApi{
+		Category: categoryApi,
+		auditor:  auditor{auditAdapter: auditAdapter, entity: model.AuditEntityCategory},
+	}
+}
+
+// Create creates a new category and records its creation.
+func (api auditedCategoryApi) Create(ctx context.Context, req CreateCategoryRequest) (string, error) {
+	return auditedCreate(ctx, api.auditor, api.Category.Find, func() (string, error) {
+		return api.Category.Create(ctx, req)
+	})
+}
+
+// Update updates an existing category and records the changed fields.
+func (api auditedCategoryApi) Update(ctx context.Context, uuid string, updates UpdateCategoryRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionUpdate, uuid, "", api.Category.Find, func() error {
+		return api.Category.Update(ctx, uuid, updates)
+	})
+}
+
+// Move moves a category under another parent and records its new parent.
+func (api auditedCategoryApi) Move(ctx context.Context, uuid string, req MoveCategoryRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionMove, uuid, "", api.Category.Find, func() error {
+		return api.Category.Move(ctx, uuid, req)
+	})
+}
+
+// Delete deletes a category and records its last state.
+func (api auditedCategoryApi) Delete(ctx context.Context, uuid string, req DeleteRequest) error {
+	return audited(ctx, api.auditor, model.AuditActionDelete, uuid, "", api.Category.Find, func() error {
+		return api.Category.Delete(ctx, uuid, req)
+	})
+}
+
+// Restore restores a category from the trash and records its restored state.
+func (api auditedCategoryApi) Restore(ctx context.Context, uuid string) error {
+	return audited(ctx, api.auditor, model.AuditActionRestore, uuid, "", api.Category.Find, func() error {
+		return api.Category.Restore(ctx, uuid)
+	})
+}
+
+// auditedFeedImportApi decorates a FeedImport api, recording the imports in the audit log.
+type auditedFeedImportApi struct {
+	FeedImport
+	auditor auditor
+}
+
+// NewAuditedFeedImportApi creates a new instance of FeedImport recording the imports of feedImportApi in the audit log.
+// It takes the decorated api and an adapter for audit log persistence as dependencies.
+func NewAuditedFeedImportApi(feedImportApi FeedImport, auditAdapter port.AuditPersister) FeedImport {
+	return &auditedFeedImportApi{
+		FeedImport: feedImportApi,
+		auditor:    auditor{auditAdapter: auditAdapter, entity: model.AuditEntityProgram},
+	}
+}
+
+// Import imports a podcast feed and records the import on its program, along with the summary of its changes.
+func (api auditedFeedImportApi) Import(ctx context.Context, req ImportFeedRequest) (*pkg.ImportResponse, error) {
+	result, err := api.FeedImport.Import(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	api.auditor.record(ctx, model.AuditActionImport, result.ProgramID, "", nil, result)
+	return result, nil
+}
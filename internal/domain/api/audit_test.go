@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+// auditContext returns the context of a request performed by user-1.
+func auditContext() context.Context {
+	ctx := model.ContextWithClaims(context.Background(), &model.Claims{Subject: "user-1"})
+	return model.ContextWithRequestID(ctx, "req-1")
+}
+
+func TestAuditedTagApi(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        func(api Tag) (string, error)
+		failOn     string
+		auditFail  bool
+		wantErr    error
+		wantAction string
+		wantBefore string
+		wantAfter  string
+	}{
+		{
+			name: "records a creation with the new state",
+			run: func(api Tag) (string, error) {
+				return api.Create(auditContext(), pkg.CreateTagRequestJSON{NameJSON: "culture", DescriptionJSON: "culture news"})
+			},
+			wantAction: model.AuditActionCreate,
+			wantAfter:  `"name":"culture","description":"culture news"}`,
+		},
+		{
+			name: "records the changed fields of an update",
+			run: func(api Tag) (string, error) {
+				return "t1", api.Update(auditContext(), "t1", pkg.UpdateTagRequestJSON{NameJSON: "politics", DescriptionJSON: "latest news"})
+			},
+			wantAction: model.AuditActionUpdate,
+			wantBefore: `{"name":"news"}`,
+			wantAfter:  `{"name":"politics"}`,
+		},
+		{
+			name:       "records the last state of a deleted tag",
+			run:        func(api Tag) (string, error) { return "t1", api.Delete(auditContext(), "t1", pkg.DeleteRequestJSON{}) },
+			wantAction: model.AuditActionDelete,
+			wantBefore: `{"ID":"t1","name":"news","description":"latest news"}`,
+		},
+		{
+			name: "records nothing when the operation fails",
+			run: func(api Tag) (string, error) {
+				return "t1", api.Update(auditContext(), "t1", pkg.UpdateTagRequestJSON{NameJSON: "politics", DescriptionJSON: "latest news"})
+			},
+			failOn:  "Update",
+			wantErr: errAdapter,
+		},
+		{
+			name:      "does not fail the operation when the audit log cannot be written",
+			run:       func(api Tag) (string, error) { return "t1", api.Delete(auditContext(), "t1", pkg.DeleteRequestJSON{}) },
+			auditFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := newFakeTagPersister(model.Tag{ID: "t1", Name: "news", Description: "latest news"})
+			if tt.failOn != "" {
+				tags.failOn(tt.failOn)
+			}
+			audits := newFakeAuditPersister()
+			if tt.auditFail {
+				audits.failOn("Create")
+			}
+			api := NewAuditedTagApi(NewTagApi(tags, newFakeProgramPersister(), newFakeProgramTagPersister(), newFakeTxManager()), audits)
+
+			id, err := tt.run(api)
+
+			assertError(t, err, nil, tt.wantErr)
+			if tt.wantAction == "" {
+				if len(audits.rows) != 0 {
+					t.Fatalf("got audit entries %+v, want none", audits.rows)
+				}
+				return
+			}
+			if len(audits.rows) != 1 {
+				t.Fatalf("got %d audit entries, want 1", len(audits.rows))
+			}
+			entry := audits.rows[0]
+			if entry.Action != tt.wantAction || entry.Entity != model.AuditEntityTag || entry.EntityID != id {
+				t.Fatalf("got entry %s %s %s, want %s tag %s", entry.Action, entry.Entity, entry.EntityID, tt.wantAction, id)
+			}
+			if entry.Actor != "user-1" || entry.RequestID != "req-1" || entry.ID == "" {
+				t.Fatalf("got actor %q and request %q, want user-1 and req-1", entry.Actor, entry.RequestID)
+			}
+			if entry.Before != tt.wantBefore || !strings.HasSuffix(entry.After, tt.wantAfter) || (tt.wantAfter == "") != (entry.After == "") {
+				t.Fatalf("got before %s and after %s, want %s and %s", entry.Before, entry.After, tt.wantBefore, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestAuditedProgramApi_OverwriteTags(t *testing.T) {
+	fakes := newProgramFakes()
+	audits := newFakeAuditPersister()
+	api := NewAuditedProgramApi(fakes.api(), audits)
+
+	if err := api.OverwriteTags(auditContext(), "p1", []string{"t1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(audits.rows) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(audits.rows))
+	}
+	entry := audits.rows[0]
+	if entry.Action != model.AuditActionOverwrite || entry.Entity != model.AuditEntityProgram || entry.EntityID != "p1" || entry.Field != "tags" {
+		t.Fatalf("got entry %s %s %s on %q, want overwrite program p1 on tags", entry.Action, entry.Entity, entry.EntityID, entry.Field)
+	}
+	if !strings.Contains(entry.Before, `"t2"`) || strings.Contains(entry.After, `"t2"`) || !strings.Contains(entry.After, `"t1"`) {
+		t.Fatalf("got before %s and after %s, want t2 removed", entry.Before, entry.After)
+	}
+}
+
+func TestAuditedEpisodeApi_Publication(t *testing.T) {
+	now := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	episodes := newFakeEpisodePersister(
+		model.Episode{ID: "e1", Name: "pilot", Status: model.EpisodeStatusDraft},
+		model.Episode{ID: "e2", Name: "finale", Status: model.EpisodeStatusScheduled, PublishAt: now},
+	)
+	medias := newFakeMediaPersister(model.Media{ID: "m1", EpisodeID: "e1"}, model.Media{ID: "m2", EpisodeID: "e2"})
+	audits := newFakeAuditPersister()
+	api := NewAuditedEpisodeApi(NewEpisodeApi(episodes, medias), audits)
+
+	if err := api.Publish(auditContext(), "e1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := api.PublishDue(context.Background(), now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(audits.rows) != 2 {
+		t.Fatalf("got %d audit entries, want 2", len(audits.rows))
+	}
+	published, due := audits.rows[0], audits.rows[1]
+	if published.Action != model.AuditActionPublish || published.EntityID != "e1" || published.Actor != "user-1" ||
+		published.Before != `{"status":"draft"}` || !strings.HasPrefix(published.After, `{"publishAt":`) || !strings.HasSuffix(published.After, `,"status":"published"}`) {
+		t.Fatalf("got entry %+v, want the publication of e1 by user-1", published)
+	}
+	if due.Action != model.AuditActionPublish || due.EntityID != "e2" || due.Actor != "" ||
+		due.Before != `{"status":"scheduled"}` || due.After != `{"status":"published"}` {
+		t.Fatalf("got entry %+v, want the scheduled publication of e2", due)
+	}
+}
+
+func TestAuditApi_FindAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.AuditRequestJSON
+		wantFields []string
+		wantFilter model.AuditFilter
+	}{
+		{
+			name:       "defaults to the first page",
+			wantFilter: model.AuditFilter{Limit: defaultPageLimit},
+		},
+		{
+			name: "filters by entity and actor",
+			req: pkg.AuditRequestJSON{
+				LimitJSON:    5,
+				EntityJSON:   model.AuditEntityProgram,
+				EntityIDJSON: "0e4f8b36-1c8c-4a55-9b7a-0d1e7f3c2a10",
+				ActorJSON:    "user-1",
+			},
+			wantFilter: model.AuditFilter{
+				Limit:    5,
+				Entity:   model.AuditEntityProgram,
+				EntityID: "0e4f8b36-1c8c-4a55-9b7a-0d1e7f3c2a10",
+				Actor:    "user-1",
+			},
+		},
+		{
+			name:       "rejects unknown entity and malformed entity ID",
+			req:        pkg.AuditRequestJSON{EntityJSON: "user", EntityIDJSON: "p1"},
+			wantFields: []string{"entity", "entityID"},
+		},
+		{
+			name:       "rejects out of range limit and offset",
+			req:        pkg.AuditRequestJSON{LimitJSON: maxPageLimit + 1, OffsetJSON: -1},
+			wantFields: []string{"limit", "offset"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audits := newFakeAuditPersister(
+				model.AuditEntry{ID: "a1", Action: model.AuditActionUpdate, Before: `{"name":"news"}`, After: `{"name":"politics"}`},
+				model.AuditEntry{ID: "a2", Action: model.AuditActionDelete, Before: `{"name":"politics"}`},
+			)
+
+			page, err := NewAuditApi(audits).FindAll(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, nil)
+			if err != nil {
+				return
+			}
+			if audits.filtered != tt.wantFilter {
+				t.Fatalf("got filter %+v, want %+v", audits.filtered, tt.wantFilter)
+			}
+			if page.Total != 2 || len(page.Items) != 2 {
+				t.Fatalf("got %d of %d entries, want 2", len(page.Items), page.Total)
+			}
+			if string(page.Items[0].After) != `{"name":"politics"}` || page.Items[1].After != nil {
+				t.Fatalf("got after %s and %s", page.Items[0].After, page.Items[1].After)
+			}
+		})
+	}
+}
@@ -27,13 +27,18 @@ type UpdateBlockRequest interface {
 
 // Block represents the interface for managing blocks.
 type Block interface {
-	Create(ctx context.Context, block CreateBlockRequest) error
+	Create(ctx context.Context, block CreateBlockRequest) (string, error)
 	Update(ctx context.Context, uuid string, updates UpdateBlockRequest) error
 	Find(ctx context.Context, uuid string) (*pkg.BlockResponse, error)
-	FindAll(ctx context.Context) ([]*pkg.BlockResponse, error)
-	Delete(ctx context.Context, uuid string) error
+	FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.BlockResponse], error)
+	Delete(ctx context.Context, uuid string, req DeleteRequest) error
+	Restore(ctx context.Context, uuid string) error
+	FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.BlockResponse], error)
 	FindPrograms(ctx context.Context, uuid string) ([]*pkg.BlockProgramsResponse, error)
 	OverwritePrograms(ctx context.Context, blockID string, req OverwriteProgramsRequest) error
+	ReorderPrograms(ctx context.Context, blockID string, req ReorderRequest) error
+	AddProgram(ctx context.Context, blockID string, programID string) error
+	RemoveProgram(ctx context.Context, blockID string, programID string) error
 }
 
 // blockApi is an implementation of the Block interface.
@@ -41,26 +46,39 @@ type blockApi struct {
 	blockAdapter        port.BlockPersister
 	blockProgramAdapter port.BlockProgramPersister
 	programAdapter      port.ProgramPersister
+	wallBlockAdapter    port.WallBlockPersister
+	wallAdapter         port.WallPersister
+	txManager           port.TxManager
 }
 
 // NewBlockApi creates a new instance of Block.
-// It takes blockAdapter, blockProgramAdapter, and programAdapter as dependencies.
-func NewBlockApi(blockAdapter port.BlockPersister, blockProgramAdapter port.BlockProgramPersister, programAdapter port.ProgramPersister) Block {
+// It takes blockAdapter, blockProgramAdapter, programAdapter, wallBlockAdapter, wallAdapter, and txManager as dependencies.
+func NewBlockApi(
+	blockAdapter port.BlockPersister,
+	blockProgramAdapter port.BlockProgramPersister,
+	programAdapter port.ProgramPersister,
+	wallBlockAdapter port.WallBlockPersister,
+	wallAdapter port.WallPersister,
+	txManager port.TxManager,
+) Block {
 	return &blockApi{
 		blockAdapter:        blockAdapter,
 		blockProgramAdapter: blockProgramAdapter,
 		programAdapter:      programAdapter,
+		wallBlockAdapter:    wallBlockAdapter,
+		wallAdapter:         wallAdapter,
+		txManager:           txManager,
 	}
 }
 
 // Create creates a new block.
-// It takes the context and CreateBlockRequest, and returns an error if any.
-func (api blockApi) Create(ctx context.Context, req CreateBlockRequest) error {
+// It takes the context and CreateBlockRequest, and returns the UUID of the new block or an error.
+func (api blockApi) Create(ctx context.Context, req CreateBlockRequest) (string, error) {
 	// Validate request
 	vErrs := createBlockRequestValidation(ctx, req)
 	if len(vErrs) > 0 {
 		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
-		return fmt.Errorf("request was not validated: %w", vErrs)
+		return "", fmt.Errorf("request was not validated: %w", vErrs)
 	}
 	// Map to domain model
 	block := model.Block{
@@ -72,10 +90,10 @@ func (api blockApi) Create(ctx context.Context, req CreateBlockRequest) error {
 	// Call adapter
 	if err := api.blockAdapter.Create(ctx, block); err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("block", block).Msg("error while creating block")
-		return fmt.Errorf("error occurred while creating block: %w", err)
+		return "", fmt.Errorf("error occurred while creating block: %w", err)
 	}
 
-	return nil
+	return block.ID, nil
 }
 
 // createBlockRequestValidation validates the creation request.
@@ -152,11 +170,22 @@ func (api blockApi) Find(ctx context.Context, uuid string) (*pkg.BlockResponse,
 	return response, nil
 }
 
-// FindAll finds all blocks.
-// It takes the context and returns a slice of BlockResponse or an error.
-func (api blockApi) FindAll(ctx context.Context) ([]*pkg.BlockResponse, error) {
+// blockListing describes how blocks can be sorted and filtered.
+var blockListing = listing{named: true, sortable: []string{model.SortByName, model.SortByCreatedAt, model.SortByKind}}
+
+// FindAll finds a page of blocks.
+// It takes the context and ListRequest, and returns a page of BlockResponse or an error.
+func (api blockApi) FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.BlockResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, blockListing)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := listOptions(req)
+
 	// Call adapter
-	blockSlice, err := api.blockAdapter.FindAll(ctx)
+	blockSlice, total, err := api.blockAdapter.FindAll(ctx, opts)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while finding blocks")
 		return nil, fmt.Errorf("error occurred while finding blocks: %w", err)
@@ -173,19 +202,99 @@ func (api blockApi) FindAll(ctx context.Context) ([]*pkg.BlockResponse, error) {
 		})
 	}
 	// Return result
-	return response, nil
+	return newPage(response, total, opts), nil
 }
 
-// Delete deletes a block by UUID.
-// It takes the context and block UUID, and returns an error if any.
-func (api blockApi) Delete(ctx context.Context, uuid string) error {
-	if err := api.blockAdapter.Delete(ctx, uuid); err != nil {
+// Delete moves a block to the trash by UUID.
+// A block still placed on walls is refused with a model.DependentsError listing them,
+// unless the request cascades, in which case the block is removed from the walls first.
+// It takes the context, block UUID and DeleteRequest, and returns an error if any.
+func (api blockApi) Delete(ctx context.Context, uuid string, req DeleteRequest) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Find the walls the block is placed on
+		associations, err := api.wallBlockAdapter.FindByBlockID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if req.Cascade() {
+			// Remove the block from the walls
+			for _, association := range associations {
+				if err := api.wallBlockAdapter.Delete(ctx, association.ID); err != nil {
+					return err
+				}
+			}
+		} else {
+			// Refuse while live walls still show the block
+			wallIDs := make([]string, 0, len(associations))
+			for _, association := range associations {
+				wallIDs = append(wallIDs, association.WallID)
+			}
+			walls, err := api.wallAdapter.FindByIDs(ctx, wallIDs)
+			if err != nil {
+				return err
+			}
+			var dependents []model.Dependent
+			for _, wall := range walls {
+				dependents = append(dependents, model.Dependent{Entity: model.AuditEntityWall, ID: wall.ID, Name: wall.Name})
+			}
+			if err := dependentsError(model.AuditEntityBlock, uuid, dependents); err != nil {
+				return err
+			}
+		}
+
+		return api.blockAdapter.Delete(ctx, uuid)
+	})
+	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while deleting block")
 		return fmt.Errorf("error occurred while deleting block: %w", err)
 	}
 	return nil
 }
 
+// Restore restores a block from the trash by UUID.
+// It takes the context and block UUID, and returns an error if any.
+func (api blockApi) Restore(ctx context.Context, uuid string) error {
+	if err := api.blockAdapter.Restore(ctx, uuid); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while restoring block")
+		return fmt.Errorf("error occurred while restoring block: %w", err)
+	}
+	return nil
+}
+
+// FindDeleted finds a page of the blocks in the trash, the most recently deleted first unless sorted otherwise.
+// It takes the context and ListRequest, and returns a page of BlockResponse or an error.
+func (api blockApi) FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.BlockResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, blockListing.trash())
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := trashOptions(req)
+
+	// Call adapter
+	blockSlice, total, err := api.blockAdapter.FindAll(ctx, opts)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while finding deleted blocks")
+		return nil, fmt.Errorf("error occurred while finding deleted blocks: %w", err)
+	}
+
+	// Map to response
+	var response []*pkg.BlockResponse
+	for _, block := range blockSlice {
+		response = append(response, &pkg.BlockResponse{
+			ID:          block.ID,
+			Name:        block.Name,
+			Description: block.Description,
+			Kind:        block.Kind,
+			DeletedAt:   optionalTime(block.DeletedAt),
+		})
+	}
+	// Return result
+	return newPage(response, total, opts), nil
+}
+
 // FindPrograms finds programs associated with a block.
 // It takes the context and block UUID, and returns a slice of BlockProgramsResponse or an error.
 func (api blockApi) FindPrograms(ctx context.Context, uuid string) ([]*pkg.BlockProgramsResponse, error) {
@@ -194,11 +303,25 @@ func (api blockApi) FindPrograms(ctx context.Context, uuid string) ([]*pkg.Block
 		return nil, err
 	}
 
+	// Find the associated programs in a single lookup
+	var programIDs []string
+	for _, association := range associations {
+		programIDs = append(programIDs, association.ProgramID)
+	}
+	programs, err := api.programAdapter.FindByIDs(ctx, programIDs)
+	if err != nil {
+		return nil, err
+	}
+	programsByID := make(map[string]*model.Program, len(programs))
+	for _, program := range programs {
+		programsByID[program.ID] = program
+	}
+
 	var response []*pkg.BlockProgramsResponse
 	for _, association := range associations {
-		program, err := api.programAdapter.Find(ctx, association.ProgramID)
-		if err != nil {
-			return nil, err
+		program, ok := programsByID[association.ProgramID]
+		if !ok {
+			continue
 		}
 		blockProgram := &pkg.BlockProgramsResponse{
 			ProgramResponse: pkg.ProgramResponse{
@@ -217,41 +340,151 @@ func (api blockApi) FindPrograms(ctx context.Context, uuid string) ([]*pkg.Block
 
 // OverwritePrograms overwrites the programs associated with a block.
 // It takes the context, block ID, and OverwriteProgramsRequest, and returns an error if any.
+// The existing associations are removed and the new ones created in a single transaction.
 func (api blockApi) OverwritePrograms(ctx context.Context, blockID string, req OverwriteProgramsRequest) error {
-	// Find all existing associations by blockID
-	associations, err := api.blockProgramAdapter.FindByBlockID(ctx, blockID)
-	if err != nil {
-		return err
-	}
+	return api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Find all existing associations by blockID
+		associations, err := api.blockProgramAdapter.FindByBlockID(ctx, blockID)
+		if err != nil {
+			return err
+		}
 
-	// Remove all existing associations for the block
-	for _, association := range associations {
-		err = api.blockProgramAdapter.Delete(ctx, association.ID)
+		// Remove all existing associations for the block
+		for _, association := range associations {
+			err = api.blockProgramAdapter.Delete(ctx, association.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Create all new associations
+		for programID, position := range req.OrderedPrograms() {
+			blockProgram := model.BlockProgram{
+				ID:        uuid.New().String(),
+				BlockID:   blockID,
+				ProgramID: programID,
+				Position:  position,
+			}
+
+			// Call adapter to create block program
+			if err := api.blockProgramAdapter.Create(ctx, blockProgram); err != nil {
+				log.Ctx(ctx).Error().Err(err).Interface("blockProgram", blockProgram).Msg("error while creating block program")
+				return fmt.Errorf("error occurred while creating block program: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// OverwriteProgramsRequest represents the interface for overwriting block program associations.
+type OverwriteProgramsRequest interface {
+	OrderedPrograms() map[string]int
+}
+
+// ReorderPrograms reorders the programs of a block, identified by their program ID, as requested.
+// It takes the context, block ID, and ReorderRequest, and returns an error if any.
+// The positions are renumbered densely from 1 in a single transaction.
+func (api blockApi) ReorderPrograms(ctx context.Context, blockID string, req ReorderRequest) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.blockAdapter.Find(ctx, blockID); err != nil {
+			return err
+		}
+		associations, err := api.blockProgramAdapter.FindByBlockID(ctx, blockID)
 		if err != nil {
 			return err
 		}
+		associations, err = liveAssociations(ctx, associations,
+			func(association *model.BlockProgram) string { return association.ProgramID },
+			api.programAdapter.FindByIDs,
+			func(program *model.Program) string { return program.ID },
+		)
+		if err != nil {
+			return err
+		}
+		return renumber(associations,
+			func(association *model.BlockProgram) string { return association.ProgramID },
+			func(association *model.BlockProgram) *int { return &association.Position },
+			req,
+			func(association *model.BlockProgram) error {
+				return api.blockProgramAdapter.Update(ctx, association.ID, *association)
+			},
+		)
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", blockID).Interface("request", req).Msg("error while reordering block programs")
+		return fmt.Errorf("error occurred while reordering block programs: %w", err)
 	}
+	return nil
+}
 
-	// Create all new associations
-	for programID, position := range req.OrderedPrograms() {
-		blockProgram := model.BlockProgram{
+// AddProgram appends a program to a block, after its last program, doing nothing when the block already holds it.
+// It takes the context, block ID and program ID, and returns an error if any, model.ErrNotFound when either does not exist.
+func (api blockApi) AddProgram(ctx context.Context, blockID string, programID string) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.blockAdapter.Find(ctx, blockID); err != nil {
+			return err
+		}
+		if _, err := api.programAdapter.Find(ctx, programID); err != nil {
+			return err
+		}
+		existing, err := api.blockProgramAdapter.FindByBlockIDAndProgramID(ctx, blockID, programID)
+		if err != nil || len(existing) > 0 {
+			return err
+		}
+		associations, err := api.blockProgramAdapter.FindByBlockID(ctx, blockID)
+		if err != nil {
+			return err
+		}
+		position := 1
+		for _, association := range associations {
+			position = max(position, association.Position+1)
+		}
+		return associated(api.blockProgramAdapter.Create(ctx, model.BlockProgram{
 			ID:        uuid.New().String(),
 			BlockID:   blockID,
 			ProgramID: programID,
 			Position:  position,
-		}
-
-		// Call adapter to create block program
-		if err := api.blockProgramAdapter.Create(ctx, blockProgram); err != nil {
-			log.Ctx(ctx).Error().Err(err).Interface("blockProgram", blockProgram).Msg("error while creating block program")
-			return fmt.Errorf("error occurred while creating block program: %w", err)
-		}
+		}))
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", blockID).Interface("programID", programID).Msg("error while adding block program")
+		return fmt.Errorf("error occurred while adding block program: %w", err)
 	}
-
 	return nil
 }
 
-// OverwriteProgramsRequest represents the interface for overwriting block program associations.
-type OverwriteProgramsRequest interface {
-	OrderedPrograms() map[string]int
+// RemoveProgram removes a program from a block, doing nothing when the block does not hold it.
+// The positions of the remaining programs are renumbered densely from 1, in the same transaction.
+// It takes the context, block ID and program ID, and returns an error if any, model.ErrNotFound when the block does not exist.
+func (api blockApi) RemoveProgram(ctx context.Context, blockID string, programID string) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.blockAdapter.Find(ctx, blockID); err != nil {
+			return err
+		}
+		existing, err := api.blockProgramAdapter.FindByBlockIDAndProgramID(ctx, blockID, programID)
+		if err != nil || len(existing) == 0 {
+			return err
+		}
+		for _, association := range existing {
+			if err := api.blockProgramAdapter.Delete(ctx, association.ID); err != nil {
+				return err
+			}
+		}
+		associations, err := api.blockProgramAdapter.FindByBlockID(ctx, blockID)
+		if err != nil {
+			return err
+		}
+		return closeGaps(associations,
+			func(association *model.BlockProgram) *int { return &association.Position },
+			func(association *model.BlockProgram) error {
+				return api.blockProgramAdapter.Update(ctx, association.ID, *association)
+			},
+		)
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", blockID).Interface("programID", programID).Msg("error while removing block program")
+		return fmt.Errorf("error occurred while removing block program: %w", err)
+	}
+	return nil
 }
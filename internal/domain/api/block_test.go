@@ -0,0 +1,512 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+func TestBlockApi_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.CreateBlockRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+	}{
+		{
+			name: "creates the block",
+			req:  pkg.CreateBlockRequestJSON{NameJSON: "news", DescriptionJSON: "latest news", KindJSON: "carousel"},
+		},
+		{
+			name:       "requires name and description",
+			req:        pkg.CreateBlockRequestJSON{KindJSON: "carousel"},
+			wantFields: []string{"name", "description"},
+		},
+		{
+			name:       "requires description",
+			req:        pkg.CreateBlockRequestJSON{NameJSON: "news"},
+			wantFields: []string{"description"},
+		},
+		{
+			name:    "wraps adapter failure",
+			req:     pkg.CreateBlockRequestJSON{NameJSON: "news", DescriptionJSON: "latest news"},
+			failOn:  "Create",
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := newFakeBlockPersister()
+			if tt.failOn != "" {
+				blocks.failOn(tt.failOn)
+			}
+			api := NewBlockApi(blocks, newFakeBlockProgramPersister(), newFakeProgramPersister(), newFakeWallBlockPersister(), newFakeWallPersister(), newFakeTxManager())
+
+			id, err := api.Create(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if err == nil {
+				if len(blocks.rows) != 1 || blocks.rows[0].ID != id || blocks.rows[0].Kind != tt.req.KindJSON {
+					t.Fatalf("unexpected stored blocks: %+v", blocks.rows)
+				}
+			}
+		})
+	}
+}
+
+func TestBlockApi_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		uuid       string
+		req        pkg.UpdateBlockRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+		want       model.Block
+	}{
+		{
+			name: "updates only the given fields",
+			uuid: "b1",
+			req:  pkg.UpdateBlockRequestJSON{KindJSON: "grid"},
+			want: model.Block{ID: "b1", Name: "news", Description: "latest news", Kind: "grid"},
+		},
+		{
+			name:       "requires the uuid",
+			req:        pkg.UpdateBlockRequestJSON{KindJSON: "grid"},
+			wantFields: []string{"uuid"},
+			want:       model.Block{ID: "b1", Name: "news", Description: "latest news", Kind: "carousel"},
+		},
+		{
+			name:    "wraps adapter failure",
+			uuid:    "b1",
+			req:     pkg.UpdateBlockRequestJSON{KindJSON: "grid"},
+			failOn:  "Update",
+			wantErr: errAdapter,
+			want:    model.Block{ID: "b1", Name: "news", Description: "latest news", Kind: "carousel"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := newFakeBlockPersister(model.Block{ID: "b1", Name: "news", Description: "latest news", Kind: "carousel"})
+			if tt.failOn != "" {
+				blocks.failOn(tt.failOn)
+			}
+			api := NewBlockApi(blocks, newFakeBlockProgramPersister(), newFakeProgramPersister(), newFakeWallBlockPersister(), newFakeWallPersister(), newFakeTxManager())
+
+			err := api.Update(context.Background(), tt.uuid, tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if got := blocks.rows[0]; got.Name != tt.want.Name || got.Description != tt.want.Description || got.Kind != tt.want.Kind {
+				t.Fatalf("got block %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockApi_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		failOn  string
+		call    func(api Block) (int, error)
+		want    int
+		wantErr error
+	}{
+		{
+			name: "finds a block",
+			call: func(api Block) (int, error) {
+				block, err := api.Find(context.Background(), "b1")
+				if err != nil {
+					return 0, err
+				}
+				if block.Kind != "carousel" {
+					t.Fatalf("unexpected block: %+v", block)
+				}
+				return 1, nil
+			},
+			want: 1,
+		},
+		{
+			name:    "wraps find failure",
+			failOn:  "Find",
+			call:    func(api Block) (int, error) { _, err := api.Find(context.Background(), "b1"); return 0, err },
+			wantErr: errAdapter,
+		},
+		{
+			name:    "reports a missing block",
+			call:    func(api Block) (int, error) { _, err := api.Find(context.Background(), "b9"); return 0, err },
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "reports the deletion of a missing block",
+			call: func(api Block) (int, error) {
+				return 0, api.Delete(context.Background(), "b9", pkg.DeleteRequestJSON{})
+			},
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "finds all blocks",
+			call: func(api Block) (int, error) {
+				blocks, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				if err != nil {
+					return 0, err
+				}
+				return len(blocks.Items), nil
+			},
+			want: 2,
+		},
+		{
+			name:   "wraps find all failure",
+			failOn: "FindAll",
+			call: func(api Block) (int, error) {
+				_, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				return 0, err
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name:   "wraps delete failure",
+			failOn: "Delete",
+			call: func(api Block) (int, error) {
+				return 0, api.Delete(context.Background(), "b1", pkg.DeleteRequestJSON{})
+			},
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := newFakeBlockPersister(model.Block{ID: "b1", Name: "news", Kind: "carousel"}, model.Block{ID: "b2", Name: "sport"})
+			if tt.failOn != "" {
+				blocks.failOn(tt.failOn)
+			}
+			api := NewBlockApi(blocks, newFakeBlockProgramPersister(), newFakeProgramPersister(), newFakeWallBlockPersister(), newFakeWallPersister(), newFakeTxManager())
+
+			got, err := tt.call(api)
+
+			assertError(t, err, nil, tt.wantErr)
+			if got != tt.want {
+				t.Fatalf("got %d results, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockApi_FindPrograms(t *testing.T) {
+	tests := []struct {
+		name         string
+		failOn       string
+		failPrograms bool
+		wantIDs      []string
+		wantErr      error
+	}{
+		{
+			name:    "returns the programs ordered by position",
+			wantIDs: []string{"p2", "p1"},
+		},
+		{
+			name:    "fails when associations cannot be read",
+			failOn:  "FindByBlockID",
+			wantErr: errAdapter,
+		},
+		{
+			name:         "fails when a program cannot be read",
+			failPrograms: true,
+			wantErr:      errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockPrograms := newFakeBlockProgramPersister(
+				model.BlockProgram{ID: "bp1", BlockID: "b1", ProgramID: "p1", Position: 2},
+				model.BlockProgram{ID: "bp2", BlockID: "b1", ProgramID: "p2", Position: 1},
+				model.BlockProgram{ID: "bp3", BlockID: "b2", ProgramID: "p1", Position: 1},
+			)
+			programs := newFakeProgramPersister(model.Program{ID: "p1", Name: "morning"}, model.Program{ID: "p2", Name: "evening"})
+			if tt.failOn != "" {
+				blockPrograms.failOn(tt.failOn)
+			}
+			if tt.failPrograms {
+				programs.failOn("FindByIDs")
+			}
+			api := NewBlockApi(newFakeBlockPersister(), blockPrograms, programs, newFakeWallBlockPersister(), newFakeWallPersister(), newFakeTxManager())
+
+			response, err := api.FindPrograms(context.Background(), "b1")
+
+			assertError(t, err, nil, tt.wantErr)
+			var gotIDs []string
+			for i, program := range response {
+				gotIDs = append(gotIDs, program.ID)
+				if program.Position != i+1 {
+					t.Fatalf("program %s has position %d, want %d", program.ID, program.Position, i+1)
+				}
+			}
+			if !equalStrings(gotIDs, tt.wantIDs) {
+				t.Fatalf("got programs %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestBlockApi_OverwritePrograms(t *testing.T) {
+	tests := []struct {
+		name        string
+		ordered     map[string]int
+		failOn      string
+		wantErr     error
+		wantProgram map[string]int
+	}{
+		{
+			name:        "replaces the block programs",
+			ordered:     map[string]int{"p3": 1, "p1": 2},
+			wantProgram: map[string]int{"p3": 1, "p1": 2},
+		},
+		{
+			name:        "clears the block programs",
+			ordered:     map[string]int{},
+			wantProgram: map[string]int{},
+		},
+		{
+			name:        "fails when associations cannot be read",
+			ordered:     map[string]int{"p3": 1},
+			failOn:      "FindByBlockID",
+			wantErr:     errAdapter,
+			wantProgram: map[string]int{"p1": 1, "p2": 2},
+		},
+		{
+			name:        "fails when an association cannot be removed",
+			ordered:     map[string]int{"p3": 1},
+			failOn:      "Delete",
+			wantErr:     errAdapter,
+			wantProgram: map[string]int{"p1": 1, "p2": 2},
+		},
+		{
+			name:        "rolls back when an association cannot be created",
+			ordered:     map[string]int{"p3": 1},
+			failOn:      "Create",
+			wantErr:     errAdapter,
+			wantProgram: map[string]int{"p1": 1, "p2": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockPrograms := newFakeBlockProgramPersister(
+				model.BlockProgram{ID: "bp1", BlockID: "b1", ProgramID: "p1", Position: 1},
+				model.BlockProgram{ID: "bp2", BlockID: "b1", ProgramID: "p2", Position: 2},
+				model.BlockProgram{ID: "bp3", BlockID: "b2", ProgramID: "p1", Position: 1},
+			)
+			if tt.failOn != "" {
+				blockPrograms.failOn(tt.failOn)
+			}
+			api := NewBlockApi(newFakeBlockPersister(), blockPrograms, newFakeProgramPersister(), newFakeWallBlockPersister(), newFakeWallPersister(), newFakeTxManager(blockPrograms))
+
+			err := api.OverwritePrograms(context.Background(), "b1", pkg.OverwriteProgramsRequestJSON{OrderedProgramsJSON: tt.ordered})
+
+			assertError(t, err, nil, tt.wantErr)
+			got := make(map[string]int)
+			for _, blockProgram := range blockPrograms.rows {
+				if blockProgram.BlockID == "b1" {
+					got[blockProgram.ProgramID] = blockProgram.Position
+				}
+			}
+			if len(got) != len(tt.wantProgram) {
+				t.Fatalf("got programs %v, want %v", got, tt.wantProgram)
+			}
+			for programID, position := range tt.wantProgram {
+				if got[programID] != position {
+					t.Fatalf("got programs %v, want %v", got, tt.wantProgram)
+				}
+			}
+			if len(blockPrograms.where(func(bp model.BlockProgram) bool { return bp.BlockID == "b2" })) != 1 {
+				t.Fatal("programs of another block were rewritten")
+			}
+		})
+	}
+}
+
+func TestBlockApi_ReorderPrograms(t *testing.T) {
+	tests := []struct {
+		name          string
+		block         string
+		req           pkg.ReorderRequestJSON
+		failOn        string
+		wantFields    []string
+		wantErr       error
+		wantPositions map[string]int
+	}{
+		{
+			name:          "renumbers the listed programs densely",
+			block:         "b1",
+			req:           pkg.ReorderRequestJSON{IDsJSON: []string{"p3", "p1", "p2"}},
+			wantPositions: map[string]int{"bp1": 2, "bp2": 3, "bp3": 1, "bp4": 1, "bp5": 4},
+		},
+		{
+			name:          "moves a program",
+			block:         "b1",
+			req:           pkg.ReorderRequestJSON{FromJSON: 3, ToJSON: 1},
+			wantPositions: map[string]int{"bp1": 2, "bp2": 3, "bp3": 1, "bp4": 1, "bp5": 4},
+		},
+		{
+			name:          "rejects duplicates",
+			block:         "b1",
+			req:           pkg.ReorderRequestJSON{IDsJSON: []string{"p1", "p1", "p3"}},
+			wantFields:    []string{"ids"},
+			wantPositions: map[string]int{"bp1": 2, "bp2": 2, "bp3": 7, "bp4": 1, "bp5": 4},
+		},
+		{
+			name:          "refuses a program in the trash",
+			block:         "b1",
+			req:           pkg.ReorderRequestJSON{IDsJSON: []string{"p3", "p1", "p2", "p4"}},
+			wantFields:    []string{"ids"},
+			wantPositions: map[string]int{"bp1": 2, "bp2": 2, "bp3": 7, "bp4": 1, "bp5": 4},
+		},
+		{
+			name:          "refuses an unknown block",
+			block:         "unknown",
+			req:           pkg.ReorderRequestJSON{FromJSON: 1, ToJSON: 1},
+			wantErr:       model.ErrNotFound,
+			wantPositions: map[string]int{"bp1": 2, "bp2": 2, "bp3": 7, "bp4": 1, "bp5": 4},
+		},
+		{
+			name:          "rolls back the positions on failure",
+			block:         "b1",
+			req:           pkg.ReorderRequestJSON{IDsJSON: []string{"p3", "p1", "p2"}},
+			failOn:        "Update",
+			wantErr:       errAdapter,
+			wantPositions: map[string]int{"bp1": 2, "bp2": 2, "bp3": 7, "bp4": 1, "bp5": 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockPrograms := newFakeBlockProgramPersister(
+				model.BlockProgram{ID: "bp1", BlockID: "b1", ProgramID: "p1", Position: 2},
+				model.BlockProgram{ID: "bp2", BlockID: "b1", ProgramID: "p2", Position: 2},
+				model.BlockProgram{ID: "bp3", BlockID: "b1", ProgramID: "p3", Position: 7},
+				model.BlockProgram{ID: "bp4", BlockID: "b2", ProgramID: "p1", Position: 1},
+				model.BlockProgram{ID: "bp5", BlockID: "b1", ProgramID: "p4", Position: 4},
+			)
+			if tt.failOn != "" {
+				blockPrograms.failOn(tt.failOn)
+			}
+			blocks := newFakeBlockPersister(model.Block{ID: "b1", Name: "morning shows"}, model.Block{ID: "b2", Name: "evening shows"})
+			programs := newFakeProgramPersister(model.Program{ID: "p1", Name: "news"}, model.Program{ID: "p2", Name: "sport"}, model.Program{ID: "p3", Name: "weather"})
+			programs.trash = []model.Program{{ID: "p4", Name: "traffic"}}
+			api := NewBlockApi(blocks, blockPrograms, programs, newFakeWallBlockPersister(), newFakeWallPersister(), newFakeTxManager(blockPrograms))
+
+			err := api.ReorderPrograms(context.Background(), tt.block, tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			for _, blockProgram := range blockPrograms.rows {
+				if blockProgram.Position != tt.wantPositions[blockProgram.ID] {
+					t.Fatalf("association %s has position %d, want %d", blockProgram.ID, blockProgram.Position, tt.wantPositions[blockProgram.ID])
+				}
+			}
+		})
+	}
+}
+
+func TestBlockApi_AddAndRemoveProgram(t *testing.T) {
+	tests := []struct {
+		name          string
+		remove        bool
+		block         string
+		program       string
+		failOn        string
+		wantErr       error
+		wantPositions map[string]int
+	}{
+		{
+			name:          "appends a program after the last one",
+			block:         "b1",
+			program:       "p4",
+			wantPositions: map[string]int{"p1": 2, "p2": 4, "p3": 7, "p4": 8},
+		},
+		{
+			name:          "adding a program twice does nothing",
+			block:         "b1",
+			program:       "p2",
+			wantPositions: map[string]int{"p1": 2, "p2": 4, "p3": 7},
+		},
+		{
+			name:          "refuses an unknown program",
+			block:         "b1",
+			program:       "unknown",
+			wantErr:       model.ErrNotFound,
+			wantPositions: map[string]int{"p1": 2, "p2": 4, "p3": 7},
+		},
+		{
+			name:          "removes a program and closes the gaps",
+			remove:        true,
+			block:         "b1",
+			program:       "p2",
+			wantPositions: map[string]int{"p1": 1, "p3": 2},
+		},
+		{
+			name:          "removing a missing program does nothing",
+			remove:        true,
+			block:         "b1",
+			program:       "p4",
+			wantPositions: map[string]int{"p1": 2, "p2": 4, "p3": 7},
+		},
+		{
+			name:          "refuses an unknown block",
+			remove:        true,
+			block:         "unknown",
+			program:       "p1",
+			wantErr:       model.ErrNotFound,
+			wantPositions: map[string]int{},
+		},
+		{
+			name:          "rolls back the removal when a position cannot be saved",
+			remove:        true,
+			block:         "b1",
+			program:       "p1",
+			failOn:        "Update",
+			wantErr:       errAdapter,
+			wantPositions: map[string]int{"p1": 2, "p2": 4, "p3": 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockPrograms := newFakeBlockProgramPersister(
+				model.BlockProgram{ID: "bp1", BlockID: "b1", ProgramID: "p1", Position: 2},
+				model.BlockProgram{ID: "bp2", BlockID: "b1", ProgramID: "p2", Position: 4},
+				model.BlockProgram{ID: "bp3", BlockID: "b1", ProgramID: "p3", Position: 7},
+				model.BlockProgram{ID: "bp4", BlockID: "b2", ProgramID: "p1", Position: 1},
+			)
+			if tt.failOn != "" {
+				blockPrograms.failOn(tt.failOn)
+			}
+			blocks := newFakeBlockPersister(model.Block{ID: "b1", Name: "morning shows"}, model.Block{ID: "b2", Name: "evening shows"})
+			programs := newFakeProgramPersister(
+				model.Program{ID: "p1", Name: "news"},
+				model.Program{ID: "p2", Name: "sport"},
+				model.Program{ID: "p3", Name: "music"},
+				model.Program{ID: "p4", Name: "culture"},
+			)
+			api := NewBlockApi(blocks, blockPrograms, programs, newFakeWallBlockPersister(), newFakeWallPersister(), newFakeTxManager(blockPrograms))
+
+			var err error
+			if tt.remove {
+				err = api.RemoveProgram(context.Background(), tt.block, tt.program)
+			} else {
+				err = api.AddProgram(context.Background(), tt.block, tt.program)
+			}
+
+			assertError(t, err, nil, tt.wantErr)
+			got := make(map[string]int)
+			for _, blockProgram := range blockPrograms.where(func(bp model.BlockProgram) bool { return bp.BlockID == tt.block }) {
+				got[blockProgram.ProgramID] = blockProgram.Position
+			}
+			if len(got) != len(tt.wantPositions) {
+				t.Fatalf("got programs %v, want %v", got, tt.wantPositions)
+			}
+			for programID, position := range tt.wantPositions {
+				if got[programID] != position {
+					t.Fatalf("got programs %v, want %v", got, tt.wantPositions)
+				}
+			}
+		})
+	}
+}
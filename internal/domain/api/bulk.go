@@ -0,0 +1,322 @@
+// Package api provides functionality for creating, updating and deleting catalogue entities in bulk.
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"github.com/rs/zerolog/log"
+)
+
+// maxBulkItems is the largest number of items a bulk operation can hold.
+const maxBulkItems = 100
+
+// BulkUpdateProgramRequest represents the interface for updating one program of a bulk update.
+type BulkUpdateProgramRequest interface {
+	ID() string
+	UpdateProgramRequest
+}
+
+// BulkUpdateEpisodeRequest represents the interface for updating one episode of a bulk update.
+type BulkUpdateEpisodeRequest interface {
+	ID() string
+	UpdateEpisodeRequest
+}
+
+// BulkUpdateMediaRequest represents the interface for updating one media of a bulk update.
+type BulkUpdateMediaRequest interface {
+	ID() string
+	UpdateMediaRequest
+}
+
+// BulkUpdateTagRequest represents the interface for updating one tag of a bulk update.
+type BulkUpdateTagRequest interface {
+	ID() string
+	UpdateTagRequest
+}
+
+// Bulk represents the interface for creating, updating and deleting catalogue entities in bulk.
+// Every item of an operation is validated before any is saved, and the items are saved in a single transaction:
+// when an item fails, none is saved and the returned model.BulkError lists the failed items by their index.
+// The items following a failure that is not a validation error, model.ErrNotFound or model.ErrConflict are not tried.
+type Bulk interface {
+	CreatePrograms(ctx context.Context, reqs []CreateProgramRequest) (*pkg.BulkResponse, error)
+	UpdatePrograms(ctx context.Context, reqs []BulkUpdateProgramRequest) (*pkg.BulkResponse, error)
+	DeletePrograms(ctx context.Context, ids []string, req DeleteRequest) (*pkg.BulkResponse, error)
+	CreateEpisodes(ctx context.Context, reqs []CreateEpisodeRequest) (*pkg.BulkResponse, error)
+	UpdateEpisodes(ctx context.Context, reqs []BulkUpdateEpisodeRequest) (*pkg.BulkResponse, error)
+	DeleteEpisodes(ctx context.Context, ids []string) (*pkg.BulkResponse, error)
+	CreateMedias(ctx context.Context, reqs []CreateMediaRequest) (*pkg.BulkResponse, error)
+	UpdateMedias(ctx context.Context, reqs []BulkUpdateMediaRequest) (*pkg.BulkResponse, error)
+	DeleteMedias(ctx context.Context, ids []string) (*pkg.BulkResponse, error)
+	CreateTags(ctx context.Context, reqs []CreateTagRequest) (*pkg.BulkResponse, error)
+	UpdateTags(ctx context.Context, reqs []BulkUpdateTagRequest) (*pkg.BulkResponse, error)
+	DeleteTags(ctx context.Context, ids []string, req DeleteRequest) (*pkg.BulkResponse, error)
+}
+
+// bulkApi is an implementation of the Bulk interface.
+type bulkApi struct {
+	programApi Program
+	episodeApi Episode
+	mediaApi   Media
+	tagApi     Tag
+	txManager  port.TxManager
+}
+
+// NewBulkApi creates a new instance of Bulk.
+// It takes the apis saving each item, so that items are saved, and audited, as if they were sent one by one,
+// and txManager as dependencies.
+func NewBulkApi(programApi Program, episodeApi Episode, mediaApi Media, tagApi Tag, txManager port.TxManager) Bulk {
+	return &bulkApi{
+		programApi: programApi,
+		episodeApi: episodeApi,
+		mediaApi:   mediaApi,
+		tagApi:     tagApi,
+		txManager:  txManager,
+	}
+}
+
+// CreatePrograms creates several programs.
+// It takes the context and the CreateProgramRequest of each program, and returns the ID of each program or an error.
+func (api bulkApi) CreatePrograms(ctx context.Context, reqs []CreateProgramRequest) (*pkg.BulkResponse, error) {
+	return bulk(ctx, api.txManager, "creating programs", reqs,
+		func(req CreateProgramRequest) model.ValidationErrors { return createProgramRequestValidation(ctx, req) },
+		func(ctx context.Context, req CreateProgramRequest) (string, error) {
+			return api.programApi.Create(ctx, req)
+		},
+	)
+}
+
+// UpdatePrograms updates several programs.
+// It takes the context and the BulkUpdateProgramRequest of each program, and returns the ID of each program or an error.
+func (api bulkApi) UpdatePrograms(ctx context.Context, reqs []BulkUpdateProgramRequest) (*pkg.BulkResponse, error) {
+	return bulk(ctx, api.txManager, "updating programs", reqs,
+		func(req BulkUpdateProgramRequest) model.ValidationErrors {
+			return updateProgramRequestValidation(ctx, req.ID(), req)
+		},
+		func(ctx context.Context, req BulkUpdateProgramRequest) (string, error) {
+			return req.ID(), api.programApi.Update(ctx, req.ID(), req)
+		},
+	)
+}
+
+// DeletePrograms moves several programs to the trash, cascading to their references as the DeleteRequest tells.
+// It takes the context, the program IDs and DeleteRequest, and returns the ID of each program or an error.
+func (api bulkApi) DeletePrograms(ctx context.Context, ids []string, req DeleteRequest) (*pkg.BulkResponse, error) {
+	return bulk(ctx, api.txManager, "deleting programs", ids, bulkDeleteValidation, func(ctx context.Context, id string) (string, error) {
+		return id, api.programApi.Delete(ctx, id, req)
+	})
+}
+
+// CreateEpisodes creates several episodes.
+// It takes the context and the CreateEpisodeRequest of each episode, and returns the ID of each episode or an error.
+func (api bulkApi) CreateEpisodes(ctx context.Context, reqs []CreateEpisodeRequest) (*pkg.BulkResponse, error) {
+	return bulk(ctx, api.txManager, "creating episodes", reqs,
+		func(req CreateEpisodeRequest) model.ValidationErrors { return createEpisodeRequestValidation(ctx, req) },
+		func(ctx context.Context, req CreateEpisodeRequest) (string, error) {
+			return api.episodeApi.Create(ctx, req)
+		},
+	)
+}
+
+// UpdateEpisodes updates several episodes.
+// It takes the context and the BulkUpdateEpisodeRequest of each episode, and returns the ID of each episode or an error.
+func (api bulkApi) UpdateEpisodes(ctx context.Context, reqs []BulkUpdateEpisodeRequest) (*pkg.BulkResponse, error) {
+	return bulk(ctx, api.txManager, "updating episodes", reqs,
+		func(req BulkUpdateEpisodeRequest) model.ValidationErrors {
+			return updateEpisodeRequestValidation(ctx, req.ID(), req)
+		},
+		func(ctx context.Context, req BulkUpdateEpisodeRequest) (string, error) {
+			return req.ID(), api.episodeApi.Update(ctx, req.ID(), req)
+		},
+	)
+}
+
+// DeleteEpisodes moves several episodes to the trash, along with their medias.
+// It takes the context and the episode IDs, and returns the ID of each episode or an error.
+func (api bulkApi) DeleteEpisodes(ctx context.Context, ids []string) (*pkg.BulkResponse, error) {
+	return bulk(ctx, api.txManager, "deleting episodes", ids, bulkDeleteValidation, func(ctx context.Context, id string) (string, error) {
+		return id, api.episodeApi.Delete(ctx, id)
+	})
+}
+
+// CreateMedias creates several medias from their direct links.
+// The linked files are described before the transaction is opened, and their stored artworks are removed
+// when the medias are not created.
+// It takes the context and the CreateMediaRequest of each media, and returns the ID of each media or an error.
+func (api bulkApi) CreateMedias(ctx context.Context, reqs []CreateMediaRequest) (*pkg.BulkResponse, error) {
+	if err := validateBulk(ctx, reqs, func(req CreateMediaRequest) model.ValidationErrors {
+		return createMediaRequestValidation(ctx, req)
+	}); err != nil {
+		return nil, err
+	}
+
+	prepared := make([]CreateMediaRequest, 0, len(reqs))
+	for _, req := range reqs {
+		prepared = append(prepared, api.mediaApi.Prepare(ctx, req))
+	}
+	response, err := saveBulk(ctx, api.txManager, "creating medias", prepared, api.mediaApi.Create)
+	if err != nil {
+		for _, req := range prepared {
+			api.mediaApi.Discard(ctx, req)
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateMedias updates several medias.
+// The files of the new links are described before the transaction is opened. The objects of the replaced files
+// are removed once the transaction is committed, and the artworks of the new files when it is rolled back.
+// It takes the context and the BulkUpdateMediaRequest of each media, and returns the ID of each media or an error.
+func (api bulkApi) UpdateMedias(ctx context.Context, reqs []BulkUpdateMediaRequest) (*pkg.BulkResponse, error) {
+	if err := validateBulk(ctx, reqs, func(req BulkUpdateMediaRequest) model.ValidationErrors {
+		return updateMediaRequestValidation(ctx, req.ID(), req)
+	}); err != nil {
+		return nil, err
+	}
+
+	prepared := make([]preparedBulkUpdate, 0, len(reqs))
+	for _, req := range reqs {
+		prepared = append(prepared, preparedBulkUpdate{id: req.ID(), updates: api.mediaApi.PrepareUpdate(ctx, req)})
+	}
+	response, err := saveBulk(ctx, api.txManager, "updating medias", prepared, func(ctx context.Context, item preparedBulkUpdate) (string, error) {
+		return item.id, api.mediaApi.Update(ctx, item.id, item.updates)
+	})
+	for _, item := range prepared {
+		api.mediaApi.DiscardUpdate(ctx, item.updates, err == nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// preparedBulkUpdate is a media update of a bulk update, prepared before its transaction.
+type preparedBulkUpdate struct {
+	id      string
+	updates UpdateMediaRequest
+}
+
+// DeleteMedias moves several medias to the trash.
+// It takes the context and the media IDs, and returns the ID of each media or an error.
+func (api bulkApi) DeleteMedias(ctx context.Context, ids []string) (*pkg.BulkResponse, error) {
+	return bulk(ctx, api.txManager, "deleting medias", ids, bulkDeleteValidation, func(ctx context.Context, id string) (string, error) {
+		return id, api.mediaApi.Delete(ctx, id)
+	})
+}
+
+// CreateTags creates several tags.
+// It takes the context and the CreateTagRequest of each tag, and returns the ID of each tag or an error.
+func (api bulkApi) CreateTags(ctx context.Context, reqs []CreateTagRequest) (*pkg.BulkResponse, error) {
+	return bulk(ctx, api.txManager, "creating tags", reqs,
+		func(req CreateTagRequest) model.ValidationErrors { return createTagRequestValidation(ctx, req) },
+		func(ctx context.Context, req CreateTagRequest) (string, error) { return api.tagApi.Create(ctx, req) },
+	)
+}
+
+// UpdateTags updates several tags.
+// It takes the context and the BulkUpdateTagRequest of each tag, and returns the ID of each tag or an error.
+func (api bulkApi) UpdateTags(ctx context.Context, reqs []BulkUpdateTagRequest) (*pkg.BulkResponse, error) {
+	return bulk(ctx, api.txManager, "updating tags", reqs,
+		func(req BulkUpdateTagRequest) model.ValidationErrors {
+			return updateTagRequestValidation(ctx, req.ID(), req)
+		},
+		func(ctx context.Context, req BulkUpdateTagRequest) (string, error) {
+			return req.ID(), api.tagApi.Update(ctx, req.ID(), req)
+		},
+	)
+}
+
+// DeleteTags moves several tags to the trash, cascading to their references as the DeleteRequest tells.
+// It takes the context, the tag IDs and DeleteRequest, and returns the ID of each tag or an error.
+func (api bulkApi) DeleteTags(ctx context.Context, ids []string, req DeleteRequest) (*pkg.BulkResponse, error) {
+	return bulk(ctx, api.txManager, "deleting tags", ids, bulkDeleteValidation, func(ctx context.Context, id string) (string, error) {
+		return id, api.tagApi.Delete(ctx, id, req)
+	})
+}
+
+// bulkDeleteValidation validates the ID of an item of a bulk deletion.
+func bulkDeleteValidation(id string) model.ValidationErrors {
+	var vErrs []model.ValidationError
+	if id == "" {
+		vErrs = append(vErrs, model.ValidationError{Field: "uuid", Message: "cannot be empty"})
+	}
+	return vErrs
+}
+
+// bulk validates every item with validate, then saves them one after the other with save in a single transaction.
+// It returns the ID of each item, or a model.BulkError listing the items that failed validation or could not be saved.
+func bulk[R any](ctx context.Context, txManager port.TxManager, operation string, reqs []R, validate func(R) model.ValidationErrors, save func(context.Context, R) (string, error)) (*pkg.BulkResponse, error) {
+	if err := validateBulk(ctx, reqs, validate); err != nil {
+		return nil, err
+	}
+	return saveBulk(ctx, txManager, operation, reqs, save)
+}
+
+// validateBulk validates the number of items of a bulk operation, then every item with validate.
+// It returns a model.BulkError listing the items that failed validation, if any.
+func validateBulk[R any](ctx context.Context, reqs []R, validate func(R) model.ValidationErrors) error {
+	if len(reqs) == 0 || len(reqs) > maxBulkItems {
+		vErrs := model.ValidationErrors{{Field: "items", Message: fmt.Sprintf("must hold between 1 and %d items", maxBulkItems)}}
+		log.Ctx(ctx).Error().Err(vErrs).Int("items", len(reqs)).Msg("request was not validated")
+		return fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	bErr := &model.BulkError{}
+	for i, req := range reqs {
+		if vErrs := validate(req); len(vErrs) > 0 {
+			bErr.Items = append(bErr.Items, model.BulkItemError{Index: i, Err: vErrs})
+		}
+	}
+	if len(bErr.Items) > 0 {
+		log.Ctx(ctx).Error().Err(bErr).Msg("request was not validated")
+		return fmt.Errorf("request was not validated: %w", bErr)
+	}
+	return nil
+}
+
+// saveBulk saves the items one after the other with save in a single transaction.
+// Saving goes on after an item that is invalid, missing or conflicting so that every such failure is reported,
+// but the transaction is then rolled back. Any other failure stops the saving, as it may have already aborted
+// the transaction, like a deadlock does.
+// It returns the ID of each item, or a model.BulkError listing the items that could not be saved.
+func saveBulk[R any](ctx context.Context, txManager port.TxManager, operation string, reqs []R, save func(context.Context, R) (string, error)) (*pkg.BulkResponse, error) {
+	response := &pkg.BulkResponse{Items: make([]pkg.BulkItemResponse, 0, len(reqs))}
+	bErr := &model.BulkError{}
+	err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		for i, req := range reqs {
+			id, err := save(ctx, req)
+			if err != nil {
+				bErr.Items = append(bErr.Items, model.BulkItemError{Index: i, Err: err})
+				if !recoverableBulkError(err) {
+					break
+				}
+				continue
+			}
+			response.Items = append(response.Items, pkg.BulkItemResponse{Index: i, ID: id})
+		}
+		if len(bErr.Items) > 0 {
+			return bErr
+		}
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while " + operation)
+		return nil, fmt.Errorf("error occurred while %s: %w", operation, err)
+	}
+
+	return response, nil
+}
+
+// recoverableBulkError reports whether err leaves the transaction of a bulk operation usable,
+// so that the following items can still be saved: validation errors, model.ErrNotFound and model.ErrConflict.
+func recoverableBulkError(err error) bool {
+	if _, ok := model.AsValidationErrors(err); ok {
+		return true
+	}
+	return errors.Is(err, model.ErrNotFound) || errors.Is(err, model.ErrConflict)
+}
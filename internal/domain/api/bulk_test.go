@@ -0,0 +1,291 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+func TestBulkApi_CreateTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		reqs        []pkg.CreateTagRequestJSON
+		failOn      string
+		wantFields  []string
+		wantErr     error
+		wantInvalid bool
+		wantFailed  []int
+		wantCreated int
+	}{
+		{
+			name: "creates every tag",
+			reqs: []pkg.CreateTagRequestJSON{
+				{NameJSON: "news", DescriptionJSON: "daily news"},
+				{NameJSON: "culture", DescriptionJSON: "arts and books"},
+			},
+			wantCreated: 2,
+		},
+		{
+			name:       "requires items",
+			wantFields: []string{"items"},
+		},
+		{
+			name: "lists the invalid items and creates none",
+			reqs: []pkg.CreateTagRequestJSON{
+				{NameJSON: "news", DescriptionJSON: "daily news"},
+				{NameJSON: "culture"},
+				{NameJSON: "sport", DescriptionJSON: "results"},
+				{DescriptionJSON: "no name"},
+			},
+			wantInvalid: true,
+			wantFailed:  []int{1, 3},
+		},
+		{
+			name: "stops at the first item that cannot be saved and rolls back",
+			reqs: []pkg.CreateTagRequestJSON{
+				{NameJSON: "news", DescriptionJSON: "daily news"},
+				{NameJSON: "culture", DescriptionJSON: "arts and books"},
+			},
+			failOn:     "Create",
+			wantErr:    errAdapter,
+			wantFailed: []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := newFakeTagPersister()
+			if tt.failOn != "" {
+				tags.failOn(tt.failOn)
+			}
+			tagApi := NewTagApi(tags, newFakeProgramPersister(), newFakeProgramTagPersister(), newFakeTxManager(tags))
+			api := NewBulkApi(nil, nil, nil, tagApi, newFakeTxManager(tags))
+			var reqs []CreateTagRequest
+			for _, req := range tt.reqs {
+				reqs = append(reqs, req)
+			}
+
+			response, err := api.CreateTags(context.Background(), reqs)
+
+			if tt.wantInvalid {
+				if _, ok := model.AsValidationErrors(err); !ok {
+					t.Fatalf("got error %v, want validation errors", err)
+				}
+			} else {
+				assertError(t, err, tt.wantFields, tt.wantErr)
+			}
+			assertFailedItems(t, err, tt.wantFailed)
+			if len(tags.rows) != tt.wantCreated {
+				t.Fatalf("got %d stored tags, want %d", len(tags.rows), tt.wantCreated)
+			}
+			if err == nil {
+				for i, item := range response.Items {
+					if item.Index != i || item.ID != tags.rows[i].ID {
+						t.Fatalf("got item %+v, want index %d and ID %s", item, i, tags.rows[i].ID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBulkApi_DeleteTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		ids        []string
+		cascade    bool
+		wantErr    error
+		wantFailed []int
+		wantTags   []string
+	}{
+		{
+			name:     "deletes every tag",
+			ids:      []string{"t2", "t3"},
+			wantTags: []string{"t1"},
+		},
+		{
+			name:       "refuses an unknown tag and deletes none",
+			ids:        []string{"t2", "unknown"},
+			wantErr:    model.ErrNotFound,
+			wantFailed: []int{1},
+			wantTags:   []string{"t1", "t2", "t3"},
+		},
+		{
+			name:       "refuses a tag still attached to programs",
+			ids:        []string{"t1", "t2"},
+			wantErr:    model.ErrConflict,
+			wantFailed: []int{0},
+			wantTags:   []string{"t1", "t2", "t3"},
+		},
+		{
+			name:     "cascades as requested",
+			ids:      []string{"t1", "t2"},
+			cascade:  true,
+			wantTags: []string{"t3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			programTags := newFakeProgramTagPersister(model.ProgramTag{ID: "pt1", ProgramID: "p1", TagID: "t1"})
+			tags := newFakeTagPersister(model.Tag{ID: "t1", Name: "news"}, model.Tag{ID: "t2", Name: "culture"}, model.Tag{ID: "t3", Name: "sport"})
+			tags.programTags = programTags
+			programs := newFakeProgramPersister(model.Program{ID: "p1", Name: "morning"})
+			programs.programTags = programTags
+			txManager := newFakeTxManager(tags, programTags)
+			api := NewBulkApi(nil, nil, nil, NewTagApi(tags, programs, programTags, txManager), txManager)
+
+			_, err := api.DeleteTags(context.Background(), tt.ids, pkg.DeleteRequestJSON{CascadeJSON: tt.cascade})
+
+			assertError(t, err, nil, tt.wantErr)
+			assertFailedItems(t, err, tt.wantFailed)
+			var got []string
+			for _, tag := range tags.rows {
+				got = append(got, tag.ID)
+			}
+			if !equalStrings(got, tt.wantTags) {
+				t.Fatalf("got tags %v, want %v", got, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestBulkApi_CreateMedias(t *testing.T) {
+	probed := model.AudioInfo{Codec: "mp3", Artwork: &model.Artwork{MimeType: "image/jpeg", Data: []byte("cover")}}
+	tests := []struct {
+		name        string
+		failOn      string
+		wantErr     error
+		wantFailed  []int
+		wantCreated int
+		wantObjects int
+	}{
+		{
+			name:        "creates every media with the artwork of its file",
+			wantCreated: 2,
+			wantObjects: 2,
+		},
+		{
+			name:       "removes the stored artworks when the medias are not created",
+			failOn:     "Create",
+			wantErr:    errAdapter,
+			wantFailed: []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			medias := newFakeMediaPersister()
+			if tt.failOn != "" {
+				medias.failOn(tt.failOn)
+			}
+			store := newFakeObjectStore()
+			prober := &fakeAudioProber{info: probed}
+			fetcher := &fakeMediaFetcher{files: map[string][]byte{"https://cdn/e1.mp3": []byte("one"), "https://cdn/e2.mp3": []byte("two")}}
+			txManager := newFakeTxManager(medias)
+			api := NewBulkApi(nil, nil, NewMediaApi(medias, store, prober, fetcher, 0), nil, txManager)
+
+			_, err := api.CreateMedias(context.Background(), []CreateMediaRequest{
+				pkg.CreateMediaRequestJSON{DirectLinkJSON: "https://cdn/e1.mp3", KindJSON: "audio", EpisodeIDJSON: "e1"},
+				pkg.CreateMediaRequestJSON{DirectLinkJSON: "https://cdn/e2.mp3", KindJSON: "audio", EpisodeIDJSON: "e2"},
+			})
+
+			assertError(t, err, nil, tt.wantErr)
+			assertFailedItems(t, err, tt.wantFailed)
+			if len(medias.rows) != tt.wantCreated {
+				t.Fatalf("got %d stored medias, want %d", len(medias.rows), tt.wantCreated)
+			}
+			if len(prober.probed) != 2 {
+				t.Fatalf("got %d probed files, want 2", len(prober.probed))
+			}
+			if len(store.objects) != tt.wantObjects {
+				t.Fatalf("got %d stored objects, want %d", len(store.objects), tt.wantObjects)
+			}
+			for _, media := range medias.rows {
+				if media.Codec != "mp3" || store.objects[media.ArtworkKey] == nil {
+					t.Fatalf("got media %+v, want it described with a stored artwork", media)
+				}
+			}
+		})
+	}
+}
+
+func TestBulkApi_UpdateMedias(t *testing.T) {
+	uploaded := model.Media{ID: "m1", DirectLink: "https://cdn/medias/m1.mp3", Kind: "audio", EpisodeID: "e1", StorageKey: "medias/m1.mp3", ArtworkKey: "artworks/m1.png"}
+	probed := model.AudioInfo{Codec: "mp3", Artwork: &model.Artwork{MimeType: "image/jpeg", Data: []byte("cover")}}
+	tests := []struct {
+		name       string
+		second     string
+		wantErr    error
+		wantFailed []int
+		wantLink   string
+		wantOld    bool
+	}{
+		{
+			name:     "removes the replaced file once every media is updated",
+			second:   "m2",
+			wantLink: "https://cdn/e1.mp3",
+		},
+		{
+			name:       "keeps the replaced file when a later media cannot be updated",
+			second:     "unknown",
+			wantErr:    model.ErrNotFound,
+			wantFailed: []int{1},
+			wantLink:   uploaded.DirectLink,
+			wantOld:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			medias := newFakeMediaPersister(uploaded, model.Media{ID: "m2", DirectLink: "https://cdn/e2.mp3", Kind: "audio", EpisodeID: "e2"})
+			store := newFakeObjectStore()
+			store.objects["medias/m1.mp3"] = []byte("audio")
+			store.objects["artworks/m1.png"] = []byte("image")
+			prober := &fakeAudioProber{info: probed}
+			fetcher := &fakeMediaFetcher{files: map[string][]byte{"https://cdn/e1.mp3": []byte("one")}}
+			api := NewBulkApi(nil, nil, NewMediaApi(medias, store, prober, fetcher, 0), nil, newFakeTxManager(medias))
+
+			_, err := api.UpdateMedias(context.Background(), []BulkUpdateMediaRequest{
+				pkg.BulkUpdateMediaRequestJSON{IDJSON: "m1", UpdateMediaRequestJSON: pkg.UpdateMediaRequestJSON{DirectLinkJSON: "https://cdn/e1.mp3"}},
+				pkg.BulkUpdateMediaRequestJSON{IDJSON: tt.second, UpdateMediaRequestJSON: pkg.UpdateMediaRequestJSON{KindJSON: "video"}},
+			})
+
+			assertError(t, err, nil, tt.wantErr)
+			assertFailedItems(t, err, tt.wantFailed)
+			media := medias.find("m1")
+			if media.DirectLink != tt.wantLink {
+				t.Fatalf("got link %q, want %q", media.DirectLink, tt.wantLink)
+			}
+			if _, ok := store.objects["medias/m1.mp3"]; ok != tt.wantOld {
+				t.Fatalf("got the replaced file stored %t, want %t", ok, tt.wantOld)
+			}
+			var keys []string
+			for _, key := range []string{media.StorageKey, media.ArtworkKey} {
+				if key != "" && store.objects[key] != nil {
+					keys = append(keys, key)
+				}
+			}
+			if len(keys) == 0 || len(store.objects) != len(keys) {
+				t.Fatalf("got objects %v, want only the objects of the media %+v", store.objects, media)
+			}
+		})
+	}
+}
+
+// assertFailedItems checks the indexes of the items listed by the model.BulkError wrapped by err.
+func assertFailedItems(t *testing.T, err error, wantFailed []int) {
+	t.Helper()
+	var got []int
+	if bErr, ok := model.AsBulkError(err); ok {
+		for _, item := range bErr.Items {
+			got = append(got, item.Index)
+		}
+	}
+	if len(got) != len(wantFailed) {
+		t.Fatalf("got failed items %v, want %v", got, wantFailed)
+	}
+	for i := range got {
+		if got[i] != wantFailed[i] {
+			t.Fatalf("got failed items %v, want %v", got, wantFailed)
+		}
+	}
+}
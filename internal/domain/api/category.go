@@ -4,6 +4,8 @@ package api
 import (
 	"context"
 	"fmt"
+	"slices"
+
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
@@ -25,58 +27,88 @@ type UpdateCategoryRequest interface {
 	ParentID() string
 }
 
+// MoveCategoryRequest represents the interface for moving categories under another parent.
+type MoveCategoryRequest interface {
+	ParentID() string
+}
+
 // Category represents the interface for managing categories.
 type Category interface {
-	Create(ctx context.Context, category CreateCategoryRequest) error
+	Create(ctx context.Context, category CreateCategoryRequest) (string, error)
 	Update(ctx context.Context, uuid string, updates UpdateCategoryRequest) error
+	Move(ctx context.Context, uuid string, req MoveCategoryRequest) error
 	Find(ctx context.Context, uuid string) (*pkg.CategoryResponse, error)
-	FindAll(ctx context.Context) ([]*pkg.CategoryResponse, error)
-	Delete(ctx context.Context, uuid string) error
-	FindPrograms(ctx context.Context, uuid string) ([]*pkg.ProgramResponse, error)
+	FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.CategoryResponse], error)
+	Delete(ctx context.Context, uuid string, req DeleteRequest) error
+	Restore(ctx context.Context, uuid string) error
+	FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.CategoryResponse], error)
+	FindPrograms(ctx context.Context, uuid string, req FindCategoryProgramsRequest) ([]*pkg.ProgramResponse, error)
+	FindTree(ctx context.Context) ([]*pkg.CategoryTreeResponse, error)
+	FindAncestors(ctx context.Context, uuid string) ([]*pkg.CategoryResponse, error)
+	FindDescendants(ctx context.Context, uuid string) ([]*pkg.CategoryTreeResponse, error)
+}
+
+// FindCategoryProgramsRequest represents the interface for finding the programs of a category.
+type FindCategoryProgramsRequest interface {
+	Descendants() bool
 }
 
 // categoryApi is an implementation of the Category interface.
 type categoryApi struct {
-	categoryAdapter   port.CategoryPersister
-	programCatAdapter port.ProgramCategoryPersister
-	programAdapter    port.ProgramPersister
+	categoryAdapter        port.CategoryPersister
+	programAdapter         port.ProgramPersister
+	programCategoryAdapter port.ProgramCategoryPersister
+	txManager              port.TxManager
+	maxDepth               int
 }
 
 // NewCategoryApi creates a new instance of Category.
-// It takes adapters for category, program-category, and program persistence as dependencies.
-func NewCategoryApi(categoryAdapter port.CategoryPersister, programCatAdapter port.ProgramCategoryPersister, programAdapter port.ProgramPersister) Category {
+// It takes adapters for category, program and program-category association persistence, and txManager as dependencies,
+// along with the maximum number of nested levels of categories, 0 disabling the limit.
+func NewCategoryApi(categoryAdapter port.CategoryPersister, programAdapter port.ProgramPersister, programCategoryAdapter port.ProgramCategoryPersister, txManager port.TxManager, maxDepth int) Category {
 	return &categoryApi{
-		categoryAdapter:   categoryAdapter,
-		programCatAdapter: programCatAdapter,
-		programAdapter:    programAdapter,
+		categoryAdapter:        categoryAdapter,
+		programAdapter:         programAdapter,
+		programCategoryAdapter: programCategoryAdapter,
+		txManager:              txManager,
+		maxDepth:               maxDepth,
 	}
 }
 
 // Create creates a new category.
-// It takes the context and CreateCategoryRequest, and returns an error if any.
-func (api categoryApi) Create(ctx context.Context, req CreateCategoryRequest) error {
+// Its parent, when given, must be a live category with room for another level of nesting.
+// It takes the context and CreateCategoryRequest, and returns the UUID of the new category or an error.
+func (api categoryApi) Create(ctx context.Context, req CreateCategoryRequest) (string, error) {
 	// Validate request
 	vErrs := createCategoryRequestValidation(ctx, req)
 	if len(vErrs) > 0 {
 		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
-		return fmt.Errorf("request was not validated: %w", vErrs)
+		return "", fmt.Errorf("request was not validated: %w", vErrs)
 	}
 	// Map to domain model
 	category := model.Category{
 		ID:          uuid.New().String(),
 		Name:        req.Name(),
 		Description: req.Description(),
-		Parent: &model.Category{
+	}
+	if req.ParentID() != "" {
+		category.Parent = &model.Category{
 			ID: req.ParentID(),
-		},
+		}
 	}
-	// Call adapter
-	if err := api.categoryAdapter.Create(ctx, category); err != nil {
+	// Call adapters, checking the parent within the transaction so that the hierarchy cannot change meanwhile
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := api.parentValidation(ctx, category.ID, req.ParentID()); err != nil {
+			return err
+		}
+		return api.categoryAdapter.Create(ctx, category)
+	})
+	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("category", category).Msg("error while creating category")
-		return fmt.Errorf("error occurred while creating category: %w", err)
+		return "", fmt.Errorf("error occurred while creating category: %w", err)
 	}
 
-	return nil
+	return category.ID, nil
 }
 
 // createCategoryRequestValidation validates the creation request.
@@ -93,6 +125,7 @@ func createCategoryRequestValidation(ctx context.Context, req CreateCategoryRequ
 }
 
 // Update updates an existing category.
+// A new parent is checked like the parent of a created category, and must not be the category itself or one of its subcategories.
 // It takes the context, category UUID, and UpdateCategoryRequest, and returns an error if any.
 func (api categoryApi) Update(ctx context.Context, uuid string, updates UpdateCategoryRequest) error {
 	// Validate request
@@ -116,8 +149,14 @@ func (api categoryApi) Update(ctx context.Context, uuid string, updates UpdateCa
 		}
 	}
 
-	// Call adapter
-	if err := api.categoryAdapter.Update(ctx, uuid, category); err != nil {
+	// Call adapters, checking the new parent within the transaction so that the hierarchy cannot change meanwhile
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := api.parentValidation(ctx, uuid, updates.ParentID()); err != nil {
+			return err
+		}
+		return api.categoryAdapter.Update(ctx, uuid, category)
+	})
+	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("category", category).Msg("error while updating category")
 		return fmt.Errorf("error occurred while updating category: %w", err)
 	}
@@ -135,6 +174,71 @@ func updateCategoryRequestValidation(ctx context.Context, uuid string, req Updat
 	return vErrs
 }
 
+// Move moves a category, along with its whole subtree, under another parent, or to the root when the parent is empty.
+// The parent is checked like the new parent of an updated category.
+// It takes the context, category UUID and MoveCategoryRequest, and returns an error if any.
+func (api categoryApi) Move(ctx context.Context, uuid string, req MoveCategoryRequest) error {
+	// Validate request
+	vErrs := moveCategoryRequestValidation(ctx, uuid, req)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return fmt.Errorf("request was not validated: %w", vErrs)
+	}
+
+	// Call adapters within a transaction, so that the subtree moves at once under a parent checked against the current hierarchy
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := api.parentValidation(ctx, uuid, req.ParentID()); err != nil {
+			return err
+		}
+		return api.categoryAdapter.Move(ctx, uuid, req.ParentID())
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Interface("request", req).Msg("error while moving category")
+		return fmt.Errorf("error occurred while moving category: %w", err)
+	}
+	return nil
+}
+
+// moveCategoryRequestValidation validates the move request.
+// It takes the context, category UUID, and MoveCategoryRequest, and returns a slice of ValidationErrors.
+func moveCategoryRequestValidation(ctx context.Context, uuid string, req MoveCategoryRequest) model.ValidationErrors {
+	var vErrs []model.ValidationError
+	if uuid == "" {
+		vErrs = append(vErrs, model.ValidationError{Field: "uuid", Message: "cannot be empty"})
+	}
+	return vErrs
+}
+
+// parentValidation checks that the category identified by id can be nested under the category identified by parentID.
+// The hierarchy is locked until the end of the transaction ctx belongs to, so that it cannot change before the
+// category is saved under its parent.
+// It returns the ValidationErrors of the parent, or the error of the hierarchy lookup. An empty parentID is always valid.
+func (api categoryApi) parentValidation(ctx context.Context, id string, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+	if err := api.lockHierarchy(ctx); err != nil {
+		return err
+	}
+	hierarchy, err := api.findHierarchy(ctx, "")
+	if err != nil {
+		return err
+	}
+	if vErrs := hierarchy.parentValidation(id, parentID, api.maxDepth); len(vErrs) > 0 {
+		return vErrs
+	}
+	return nil
+}
+
+// lockHierarchy locks the category hierarchy until the end of the transaction ctx belongs to.
+func (api categoryApi) lockHierarchy(ctx context.Context) error {
+	if err := api.categoryAdapter.LockHierarchy(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while locking the category hierarchy")
+		return fmt.Errorf("error occurred while locking the category hierarchy: %w", err)
+	}
+	return nil
+}
+
 // Find finds a category by UUID.
 // It takes the context and category UUID, and returns a CategoryResponse or an error.
 func (api categoryApi) Find(ctx context.Context, uuid string) (*pkg.CategoryResponse, error) {
@@ -160,11 +264,22 @@ func (api categoryApi) Find(ctx context.Context, uuid string) (*pkg.CategoryResp
 	return response, nil
 }
 
-// FindAll finds all categories.
-// It takes the context and returns a slice of CategoryResponse or an error.
-func (api categoryApi) FindAll(ctx context.Context) ([]*pkg.CategoryResponse, error) {
+// categoryListing describes how categories can be sorted and filtered.
+var categoryListing = listing{named: true, sortable: []string{model.SortByName, model.SortByCreatedAt}}
+
+// FindAll finds a page of categories.
+// It takes the context and ListRequest, and returns a page of CategoryResponse or an error.
+func (api categoryApi) FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.CategoryResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, categoryListing)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := listOptions(req)
+
 	// Call adapter
-	categories, err := api.categoryAdapter.FindAll(ctx)
+	categories, total, err := api.categoryAdapter.FindAll(ctx, opts)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while finding categories")
 		return nil, fmt.Errorf("error occurred while finding categories: %w", err)
@@ -185,34 +300,139 @@ func (api categoryApi) FindAll(ctx context.Context) ([]*pkg.CategoryResponse, er
 		})
 	}
 	// Return result
-	return response, nil
+	return newPage(response, total, opts), nil
 }
 
-// Delete deletes a category by UUID.
-// It takes the context and category UUID, and returns an error if any.
-func (api categoryApi) Delete(ctx context.Context, uuid string) error {
-	if err := api.categoryAdapter.Delete(ctx, uuid); err != nil {
+// Delete moves a category to the trash by UUID.
+// A category still attached to programs is refused with a model.DependentsError listing them,
+// unless the request cascades, in which case the category is detached from the programs first.
+// It takes the context, category UUID and DeleteRequest, and returns an error if any.
+func (api categoryApi) Delete(ctx context.Context, uuid string, req DeleteRequest) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if req.Cascade() {
+			// Detach the category from the programs
+			associations, err := api.programCategoryAdapter.FindByCategoryID(ctx, uuid)
+			if err != nil {
+				return err
+			}
+			for _, association := range associations {
+				if err := api.programCategoryAdapter.Delete(ctx, association.ID); err != nil {
+					return err
+				}
+			}
+		} else {
+			// Refuse while live programs are still attached to the category
+			programs, err := api.programAdapter.FindByCategoryID(ctx, uuid)
+			if err != nil {
+				return err
+			}
+			var dependents []model.Dependent
+			for _, program := range programs {
+				dependents = append(dependents, model.Dependent{Entity: model.AuditEntityProgram, ID: program.ID, Name: program.Name})
+			}
+			if err := dependentsError(model.AuditEntityCategory, uuid, dependents); err != nil {
+				return err
+			}
+		}
+
+		return api.categoryAdapter.Delete(ctx, uuid)
+	})
+	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while deleting category")
 		return fmt.Errorf("error occurred while deleting category: %w", err)
 	}
 	return nil
 }
 
-// FindPrograms finds programs associated with a category.
-// It takes the context and category UUID, and returns a slice of ProgramResponse or an error.
-func (api categoryApi) FindPrograms(ctx context.Context, uuid string) ([]*pkg.ProgramResponse, error) {
-	associations, err := api.programCatAdapter.FindByCategoryID(ctx, uuid)
+// Restore restores a category from the trash by UUID.
+// The restored category is checked against the hierarchy like a moved one, as its parent or its subcategories
+// may have moved while it was in the trash: a category that would close a cycle or nest categories too deep
+// is refused with model.ValidationErrors.
+// It takes the context and category UUID, and returns an error if any.
+func (api categoryApi) Restore(ctx context.Context, uuid string) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := api.lockHierarchy(ctx); err != nil {
+			return err
+		}
+		if err := api.categoryAdapter.Restore(ctx, uuid); err != nil {
+			return err
+		}
+		hierarchy, err := api.findHierarchy(ctx, "")
+		if err != nil {
+			return err
+		}
+		if vErrs := hierarchy.restoreValidation(uuid, api.maxDepth); len(vErrs) > 0 {
+			return fmt.Errorf("request was not validated: %w", vErrs)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while restoring category")
+		return fmt.Errorf("error occurred while restoring category: %w", err)
 	}
+	return nil
+}
 
-	var response []*pkg.ProgramResponse
-	for _, association := range associations {
-		program, err := api.programAdapter.Find(ctx, association.ProgramID)
+// FindDeleted finds a page of the categories in the trash, the most recently deleted first unless sorted otherwise.
+// It takes the context and ListRequest, and returns a page of CategoryResponse or an error.
+func (api categoryApi) FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.CategoryResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, categoryListing.trash())
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := trashOptions(req)
+
+	// Call adapter
+	categories, total, err := api.categoryAdapter.FindAll(ctx, opts)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while finding deleted categories")
+		return nil, fmt.Errorf("error occurred while finding deleted categories: %w", err)
+	}
+
+	// Map to response
+	var response []*pkg.CategoryResponse
+	for _, category := range categories {
+		parentID := ""
+		if category.Parent != nil {
+			parentID = category.Parent.ID
+		}
+		response = append(response, &pkg.CategoryResponse{
+			ID:          category.ID,
+			Name:        category.Name,
+			Description: category.Description,
+			ParentID:    parentID,
+			DeletedAt:   optionalTime(category.DeletedAt),
+		})
+	}
+	// Return result
+	return newPage(response, total, opts), nil
+}
+
+// FindPrograms finds programs associated with a category.
+// When the request includes descendants, the programs of every subcategory at any depth are included too, each once.
+// It takes the context, category UUID and FindCategoryProgramsRequest, and returns a slice of ProgramResponse or an error.
+func (api categoryApi) FindPrograms(ctx context.Context, uuid string, req FindCategoryProgramsRequest) ([]*pkg.ProgramResponse, error) {
+	var programs []*model.Program
+	var err error
+	if req.Descendants() {
+		var hierarchy *categoryHierarchy
+		hierarchy, err = api.findHierarchy(ctx, uuid)
 		if err != nil {
 			return nil, err
 		}
+		programs, err = api.programAdapter.FindByCategoryIDs(ctx, append([]string{uuid}, hierarchy.descendantIDs(uuid)...))
+	} else {
+		programs, err = api.programAdapter.FindByCategoryID(ctx, uuid)
+	}
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while finding category programs")
+		return nil, fmt.Errorf("error occurred while finding category programs: %w", err)
+	}
 
+	var response []*pkg.ProgramResponse
+	for _, program := range programs {
 		response = append(response, &pkg.ProgramResponse{
 			ID:          program.ID,
 			Name:        program.Name,
@@ -222,3 +442,197 @@ func (api categoryApi) FindPrograms(ctx context.Context, uuid string) ([]*pkg.Pr
 
 	return response, nil
 }
+
+// FindTree finds the whole hierarchy of the live categories, the root categories first, each with its nested subcategories.
+// Categories are ordered by name at every level, and a category whose parent is in the trash is listed as a root.
+// It takes the context, and returns a slice of CategoryTreeResponse or an error.
+func (api categoryApi) FindTree(ctx context.Context) ([]*pkg.CategoryTreeResponse, error) {
+	hierarchy, err := api.findHierarchy(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return hierarchy.tree("", map[string]bool{}), nil
+}
+
+// FindAncestors finds the ancestors of a category, from its root category down to its parent.
+// It takes the context and category UUID, and returns a slice of CategoryResponse or an error.
+func (api categoryApi) FindAncestors(ctx context.Context, uuid string) ([]*pkg.CategoryResponse, error) {
+	hierarchy, err := api.findHierarchy(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []*pkg.CategoryResponse
+	visited := map[string]bool{uuid: true}
+	for parentID := hierarchy.parentID(uuid); parentID != "" && !visited[parentID]; parentID = hierarchy.parentID(parentID) {
+		visited[parentID] = true
+		response = append(response, newCategoryResponse(hierarchy.byID[parentID]))
+	}
+	slices.Reverse(response)
+	return response, nil
+}
+
+// FindDescendants finds the subcategories of a category at any depth, nested under their parent and ordered by name.
+// It takes the context and category UUID, and returns a slice of CategoryTreeResponse or an error.
+func (api categoryApi) FindDescendants(ctx context.Context, uuid string) ([]*pkg.CategoryTreeResponse, error) {
+	hierarchy, err := api.findHierarchy(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return hierarchy.tree(uuid, map[string]bool{uuid: true}), nil
+}
+
+// findHierarchy reads every live category in a single lookup and indexes them.
+// When uuid is not empty, it returns model.ErrNotFound unless the hierarchy contains that category.
+func (api categoryApi) findHierarchy(ctx context.Context, uuid string) (*categoryHierarchy, error) {
+	categories, err := api.categoryAdapter.FindHierarchy(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while finding the category hierarchy")
+		return nil, fmt.Errorf("error occurred while finding the category hierarchy: %w", err)
+	}
+	hierarchy := newCategoryHierarchy(categories)
+	if _, ok := hierarchy.byID[uuid]; uuid != "" && !ok {
+		err := fmt.Errorf("category %s: %w", uuid, model.ErrNotFound)
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while finding category")
+		return nil, fmt.Errorf("error occurred while finding category: %w", err)
+	}
+	return hierarchy, nil
+}
+
+// categoryHierarchy indexes the live categories by ID, and by the ID of their parent.
+type categoryHierarchy struct {
+	byID     map[string]*model.Category
+	children map[string][]*model.Category // keyed by parent ID, the empty ID holding the root categories
+}
+
+// newCategoryHierarchy indexes the given categories, keeping their order among siblings.
+// A category whose parent is not among them, such as a parent in the trash, is indexed as a root.
+func newCategoryHierarchy(categories []*model.Category) *categoryHierarchy {
+	hierarchy := &categoryHierarchy{
+		byID:     make(map[string]*model.Category, len(categories)),
+		children: make(map[string][]*model.Category),
+	}
+	for _, category := range categories {
+		hierarchy.byID[category.ID] = category
+	}
+	for _, category := range categories {
+		parentID := hierarchy.parentID(category.ID)
+		hierarchy.children[parentID] = append(hierarchy.children[parentID], category)
+	}
+	return hierarchy
+}
+
+// parentValidation checks that the category identified by id, or a new category when it is not in the hierarchy,
+// can be nested under the category identified by parentID without creating a cycle nor exceeding maxDepth levels.
+func (h *categoryHierarchy) parentValidation(id string, parentID string, maxDepth int) model.ValidationErrors {
+	var vErrs []model.ValidationError
+	if _, ok := h.byID[parentID]; !ok {
+		return append(vErrs, model.ValidationError{Field: "parentID", Message: "does not exist"})
+	}
+	if parentID == id || slices.Contains(h.descendantIDs(id), parentID) {
+		return append(vErrs, model.ValidationError{Field: "parentID", Message: "cannot be the category itself or one of its subcategories"})
+	}
+	if maxDepth > 0 && h.depth(parentID)+h.height(id) > maxDepth {
+		vErrs = append(vErrs, model.ValidationError{Field: "parentID", Message: fmt.Sprintf("would nest categories deeper than %d levels", maxDepth)})
+	}
+	return vErrs
+}
+
+// restoreValidation checks that the category identified by id, just restored, is nested under its parent
+// without closing a cycle nor exceeding maxDepth levels. A category whose parent is in the trash is checked as a root.
+func (h *categoryHierarchy) restoreValidation(id string, maxDepth int) model.ValidationErrors {
+	if parentID := h.parentID(id); parentID != "" {
+		return h.parentValidation(id, parentID, maxDepth)
+	}
+	var vErrs []model.ValidationError
+	if maxDepth > 0 && h.height(id) > maxDepth {
+		vErrs = append(vErrs, model.ValidationError{Field: "parentID", Message: fmt.Sprintf("would nest categories deeper than %d levels", maxDepth)})
+	}
+	return vErrs
+}
+
+// depth returns the level of a category in the hierarchy, a root category being on the first one.
+func (h *categoryHierarchy) depth(id string) int {
+	depth := 1
+	visited := map[string]bool{id: true}
+	for parentID := h.parentID(id); parentID != "" && !visited[parentID]; parentID = h.parentID(parentID) {
+		visited[parentID] = true
+		depth++
+	}
+	return depth
+}
+
+// height returns the number of levels of the subtree of a category, 1 for a category without subcategories.
+func (h *categoryHierarchy) height(id string) int {
+	var height func(id string, visited map[string]bool) int
+	height = func(id string, visited map[string]bool) int {
+		visited[id] = true
+		levels := 0
+		for _, child := range h.children[id] {
+			if !visited[child.ID] {
+				levels = max(levels, height(child.ID, visited))
+			}
+		}
+		return levels + 1
+	}
+	return height(id, map[string]bool{})
+}
+
+// parentID returns the ID of the parent of a category, or an empty ID when the parent is not in the hierarchy.
+func (h *categoryHierarchy) parentID(id string) string {
+	category, ok := h.byID[id]
+	if !ok || category.Parent == nil {
+		return ""
+	}
+	if _, ok := h.byID[category.Parent.ID]; !ok || category.Parent.ID == id {
+		return ""
+	}
+	return category.Parent.ID
+}
+
+// tree returns the nested subcategories of the category identified by parentID, skipping the visited ones
+// so that a cycle in the stored parents cannot loop forever.
+func (h *categoryHierarchy) tree(parentID string, visited map[string]bool) []*pkg.CategoryTreeResponse {
+	response := []*pkg.CategoryTreeResponse{}
+	for _, category := range h.children[parentID] {
+		if visited[category.ID] {
+			continue
+		}
+		visited[category.ID] = true
+		response = append(response, &pkg.CategoryTreeResponse{
+			CategoryResponse: *newCategoryResponse(category),
+			Children:         h.tree(category.ID, visited),
+		})
+	}
+	return response
+}
+
+// descendantIDs returns the IDs of the subcategories of a category at any depth, parents before their children.
+func (h *categoryHierarchy) descendantIDs(id string) []string {
+	var ids []string
+	visited := map[string]bool{id: true}
+	for queue := []string{id}; len(queue) > 0; queue = queue[1:] {
+		for _, child := range h.children[queue[0]] {
+			if !visited[child.ID] {
+				visited[child.ID] = true
+				ids = append(ids, child.ID)
+				queue = append(queue, child.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// newCategoryResponse maps a category to its response.
+func newCategoryResponse(category *model.Category) *pkg.CategoryResponse {
+	parentID := ""
+	if category.Parent != nil {
+		parentID = category.Parent.ID
+	}
+	return &pkg.CategoryResponse{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		ParentID:    parentID,
+	}
+}
@@ -0,0 +1,573 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+func TestCategoryApi_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.CreateCategoryRequestJSON
+		maxDepth   int
+		failOn     string
+		wantFields []string
+		wantErr    error
+	}{
+		{
+			name: "creates a root category",
+			req:  pkg.CreateCategoryRequestJSON{NameJSON: "talk", DescriptionJSON: "talk shows"},
+		},
+		{
+			name: "creates a child category",
+			req:  pkg.CreateCategoryRequestJSON{NameJSON: "debate", DescriptionJSON: "debates", ParentIDJSON: "c1"},
+		},
+		{
+			name:       "requires name and description",
+			req:        pkg.CreateCategoryRequestJSON{ParentIDJSON: "c1"},
+			wantFields: []string{"name", "description"},
+		},
+		{
+			name:       "refuses an unknown parent",
+			req:        pkg.CreateCategoryRequestJSON{NameJSON: "debate", DescriptionJSON: "debates", ParentIDJSON: "unknown"},
+			wantFields: []string{"parentID"},
+		},
+		{
+			name:     "creates a category on the last allowed level",
+			req:      pkg.CreateCategoryRequestJSON{NameJSON: "debate", DescriptionJSON: "debates", ParentIDJSON: "c1"},
+			maxDepth: 2,
+		},
+		{
+			name:       "refuses to nest deeper than the maximum depth",
+			req:        pkg.CreateCategoryRequestJSON{NameJSON: "debate", DescriptionJSON: "debates", ParentIDJSON: "c1"},
+			maxDepth:   1,
+			wantFields: []string{"parentID"},
+		},
+		{
+			name:    "wraps adapter failure",
+			req:     pkg.CreateCategoryRequestJSON{NameJSON: "talk", DescriptionJSON: "talk shows"},
+			failOn:  "Create",
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories := newFakeCategoryPersister(model.Category{ID: "c1", Name: "talk"})
+			if tt.failOn != "" {
+				categories.failOn(tt.failOn)
+			}
+			api := NewCategoryApi(categories, newFakeProgramPersister(), newFakeProgramCategoryPersister(), newFakeTxManager(), tt.maxDepth)
+
+			id, err := api.Create(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if err == nil {
+				created := categories.find(id)
+				if len(categories.rows) != 2 || created == nil {
+					t.Fatalf("unexpected stored categories: %+v", categories.rows)
+				}
+				parentID := ""
+				if created.Parent != nil {
+					parentID = created.Parent.ID
+				}
+				if parentID != tt.req.ParentIDJSON {
+					t.Fatalf("got parent %q, want %q", parentID, tt.req.ParentIDJSON)
+				}
+			} else if len(categories.rows) != 1 {
+				t.Fatalf("unexpected stored categories: %+v", categories.rows)
+			}
+		})
+	}
+}
+
+func TestCategoryApi_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		uuid       string
+		req        pkg.UpdateCategoryRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+		wantName   string
+		wantParent string
+	}{
+		{
+			name:       "updates only the given fields",
+			uuid:       "c2",
+			req:        pkg.UpdateCategoryRequestJSON{NameJSON: "debates"},
+			wantName:   "debates",
+			wantParent: "c1",
+		},
+		{
+			name:       "moves the category under another parent",
+			uuid:       "c2",
+			req:        pkg.UpdateCategoryRequestJSON{ParentIDJSON: "c3"},
+			wantName:   "debate",
+			wantParent: "c3",
+		},
+		{
+			name:       "refuses to move the category under one of its subcategories",
+			uuid:       "c2",
+			req:        pkg.UpdateCategoryRequestJSON{ParentIDJSON: "c4"},
+			wantFields: []string{"parentID"},
+			wantName:   "debate",
+			wantParent: "c1",
+		},
+		{
+			name:       "refuses to move the category under itself",
+			uuid:       "c2",
+			req:        pkg.UpdateCategoryRequestJSON{ParentIDJSON: "c2"},
+			wantFields: []string{"parentID"},
+			wantName:   "debate",
+			wantParent: "c1",
+		},
+		{
+			name:       "requires the uuid",
+			req:        pkg.UpdateCategoryRequestJSON{NameJSON: "debates"},
+			wantFields: []string{"uuid"},
+			wantName:   "debate",
+			wantParent: "c1",
+		},
+		{
+			name:       "wraps adapter failure",
+			uuid:       "c2",
+			req:        pkg.UpdateCategoryRequestJSON{NameJSON: "debates"},
+			failOn:     "Update",
+			wantErr:    errAdapter,
+			wantName:   "debate",
+			wantParent: "c1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories := newFakeCategoryPersister(
+				model.Category{ID: "c2", Name: "debate", Description: "debates", Parent: &model.Category{ID: "c1"}},
+				model.Category{ID: "c1", Name: "talk"},
+				model.Category{ID: "c3", Name: "news"},
+				model.Category{ID: "c4", Name: "politics", Parent: &model.Category{ID: "c2"}},
+			)
+			if tt.failOn != "" {
+				categories.failOn(tt.failOn)
+			}
+			api := NewCategoryApi(categories, newFakeProgramPersister(), newFakeProgramCategoryPersister(), newFakeTxManager(), 0)
+
+			err := api.Update(context.Background(), tt.uuid, tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			got := categories.rows[0]
+			if got.Name != tt.wantName || got.Parent.ID != tt.wantParent {
+				t.Fatalf("got category %q under %q, want %q under %q", got.Name, got.Parent.ID, tt.wantName, tt.wantParent)
+			}
+		})
+	}
+}
+
+func TestCategoryApi_Move(t *testing.T) {
+	tests := []struct {
+		name       string
+		uuid       string
+		parentID   string
+		maxDepth   int
+		failOn     string
+		wantFields []string
+		wantErr    error
+		wantParent string
+	}{
+		{
+			name:       "moves the subtree under another parent",
+			uuid:       "c2",
+			parentID:   "c3",
+			maxDepth:   3,
+			wantParent: "c3",
+		},
+		{
+			name:     "moves the subtree to the root",
+			uuid:     "c2",
+			maxDepth: 3,
+		},
+		{
+			name:       "refuses to move the subtree under one of its subcategories",
+			uuid:       "c2",
+			parentID:   "c4",
+			wantFields: []string{"parentID"},
+			wantParent: "c1",
+		},
+		{
+			name:       "refuses to nest the subtree deeper than the maximum depth",
+			uuid:       "c2",
+			parentID:   "c3",
+			maxDepth:   2,
+			wantFields: []string{"parentID"},
+			wantParent: "c1",
+		},
+		{
+			name:       "refuses an unknown parent",
+			uuid:       "c2",
+			parentID:   "unknown",
+			wantFields: []string{"parentID"},
+			wantParent: "c1",
+		},
+		{
+			name:       "requires the uuid",
+			parentID:   "c3",
+			wantFields: []string{"uuid"},
+			wantParent: "c1",
+		},
+		{
+			name:       "wraps adapter failure",
+			uuid:       "c2",
+			parentID:   "c3",
+			failOn:     "Move",
+			wantErr:    errAdapter,
+			wantParent: "c1",
+		},
+		{
+			name:       "wraps lock failure",
+			uuid:       "c2",
+			parentID:   "c3",
+			failOn:     "LockHierarchy",
+			wantErr:    errAdapter,
+			wantParent: "c1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories := newFakeCategoryPersister(
+				model.Category{ID: "c1", Name: "talk"},
+				model.Category{ID: "c2", Name: "debate", Parent: &model.Category{ID: "c1"}},
+				model.Category{ID: "c3", Name: "news"},
+				model.Category{ID: "c4", Name: "politics", Parent: &model.Category{ID: "c2"}},
+			)
+			if tt.failOn != "" {
+				categories.failOn(tt.failOn)
+			}
+			api := NewCategoryApi(categories, newFakeProgramPersister(), newFakeProgramCategoryPersister(), newFakeTxManager(), tt.maxDepth)
+
+			err := api.Move(context.Background(), tt.uuid, pkg.MoveCategoryRequestJSON{ParentIDJSON: tt.parentID})
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			moved := categories.find("c2")
+			parentID := ""
+			if moved.Parent != nil {
+				parentID = moved.Parent.ID
+			}
+			if parentID != tt.wantParent {
+				t.Fatalf("got parent %q, want %q", parentID, tt.wantParent)
+			}
+			if child := categories.find("c4"); child.Parent.ID != "c2" {
+				t.Fatalf("got subcategory under %q, want it to follow c2", child.Parent.ID)
+			}
+		})
+	}
+}
+
+func TestCategoryApi_Restore(t *testing.T) {
+	tests := []struct {
+		name        string
+		uuid        string
+		movedParent string // parent c1 was moved under while c2 was in the trash
+		maxDepth    int
+		wantFields  []string
+		wantErr     error
+		wantLive    bool
+	}{
+		{
+			name:     "restores a category under its parent",
+			uuid:     "c2",
+			maxDepth: 3,
+			wantLive: true,
+		},
+		{
+			name:        "refuses a category whose parent moved under its subcategories",
+			uuid:        "c2",
+			movedParent: "c4",
+			wantFields:  []string{"parentID"},
+		},
+		{
+			name:       "refuses to nest its subtree deeper than the maximum depth",
+			uuid:       "c2",
+			maxDepth:   2,
+			wantFields: []string{"parentID"},
+		},
+		{
+			name:    "refuses an unknown category",
+			uuid:    "unknown",
+			wantErr: model.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			talk := model.Category{ID: "c1", Name: "talk"}
+			if tt.movedParent != "" {
+				talk.Parent = &model.Category{ID: tt.movedParent}
+			}
+			categories := newFakeCategoryPersister(talk, model.Category{ID: "c4", Name: "politics", Parent: &model.Category{ID: "c2"}})
+			categories.trash = []model.Category{{ID: "c2", Name: "debate", Parent: &model.Category{ID: "c1"}}}
+			api := NewCategoryApi(categories, newFakeProgramPersister(), newFakeProgramCategoryPersister(), newFakeTxManager(categories), tt.maxDepth)
+
+			err := api.Restore(context.Background(), tt.uuid)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if live := categories.find("c2") != nil; live != tt.wantLive {
+				t.Fatalf("got c2 live %t, want %t", live, tt.wantLive)
+			}
+		})
+	}
+}
+
+func TestCategoryApi_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		fail    func(categories *fakeCategoryPersister, programCategories *fakeProgramCategoryPersister, programs *fakeProgramPersister)
+		call    func(api Category) ([]string, error)
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "finds a category with its parent",
+			call: func(api Category) ([]string, error) {
+				category, err := api.Find(context.Background(), "c2")
+				if err != nil {
+					return nil, err
+				}
+				return []string{category.ID, category.ParentID}, nil
+			},
+			want: []string{"c2", "c1"},
+		},
+		{
+			name: "wraps find failure",
+			fail: func(categories *fakeCategoryPersister, _ *fakeProgramCategoryPersister, _ *fakeProgramPersister) {
+				categories.failOn("Find")
+			},
+			call:    func(api Category) ([]string, error) { _, err := api.Find(context.Background(), "c1"); return nil, err },
+			wantErr: errAdapter,
+		},
+		{
+			name:    "reports a missing category",
+			call:    func(api Category) ([]string, error) { _, err := api.Find(context.Background(), "c9"); return nil, err },
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "reports the deletion of a missing category",
+			call: func(api Category) ([]string, error) {
+				return nil, api.Delete(context.Background(), "c9", pkg.DeleteRequestJSON{})
+			},
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "finds all categories with their parents",
+			call: func(api Category) ([]string, error) {
+				categories, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				if err != nil {
+					return nil, err
+				}
+				var ids []string
+				for _, category := range categories.Items {
+					ids = append(ids, category.ID+">"+category.ParentID)
+				}
+				return ids, nil
+			},
+			want: []string{"c1>", "c2>c1"},
+		},
+		{
+			name: "wraps find all failure",
+			fail: func(categories *fakeCategoryPersister, _ *fakeProgramCategoryPersister, _ *fakeProgramPersister) {
+				categories.failOn("FindAll")
+			},
+			call: func(api Category) ([]string, error) {
+				_, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				return nil, err
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name: "wraps delete failure",
+			fail: func(categories *fakeCategoryPersister, _ *fakeProgramCategoryPersister, _ *fakeProgramPersister) {
+				categories.failOn("Delete")
+			},
+			call: func(api Category) ([]string, error) {
+				return nil, api.Delete(context.Background(), "c1", pkg.DeleteRequestJSON{CascadeJSON: true})
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name: "finds the categorized programs",
+			call: func(api Category) ([]string, error) {
+				programs, err := api.FindPrograms(context.Background(), "c1", pkg.FindCategoryProgramsRequestJSON{})
+				var ids []string
+				for _, program := range programs {
+					ids = append(ids, program.ID)
+				}
+				return ids, err
+			},
+			want: []string{"p2", "p1"},
+		},
+		{
+			name: "fails when the categorized programs cannot be read",
+			fail: func(_ *fakeCategoryPersister, _ *fakeProgramCategoryPersister, programs *fakeProgramPersister) {
+				programs.failOn("FindByCategoryID")
+			},
+			call: func(api Category) ([]string, error) {
+				_, err := api.FindPrograms(context.Background(), "c1", pkg.FindCategoryProgramsRequestJSON{})
+				return nil, err
+			},
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories := newFakeCategoryPersister(
+				model.Category{ID: "c1", Name: "talk"},
+				model.Category{ID: "c2", Name: "debate", Parent: &model.Category{ID: "c1"}},
+			)
+			programCategories := newFakeProgramCategoryPersister(
+				model.ProgramCategory{ID: "pc1", ProgramID: "p1", CategoryID: "c1"},
+				model.ProgramCategory{ID: "pc2", ProgramID: "p2", CategoryID: "c1"},
+				model.ProgramCategory{ID: "pc3", ProgramID: "p1", CategoryID: "c2"},
+			)
+			programs := newFakeProgramPersister(model.Program{ID: "p1", Name: "morning"}, model.Program{ID: "p2", Name: "evening"})
+			programs.programCategories = programCategories
+			if tt.fail != nil {
+				tt.fail(categories, programCategories, programs)
+			}
+
+			got, err := tt.call(NewCategoryApi(categories, programs, newFakeProgramCategoryPersister(), newFakeTxManager(), 0))
+
+			assertError(t, err, nil, tt.wantErr)
+			if !equalStrings(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryApi_Hierarchy(t *testing.T) {
+	// flatten renders a tree as "parent>child" paths, depth first.
+	var flatten func(prefix string, tree []*pkg.CategoryTreeResponse) []string
+	flatten = func(prefix string, tree []*pkg.CategoryTreeResponse) []string {
+		var paths []string
+		for _, node := range tree {
+			paths = append(paths, prefix+node.ID)
+			paths = append(paths, flatten(prefix+node.ID+">", node.Children)...)
+		}
+		return paths
+	}
+	tests := []struct {
+		name    string
+		failOn  string
+		call    func(api Category) ([]string, error)
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "nests every live category under its parent, ordered by name",
+			call: func(api Category) ([]string, error) {
+				tree, err := api.FindTree(context.Background())
+				return flatten("", tree), err
+			},
+			want: []string{"c4", "c5", "c1", "c1>c3", "c1>c2", "c1>c2>c6"},
+		},
+		{
+			name: "finds the ancestors from the root down",
+			call: func(api Category) ([]string, error) {
+				ancestors, err := api.FindAncestors(context.Background(), "c6")
+				var ids []string
+				for _, ancestor := range ancestors {
+					ids = append(ids, ancestor.ID)
+				}
+				return ids, err
+			},
+			want: []string{"c1", "c2"},
+		},
+		{
+			name: "stops at a cycle of parents",
+			call: func(api Category) ([]string, error) {
+				ancestors, err := api.FindAncestors(context.Background(), "c7")
+				var ids []string
+				for _, ancestor := range ancestors {
+					ids = append(ids, ancestor.ID)
+				}
+				return ids, err
+			},
+			want: []string{"c8"},
+		},
+		{
+			name: "finds the nested descendants",
+			call: func(api Category) ([]string, error) {
+				descendants, err := api.FindDescendants(context.Background(), "c1")
+				return flatten("", descendants), err
+			},
+			want: []string{"c3", "c2", "c2>c6"},
+		},
+		{
+			name: "refuses the descendants of an unknown category",
+			call: func(api Category) ([]string, error) {
+				_, err := api.FindDescendants(context.Background(), "unknown")
+				return nil, err
+			},
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "includes the programs of the descendants once",
+			call: func(api Category) ([]string, error) {
+				programs, err := api.FindPrograms(context.Background(), "c1", pkg.FindCategoryProgramsRequestJSON{DescendantsJSON: true})
+				var ids []string
+				for _, program := range programs {
+					ids = append(ids, program.ID)
+				}
+				return ids, err
+			},
+			want: []string{"p3", "p2", "p1"},
+		},
+		{
+			name:   "wraps hierarchy failure",
+			failOn: "FindHierarchy",
+			call: func(api Category) ([]string, error) {
+				_, err := api.FindTree(context.Background())
+				return nil, err
+			},
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories := newFakeCategoryPersister(
+				model.Category{ID: "c1", Name: "talk"},
+				model.Category{ID: "c2", Name: "debate", Parent: &model.Category{ID: "c1"}},
+				model.Category{ID: "c3", Name: "comedy", Parent: &model.Category{ID: "c1"}},
+				model.Category{ID: "c4", Name: "music"},
+				model.Category{ID: "c5", Name: "orphan", Parent: &model.Category{ID: "trashed"}},
+				model.Category{ID: "c6", Name: "politics", Parent: &model.Category{ID: "c2"}},
+				model.Category{ID: "c7", Name: "loop", Parent: &model.Category{ID: "c8"}},
+				model.Category{ID: "c8", Name: "loop back", Parent: &model.Category{ID: "c7"}},
+			)
+			if tt.failOn != "" {
+				categories.failOn(tt.failOn)
+			}
+			programCategories := newFakeProgramCategoryPersister(
+				model.ProgramCategory{ID: "pc1", ProgramID: "p1", CategoryID: "c1"},
+				model.ProgramCategory{ID: "pc2", ProgramID: "p1", CategoryID: "c6"},
+				model.ProgramCategory{ID: "pc3", ProgramID: "p2", CategoryID: "c2"},
+				model.ProgramCategory{ID: "pc4", ProgramID: "p3", CategoryID: "c3"},
+				model.ProgramCategory{ID: "pc5", ProgramID: "p4", CategoryID: "c4"},
+			)
+			programs := newFakeProgramPersister(
+				model.Program{ID: "p1", Name: "morning"},
+				model.Program{ID: "p2", Name: "evening"},
+				model.Program{ID: "p3", Name: "afternoon"},
+				model.Program{ID: "p4", Name: "night"},
+			)
+			programs.programCategories = programCategories
+
+			got, err := tt.call(NewCategoryApi(categories, programs, programCategories, newFakeTxManager(), 0))
+
+			assertError(t, err, nil, tt.wantErr)
+			if !equalStrings(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
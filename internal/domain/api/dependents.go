@@ -0,0 +1,18 @@
+// Package api provides functionality for deleting entities other entities may still reference.
+package api
+
+import "github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+
+// DeleteRequest represents the interface for deleting an entity other entities may still reference.
+type DeleteRequest interface {
+	Cascade() bool
+}
+
+// dependentsError returns the error refusing the deletion of the entity identified by id,
+// or nil when no dependent references it anymore.
+func dependentsError(entity, id string, dependents []model.Dependent) error {
+	if len(dependents) == 0 {
+		return nil
+	}
+	return &model.DependentsError{Entity: entity, ID: id, Dependents: dependents}
+}
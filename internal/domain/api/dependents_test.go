@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+// assertDependents checks err refuses a deletion because of the dependents with the given IDs.
+func assertDependents(t *testing.T, err error, wantDependents []string) {
+	t.Helper()
+	if wantDependents == nil {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	dErr, ok := model.AsDependentsError(err)
+	if !ok || !errors.Is(err, model.ErrConflict) {
+		t.Fatalf("got error %v, want a conflict listing %v", err, wantDependents)
+	}
+	var got []string
+	for _, dependent := range dErr.Dependents {
+		got = append(got, dependent.ID)
+	}
+	if !equalStrings(got, wantDependents) {
+		t.Fatalf("got dependents %v, want %v", got, wantDependents)
+	}
+}
+
+func TestBlockApi_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		cascade        bool
+		wantDependents []string
+		wantPlacements []string
+		wantDeleted    bool
+	}{
+		{
+			name:           "refuses a block still placed on live walls",
+			wantDependents: []string{"w1"},
+			wantPlacements: []string{"wb1", "wb2", "wb3"},
+		},
+		{
+			name:           "removes the block from every wall when cascading",
+			cascade:        true,
+			wantPlacements: []string{"wb3"},
+			wantDeleted:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := newFakeBlockPersister(model.Block{ID: "b1"}, model.Block{ID: "b2"})
+			walls := newFakeWallPersister(model.Wall{ID: "w1", Name: "home"})
+			walls.trash = []model.Wall{{ID: "w2"}}
+			wallBlocks := newFakeWallBlockPersister(
+				model.WallBlock{ID: "wb1", WallID: "w1", BlockID: "b1"},
+				model.WallBlock{ID: "wb2", WallID: "w2", BlockID: "b1"},
+				model.WallBlock{ID: "wb3", WallID: "w1", BlockID: "b2"},
+			)
+			api := NewBlockApi(blocks, newFakeBlockProgramPersister(), newFakeProgramPersister(), wallBlocks, walls, newFakeTxManager(blocks, wallBlocks))
+
+			err := api.Delete(context.Background(), "b1", pkg.DeleteRequestJSON{CascadeJSON: tt.cascade})
+
+			assertDependents(t, err, tt.wantDependents)
+			var gotPlacements []string
+			for _, wallBlock := range wallBlocks.rows {
+				gotPlacements = append(gotPlacements, wallBlock.ID)
+			}
+			if !equalStrings(gotPlacements, tt.wantPlacements) {
+				t.Fatalf("got placements %v, want %v", gotPlacements, tt.wantPlacements)
+			}
+			if deleted := blocks.find("b1") == nil; deleted != tt.wantDeleted {
+				t.Fatalf("got block deleted %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestTagApi_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		tagID          string
+		cascade        bool
+		failOn         string
+		wantErr        error
+		wantDependents []string
+		wantAttached   []string
+	}{
+		{
+			name:           "refuses a tag still attached to programs",
+			tagID:          "t1",
+			wantDependents: []string{"p2", "p1"},
+			wantAttached:   []string{"pt1", "pt2", "pt3"},
+		},
+		{
+			name:         "deletes a tag no program uses",
+			tagID:        "t3",
+			wantAttached: []string{"pt1", "pt2", "pt3"},
+		},
+		{
+			name:         "detaches the tag from its programs when cascading",
+			tagID:        "t1",
+			cascade:      true,
+			wantAttached: []string{"pt2"},
+		},
+		{
+			name:         "keeps the programs attached when the deletion fails",
+			tagID:        "t1",
+			cascade:      true,
+			failOn:       "Delete",
+			wantErr:      errAdapter,
+			wantAttached: []string{"pt1", "pt2", "pt3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes := newProgramFakes()
+			fakes.tags.rows = append(fakes.tags.rows, model.Tag{ID: "t3", Name: "sports"})
+			fakes.programs.programTags = fakes.programTags
+			if tt.failOn != "" {
+				fakes.tags.failOn(tt.failOn)
+			}
+			api := NewTagApi(fakes.tags, fakes.programs, fakes.programTags, newFakeTxManager(fakes.tags, fakes.programTags))
+
+			err := api.Delete(context.Background(), tt.tagID, pkg.DeleteRequestJSON{CascadeJSON: tt.cascade})
+
+			if tt.wantErr != nil {
+				assertError(t, err, nil, tt.wantErr)
+			} else {
+				assertDependents(t, err, tt.wantDependents)
+			}
+			var gotAttached []string
+			for _, programTag := range fakes.programTags.rows {
+				gotAttached = append(gotAttached, programTag.ID)
+			}
+			if !equalStrings(gotAttached, tt.wantAttached) {
+				t.Fatalf("got attachments %v, want %v", gotAttached, tt.wantAttached)
+			}
+		})
+	}
+}
+
+func TestProgramApi_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		programID      string
+		cascade        bool
+		wantDependents []string
+		wantDeleted    bool
+	}{
+		{
+			name:           "refuses a program that still has episodes",
+			programID:      "p1",
+			wantDependents: []string{"e2", "e1"},
+		},
+		{
+			name:        "deletes a program along with its episodes when cascading",
+			programID:   "p1",
+			cascade:     true,
+			wantDeleted: true,
+		},
+		{
+			name:        "deletes a program without episodes",
+			programID:   "p3",
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes := newProgramFakes()
+			fakes.programs.rows = append(fakes.programs.rows, model.Program{ID: "p3", Name: "night"})
+
+			err := fakes.api().Delete(context.Background(), tt.programID, pkg.DeleteRequestJSON{CascadeJSON: tt.cascade})
+
+			assertDependents(t, err, tt.wantDependents)
+			if deleted := fakes.programs.find(tt.programID) == nil; deleted != tt.wantDeleted {
+				t.Fatalf("got program deleted %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
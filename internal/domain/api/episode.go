@@ -3,44 +3,56 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
 	"github.com/khedhrije/podcaster-backoffice-api/pkg"
 	"github.com/rs/zerolog/log"
+	"time"
 )
 
 // Episode represents the interface for managing episodes.
 type Episode interface {
-	Create(ctx context.Context, episode CreateEpisodeRequest) error
+	Create(ctx context.Context, episode CreateEpisodeRequest) (string, error)
 	Update(ctx context.Context, uuid string, updates UpdateEpisodeRequest) error
 	Find(ctx context.Context, uuid string) (*pkg.EpisodeResponse, error)
-	FindAll(ctx context.Context) ([]*pkg.EpisodeResponse, error)
+	FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.EpisodeResponse], error)
 	Delete(ctx context.Context, uuid string) error
+	Restore(ctx context.Context, uuid string) error
+	FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.EpisodeResponse], error)
+	Publish(ctx context.Context, uuid string) error
+	Schedule(ctx context.Context, uuid string, req ScheduleEpisodeRequest) error
+	Unpublish(ctx context.Context, uuid string) error
+	Draft(ctx context.Context, uuid string) error
+	PublishDue(ctx context.Context, now time.Time) ([]*pkg.EpisodeResponse, error)
 }
 
 // episodeApi is an implementation of the Episode interface.
 type episodeApi struct {
 	episodeAdapter port.EpisodePersister
+	mediaAdapter   port.MediaPersister
 }
 
 // NewEpisodeApi creates a new instance of Episode.
-// It takes an EpisodePersister as a dependency.
-func NewEpisodeApi(episodeAdapter port.EpisodePersister) Episode {
+// It takes an EpisodePersister, and a MediaPersister to check that the episodes being published hold a media,
+// as dependencies.
+func NewEpisodeApi(episodeAdapter port.EpisodePersister, mediaAdapter port.MediaPersister) Episode {
 	return &episodeApi{
 		episodeAdapter: episodeAdapter,
+		mediaAdapter:   mediaAdapter,
 	}
 }
 
 // Create creates a new episode.
-// It takes the context and CreateEpisodeRequest, and returns an error if any.
-func (api episodeApi) Create(ctx context.Context, req CreateEpisodeRequest) error {
+// It takes the context and CreateEpisodeRequest, and returns the UUID of the new episode or an error.
+func (api episodeApi) Create(ctx context.Context, req CreateEpisodeRequest) (string, error) {
 	// Validate request
 	vErrs := createEpisodeRequestValidation(ctx, req)
 	if len(vErrs) > 0 {
 		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
-		return fmt.Errorf("request was not validated: %w", vErrs)
+		return "", fmt.Errorf("request was not validated: %w", vErrs)
 	}
 
 	// Map to domain model
@@ -50,14 +62,15 @@ func (api episodeApi) Create(ctx context.Context, req CreateEpisodeRequest) erro
 		Description: req.Description(),
 		ProgramID:   req.ProgramID(),
 		Position:    req.Position(),
+		Status:      model.EpisodeStatusDraft,
 	}
 	// Call adapter
 	if err := api.episodeAdapter.Create(ctx, episode); err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("episode", episode).Msg("error while creating episode")
-		return fmt.Errorf("error occurred while creating episode: %w", err)
+		return "", fmt.Errorf("error occurred while creating episode: %w", err)
 	}
 
-	return nil
+	return episode.ID, nil
 }
 
 // createEpisodeRequestValidation validates the creation request.
@@ -135,16 +148,29 @@ func (api episodeApi) Find(ctx context.Context, uuid string) (*pkg.EpisodeRespon
 		Description: episode.Description,
 		ProgramID:   episode.ProgramID,
 		Position:    episode.Position,
+		Status:      string(episode.Status),
+		PublishAt:   optionalTime(episode.PublishAt),
 	}
 	// Return result
 	return response, nil
 }
 
-// FindAll finds all episodes.
-// It takes the context and returns a slice of EpisodeResponse or an error.
-func (api episodeApi) FindAll(ctx context.Context) ([]*pkg.EpisodeResponse, error) {
+// episodeListing describes how episodes can be sorted and filtered.
+var episodeListing = listing{named: true, sortable: []string{model.SortByName, model.SortByCreatedAt, model.SortByPosition}}
+
+// FindAll finds a page of episodes.
+// It takes the context and ListRequest, and returns a page of EpisodeResponse or an error.
+func (api episodeApi) FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.EpisodeResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, episodeListing)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := listOptions(req)
+
 	// Call adapter
-	episodeSlice, err := api.episodeAdapter.FindAll(ctx)
+	episodeSlice, total, err := api.episodeAdapter.FindAll(ctx, opts)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while finding episodes")
 		return nil, fmt.Errorf("error occurred while finding episodes: %w", err)
@@ -159,10 +185,12 @@ func (api episodeApi) FindAll(ctx context.Context) ([]*pkg.EpisodeResponse, erro
 			Description: episode.Description,
 			ProgramID:   episode.ProgramID,
 			Position:    episode.Position,
+			Status:      string(episode.Status),
+			PublishAt:   optionalTime(episode.PublishAt),
 		})
 	}
 	// Return result
-	return response, nil
+	return newPage(response, total, opts), nil
 }
 
 // Delete deletes an episode by UUID.
@@ -175,6 +203,201 @@ func (api episodeApi) Delete(ctx context.Context, uuid string) error {
 	return nil
 }
 
+// Restore restores a episode from the trash by UUID along with the medias moved to the trash with it.
+// It fails with model.ErrConflict while the program of the episode is in the trash.
+// It takes the context and episode UUID, and returns an error if any.
+func (api episodeApi) Restore(ctx context.Context, uuid string) error {
+	if err := api.episodeAdapter.Restore(ctx, uuid); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while restoring episode")
+		return fmt.Errorf("error occurred while restoring episode: %w", err)
+	}
+	return nil
+}
+
+// FindDeleted finds a page of the episodes in the trash, the most recently deleted first unless sorted otherwise.
+// It takes the context and ListRequest, and returns a page of EpisodeResponse or an error.
+func (api episodeApi) FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.EpisodeResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, episodeListing.trash())
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := trashOptions(req)
+
+	// Call adapter
+	episodeSlice, total, err := api.episodeAdapter.FindAll(ctx, opts)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while finding deleted episodes")
+		return nil, fmt.Errorf("error occurred while finding deleted episodes: %w", err)
+	}
+
+	// Map to response
+	var response []*pkg.EpisodeResponse
+	for _, episode := range episodeSlice {
+		response = append(response, &pkg.EpisodeResponse{
+			ID:          episode.ID,
+			Name:        episode.Name,
+			Description: episode.Description,
+			ProgramID:   episode.ProgramID,
+			Position:    episode.Position,
+			Status:      string(episode.Status),
+			PublishAt:   optionalTime(episode.PublishAt),
+			DeletedAt:   optionalTime(episode.DeletedAt),
+		})
+	}
+	// Return result
+	return newPage(response, total, opts), nil
+}
+
+// Publish publishes an episode right away, whether it is a draft, scheduled or unpublished.
+// It fails with a validation error when the episode holds no media, and with model.ErrConflict when it is
+// already published.
+// It takes the context and episode UUID, and returns an error if any.
+func (api episodeApi) Publish(ctx context.Context, uuid string) error {
+	if err := api.transition(ctx, uuid, model.EpisodeStatusPublished, time.Now().UTC()); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while publishing episode")
+		return fmt.Errorf("error occurred while publishing episode: %w", err)
+	}
+	return nil
+}
+
+// Schedule schedules the publication of an episode at a time in the future, or moves the publication
+// of a scheduled episode to another time.
+// It fails with a validation error when the episode holds no media, and with model.ErrConflict when it is published.
+// It takes the context, episode UUID, and ScheduleEpisodeRequest, and returns an error if any.
+func (api episodeApi) Schedule(ctx context.Context, uuid string, req ScheduleEpisodeRequest) error {
+	// Validate request
+	vErrs := scheduleEpisodeRequestValidation(ctx, req, time.Now())
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return fmt.Errorf("request was not validated: %w", vErrs)
+	}
+
+	if err := api.transition(ctx, uuid, model.EpisodeStatusScheduled, req.PublishAt().UTC()); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while scheduling episode")
+		return fmt.Errorf("error occurred while scheduling episode: %w", err)
+	}
+	return nil
+}
+
+// scheduleEpisodeRequestValidation validates the scheduling request against the current time.
+// It takes the context, ScheduleEpisodeRequest and current time, and returns a slice of ValidationErrors.
+func scheduleEpisodeRequestValidation(ctx context.Context, req ScheduleEpisodeRequest, now time.Time) model.ValidationErrors {
+	var vErrs []model.ValidationError
+	if req.PublishAt().IsZero() {
+		vErrs = append(vErrs, model.ValidationError{Field: "publishAt", Message: "is required"})
+	} else if !req.PublishAt().After(now) {
+		vErrs = append(vErrs, model.ValidationError{Field: "publishAt", Message: "must be in the future"})
+	}
+	return vErrs
+}
+
+// Unpublish withdraws a published episode from the listeners, keeping the time it was published at.
+// It fails with model.ErrConflict when the episode is not published.
+// It takes the context and episode UUID, and returns an error if any.
+func (api episodeApi) Unpublish(ctx context.Context, uuid string) error {
+	if err := api.transition(ctx, uuid, model.EpisodeStatusUnpublished, time.Time{}); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while unpublishing episode")
+		return fmt.Errorf("error occurred while unpublishing episode: %w", err)
+	}
+	return nil
+}
+
+// Draft moves a scheduled or unpublished episode back to draft, cancelling its scheduled publication.
+// It fails with model.ErrConflict when the episode is published or already a draft.
+// It takes the context and episode UUID, and returns an error if any.
+func (api episodeApi) Draft(ctx context.Context, uuid string) error {
+	if err := api.transition(ctx, uuid, model.EpisodeStatusDraft, time.Time{}); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while moving episode to draft")
+		return fmt.Errorf("error occurred while moving episode to draft: %w", err)
+	}
+	return nil
+}
+
+// transition moves an episode to the status to, with the publication time publishAt.
+// Unpublished episodes keep the time they were published at instead.
+// Episodes must hold a media to be published or scheduled.
+func (api episodeApi) transition(ctx context.Context, uuid string, to model.EpisodeStatus, publishAt time.Time) error {
+	episode, err := api.episodeAdapter.Find(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if !episode.Status.CanBecome(to) {
+		return fmt.Errorf("%w: episode %s is %s and cannot become %s", model.ErrConflict, uuid, episode.Status, to)
+	}
+	if to == model.EpisodeStatusPublished || to == model.EpisodeStatusScheduled {
+		medias, err := api.mediaAdapter.FindByEpisodeIDs(ctx, []string{uuid})
+		if err != nil {
+			return err
+		}
+		if len(medias) == 0 {
+			return model.ValidationErrors{{Field: "medias", Message: "at least one media is required to publish the episode"}}
+		}
+	}
+	if to == model.EpisodeStatusUnpublished {
+		publishAt = episode.PublishAt
+	}
+	return api.episodeAdapter.UpdateStatus(ctx, uuid, episode.Status, to, publishAt)
+}
+
+// PublishDue publishes the scheduled episodes whose publication time is at or before now, at their scheduled time.
+// Scheduled episodes that no longer hold a media are moved back to draft instead, and episodes changed by someone
+// else in the meantime are left alone.
+// It takes the context and current time, and returns the episodes whose status changed, along with an error
+// if any; the episodes changed before the error are returned with it.
+func (api episodeApi) PublishDue(ctx context.Context, now time.Time) ([]*pkg.EpisodeResponse, error) {
+	// Call adapters
+	episodes, err := api.episodeAdapter.FindDue(ctx, now)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Time("now", now).Msg("error while finding due episodes")
+		return nil, fmt.Errorf("error occurred while finding due episodes: %w", err)
+	}
+	if len(episodes) == 0 {
+		return nil, nil
+	}
+	episodeIDs := make([]string, 0, len(episodes))
+	for _, episode := range episodes {
+		episodeIDs = append(episodeIDs, episode.ID)
+	}
+	medias, err := api.mediaAdapter.FindByEpisodeIDs(ctx, episodeIDs)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Strs("uuids", episodeIDs).Msg("error while finding medias of due episodes")
+		return nil, fmt.Errorf("error occurred while finding medias of due episodes: %w", err)
+	}
+	withMedia := make(map[string]bool)
+	for _, media := range medias {
+		withMedia[media.EpisodeID] = true
+	}
+
+	var changed []*pkg.EpisodeResponse
+	for _, episode := range episodes {
+		to, publishAt := model.EpisodeStatusPublished, episode.PublishAt
+		if !withMedia[episode.ID] {
+			log.Ctx(ctx).Warn().Str("uuid", episode.ID).Msg("scheduled episode holds no media, moving it back to draft")
+			to, publishAt = model.EpisodeStatusDraft, time.Time{}
+		}
+		err := api.episodeAdapter.UpdateStatus(ctx, episode.ID, model.EpisodeStatusScheduled, to, publishAt)
+		if errors.Is(err, model.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("uuid", episode.ID).Msg("error while publishing due episode")
+			return changed, fmt.Errorf("error occurred while publishing due episode: %w", err)
+		}
+		changed = append(changed, &pkg.EpisodeResponse{
+			ID:          episode.ID,
+			Name:        episode.Name,
+			Description: episode.Description,
+			ProgramID:   episode.ProgramID,
+			Position:    episode.Position,
+			Status:      string(to),
+			PublishAt:   optionalTime(publishAt),
+		})
+	}
+	return changed, nil
+}
+
 // CreateEpisodeRequest represents the interface for creating episodes.
 type CreateEpisodeRequest interface {
 	Name() string
@@ -190,3 +413,8 @@ type UpdateEpisodeRequest interface {
 	ProgramID() string
 	Position() int
 }
+
+// ScheduleEpisodeRequest represents the interface for scheduling the publication of episodes.
+type ScheduleEpisodeRequest interface {
+	PublishAt() time.Time
+}
@@ -0,0 +1,387 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+func TestEpisodeApi_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.CreateEpisodeRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+	}{
+		{
+			name: "creates the episode",
+			req:  pkg.CreateEpisodeRequestJSON{NameJSON: "pilot", DescriptionJSON: "first one", ProgramIDJSON: "p1", PositionJSON: 1},
+		},
+		{
+			name:       "requires name, description and position",
+			req:        pkg.CreateEpisodeRequestJSON{ProgramIDJSON: "p1"},
+			wantFields: []string{"name", "description", "position"},
+		},
+		{
+			name:       "rejects negative position",
+			req:        pkg.CreateEpisodeRequestJSON{NameJSON: "pilot", DescriptionJSON: "first one", PositionJSON: -1},
+			wantFields: []string{"position"},
+		},
+		{
+			name:    "wraps adapter failure",
+			req:     pkg.CreateEpisodeRequestJSON{NameJSON: "pilot", DescriptionJSON: "first one", ProgramIDJSON: "p1", PositionJSON: 1},
+			failOn:  "Create",
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			episodes := newFakeEpisodePersister()
+			if tt.failOn != "" {
+				episodes.failOn(tt.failOn)
+			}
+
+			id, err := NewEpisodeApi(episodes, newFakeMediaPersister()).Create(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if err == nil {
+				if len(episodes.rows) != 1 || episodes.rows[0].ID != id || episodes.rows[0].ProgramID != "p1" || episodes.rows[0].Position != 1 || episodes.rows[0].Status != model.EpisodeStatusDraft {
+					t.Fatalf("unexpected stored episodes: %+v", episodes.rows)
+				}
+			}
+		})
+	}
+}
+
+func TestEpisodeApi_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		uuid       string
+		req        pkg.UpdateEpisodeRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+		want       model.Episode
+	}{
+		{
+			name: "updates only the given fields",
+			uuid: "e1",
+			req:  pkg.UpdateEpisodeRequestJSON{PositionJSON: 3},
+			want: model.Episode{ID: "e1", Name: "pilot", ProgramID: "p1", Position: 3},
+		},
+		{
+			name: "moves the episode to another program",
+			uuid: "e1",
+			req:  pkg.UpdateEpisodeRequestJSON{ProgramIDJSON: "p2"},
+			want: model.Episode{ID: "e1", Name: "pilot", ProgramID: "p2", Position: 1},
+		},
+		{
+			name:       "requires the uuid",
+			req:        pkg.UpdateEpisodeRequestJSON{PositionJSON: 3},
+			wantFields: []string{"uuid"},
+			want:       model.Episode{ID: "e1", Name: "pilot", ProgramID: "p1", Position: 1},
+		},
+		{
+			name:    "wraps adapter failure",
+			uuid:    "e1",
+			req:     pkg.UpdateEpisodeRequestJSON{PositionJSON: 3},
+			failOn:  "Update",
+			wantErr: errAdapter,
+			want:    model.Episode{ID: "e1", Name: "pilot", ProgramID: "p1", Position: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			episodes := newFakeEpisodePersister(model.Episode{ID: "e1", Name: "pilot", ProgramID: "p1", Position: 1})
+			if tt.failOn != "" {
+				episodes.failOn(tt.failOn)
+			}
+
+			err := NewEpisodeApi(episodes, newFakeMediaPersister()).Update(context.Background(), tt.uuid, tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if got := episodes.rows[0]; got != tt.want {
+				t.Fatalf("got episode %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEpisodeApi_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		failOn  string
+		call    func(api Episode) (int, error)
+		want    int
+		wantErr error
+	}{
+		{
+			name: "finds an episode",
+			call: func(api Episode) (int, error) {
+				episode, err := api.Find(context.Background(), "e1")
+				if err != nil {
+					return 0, err
+				}
+				if episode.ProgramID != "p1" || episode.Position != 1 {
+					t.Fatalf("unexpected episode: %+v", episode)
+				}
+				return 1, nil
+			},
+			want: 1,
+		},
+		{
+			name:    "wraps find failure",
+			failOn:  "Find",
+			call:    func(api Episode) (int, error) { _, err := api.Find(context.Background(), "e1"); return 0, err },
+			wantErr: errAdapter,
+		},
+		{
+			name:    "reports a missing episode",
+			call:    func(api Episode) (int, error) { _, err := api.Find(context.Background(), "e9"); return 0, err },
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name:    "reports the deletion of a missing episode",
+			call:    func(api Episode) (int, error) { return 0, api.Delete(context.Background(), "e9") },
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "finds all episodes",
+			call: func(api Episode) (int, error) {
+				episodes, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				if err != nil {
+					return 0, err
+				}
+				return len(episodes.Items), nil
+			},
+			want: 2,
+		},
+		{
+			name:   "wraps find all failure",
+			failOn: "FindAll",
+			call: func(api Episode) (int, error) {
+				_, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				return 0, err
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name:    "wraps delete failure",
+			failOn:  "Delete",
+			call:    func(api Episode) (int, error) { return 0, api.Delete(context.Background(), "e1") },
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			episodes := newFakeEpisodePersister(
+				model.Episode{ID: "e1", Name: "pilot", ProgramID: "p1", Position: 1},
+				model.Episode{ID: "e2", Name: "finale", ProgramID: "p1", Position: 2},
+			)
+			if tt.failOn != "" {
+				episodes.failOn(tt.failOn)
+			}
+
+			got, err := tt.call(NewEpisodeApi(episodes, newFakeMediaPersister()))
+
+			assertError(t, err, nil, tt.wantErr)
+			if got != tt.want {
+				t.Fatalf("got %d results, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEpisodeApi_Publication(t *testing.T) {
+	now := time.Now().UTC()
+	published := now.Add(-24 * time.Hour)
+	tests := []struct {
+		name          string
+		status        model.EpisodeStatus
+		withoutMedia  bool
+		failOn        string
+		call          func(api Episode) error
+		wantFields    []string
+		wantErr       error
+		wantStatus    model.EpisodeStatus
+		wantPublishAt func(publishAt time.Time) bool
+	}{
+		{
+			name:          "publishes a draft right away",
+			status:        model.EpisodeStatusDraft,
+			call:          func(api Episode) error { return api.Publish(context.Background(), "e1") },
+			wantStatus:    model.EpisodeStatusPublished,
+			wantPublishAt: func(publishAt time.Time) bool { return !publishAt.Before(now) },
+		},
+		{
+			name:   "schedules an unpublished episode",
+			status: model.EpisodeStatusUnpublished,
+			call: func(api Episode) error {
+				return api.Schedule(context.Background(), "e1", pkg.ScheduleEpisodeRequestJSON{PublishAtJSON: now.Add(time.Hour)})
+			},
+			wantStatus:    model.EpisodeStatusScheduled,
+			wantPublishAt: func(publishAt time.Time) bool { return publishAt.Equal(now.Add(time.Hour)) },
+		},
+		{
+			name:          "unpublishes a published episode, keeping its publication time",
+			status:        model.EpisodeStatusPublished,
+			call:          func(api Episode) error { return api.Unpublish(context.Background(), "e1") },
+			wantStatus:    model.EpisodeStatusUnpublished,
+			wantPublishAt: func(publishAt time.Time) bool { return publishAt.Equal(published) },
+		},
+		{
+			name:          "moves a scheduled episode back to draft",
+			status:        model.EpisodeStatusScheduled,
+			call:          func(api Episode) error { return api.Draft(context.Background(), "e1") },
+			wantStatus:    model.EpisodeStatusDraft,
+			wantPublishAt: time.Time.IsZero,
+		},
+		{
+			name:         "requires a media to publish",
+			status:       model.EpisodeStatusDraft,
+			withoutMedia: true,
+			call:         func(api Episode) error { return api.Publish(context.Background(), "e1") },
+			wantFields:   []string{"medias"},
+			wantStatus:   model.EpisodeStatusDraft,
+		},
+		{
+			name:         "requires a media to schedule",
+			status:       model.EpisodeStatusDraft,
+			withoutMedia: true,
+			call: func(api Episode) error {
+				return api.Schedule(context.Background(), "e1", pkg.ScheduleEpisodeRequestJSON{PublishAtJSON: now.Add(time.Hour)})
+			},
+			wantFields: []string{"medias"},
+			wantStatus: model.EpisodeStatusDraft,
+		},
+		{
+			name:   "requires a publication time in the future",
+			status: model.EpisodeStatusDraft,
+			call: func(api Episode) error {
+				return api.Schedule(context.Background(), "e1", pkg.ScheduleEpisodeRequestJSON{PublishAtJSON: now.Add(-time.Minute)})
+			},
+			wantFields: []string{"publishAt"},
+			wantStatus: model.EpisodeStatusDraft,
+		},
+		{
+			name:   "requires a publication time",
+			status: model.EpisodeStatusDraft,
+			call: func(api Episode) error {
+				return api.Schedule(context.Background(), "e1", pkg.ScheduleEpisodeRequestJSON{})
+			},
+			wantFields: []string{"publishAt"},
+			wantStatus: model.EpisodeStatusDraft,
+		},
+		{
+			name:       "refuses to publish a published episode",
+			status:     model.EpisodeStatusPublished,
+			call:       func(api Episode) error { return api.Publish(context.Background(), "e1") },
+			wantErr:    model.ErrConflict,
+			wantStatus: model.EpisodeStatusPublished,
+		},
+		{
+			name:       "refuses to unpublish a draft",
+			status:     model.EpisodeStatusDraft,
+			call:       func(api Episode) error { return api.Unpublish(context.Background(), "e1") },
+			wantErr:    model.ErrConflict,
+			wantStatus: model.EpisodeStatusDraft,
+		},
+		{
+			name:       "reports a missing episode",
+			status:     model.EpisodeStatusDraft,
+			call:       func(api Episode) error { return api.Publish(context.Background(), "e9") },
+			wantErr:    model.ErrNotFound,
+			wantStatus: model.EpisodeStatusDraft,
+		},
+		{
+			name:       "wraps adapter failure",
+			status:     model.EpisodeStatusDraft,
+			failOn:     "UpdateStatus",
+			call:       func(api Episode) error { return api.Publish(context.Background(), "e1") },
+			wantErr:    errAdapter,
+			wantStatus: model.EpisodeStatusDraft,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			episode := model.Episode{ID: "e1", Name: "pilot", ProgramID: "p1", Position: 1, Status: tt.status}
+			if tt.status != model.EpisodeStatusDraft {
+				episode.PublishAt = published
+			}
+			episodes := newFakeEpisodePersister(episode)
+			if tt.failOn != "" {
+				episodes.failOn(tt.failOn)
+			}
+			medias := newFakeMediaPersister(model.Media{ID: "m1", EpisodeID: "e1"})
+			if tt.withoutMedia {
+				medias.rows = nil
+			}
+
+			err := tt.call(NewEpisodeApi(episodes, medias))
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			got := episodes.rows[0]
+			if got.Status != tt.wantStatus {
+				t.Fatalf("got status %s, want %s", got.Status, tt.wantStatus)
+			}
+			if tt.wantPublishAt != nil && !tt.wantPublishAt(got.PublishAt) {
+				t.Fatalf("got publication time %v", got.PublishAt)
+			}
+		})
+	}
+}
+
+func TestEpisodeApi_PublishDue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	episodes := newFakeEpisodePersister(
+		model.Episode{ID: "e1", Status: model.EpisodeStatusScheduled, PublishAt: now.Add(-time.Minute)},
+		model.Episode{ID: "e2", Status: model.EpisodeStatusScheduled, PublishAt: now.Add(time.Minute)},
+		model.Episode{ID: "e3", Status: model.EpisodeStatusScheduled, PublishAt: now.Add(-time.Hour)},
+		model.Episode{ID: "e4", Status: model.EpisodeStatusScheduled, PublishAt: now},
+		model.Episode{ID: "e5", Status: model.EpisodeStatusDraft},
+	)
+	medias := newFakeMediaPersister(
+		model.Media{ID: "m1", EpisodeID: "e1"},
+		model.Media{ID: "m2", EpisodeID: "e2"},
+		model.Media{ID: "m4", EpisodeID: "e4"},
+	)
+
+	changed, err := NewEpisodeApi(episodes, medias).PublishDue(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, episode := range changed {
+		got = append(got, episode.ID+" "+episode.Status)
+	}
+	if want := []string{"e3 draft", "e1 published", "e4 published"}; !equalStrings(got, want) {
+		t.Fatalf("got changed episodes %v, want %v", got, want)
+	}
+	want := map[string]model.EpisodeStatus{
+		"e1": model.EpisodeStatusPublished,
+		"e2": model.EpisodeStatusScheduled,
+		"e3": model.EpisodeStatusDraft,
+		"e4": model.EpisodeStatusPublished,
+		"e5": model.EpisodeStatusDraft,
+	}
+	for _, episode := range episodes.rows {
+		if episode.Status != want[episode.ID] {
+			t.Fatalf("got episode %s %s, want %s", episode.ID, episode.Status, want[episode.ID])
+		}
+	}
+	if e1 := episodes.find("e1"); !e1.PublishAt.Equal(now.Add(-time.Minute)) {
+		t.Fatalf("got episode published at %v, want its scheduled time", e1.PublishAt)
+	}
+
+	episodes.failOn("UpdateStatus")
+	episodes.rows[1].PublishAt = now
+	if _, err := NewEpisodeApi(episodes, medias).PublishDue(context.Background(), now); !errors.Is(err, errAdapter) {
+		t.Fatalf("got error %v, want %v", err, errAdapter)
+	}
+}
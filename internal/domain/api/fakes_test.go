@@ -0,0 +1,1300 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// errAdapter is the error returned by fake persisters configured to fail.
+var errAdapter = errors.New("adapter failure")
+
+// fakeStore is the in-memory table backing every fake persister.
+// Errors can be injected per method name through errs.
+type fakeStore[T any] struct {
+	id      func(T) string
+	rows    []T
+	errs    map[string]error
+	listed  model.ListOptions // options of the last list call
+	calls   int               // number of persister methods called
+	latency time.Duration     // simulated round trip of every call, for benchmarks
+	trash   []T               // rows moved out of rows by delete
+	purged  time.Time         // time given to the last purge call
+}
+
+// fail returns the error injected for the given method, if any.
+// Every persister method starts with it, which makes it the place to count and slow down calls.
+func (s *fakeStore[T]) fail(method string) error {
+	s.calls++
+	if s.latency > 0 {
+		time.Sleep(s.latency)
+	}
+	return s.errs[method]
+}
+
+// failOn makes the given method return errAdapter.
+func (s *fakeStore[T]) failOn(method string) {
+	if s.errs == nil {
+		s.errs = make(map[string]error)
+	}
+	s.errs[method] = errAdapter
+}
+
+func (s *fakeStore[T]) create(row T) error {
+	s.rows = append(s.rows, row)
+	return nil
+}
+
+func (s *fakeStore[T]) update(id string, apply func(*T)) error {
+	for i := range s.rows {
+		if s.id(s.rows[i]) == id {
+			apply(&s.rows[i])
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %w", id, model.ErrNotFound)
+}
+
+func (s *fakeStore[T]) find(id string) *T {
+	for i := range s.rows {
+		if s.id(s.rows[i]) == id {
+			row := s.rows[i]
+			return &row
+		}
+	}
+	return nil
+}
+
+// get is find returning model.ErrNotFound, like the adapters, when the row does not exist.
+func (s *fakeStore[T]) get(id string) (*T, error) {
+	if row := s.find(id); row != nil {
+		return row, nil
+	}
+	return nil, fmt.Errorf("%s: %w", id, model.ErrNotFound)
+}
+
+// byIDs returns the rows with the given IDs, in the order of ids, skipping unknown IDs.
+func (s *fakeStore[T]) byIDs(ids []string) []*T {
+	var result []*T
+	for _, id := range ids {
+		if row := s.find(id); row != nil {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+func (s *fakeStore[T]) where(match func(T) bool) []*T {
+	var result []*T
+	for i := range s.rows {
+		if match(s.rows[i]) {
+			row := s.rows[i]
+			result = append(result, &row)
+		}
+	}
+	return result
+}
+
+func (s *fakeStore[T]) all() []*T {
+	return s.where(func(T) bool { return true })
+}
+
+// list pages the rows in insertion order, or the trash when opts.Deleted is set, ignoring filters and sort,
+// and remembers the options.
+func (s *fakeStore[T]) list(opts model.ListOptions) ([]*T, int, error) {
+	s.listed = opts
+	rows := s.all()
+	if opts.Deleted {
+		rows = nil
+		for i := range s.trash {
+			row := s.trash[i]
+			rows = append(rows, &row)
+		}
+	}
+	total := len(rows)
+	if opts.Offset >= total {
+		return nil, total, nil
+	}
+	rows = rows[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < len(rows) {
+		rows = rows[:opts.Limit]
+	}
+	return rows, total, nil
+}
+
+// snapshot copies the rows and returns the function putting the copy back.
+func (s *fakeStore[T]) snapshot() func() {
+	rows, trash := slices.Clone(s.rows), slices.Clone(s.trash)
+	return func() { s.rows, s.trash = rows, trash }
+}
+
+// delete moves the row with the given ID to the trash.
+func (s *fakeStore[T]) delete(id string) error {
+	for i := range s.rows {
+		if s.id(s.rows[i]) == id {
+			s.trash = append(s.trash, s.rows[i])
+			s.rows = append(s.rows[:i], s.rows[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %w", id, model.ErrNotFound)
+}
+
+// restore moves the row with the given ID back from the trash.
+func (s *fakeStore[T]) restore(id string) error {
+	for i := range s.trash {
+		if s.id(s.trash[i]) == id {
+			s.rows = append(s.rows, s.trash[i])
+			s.trash = append(s.trash[:i], s.trash[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %w", id, model.ErrNotFound)
+}
+
+// purge empties the trash, whatever the time the rows were deleted, and remembers before.
+func (s *fakeStore[T]) purge(before time.Time) int {
+	s.purged = before
+	count := len(s.trash)
+	s.trash = nil
+	return count
+}
+
+// fakeTxManager is a fake implementation of port.TxManager.
+// It restores the rows of its stores when the unit of work fails, like a rolled back transaction.
+type fakeTxManager struct {
+	stores []interface{ snapshot() func() }
+}
+
+func newFakeTxManager(stores ...interface{ snapshot() func() }) *fakeTxManager {
+	return &fakeTxManager{stores: stores}
+}
+
+func (m *fakeTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	var restores []func()
+	for _, store := range m.stores {
+		restores = append(restores, store.snapshot())
+	}
+	if err := fn(ctx); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	return nil
+}
+
+// fakeWallPersister is a fake implementation of port.WallPersister.
+type fakeWallPersister struct{ fakeStore[model.Wall] }
+
+func newFakeWallPersister(rows ...model.Wall) *fakeWallPersister {
+	return &fakeWallPersister{fakeStore[model.Wall]{id: func(w model.Wall) string { return w.ID }, rows: rows}}
+}
+
+func (f *fakeWallPersister) Create(_ context.Context, wall model.Wall) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(wall)
+}
+
+func (f *fakeWallPersister) Update(_ context.Context, id string, updates model.Wall) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(wall *model.Wall) {
+		wall.Name = updates.Name
+		wall.Description = updates.Description
+	})
+}
+
+func (f *fakeWallPersister) Find(_ context.Context, id string) (*model.Wall, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeWallPersister) FindByIDs(_ context.Context, ids []string) ([]*model.Wall, error) {
+	if err := f.fail("FindByIDs"); err != nil {
+		return nil, err
+	}
+	return f.byIDs(ids), nil
+}
+
+func (f *fakeWallPersister) FindAll(_ context.Context, opts model.ListOptions) ([]*model.Wall, int, error) {
+	if err := f.fail("FindAll"); err != nil {
+		return nil, 0, err
+	}
+	return f.list(opts)
+}
+
+func (f *fakeWallPersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+func (f *fakeWallPersister) Restore(_ context.Context, id string) error {
+	if err := f.fail("Restore"); err != nil {
+		return err
+	}
+	return f.restore(id)
+}
+
+func (f *fakeWallPersister) Purge(_ context.Context, before time.Time) (int, error) {
+	if err := f.fail("Purge"); err != nil {
+		return 0, err
+	}
+	return f.purge(before), nil
+}
+
+// fakeWallBlockPersister is a fake implementation of port.WallBlockPersister.
+type fakeWallBlockPersister struct{ fakeStore[model.WallBlock] }
+
+func newFakeWallBlockPersister(rows ...model.WallBlock) *fakeWallBlockPersister {
+	return &fakeWallBlockPersister{fakeStore[model.WallBlock]{id: func(wb model.WallBlock) string { return wb.ID }, rows: rows}}
+}
+
+func (f *fakeWallBlockPersister) Create(_ context.Context, wallBlock model.WallBlock) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(wallBlock)
+}
+
+func (f *fakeWallBlockPersister) Update(_ context.Context, id string, updates model.WallBlock) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(wallBlock *model.WallBlock) {
+		updates.ID = id
+		*wallBlock = updates
+	})
+}
+
+func (f *fakeWallBlockPersister) Find(_ context.Context, id string) (*model.WallBlock, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeWallBlockPersister) FindByWallID(_ context.Context, id string) ([]*model.WallBlock, error) {
+	if err := f.fail("FindByWallID"); err != nil {
+		return nil, err
+	}
+	return sortedWallBlocks(f.where(func(wb model.WallBlock) bool { return wb.WallID == id })), nil
+}
+
+func (f *fakeWallBlockPersister) FindByBlockID(_ context.Context, id string) ([]*model.WallBlock, error) {
+	if err := f.fail("FindByBlockID"); err != nil {
+		return nil, err
+	}
+	return sortedWallBlocks(f.where(func(wb model.WallBlock) bool { return wb.BlockID == id })), nil
+}
+
+func (f *fakeWallBlockPersister) FindByWallIDAndBlockID(_ context.Context, wallID string, blockID string) ([]*model.WallBlock, error) {
+	if err := f.fail("FindByWallIDAndBlockID"); err != nil {
+		return nil, err
+	}
+	return f.where(func(wb model.WallBlock) bool { return wb.WallID == wallID && wb.BlockID == blockID }), nil
+}
+
+func (f *fakeWallBlockPersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+func sortedWallBlocks(rows []*model.WallBlock) []*model.WallBlock {
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Position < rows[j].Position })
+	return rows
+}
+
+// fakeBlockPersister is a fake implementation of port.BlockPersister.
+type fakeBlockPersister struct{ fakeStore[model.Block] }
+
+func newFakeBlockPersister(rows ...model.Block) *fakeBlockPersister {
+	return &fakeBlockPersister{fakeStore[model.Block]{id: func(b model.Block) string { return b.ID }, rows: rows}}
+}
+
+func (f *fakeBlockPersister) Create(_ context.Context, block model.Block) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(block)
+}
+
+func (f *fakeBlockPersister) Update(_ context.Context, id string, updates model.Block) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(block *model.Block) {
+		block.Name = coalesceString(updates.Name, block.Name)
+		block.Description = coalesceString(updates.Description, block.Description)
+		block.Kind = coalesceString(updates.Kind, block.Kind)
+	})
+}
+
+func (f *fakeBlockPersister) Find(_ context.Context, id string) (*model.Block, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeBlockPersister) FindByIDs(_ context.Context, ids []string) ([]*model.Block, error) {
+	if err := f.fail("FindByIDs"); err != nil {
+		return nil, err
+	}
+	return f.byIDs(ids), nil
+}
+
+func (f *fakeBlockPersister) FindAll(_ context.Context, opts model.ListOptions) ([]*model.Block, int, error) {
+	if err := f.fail("FindAll"); err != nil {
+		return nil, 0, err
+	}
+	return f.list(opts)
+}
+
+func (f *fakeBlockPersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+func (f *fakeBlockPersister) Restore(_ context.Context, id string) error {
+	if err := f.fail("Restore"); err != nil {
+		return err
+	}
+	return f.restore(id)
+}
+
+func (f *fakeBlockPersister) Purge(_ context.Context, before time.Time) (int, error) {
+	if err := f.fail("Purge"); err != nil {
+		return 0, err
+	}
+	return f.purge(before), nil
+}
+
+// fakeBlockProgramPersister is a fake implementation of port.BlockProgramPersister.
+type fakeBlockProgramPersister struct{ fakeStore[model.BlockProgram] }
+
+func newFakeBlockProgramPersister(rows ...model.BlockProgram) *fakeBlockProgramPersister {
+	return &fakeBlockProgramPersister{fakeStore[model.BlockProgram]{id: func(bp model.BlockProgram) string { return bp.ID }, rows: rows}}
+}
+
+func (f *fakeBlockProgramPersister) Create(_ context.Context, blockProgram model.BlockProgram) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(blockProgram)
+}
+
+func (f *fakeBlockProgramPersister) Update(_ context.Context, id string, updates model.BlockProgram) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(blockProgram *model.BlockProgram) {
+		updates.ID = id
+		*blockProgram = updates
+	})
+}
+
+func (f *fakeBlockProgramPersister) Find(_ context.Context, id string) (*model.BlockProgram, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeBlockProgramPersister) FindByBlockID(_ context.Context, id string) ([]*model.BlockProgram, error) {
+	if err := f.fail("FindByBlockID"); err != nil {
+		return nil, err
+	}
+	return sortedBlockPrograms(f.where(func(bp model.BlockProgram) bool { return bp.BlockID == id })), nil
+}
+
+func (f *fakeBlockProgramPersister) FindByBlockIDs(_ context.Context, ids []string) ([]*model.BlockProgram, error) {
+	if err := f.fail("FindByBlockIDs"); err != nil {
+		return nil, err
+	}
+	return sortedBlockPrograms(f.where(func(bp model.BlockProgram) bool { return slices.Contains(ids, bp.BlockID) })), nil
+}
+
+func (f *fakeBlockProgramPersister) FindByProgramID(_ context.Context, id string) ([]*model.BlockProgram, error) {
+	if err := f.fail("FindByProgramID"); err != nil {
+		return nil, err
+	}
+	return sortedBlockPrograms(f.where(func(bp model.BlockProgram) bool { return bp.ProgramID == id })), nil
+}
+
+func (f *fakeBlockProgramPersister) FindByBlockIDAndProgramID(_ context.Context, blockID string, programID string) ([]*model.BlockProgram, error) {
+	if err := f.fail("FindByBlockIDAndProgramID"); err != nil {
+		return nil, err
+	}
+	return f.where(func(bp model.BlockProgram) bool { return bp.BlockID == blockID && bp.ProgramID == programID }), nil
+}
+
+func (f *fakeBlockProgramPersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+func sortedBlockPrograms(rows []*model.BlockProgram) []*model.BlockProgram {
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Position < rows[j].Position })
+	return rows
+}
+
+// fakeProgramPersister is a fake implementation of port.ProgramPersister.
+// Its association lookups join the rows of programTags and programCategories, when set.
+type fakeProgramPersister struct {
+	fakeStore[model.Program]
+	programTags       *fakeProgramTagPersister
+	programCategories *fakeProgramCategoryPersister
+}
+
+func newFakeProgramPersister(rows ...model.Program) *fakeProgramPersister {
+	return &fakeProgramPersister{fakeStore: fakeStore[model.Program]{id: func(p model.Program) string { return p.ID }, rows: rows}}
+}
+
+func (f *fakeProgramPersister) Create(_ context.Context, program model.Program) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(program)
+}
+
+func (f *fakeProgramPersister) Update(_ context.Context, id string, updates model.Program) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(program *model.Program) {
+		program.Name = coalesceString(updates.Name, program.Name)
+		program.Description = coalesceString(updates.Description, program.Description)
+	})
+}
+
+func (f *fakeProgramPersister) Find(_ context.Context, id string) (*model.Program, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeProgramPersister) FindByIDs(_ context.Context, ids []string) ([]*model.Program, error) {
+	if err := f.fail("FindByIDs"); err != nil {
+		return nil, err
+	}
+	return f.byIDs(ids), nil
+}
+
+func (f *fakeProgramPersister) FindBySourceGUID(_ context.Context, guid string) (*model.Program, error) {
+	if err := f.fail("FindBySourceGUID"); err != nil {
+		return nil, err
+	}
+	rows := f.where(func(p model.Program) bool { return p.SourceGUID == guid })
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s: %w", guid, model.ErrNotFound)
+	}
+	return rows[0], nil
+}
+
+func (f *fakeProgramPersister) FindByTagID(_ context.Context, id string) ([]*model.Program, error) {
+	if err := f.fail("FindByTagID"); err != nil {
+		return nil, err
+	}
+	var ids []string
+	if f.programTags != nil {
+		for _, pt := range f.programTags.rows {
+			if pt.TagID == id {
+				ids = append(ids, pt.ProgramID)
+			}
+		}
+	}
+	return sortedByName(f.byIDs(ids), func(p *model.Program) string { return p.Name }), nil
+}
+
+func (f *fakeProgramPersister) FindByCategoryID(_ context.Context, id string) ([]*model.Program, error) {
+	if err := f.fail("FindByCategoryID"); err != nil {
+		return nil, err
+	}
+	var ids []string
+	if f.programCategories != nil {
+		for _, pc := range f.programCategories.rows {
+			if pc.CategoryID == id {
+				ids = append(ids, pc.ProgramID)
+			}
+		}
+	}
+	return sortedByName(f.byIDs(ids), func(p *model.Program) string { return p.Name }), nil
+}
+
+func (f *fakeProgramPersister) FindByCategoryIDs(_ context.Context, ids []string) ([]*model.Program, error) {
+	if err := f.fail("FindByCategoryIDs"); err != nil {
+		return nil, err
+	}
+	var programIDs []string
+	if f.programCategories != nil {
+		for _, pc := range f.programCategories.rows {
+			if slices.Contains(ids, pc.CategoryID) && !slices.Contains(programIDs, pc.ProgramID) {
+				programIDs = append(programIDs, pc.ProgramID)
+			}
+		}
+	}
+	return sortedByName(f.byIDs(programIDs), func(p *model.Program) string { return p.Name }), nil
+}
+
+func (f *fakeProgramPersister) FindAll(_ context.Context, opts model.ListOptions) ([]*model.Program, int, error) {
+	if err := f.fail("FindAll"); err != nil {
+		return nil, 0, err
+	}
+	return f.list(opts)
+}
+
+func (f *fakeProgramPersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+func (f *fakeProgramPersister) Restore(_ context.Context, id string) error {
+	if err := f.fail("Restore"); err != nil {
+		return err
+	}
+	return f.restore(id)
+}
+
+func (f *fakeProgramPersister) Purge(_ context.Context, before time.Time) (int, error) {
+	if err := f.fail("Purge"); err != nil {
+		return 0, err
+	}
+	return f.purge(before), nil
+}
+
+// fakeEpisodePersister is a fake implementation of port.EpisodePersister.
+type fakeEpisodePersister struct{ fakeStore[model.Episode] }
+
+func newFakeEpisodePersister(rows ...model.Episode) *fakeEpisodePersister {
+	return &fakeEpisodePersister{fakeStore[model.Episode]{id: func(e model.Episode) string { return e.ID }, rows: rows}}
+}
+
+func (f *fakeEpisodePersister) Create(_ context.Context, episode model.Episode) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(episode)
+}
+
+func (f *fakeEpisodePersister) Update(_ context.Context, id string, updates model.Episode) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(episode *model.Episode) {
+		episode.Name = coalesceString(updates.Name, episode.Name)
+		episode.Description = coalesceString(updates.Description, episode.Description)
+		episode.ProgramID = coalesceString(updates.ProgramID, episode.ProgramID)
+		if updates.Position != 0 {
+			episode.Position = updates.Position
+		}
+	})
+}
+
+func (f *fakeEpisodePersister) Find(_ context.Context, id string) (*model.Episode, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeEpisodePersister) FindByIDs(_ context.Context, ids []string) ([]*model.Episode, error) {
+	if err := f.fail("FindByIDs"); err != nil {
+		return nil, err
+	}
+	return f.byIDs(ids), nil
+}
+
+func (f *fakeEpisodePersister) FindByProgramID(_ context.Context, id string) ([]*model.Episode, error) {
+	if err := f.fail("FindByProgramID"); err != nil {
+		return nil, err
+	}
+	rows := f.where(func(e model.Episode) bool { return e.ProgramID == id })
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Position < rows[j].Position })
+	return rows, nil
+}
+
+func (f *fakeEpisodePersister) FindByProgramIDs(_ context.Context, ids []string) ([]*model.Episode, error) {
+	if err := f.fail("FindByProgramIDs"); err != nil {
+		return nil, err
+	}
+	rows := f.where(func(e model.Episode) bool { return slices.Contains(ids, e.ProgramID) })
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Position < rows[j].Position })
+	return rows, nil
+}
+
+func (f *fakeEpisodePersister) FindAll(_ context.Context, opts model.ListOptions) ([]*model.Episode, int, error) {
+	if err := f.fail("FindAll"); err != nil {
+		return nil, 0, err
+	}
+	return f.list(opts)
+}
+
+func (f *fakeEpisodePersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+func (f *fakeEpisodePersister) Restore(_ context.Context, id string) error {
+	if err := f.fail("Restore"); err != nil {
+		return err
+	}
+	return f.restore(id)
+}
+
+func (f *fakeEpisodePersister) Purge(_ context.Context, before time.Time) (int, error) {
+	if err := f.fail("Purge"); err != nil {
+		return 0, err
+	}
+	return f.purge(before), nil
+}
+
+func (f *fakeEpisodePersister) UpdateStatus(_ context.Context, id string, from, to model.EpisodeStatus, publishAt time.Time) error {
+	if err := f.fail("UpdateStatus"); err != nil {
+		return err
+	}
+	if episode := f.find(id); episode == nil || episode.Status != from {
+		return fmt.Errorf("%s is no longer %s: %w", id, from, model.ErrConflict)
+	}
+	return f.update(id, func(episode *model.Episode) {
+		episode.Status = to
+		episode.PublishAt = publishAt
+	})
+}
+
+func (f *fakeEpisodePersister) FindDue(_ context.Context, now time.Time) ([]*model.Episode, error) {
+	if err := f.fail("FindDue"); err != nil {
+		return nil, err
+	}
+	rows := f.where(func(e model.Episode) bool { return e.Status == model.EpisodeStatusScheduled && !e.PublishAt.After(now) })
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].PublishAt.Before(rows[j].PublishAt) })
+	return rows, nil
+}
+
+// fakeMediaPersister is a fake implementation of port.MediaPersister.
+type fakeMediaPersister struct{ fakeStore[model.Media] }
+
+func newFakeMediaPersister(rows ...model.Media) *fakeMediaPersister {
+	return &fakeMediaPersister{fakeStore[model.Media]{id: func(m model.Media) string { return m.ID }, rows: rows}}
+}
+
+func (f *fakeMediaPersister) Create(_ context.Context, media model.Media) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(media)
+}
+
+func (f *fakeMediaPersister) Update(_ context.Context, id string, updates model.Media) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(media *model.Media) {
+		if updates.DirectLink != "" {
+			media.StorageKey, media.Size, media.Checksum, media.MimeType = updates.StorageKey, updates.Size, updates.Checksum, updates.MimeType
+			media.Codec, media.Duration, media.Title = updates.Codec, updates.Duration, updates.Title
+			media.Bitrate, media.Channels, media.SampleRate = updates.Bitrate, updates.Channels, updates.SampleRate
+			media.ArtworkKey, media.ArtworkLink = updates.ArtworkKey, updates.ArtworkLink
+		}
+		media.DirectLink = coalesceString(updates.DirectLink, media.DirectLink)
+		media.Kind = coalesceString(updates.Kind, media.Kind)
+		media.EpisodeID = coalesceString(updates.EpisodeID, media.EpisodeID)
+	})
+}
+
+func (f *fakeMediaPersister) Find(_ context.Context, id string) (*model.Media, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeMediaPersister) FindByIDs(_ context.Context, ids []string) ([]*model.Media, error) {
+	if err := f.fail("FindByIDs"); err != nil {
+		return nil, err
+	}
+	return f.byIDs(ids), nil
+}
+
+func (f *fakeMediaPersister) FindByEpisodeIDs(_ context.Context, ids []string) ([]*model.Media, error) {
+	if err := f.fail("FindByEpisodeIDs"); err != nil {
+		return nil, err
+	}
+	return f.where(func(m model.Media) bool { return slices.Contains(ids, m.EpisodeID) }), nil
+}
+
+func (f *fakeMediaPersister) FindAll(_ context.Context, opts model.ListOptions) ([]*model.Media, int, error) {
+	if err := f.fail("FindAll"); err != nil {
+		return nil, 0, err
+	}
+	return f.list(opts)
+}
+
+func (f *fakeMediaPersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+func (f *fakeMediaPersister) Restore(_ context.Context, id string) error {
+	if err := f.fail("Restore"); err != nil {
+		return err
+	}
+	return f.restore(id)
+}
+
+func (f *fakeMediaPersister) Purge(_ context.Context, before time.Time) ([]*model.Media, error) {
+	if err := f.fail("Purge"); err != nil {
+		return nil, err
+	}
+	var medias []*model.Media
+	for _, media := range f.trash {
+		medias = append(medias, &media)
+	}
+	f.purge(before)
+	return medias, nil
+}
+
+// fakeTagPersister is a fake implementation of port.TagPersister.
+// Its association lookup joins the rows of programTags, when set.
+type fakeTagPersister struct {
+	fakeStore[model.Tag]
+	programTags *fakeProgramTagPersister
+}
+
+func newFakeTagPersister(rows ...model.Tag) *fakeTagPersister {
+	return &fakeTagPersister{fakeStore: fakeStore[model.Tag]{id: func(t model.Tag) string { return t.ID }, rows: rows}}
+}
+
+func (f *fakeTagPersister) Create(_ context.Context, tag model.Tag) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(tag)
+}
+
+func (f *fakeTagPersister) Update(_ context.Context, id string, updates model.Tag) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(tag *model.Tag) {
+		tag.Name = coalesceString(updates.Name, tag.Name)
+		tag.Description = coalesceString(updates.Description, tag.Description)
+	})
+}
+
+func (f *fakeTagPersister) Find(_ context.Context, id string) (*model.Tag, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeTagPersister) FindByIDs(_ context.Context, ids []string) ([]*model.Tag, error) {
+	if err := f.fail("FindByIDs"); err != nil {
+		return nil, err
+	}
+	return f.byIDs(ids), nil
+}
+
+func (f *fakeTagPersister) FindByNames(_ context.Context, names []string) ([]*model.Tag, error) {
+	if err := f.fail("FindByNames"); err != nil {
+		return nil, err
+	}
+	match := func(t model.Tag) bool {
+		return slices.ContainsFunc(names, func(name string) bool { return strings.EqualFold(name, t.Name) })
+	}
+	tags := f.where(match)
+	for _, tag := range f.trash {
+		if match(tag) {
+			tag.DeletedAt = time.Now()
+			tags = append(tags, &tag)
+		}
+	}
+	return tags, nil
+}
+
+func (f *fakeTagPersister) FindByProgramID(_ context.Context, id string) ([]*model.Tag, error) {
+	if err := f.fail("FindByProgramID"); err != nil {
+		return nil, err
+	}
+	var ids []string
+	if f.programTags != nil {
+		for _, pt := range f.programTags.rows {
+			if pt.ProgramID == id {
+				ids = append(ids, pt.TagID)
+			}
+		}
+	}
+	return sortedByName(f.byIDs(ids), func(t *model.Tag) string { return t.Name }), nil
+}
+
+func (f *fakeTagPersister) FindAll(_ context.Context, opts model.ListOptions) ([]*model.Tag, int, error) {
+	if err := f.fail("FindAll"); err != nil {
+		return nil, 0, err
+	}
+	return f.list(opts)
+}
+
+func (f *fakeTagPersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+func (f *fakeTagPersister) Restore(_ context.Context, id string) error {
+	if err := f.fail("Restore"); err != nil {
+		return err
+	}
+	return f.restore(id)
+}
+
+func (f *fakeTagPersister) Purge(_ context.Context, before time.Time) (int, error) {
+	if err := f.fail("Purge"); err != nil {
+		return 0, err
+	}
+	return f.purge(before), nil
+}
+
+// fakeProgramTagPersister is a fake implementation of port.ProgramTagPersister.
+type fakeProgramTagPersister struct{ fakeStore[model.ProgramTag] }
+
+func newFakeProgramTagPersister(rows ...model.ProgramTag) *fakeProgramTagPersister {
+	return &fakeProgramTagPersister{fakeStore[model.ProgramTag]{id: func(pt model.ProgramTag) string { return pt.ID }, rows: rows}}
+}
+
+func (f *fakeProgramTagPersister) Create(_ context.Context, programTag model.ProgramTag) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(programTag)
+}
+
+func (f *fakeProgramTagPersister) Update(_ context.Context, id string, updates model.ProgramTag) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(programTag *model.ProgramTag) {
+		updates.ID = id
+		*programTag = updates
+	})
+}
+
+func (f *fakeProgramTagPersister) Find(_ context.Context, id string) (*model.ProgramTag, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeProgramTagPersister) FindByTagID(_ context.Context, id string) ([]*model.ProgramTag, error) {
+	if err := f.fail("FindByTagID"); err != nil {
+		return nil, err
+	}
+	return f.where(func(pt model.ProgramTag) bool { return pt.TagID == id }), nil
+}
+
+func (f *fakeProgramTagPersister) FindByProgramID(_ context.Context, id string) ([]*model.ProgramTag, error) {
+	if err := f.fail("FindByProgramID"); err != nil {
+		return nil, err
+	}
+	return f.where(func(pt model.ProgramTag) bool { return pt.ProgramID == id }), nil
+}
+
+func (f *fakeProgramTagPersister) FindByTagIDAndProgramID(_ context.Context, tagID string, programID string) ([]*model.ProgramTag, error) {
+	if err := f.fail("FindByTagIDAndProgramID"); err != nil {
+		return nil, err
+	}
+	return f.where(func(pt model.ProgramTag) bool { return pt.TagID == tagID && pt.ProgramID == programID }), nil
+}
+
+func (f *fakeProgramTagPersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+// fakeCategoryPersister is a fake implementation of port.CategoryPersister.
+// Its association lookup joins the rows of programCategories, when set.
+type fakeCategoryPersister struct {
+	fakeStore[model.Category]
+	programCategories *fakeProgramCategoryPersister
+}
+
+func newFakeCategoryPersister(rows ...model.Category) *fakeCategoryPersister {
+	return &fakeCategoryPersister{fakeStore: fakeStore[model.Category]{id: func(c model.Category) string { return c.ID }, rows: rows}}
+}
+
+func (f *fakeCategoryPersister) Create(_ context.Context, category model.Category) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(category)
+}
+
+func (f *fakeCategoryPersister) Update(_ context.Context, id string, updates model.Category) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(category *model.Category) {
+		category.Name = coalesceString(updates.Name, category.Name)
+		category.Description = coalesceString(updates.Description, category.Description)
+		if updates.Parent != nil && updates.Parent.ID != "" {
+			category.Parent = &model.Category{ID: updates.Parent.ID}
+		}
+	})
+}
+
+func (f *fakeCategoryPersister) Move(_ context.Context, id string, parentID string) error {
+	if err := f.fail("Move"); err != nil {
+		return err
+	}
+	return f.update(id, func(c *model.Category) {
+		c.Parent = nil
+		if parentID != "" {
+			c.Parent = &model.Category{ID: parentID}
+		}
+	})
+}
+
+func (f *fakeCategoryPersister) Find(_ context.Context, id string) (*model.Category, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeCategoryPersister) FindByIDs(_ context.Context, ids []string) ([]*model.Category, error) {
+	if err := f.fail("FindByIDs"); err != nil {
+		return nil, err
+	}
+	return f.byIDs(ids), nil
+}
+
+func (f *fakeCategoryPersister) FindByProgramID(_ context.Context, id string) ([]*model.Category, error) {
+	if err := f.fail("FindByProgramID"); err != nil {
+		return nil, err
+	}
+	var ids []string
+	if f.programCategories != nil {
+		for _, pc := range f.programCategories.rows {
+			if pc.ProgramID == id {
+				ids = append(ids, pc.CategoryID)
+			}
+		}
+	}
+	return sortedByName(f.byIDs(ids), func(c *model.Category) string { return c.Name }), nil
+}
+
+func (f *fakeCategoryPersister) LockHierarchy(_ context.Context) error {
+	return f.fail("LockHierarchy")
+}
+
+func (f *fakeCategoryPersister) FindHierarchy(_ context.Context) ([]*model.Category, error) {
+	if err := f.fail("FindHierarchy"); err != nil {
+		return nil, err
+	}
+	return sortedByName(f.all(), func(c *model.Category) string { return c.Name }), nil
+}
+
+func (f *fakeCategoryPersister) FindAll(_ context.Context, opts model.ListOptions) ([]*model.Category, int, error) {
+	if err := f.fail("FindAll"); err != nil {
+		return nil, 0, err
+	}
+	return f.list(opts)
+}
+
+func (f *fakeCategoryPersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+func (f *fakeCategoryPersister) Restore(_ context.Context, id string) error {
+	if err := f.fail("Restore"); err != nil {
+		return err
+	}
+	return f.restore(id)
+}
+
+func (f *fakeCategoryPersister) Purge(_ context.Context, before time.Time) (int, error) {
+	if err := f.fail("Purge"); err != nil {
+		return 0, err
+	}
+	return f.purge(before), nil
+}
+
+// fakeProgramCategoryPersister is a fake implementation of port.ProgramCategoryPersister.
+type fakeProgramCategoryPersister struct {
+	fakeStore[model.ProgramCategory]
+}
+
+func newFakeProgramCategoryPersister(rows ...model.ProgramCategory) *fakeProgramCategoryPersister {
+	return &fakeProgramCategoryPersister{fakeStore[model.ProgramCategory]{id: func(pc model.ProgramCategory) string { return pc.ID }, rows: rows}}
+}
+
+func (f *fakeProgramCategoryPersister) Create(_ context.Context, programCategory model.ProgramCategory) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(programCategory)
+}
+
+func (f *fakeProgramCategoryPersister) Update(_ context.Context, id string, updates model.ProgramCategory) error {
+	if err := f.fail("Update"); err != nil {
+		return err
+	}
+	return f.update(id, func(programCategory *model.ProgramCategory) {
+		updates.ID = id
+		*programCategory = updates
+	})
+}
+
+func (f *fakeProgramCategoryPersister) Find(_ context.Context, id string) (*model.ProgramCategory, error) {
+	if err := f.fail("Find"); err != nil {
+		return nil, err
+	}
+	return f.get(id)
+}
+
+func (f *fakeProgramCategoryPersister) FindByCategoryID(_ context.Context, id string) ([]*model.ProgramCategory, error) {
+	if err := f.fail("FindByCategoryID"); err != nil {
+		return nil, err
+	}
+	return f.where(func(pc model.ProgramCategory) bool { return pc.CategoryID == id }), nil
+}
+
+func (f *fakeProgramCategoryPersister) FindByProgramID(_ context.Context, id string) ([]*model.ProgramCategory, error) {
+	if err := f.fail("FindByProgramID"); err != nil {
+		return nil, err
+	}
+	return f.where(func(pc model.ProgramCategory) bool { return pc.ProgramID == id }), nil
+}
+
+func (f *fakeProgramCategoryPersister) FindByCategoryIDAndProgramID(_ context.Context, categoryID string, programID string) ([]*model.ProgramCategory, error) {
+	if err := f.fail("FindByCategoryIDAndProgramID"); err != nil {
+		return nil, err
+	}
+	return f.where(func(pc model.ProgramCategory) bool { return pc.CategoryID == categoryID && pc.ProgramID == programID }), nil
+}
+
+func (f *fakeProgramCategoryPersister) Delete(_ context.Context, id string) error {
+	if err := f.fail("Delete"); err != nil {
+		return err
+	}
+	return f.delete(id)
+}
+
+// fakeAuditPersister is a fake implementation of port.AuditPersister.
+type fakeAuditPersister struct {
+	fakeStore[model.AuditEntry]
+	filtered model.AuditFilter // filter of the last list call
+}
+
+func newFakeAuditPersister(rows ...model.AuditEntry) *fakeAuditPersister {
+	return &fakeAuditPersister{fakeStore: fakeStore[model.AuditEntry]{id: func(e model.AuditEntry) string { return e.ID }, rows: rows}}
+}
+
+func (f *fakeAuditPersister) Create(_ context.Context, entry model.AuditEntry) error {
+	if err := f.fail("Create"); err != nil {
+		return err
+	}
+	return f.create(entry)
+}
+
+func (f *fakeAuditPersister) FindAll(_ context.Context, filter model.AuditFilter) ([]*model.AuditEntry, int, error) {
+	if err := f.fail("FindAll"); err != nil {
+		return nil, 0, err
+	}
+	f.filtered = filter
+	return f.list(model.ListOptions{Limit: filter.Limit, Offset: filter.Offset})
+}
+
+// fakeObjectStore is a fake implementation of port.ObjectStore keeping the objects in memory.
+type fakeObjectStore struct {
+	objects      map[string][]byte
+	contentTypes map[string]string
+	errs         map[string]error
+}
+
+func newFakeObjectStore() *fakeObjectStore {
+	return &fakeObjectStore{objects: make(map[string][]byte), contentTypes: make(map[string]string), errs: make(map[string]error)}
+}
+
+func (f *fakeObjectStore) Put(_ context.Context, key string, r io.Reader, contentType string) (string, error) {
+	if err := f.errs["Put"]; err != nil {
+		return "", err
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	f.objects[key] = content
+	f.contentTypes[key] = contentType
+	return "https://cdn/" + key, nil
+}
+
+func (f *fakeObjectStore) Open(_ context.Context, key string) (io.ReadSeekCloser, error) {
+	if err := f.errs["Open"]; err != nil {
+		return nil, err
+	}
+	content, ok := f.objects[key]
+	if !ok {
+		return nil, model.ErrNotFound
+	}
+	return nopSeekCloser{bytes.NewReader(content)}, nil
+}
+
+func (f *fakeObjectStore) Delete(_ context.Context, key string) error {
+	if err := f.errs["Delete"]; err != nil {
+		return err
+	}
+	delete(f.objects, key)
+	return nil
+}
+
+// fakeAudioProber is a fake implementation of port.AudioProber describing every file with info,
+// or failing with err.
+type fakeAudioProber struct {
+	info   model.AudioInfo
+	err    error
+	probed [][]byte // content of the probed files
+}
+
+func (f *fakeAudioProber) Probe(_ context.Context, r io.ReadSeeker) (model.AudioInfo, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return model.AudioInfo{}, err
+	}
+	f.probed = append(f.probed, content)
+	return f.info, f.err
+}
+
+// fakeFeedReader is a fake implementation of port.FeedReader returning feed, whatever it reads, or failing with err.
+type fakeFeedReader struct {
+	feed    model.ImportedFeed
+	err     error
+	fetched []string // links of the fetched feeds
+	read    [][]byte // content of the read feeds
+}
+
+func (f *fakeFeedReader) Read(_ context.Context, r io.Reader) (model.ImportedFeed, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return model.ImportedFeed{}, err
+	}
+	f.read = append(f.read, content)
+	return f.feed, f.err
+}
+
+func (f *fakeFeedReader) Fetch(_ context.Context, link string) (model.ImportedFeed, error) {
+	f.fetched = append(f.fetched, link)
+	return f.feed, f.err
+}
+
+// fakeMediaFetcher is a fake implementation of port.MediaFetcher serving files from memory.
+type fakeMediaFetcher struct {
+	files map[string][]byte
+}
+
+func (f *fakeMediaFetcher) Open(_ context.Context, link string) (io.ReadSeekCloser, error) {
+	content, ok := f.files[link]
+	if !ok {
+		return nil, fmt.Errorf("no file at %s", link)
+	}
+	return nopSeekCloser{bytes.NewReader(content)}, nil
+}
+
+// nopSeekCloser adds a no-op Close method to an io.ReadSeeker.
+type nopSeekCloser struct{ io.ReadSeeker }
+
+func (nopSeekCloser) Close() error { return nil }
+
+// sortedByName orders rows by name, like the association lookups of the adapters.
+func sortedByName[T any](rows []*T, name func(*T) string) []*T {
+	sort.SliceStable(rows, func(i, j int) bool { return name(rows[i]) < name(rows[j]) })
+	return rows
+}
+
+// coalesceString mimics the COALESCE updates of the adapters: empty values keep the current one.
+func coalesceString(update, current string) string {
+	if update != "" {
+		return update
+	}
+	return current
+}
+
+// validationFields extracts the field names of the validation errors wrapped in err.
+func validationFields(err error) []string {
+	var vErrs model.ValidationErrors
+	if !errors.As(err, &vErrs) {
+		return nil
+	}
+	var fields []string
+	for _, vErr := range vErrs {
+		fields = append(fields, vErr.Field)
+	}
+	return fields
+}
+
+// equalStrings reports whether both slices contain the same values in the same order.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// assertError checks err against the expected validation fields or wrapped adapter error.
+// A nil wantFields and wantErr means no error is expected.
+func assertError(t *testing.T, err error, wantFields []string, wantErr error) {
+	t.Helper()
+	switch {
+	case wantFields != nil:
+		if got := validationFields(err); !equalStrings(got, wantFields) {
+			t.Fatalf("got validation errors on %v (err: %v), want %v", got, err, wantFields)
+		}
+	case wantErr != nil:
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("got error %v, want %v", err, wantErr)
+		}
+	case err != nil:
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
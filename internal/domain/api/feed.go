@@ -0,0 +1,284 @@
+// Package api provides functionality for publishing programs as podcast feeds.
+package api
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"github.com/rs/zerolog/log"
+)
+
+// podcastGUIDNamespace is the namespace of the UUIDv5 identifying a podcast from the URL of its feed,
+// as defined by the Podcasting 2.0 guid tag.
+var podcastGUIDNamespace = uuid.MustParse("ead4c236-bf58-58c6-a2c6-a6b28d128cb6")
+
+// Feed represents the interface for publishing programs as podcast feeds.
+type Feed interface {
+	FindByProgram(ctx context.Context, uuid string) (*pkg.FeedResponse, error)
+}
+
+// FeedSettings describes the publisher of the podcasts, shared by the feeds of every program.
+type FeedSettings struct {
+	BaseURL    string // Base URL of the API, the feeds linking to themselves and to the files it serves under it
+	Link       string // Website of the podcasts
+	Language   string // Language of the podcasts (e.g., en, fr-FR)
+	Author     string // Author of the podcasts
+	OwnerName  string // Name of the contact of the podcasts
+	OwnerEmail string // Email of the contact of the podcasts
+	Explicit   bool   // Whether the podcasts contain explicit content
+}
+
+// feedApi is an implementation of the Feed interface.
+type feedApi struct {
+	programAdapter  port.ProgramPersister
+	episodeAdapter  port.EpisodePersister
+	mediaAdapter    port.MediaPersister
+	categoryAdapter port.CategoryPersister
+	settings        FeedSettings
+}
+
+// NewFeedApi creates a new instance of Feed.
+// It takes the adapters of the programs, their episodes, the medias of these episodes and the categories of
+// the programs, along with the FeedSettings, as dependencies.
+func NewFeedApi(programAdapter port.ProgramPersister, episodeAdapter port.EpisodePersister, mediaAdapter port.MediaPersister, categoryAdapter port.CategoryPersister, settings FeedSettings) Feed {
+	return &feedApi{
+		programAdapter:  programAdapter,
+		episodeAdapter:  episodeAdapter,
+		mediaAdapter:    mediaAdapter,
+		categoryAdapter: categoryAdapter,
+		settings:        settings,
+	}
+}
+
+// FindByProgram renders the podcast feed of a program, with an item per published episode holding a media,
+// in the order of the episodes. The first media of an episode is its enclosure; episodes holding several medias also list
+// them all as alternate enclosures.
+// It takes the context and program UUID, and returns a FeedResponse or an error.
+func (api feedApi) FindByProgram(ctx context.Context, uuid string) (*pkg.FeedResponse, error) {
+	feed, err := api.findByProgram(ctx, uuid)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while finding program feed")
+		return nil, fmt.Errorf("error occurred while finding program feed: %w", err)
+	}
+	return feed, nil
+}
+
+// findByProgram loads the program, its episodes, their medias and its categories, and assembles the feed.
+func (api feedApi) findByProgram(ctx context.Context, uuid string) (*pkg.FeedResponse, error) {
+	program, err := api.programAdapter.Find(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	episodes, err := api.episodeAdapter.FindByProgramID(ctx, program.ID)
+	if err != nil {
+		return nil, err
+	}
+	episodeIDs := make([]string, 0, len(episodes))
+	for _, episode := range episodes {
+		episodeIDs = append(episodeIDs, episode.ID)
+	}
+	var medias []*model.Media
+	if len(episodeIDs) > 0 {
+		if medias, err = api.mediaAdapter.FindByEpisodeIDs(ctx, episodeIDs); err != nil {
+			return nil, err
+		}
+	}
+	categories, err := api.categoryAdapter.FindByProgramID(ctx, program.ID)
+	if err != nil {
+		return nil, err
+	}
+	parents, err := api.parentCategories(ctx, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	lastModified := lastChange(program.CreatedAt, program.UpdatedAt)
+	mediasByEpisode := make(map[string][]*model.Media)
+	for _, media := range medias {
+		mediasByEpisode[media.EpisodeID] = append(mediasByEpisode[media.EpisodeID], media)
+		lastModified = lastChange(lastModified, media.CreatedAt, media.UpdatedAt)
+	}
+	for _, category := range categories {
+		lastModified = lastChange(lastModified, category.CreatedAt, category.UpdatedAt)
+	}
+
+	channel := api.channel(program)
+	for _, category := range categories {
+		channel.Categories = append(channel.Categories, category.Name)
+		itunesCategory := pkg.FeedItunesCategory{Text: category.Name}
+		if parent, ok := parents[parentID(category)]; ok {
+			subcategory := itunesCategory
+			itunesCategory = pkg.FeedItunesCategory{Text: parent.Name, Subcategory: &subcategory}
+		}
+		channel.ItunesCategories = append(channel.ItunesCategories, itunesCategory)
+	}
+	for _, episode := range episodes {
+		lastModified = lastChange(lastModified, episode.CreatedAt, episode.UpdatedAt)
+		episodeMedias := mediasByEpisode[episode.ID]
+		if episode.Status != model.EpisodeStatusPublished || len(episodeMedias) == 0 {
+			continue
+		}
+		item := api.feedItem(episode, episodeMedias)
+		if channel.ItunesImage == nil && item.ItunesImage != nil {
+			channel.ItunesImage = item.ItunesImage
+			channel.Image = &pkg.FeedImage{URL: item.ItunesImage.Href, Title: channel.Title, Link: channel.Link}
+		}
+		channel.Items = append(channel.Items, item)
+	}
+	channel.LastBuildDate = lastModified.UTC().Format(time.RFC1123Z)
+
+	return &pkg.FeedResponse{
+		Version:      "2.0",
+		ItunesNS:     pkg.FeedItunesNamespace,
+		PodcastNS:    pkg.FeedPodcastNamespace,
+		AtomNS:       pkg.FeedAtomNamespace,
+		Channel:      channel,
+		LastModified: lastModified,
+	}, nil
+}
+
+// parentCategories finds the parents of the given categories, by UUID, so that they can be rendered as
+// iTunes categories refined by a subcategory.
+func (api feedApi) parentCategories(ctx context.Context, categories []*model.Category) (map[string]*model.Category, error) {
+	var parentIDs []string
+	for _, category := range categories {
+		if id := parentID(category); id != "" {
+			parentIDs = append(parentIDs, id)
+		}
+	}
+	parents := make(map[string]*model.Category)
+	if len(parentIDs) == 0 {
+		return parents, nil
+	}
+	found, err := api.categoryAdapter.FindByIDs(ctx, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, parent := range found {
+		parents[parent.ID] = parent
+	}
+	return parents, nil
+}
+
+// parentID returns the UUID of the parent of a category, empty for root categories.
+func parentID(category *model.Category) string {
+	if category.Parent == nil || category.Parent.ID == uuid.Nil.String() {
+		return ""
+	}
+	return category.Parent.ID
+}
+
+// channel describes a program with the settings of the publisher.
+func (api feedApi) channel(program *model.Program) pkg.FeedChannel {
+	channel := pkg.FeedChannel{
+		Title:          program.Name,
+		Link:           api.settings.Link,
+		Description:    program.Description,
+		Language:       api.settings.Language,
+		ItunesAuthor:   api.settings.Author,
+		ItunesExplicit: api.settings.Explicit,
+		ItunesType:     "serial",
+	}
+	if api.settings.OwnerName != "" || api.settings.OwnerEmail != "" {
+		channel.ItunesOwner = &pkg.FeedOwner{Name: api.settings.OwnerName, Email: api.settings.OwnerEmail}
+	}
+	if api.settings.BaseURL != "" {
+		self := strings.TrimSuffix(api.settings.BaseURL, "/") + "/public/programs/" + program.ID + "/feed.xml"
+		channel.AtomLink = &pkg.FeedAtomLink{Href: self, Rel: "self", Type: "application/rss+xml"}
+		channel.PodcastGUID = podcastGUID(self)
+		if channel.Link == "" {
+			channel.Link = self
+		}
+	}
+	return channel
+}
+
+// podcastGUID returns the Podcasting 2.0 GUID of the podcast served at feedURL: a UUIDv5 of the URL
+// without its scheme and trailing slashes.
+func podcastGUID(feedURL string) string {
+	if _, rest, ok := strings.Cut(feedURL, "://"); ok {
+		feedURL = rest
+	}
+	return uuid.NewSHA1(podcastGUIDNamespace, []byte(strings.TrimRight(feedURL, "/"))).String()
+}
+
+// feedItem describes an episode along with its medias, the first one being its enclosure.
+// Episodes are dated by their publication time, or else their creation time.
+func (api feedApi) feedItem(episode *model.Episode, medias []*model.Media) pkg.FeedItem {
+	media := medias[0]
+	pubDate := episode.PublishAt
+	if pubDate.IsZero() {
+		pubDate = episode.CreatedAt
+	}
+	item := pkg.FeedItem{
+		Title:          episode.Name,
+		Description:    episode.Description,
+		GUID:           pkg.FeedGUID{Value: episode.ID},
+		PubDate:        pubDate.UTC().Format(time.RFC1123Z),
+		Enclosure:      pkg.FeedEnclosure{URL: api.absoluteLink(media.DirectLink), Length: media.Size, Type: enclosureType(media)},
+		ItunesTitle:    episode.Name,
+		ItunesDuration: int64(media.Duration.Seconds()),
+		ItunesEpisode:  episode.Position,
+	}
+	for _, m := range medias {
+		if m.ArtworkLink != "" {
+			item.ItunesImage = &pkg.FeedItunesImage{Href: api.absoluteLink(m.ArtworkLink)}
+			break
+		}
+	}
+	if len(medias) > 1 {
+		for i, m := range medias {
+			item.AlternateEnclosures = append(item.AlternateEnclosures, pkg.FeedAlternateEnclosure{
+				Type:    enclosureType(m),
+				Length:  m.Size,
+				Bitrate: m.Bitrate,
+				Title:   m.Kind,
+				Default: i == 0,
+				Source:  pkg.FeedSource{URI: api.absoluteLink(m.DirectLink)},
+			})
+		}
+	}
+	return item
+}
+
+// absoluteLink resolves a link served by the API itself, such as the link of an uploaded file, against the
+// base URL of the API, since podcast clients only follow absolute URLs. Absolute links are returned as is.
+func (api feedApi) absoluteLink(link string) string {
+	if api.settings.BaseURL == "" || !strings.HasPrefix(link, "/") || strings.HasPrefix(link, "//") {
+		return link
+	}
+	return strings.TrimSuffix(api.settings.BaseURL, "/") + link
+}
+
+// enclosureType returns the MIME type of a media, guessed from the extension of its link when it was not recorded.
+func enclosureType(media *model.Media) string {
+	if media.MimeType != "" {
+		return media.MimeType
+	}
+	if u, err := url.Parse(media.DirectLink); err == nil {
+		if mimeType := mime.TypeByExtension(path.Ext(u.Path)); mimeType != "" {
+			return mimeType
+		}
+	}
+	return "application/octet-stream"
+}
+
+// lastChange returns the latest of the given times.
+func lastChange(times ...time.Time) time.Time {
+	var last time.Time
+	for _, t := range times {
+		if t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+// feedStores holds the fake persisters behind a Feed api.
+type feedStores struct {
+	programs          *fakeProgramPersister
+	episodes          *fakeEpisodePersister
+	medias            *fakeMediaPersister
+	categories        *fakeCategoryPersister
+	programCategories *fakeProgramCategoryPersister
+}
+
+func (s feedStores) api() Feed {
+	return NewFeedApi(s.programs, s.episodes, s.medias, s.categories, FeedSettings{
+		BaseURL:    "https://api.example.com/",
+		Language:   "fr",
+		Author:     "Radio",
+		OwnerEmail: "podcasts@example.com",
+	})
+}
+
+// newFeedStores builds a program p1 filed under the subcategory c2 of c1, with three episodes: e2 comes first,
+// is published and holds an uploaded media, e1 is published and holds two linked medias, and e3 is a draft holding
+// a linked media.
+func newFeedStores() feedStores {
+	day := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	s := feedStores{
+		programs: newFakeProgramPersister(model.Program{ID: "p1", Name: "Morning", Description: "The morning show", CreatedAt: day}),
+		episodes: newFakeEpisodePersister(
+			model.Episode{ID: "e1", ProgramID: "p1", Name: "Second", Position: 2, Status: model.EpisodeStatusPublished, CreatedAt: day.Add(48 * time.Hour)},
+			model.Episode{ID: "e2", ProgramID: "p1", Name: "First", Position: 1, Status: model.EpisodeStatusPublished, PublishAt: day.Add(26 * time.Hour), CreatedAt: day.Add(24 * time.Hour), UpdatedAt: day.Add(96 * time.Hour)},
+			model.Episode{ID: "e3", ProgramID: "p1", Name: "Draft", Position: 3, Status: model.EpisodeStatusDraft, CreatedAt: day.Add(72 * time.Hour)},
+		),
+		medias: newFakeMediaPersister(
+			model.Media{ID: "m1", EpisodeID: "e1", DirectLink: "https://cdn.example.com/e1.mp3?v=1", Kind: "audio", Bitrate: 128000, CreatedAt: day},
+			model.Media{ID: "m2", EpisodeID: "e2", DirectLink: "/files/e2.m4a", Size: 2048, MimeType: "audio/mp4", Duration: 90500 * time.Millisecond, ArtworkLink: "/files/artworks/m2.jpg", CreatedAt: day},
+			model.Media{ID: "m3", EpisodeID: "e1", DirectLink: "https://cdn.example.com/e1", Kind: "video", CreatedAt: day},
+			model.Media{ID: "m4", EpisodeID: "e3", DirectLink: "https://cdn.example.com/e3.mp3", Kind: "audio", CreatedAt: day},
+		),
+		categories: newFakeCategoryPersister(
+			model.Category{ID: "c1", Name: "News"},
+			model.Category{ID: "c2", Name: "Politics", Parent: &model.Category{ID: "c1"}},
+			model.Category{ID: "c3", Name: "Culture", Parent: &model.Category{ID: "00000000-0000-0000-0000-000000000000"}},
+		),
+		programCategories: newFakeProgramCategoryPersister(
+			model.ProgramCategory{ID: "pc1", ProgramID: "p1", CategoryID: "c2"},
+			model.ProgramCategory{ID: "pc2", ProgramID: "p1", CategoryID: "c3"},
+		),
+	}
+	s.categories.programCategories = s.programCategories
+	return s
+}
+
+func TestFeedApi_FindByProgram(t *testing.T) {
+	s := newFeedStores()
+
+	feed, err := s.api().FindByProgram(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	channel := feed.Channel
+	self := "https://api.example.com/public/programs/p1/feed.xml"
+	if channel.AtomLink == nil || channel.AtomLink.Href != self || channel.Link != self {
+		t.Fatalf("got self link %+v and link %q, want %q", channel.AtomLink, channel.Link, self)
+	}
+	if channel.Title != "Morning" || channel.Language != "fr" || channel.ItunesAuthor != "Radio" || channel.ItunesOwner == nil || channel.ItunesOwner.Email != "podcasts@example.com" {
+		t.Fatalf("got channel %+v, want it to describe the program with the settings", channel)
+	}
+	if channel.PodcastGUID != podcastGUID(self) {
+		t.Fatalf("got podcast guid %q, want %q", channel.PodcastGUID, podcastGUID(self))
+	}
+	if channel.ItunesImage == nil || channel.ItunesImage.Href != "https://api.example.com/files/artworks/m2.jpg" {
+		t.Fatalf("got channel image %+v, want the artwork of the first media", channel.ItunesImage)
+	}
+	if got := channel.Categories; !equalStrings(got, []string{"Culture", "Politics"}) {
+		t.Fatalf("got categories %v, want [Culture Politics]", got)
+	}
+	if got := channel.ItunesCategories; len(got) != 2 || got[0].Text != "Culture" || got[0].Subcategory != nil || got[1].Text != "News" || got[1].Subcategory == nil || got[1].Subcategory.Text != "Politics" {
+		t.Fatalf("got iTunes categories %+v, want Culture and Politics under News", got)
+	}
+
+	var guids []string
+	for _, item := range channel.Items {
+		guids = append(guids, item.GUID.Value)
+	}
+	if !equalStrings(guids, []string{"e2", "e1"}) {
+		t.Fatalf("got items %v, want [e2 e1]", guids)
+	}
+	first, second := channel.Items[0], channel.Items[1]
+	wantFirst := pkg.FeedEnclosure{URL: "https://api.example.com/files/e2.m4a", Length: 2048, Type: "audio/mp4"}
+	if first.Enclosure != wantFirst || first.ItunesDuration != 90 || first.ItunesEpisode != 1 || first.PubDate != "Thu, 02 May 2024 10:00:00 +0000" || len(first.AlternateEnclosures) != 0 {
+		t.Fatalf("got first item %+v, want enclosure %+v", first, wantFirst)
+	}
+	wantSecond := pkg.FeedEnclosure{URL: "https://cdn.example.com/e1.mp3?v=1", Type: "audio/mpeg"}
+	if second.Enclosure != wantSecond || second.PubDate != "Fri, 03 May 2024 08:00:00 +0000" {
+		t.Fatalf("got second enclosure %+v, want %+v", second.Enclosure, wantSecond)
+	}
+	alternates := second.AlternateEnclosures
+	if len(alternates) != 2 || !alternates[0].Default || alternates[0].Bitrate != 128000 || alternates[1].Default || alternates[1].Type != "application/octet-stream" || alternates[1].Source.URI != "https://cdn.example.com/e1" {
+		t.Fatalf("got alternate enclosures %+v, want both medias of the episode, the first one by default", alternates)
+	}
+
+	if want := time.Date(2024, 5, 5, 8, 0, 0, 0, time.UTC); !feed.LastModified.Equal(want) || channel.LastBuildDate != "Sun, 05 May 2024 08:00:00 +0000" {
+		t.Fatalf("got last modified %v (%s), want %v", feed.LastModified, channel.LastBuildDate, want)
+	}
+}
+
+func TestFeedApi_FindByProgram_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		uuid    string
+		prepare func(s feedStores)
+		wantErr error
+	}{
+		{
+			name:    "reports a missing program",
+			uuid:    "p9",
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name:    "wraps episode lookup failure",
+			uuid:    "p1",
+			prepare: func(s feedStores) { s.episodes.failOn("FindByProgramID") },
+			wantErr: errAdapter,
+		},
+		{
+			name:    "wraps parent category lookup failure",
+			uuid:    "p1",
+			prepare: func(s feedStores) { s.categories.failOn("FindByIDs") },
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stores := newFeedStores()
+			if tt.prepare != nil {
+				tt.prepare(stores)
+			}
+
+			_, err := stores.api().FindByProgram(context.Background(), tt.uuid)
+
+			assertError(t, err, nil, tt.wantErr)
+		})
+	}
+}
+
+func TestPodcastGUID(t *testing.T) {
+	// Example of the Podcasting 2.0 namespace specification.
+	if got, want := podcastGUID("https://mp3s.nashownotes.com/pc20rss.xml"), "917393e3-1b1e-5cef-ace4-edaa54e1f810"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,381 @@
+// Package api provides functionality for importing the podcast feeds published by other hosts.
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"github.com/rs/zerolog/log"
+)
+
+// ImportFeedRequest represents the interface for importing podcast feeds, either downloaded from a URL or uploaded.
+type ImportFeedRequest interface {
+	URL() string
+	FileName() string
+	Open() (io.ReadCloser, error)
+}
+
+// FeedImport represents the interface for importing the podcast feeds published by other hosts.
+type FeedImport interface {
+	Import(ctx context.Context, req ImportFeedRequest) (*pkg.ImportResponse, error)
+}
+
+// feedImportApi is an implementation of the FeedImport interface.
+type feedImportApi struct {
+	feedReader        port.FeedReader
+	programAdapter    port.ProgramPersister
+	episodeAdapter    port.EpisodePersister
+	mediaAdapter      port.MediaPersister
+	tagAdapter        port.TagPersister
+	programTagAdapter port.ProgramTagPersister
+	txManager         port.TxManager
+}
+
+// NewFeedImportApi creates a new instance of FeedImport.
+// It takes the FeedReader parsing the feeds, the adapters of the programs, episodes, medias, tags and
+// program-tag associations the feeds are imported as, and the txManager as dependencies.
+func NewFeedImportApi(
+	feedReader port.FeedReader,
+	programAdapter port.ProgramPersister,
+	episodeAdapter port.EpisodePersister,
+	mediaAdapter port.MediaPersister,
+	tagAdapter port.TagPersister,
+	programTagAdapter port.ProgramTagPersister,
+	txManager port.TxManager,
+) FeedImport {
+	return &feedImportApi{
+		feedReader:        feedReader,
+		programAdapter:    programAdapter,
+		episodeAdapter:    episodeAdapter,
+		mediaAdapter:      mediaAdapter,
+		tagAdapter:        tagAdapter,
+		programTagAdapter: programTagAdapter,
+		txManager:         txManager,
+	}
+}
+
+// Import imports a podcast feed as a program, in a single transaction. The channel becomes the program, its items
+// the episodes of the program, positioned in publication order, the enclosures of the items the medias of the
+// episodes, and the iTunes keywords of the channel the tags of the program.
+// Importing a feed again updates the program and the episodes imported from it, matched by the GUIDs of the feed and
+// of its items, adds the episodes and enclosures that are new, and leaves the rest untouched.
+// It takes the context and ImportFeedRequest, and returns an ImportResponse summing up the changes or an error.
+func (api feedImportApi) Import(ctx context.Context, req ImportFeedRequest) (*pkg.ImportResponse, error) {
+	// Validate request
+	vErrs := importFeedRequestValidation(ctx, req)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Str("url", req.URL()).Str("fileName", req.FileName()).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+
+	// Read the feed
+	feed, err := api.read(ctx, req)
+	if errors.Is(err, model.ErrInvalidFeed) {
+		field := "url"
+		if req.URL() == "" {
+			field = "file"
+		}
+		vErrs = model.ValidationErrors{{Field: field, Message: err.Error()}}
+	} else if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("url", req.URL()).Str("fileName", req.FileName()).Msg("error while reading feed")
+		return nil, fmt.Errorf("error occurred while reading feed: %w", err)
+	} else {
+		vErrs = importedFeedValidation(ctx, feed)
+	}
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Str("url", req.URL()).Str("fileName", req.FileName()).Msg("feed was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+
+	// Import it
+	var result *pkg.ImportResponse
+	err = api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		result, err = api.importFeed(ctx, feed)
+		return err
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("guid", feed.GUID).Msg("error while importing feed")
+		return nil, fmt.Errorf("error occurred while importing feed: %w", err)
+	}
+	return result, nil
+}
+
+// importFeedRequestValidation validates the import request, which takes either a URL or a file.
+// It takes the context and ImportFeedRequest, and returns a slice of ValidationErrors.
+func importFeedRequestValidation(ctx context.Context, req ImportFeedRequest) model.ValidationErrors {
+	var vErrs []model.ValidationError
+	switch {
+	case req.URL() == "" && req.FileName() == "":
+		vErrs = append(vErrs, model.ValidationError{Field: "url", Message: "is required when no file is sent"})
+	case req.URL() != "" && req.FileName() != "":
+		vErrs = append(vErrs, model.ValidationError{Field: "url", Message: "cannot be set along with a file"})
+	case req.URL() != "":
+		if u, err := url.Parse(req.URL()); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			vErrs = append(vErrs, model.ValidationError{Field: "url", Message: "must be an absolute http or https URL"})
+		}
+	}
+	return vErrs
+}
+
+// importedFeedValidation validates the feed read, whose channel must be identified and titled to become a program.
+// It takes the context and the feed, and returns a slice of ValidationErrors.
+func importedFeedValidation(ctx context.Context, feed model.ImportedFeed) model.ValidationErrors {
+	var vErrs []model.ValidationError
+	if feed.GUID == "" {
+		vErrs = append(vErrs, model.ValidationError{Field: "feed", Message: "has neither a podcast:guid, a self link nor a link to identify it"})
+	}
+	if feed.Title == "" {
+		vErrs = append(vErrs, model.ValidationError{Field: "feed", Message: "has no title"})
+	}
+	return vErrs
+}
+
+// read downloads the feed at the requested URL, or else parses the uploaded file.
+func (api feedImportApi) read(ctx context.Context, req ImportFeedRequest) (model.ImportedFeed, error) {
+	if req.URL() != "" {
+		return api.feedReader.Fetch(ctx, req.URL())
+	}
+	file, err := req.Open()
+	if err != nil {
+		return model.ImportedFeed{}, err
+	}
+	defer file.Close()
+	return api.feedReader.Read(ctx, file)
+}
+
+// importFeed creates or updates the program, episodes, medias and tags of a feed. It must run within a transaction.
+func (api feedImportApi) importFeed(ctx context.Context, feed model.ImportedFeed) (*pkg.ImportResponse, error) {
+	result := &pkg.ImportResponse{}
+
+	// Find the program imported from the feed before, or create it
+	program, err := api.programAdapter.FindBySourceGUID(ctx, feed.GUID)
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		program = &model.Program{
+			ID:          uuid.New().String(),
+			Name:        feed.Title,
+			Description: feed.Description,
+			SourceGUID:  feed.GUID,
+		}
+		if err := api.programAdapter.Create(ctx, *program); err != nil {
+			return nil, err
+		}
+		result.ProgramCreated = true
+	case err != nil:
+		return nil, err
+	default:
+		if err := api.programAdapter.Update(ctx, program.ID, model.Program{Name: feed.Title, Description: feed.Description}); err != nil {
+			return nil, err
+		}
+	}
+	result.ProgramID = program.ID
+
+	if err := api.importItems(ctx, program.ID, feed.Items, result); err != nil {
+		return nil, err
+	}
+	if err := api.importKeywords(ctx, program.ID, feed.Keywords, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importItems creates or updates the episodes of the program imported from the items of a feed, along with the
+// medias of their enclosures. Items are positioned in publication order, those without a date coming first, and
+// items repeating the GUID of a previous one are skipped.
+// The other episodes of the program, created by hand or imported from items the feed no longer lists, come first:
+// they keep their order and are renumbered from 1, the items being positioned after them.
+func (api feedImportApi) importItems(ctx context.Context, programID string, items []model.ImportedItem, result *pkg.ImportResponse) error {
+	// Feeds list their items newest first: keep the first of the items sharing a GUID, then reverse them so that
+	// items published at the same time keep their order
+	seen := make(map[string]bool)
+	var ordered []model.ImportedItem
+	for _, item := range items {
+		if !seen[item.GUID] {
+			seen[item.GUID] = true
+			ordered = append(ordered, item)
+		}
+	}
+	slices.Reverse(ordered)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].PublishedAt.Before(ordered[j].PublishedAt) })
+
+	// Index the episodes imported before by GUID, along with the links of their medias
+	episodes, err := api.episodeAdapter.FindByProgramID(ctx, programID)
+	if err != nil {
+		return err
+	}
+	imported := make(map[string]*model.Episode)
+	var importedIDs []string
+	for _, episode := range episodes {
+		if episode.SourceGUID != "" {
+			if _, ok := imported[episode.SourceGUID]; !ok {
+				imported[episode.SourceGUID] = episode
+				importedIDs = append(importedIDs, episode.ID)
+			}
+		}
+	}
+
+	// Move the episodes the feed does not list ahead of its items
+	var others []*model.Episode
+	for _, episode := range episodes {
+		if !seen[episode.SourceGUID] || imported[episode.SourceGUID] != episode {
+			others = append(others, episode)
+		}
+	}
+	err = closeGaps(others,
+		func(episode *model.Episode) *int { return &episode.Position },
+		func(episode *model.Episode) error {
+			return api.episodeAdapter.Update(ctx, episode.ID, model.Episode{Position: episode.Position})
+		},
+	)
+	if err != nil {
+		return err
+	}
+	links := make(map[string]map[string]bool)
+	if len(importedIDs) > 0 {
+		medias, err := api.mediaAdapter.FindByEpisodeIDs(ctx, importedIDs)
+		if err != nil {
+			return err
+		}
+		for _, media := range medias {
+			if links[media.EpisodeID] == nil {
+				links[media.EpisodeID] = make(map[string]bool)
+			}
+			links[media.EpisodeID][media.DirectLink] = true
+		}
+	}
+
+	for i, item := range ordered {
+		position := len(others) + i + 1
+
+		episodeID := ""
+		if episode, ok := imported[item.GUID]; ok {
+			episodeID = episode.ID
+			if err := api.episodeAdapter.Update(ctx, episodeID, model.Episode{Name: item.Title, Description: item.Description, Position: position}); err != nil {
+				return err
+			}
+			result.EpisodesUpdated++
+		} else {
+			// Items are already published by the host of the feed: so are the new episodes, unless they hold no media
+			episodeID = uuid.New().String()
+			status, publishAt := model.EpisodeStatusDraft, time.Time{}
+			if len(item.Enclosures) > 0 {
+				status, publishAt = model.EpisodeStatusPublished, item.PublishedAt
+				if publishAt.IsZero() {
+					publishAt = time.Now().UTC()
+				}
+			}
+			if err := api.episodeAdapter.Create(ctx, model.Episode{
+				ID:          episodeID,
+				Name:        item.Title,
+				Description: item.Description,
+				Position:    position,
+				ProgramID:   programID,
+				SourceGUID:  item.GUID,
+				Status:      status,
+				PublishAt:   publishAt,
+			}); err != nil {
+				return err
+			}
+			result.EpisodesCreated++
+		}
+
+		for _, enclosure := range item.Enclosures {
+			if links[episodeID][enclosure.URL] {
+				continue
+			}
+			if links[episodeID] == nil {
+				links[episodeID] = make(map[string]bool)
+			}
+			links[episodeID][enclosure.URL] = true
+			if err := api.mediaAdapter.Create(ctx, model.Media{
+				ID:         uuid.New().String(),
+				DirectLink: enclosure.URL,
+				Kind:       enclosureKind(enclosure.MimeType),
+				EpisodeID:  episodeID,
+				Size:       enclosure.Size,
+				MimeType:   enclosure.MimeType,
+				Duration:   item.Duration,
+			}); err != nil {
+				return err
+			}
+			result.MediasCreated++
+		}
+	}
+	return nil
+}
+
+// importKeywords tags the program with the keywords of a feed, creating the tags that do not exist yet
+// and restoring the tags in the trash, whose names cannot be taken again.
+// Keywords are matched with the names of the tags regardless of case.
+func (api feedImportApi) importKeywords(ctx context.Context, programID string, keywords []string, result *pkg.ImportResponse) error {
+	var names []string
+	seen := make(map[string]bool)
+	for _, keyword := range keywords {
+		if key := strings.ToLower(keyword); !seen[key] {
+			seen[key] = true
+			names = append(names, keyword)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	tags, err := api.tagAdapter.FindByNames(ctx, names)
+	if err != nil {
+		return err
+	}
+	tagIDs := make(map[string]string)
+	for _, tag := range tags {
+		if !tag.DeletedAt.IsZero() {
+			if err := api.tagAdapter.Restore(ctx, tag.ID); err != nil {
+				return err
+			}
+		}
+		tagIDs[strings.ToLower(tag.Name)] = tag.ID
+	}
+	programTags, err := api.programTagAdapter.FindByProgramID(ctx, programID)
+	if err != nil {
+		return err
+	}
+	tagged := make(map[string]bool)
+	for _, programTag := range programTags {
+		tagged[programTag.TagID] = true
+	}
+
+	for _, name := range names {
+		tagID, ok := tagIDs[strings.ToLower(name)]
+		if !ok {
+			tagID = uuid.New().String()
+			if err := api.tagAdapter.Create(ctx, model.Tag{ID: tagID, Name: name}); err != nil {
+				return err
+			}
+			result.TagsCreated++
+		}
+		if tagged[tagID] {
+			continue
+		}
+		if err := api.programTagAdapter.Create(ctx, model.ProgramTag{ID: uuid.New().String(), ProgramID: programID, TagID: tagID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enclosureKind returns the kind of the media of an enclosure from its MIME type, audio unless it is a video.
+func enclosureKind(mimeType string) string {
+	if strings.HasPrefix(mimeType, "video/") {
+		return "video"
+	}
+	return "audio"
+}
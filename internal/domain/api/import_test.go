@@ -0,0 +1,266 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// importRequest is an ImportFeedRequest naming a feed by URL, or sending content as a file when fileName is set.
+type importRequest struct {
+	url      string
+	fileName string
+	content  []byte
+}
+
+func (r importRequest) URL() string      { return r.url }
+func (r importRequest) FileName() string { return r.fileName }
+func (r importRequest) Open() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(r.content)), nil
+}
+
+// importStores holds the fake persisters behind a FeedImport api.
+type importStores struct {
+	reader      *fakeFeedReader
+	programs    *fakeProgramPersister
+	episodes    *fakeEpisodePersister
+	medias      *fakeMediaPersister
+	tags        *fakeTagPersister
+	programTags *fakeProgramTagPersister
+}
+
+func newImportStores(feed model.ImportedFeed) importStores {
+	return importStores{
+		reader:      &fakeFeedReader{feed: feed},
+		programs:    newFakeProgramPersister(),
+		episodes:    newFakeEpisodePersister(),
+		medias:      newFakeMediaPersister(),
+		tags:        newFakeTagPersister(),
+		programTags: newFakeProgramTagPersister(),
+	}
+}
+
+func (s importStores) api() FeedImport {
+	return NewFeedImportApi(s.reader, s.programs, s.episodes, s.medias, s.tags, s.programTags,
+		newFakeTxManager(s.programs, s.episodes, s.medias, s.tags, s.programTags))
+}
+
+// importedShow is a feed listing its items newest first, the last one having no publication date.
+func importedShow() model.ImportedFeed {
+	day := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	return model.ImportedFeed{
+		GUID:        "show-guid",
+		Title:       "Morning",
+		Description: "The morning show",
+		Keywords:    []string{"News", "politics", "news"},
+		Items: []model.ImportedItem{
+			{GUID: "ep-3", Title: "Third", PublishedAt: day.Add(48 * time.Hour), Enclosures: []model.ImportedEnclosure{{URL: "https://cdn/ep-3.mp4", MimeType: "video/mp4"}}},
+			{GUID: "ep-1", Title: "First", PublishedAt: day, Duration: time.Minute, Enclosures: []model.ImportedEnclosure{{URL: "https://cdn/ep-1.mp3", MimeType: "audio/mpeg", Size: 2048}}},
+			{GUID: "ep-2", Title: "Second", PublishedAt: day.Add(24 * time.Hour), Enclosures: []model.ImportedEnclosure{{URL: "https://cdn/ep-2.mp3"}, {URL: "https://cdn/ep-2.ogg"}}},
+			{GUID: "ep-1", Title: "First again"},
+			{GUID: "trailer", Title: "Trailer"},
+		},
+	}
+}
+
+// episodeSummary lists the episodes of the stores in position order, with their status and the kinds and links of their medias.
+func (s importStores) episodeSummary(programID string) []string {
+	episodes, _ := s.episodes.FindByProgramID(context.Background(), programID)
+	var summary []string
+	for _, episode := range episodes {
+		var medias []string
+		for _, media := range s.medias.where(func(m model.Media) bool { return m.EpisodeID == episode.ID }) {
+			medias = append(medias, media.Kind+":"+media.DirectLink)
+		}
+		summary = append(summary, fmt.Sprintf("%d %s %s %s [%s]", episode.Position, episode.SourceGUID, episode.Name, episode.Status, strings.Join(medias, " ")))
+	}
+	return summary
+}
+
+func TestFeedImportApi_Import(t *testing.T) {
+	s := newImportStores(importedShow())
+	s.tags.rows = []model.Tag{{ID: "t1", Name: "news"}}
+
+	result, err := s.api().Import(context.Background(), importRequest{url: "https://host.example.com/show.xml"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.ProgramCreated || result.EpisodesCreated != 4 || result.EpisodesUpdated != 0 || result.MediasCreated != 4 || result.TagsCreated != 1 {
+		t.Fatalf("got result %+v, want a new program with 4 episodes, 4 medias and 1 new tag", result)
+	}
+	program := s.programs.find(result.ProgramID)
+	if program == nil || program.Name != "Morning" || program.SourceGUID != "show-guid" {
+		t.Fatalf("got program %+v, want it imported from the feed", program)
+	}
+	want := []string{
+		"1 trailer Trailer draft []",
+		"2 ep-1 First published [audio:https://cdn/ep-1.mp3]",
+		"3 ep-2 Second published [audio:https://cdn/ep-2.mp3 audio:https://cdn/ep-2.ogg]",
+		"4 ep-3 Third published [video:https://cdn/ep-3.mp4]",
+	}
+	if got := s.episodeSummary(result.ProgramID); !equalStrings(got, want) {
+		t.Fatalf("got episodes %v, want %v", got, want)
+	}
+	if episode := s.episodes.where(func(e model.Episode) bool { return e.SourceGUID == "ep-1" })[0]; !episode.PublishAt.Equal(time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Fatalf("got episode published at %v, want the publication time of the item", episode.PublishAt)
+	}
+	if media := s.medias.where(func(m model.Media) bool { return m.DirectLink == "https://cdn/ep-1.mp3" })[0]; media.Size != 2048 || media.MimeType != "audio/mpeg" || media.Duration != time.Minute {
+		t.Fatalf("got media %+v, want the size, type and duration of the enclosure", media)
+	}
+	var tagged []string
+	for _, programTag := range s.programTags.rows {
+		tagged = append(tagged, s.tags.find(programTag.TagID).Name)
+	}
+	if !equalStrings(tagged, []string{"news", "politics"}) {
+		t.Fatalf("got program tags %v, want [news politics]", tagged)
+	}
+	if len(s.reader.fetched) != 1 || s.reader.fetched[0] != "https://host.example.com/show.xml" {
+		t.Fatalf("got fetched feeds %v, want the requested URL", s.reader.fetched)
+	}
+}
+
+func TestFeedImportApi_Import_TrashedTag(t *testing.T) {
+	s := newImportStores(importedShow())
+	s.tags.rows = []model.Tag{{ID: "t1", Name: "news"}}
+	s.tags.trash = []model.Tag{{ID: "t2", Name: "Politics"}}
+
+	result, err := s.api().Import(context.Background(), importRequest{url: "https://host.example.com/show.xml"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.TagsCreated != 0 {
+		t.Fatalf("got %d new tags, want the tag in the trash reused", result.TagsCreated)
+	}
+	if len(s.tags.trash) != 0 || s.tags.find("t2") == nil {
+		t.Fatalf("got tags %+v and trash %+v, want the tag restored", s.tags.rows, s.tags.trash)
+	}
+	var tagged []string
+	for _, programTag := range s.programTags.rows {
+		tagged = append(tagged, programTag.TagID)
+	}
+	if !equalStrings(tagged, []string{"t1", "t2"}) {
+		t.Fatalf("got program tags %v, want [t1 t2]", tagged)
+	}
+}
+
+func TestFeedImportApi_Import_Again(t *testing.T) {
+	s := newImportStores(importedShow())
+	first, err := s.api().Import(context.Background(), importRequest{fileName: "show.xml", content: []byte("<rss/>")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// An episode is added by hand after the imported ones, and another one is imported again from the feed
+	s.episodes.rows = append(s.episodes.rows,
+		model.Episode{ID: "h1", ProgramID: first.ProgramID, Name: "Bonus", Position: 5, Status: model.EpisodeStatusDraft},
+		model.Episode{ID: "h2", ProgramID: first.ProgramID, Name: "Copy", Position: 2, SourceGUID: "ep-1", Status: model.EpisodeStatusDraft},
+	)
+
+	// The feed gains an episode and an alternate enclosure, and renames its first episode
+	feed := importedShow()
+	feed.Title = "Morning show"
+	feed.Items[1].Title = "Pilot"
+	feed.Items[1].Enclosures = append(feed.Items[1].Enclosures, model.ImportedEnclosure{URL: "https://cdn/ep-1.ogg", MimeType: "audio/ogg"})
+	feed.Items = append([]model.ImportedItem{{GUID: "ep-4", Title: "Fourth", PublishedAt: time.Date(2024, 5, 4, 8, 0, 0, 0, time.UTC)}}, feed.Items...)
+	s.reader.feed = feed
+
+	again, err := s.api().Import(context.Background(), importRequest{fileName: "show.xml", content: []byte("<rss/>")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if again.ProgramID != first.ProgramID || again.ProgramCreated || again.EpisodesCreated != 1 || again.EpisodesUpdated != 4 || again.MediasCreated != 1 || again.TagsCreated != 0 {
+		t.Fatalf("got result %+v, want the program of the first import updated with 1 new episode and 1 new media", again)
+	}
+	if len(s.programs.rows) != 1 || s.programs.rows[0].Name != "Morning show" {
+		t.Fatalf("got programs %+v, want the single program renamed", s.programs.rows)
+	}
+	want := []string{
+		"1 ep-1 Copy draft []",
+		"2  Bonus draft []",
+		"3 trailer Trailer draft []",
+		"4 ep-1 Pilot published [audio:https://cdn/ep-1.mp3 audio:https://cdn/ep-1.ogg]",
+		"5 ep-2 Second published [audio:https://cdn/ep-2.mp3 audio:https://cdn/ep-2.ogg]",
+		"6 ep-3 Third published [video:https://cdn/ep-3.mp4]",
+		"7 ep-4 Fourth draft []",
+	}
+	if got := s.episodeSummary(first.ProgramID); !equalStrings(got, want) {
+		t.Fatalf("got episodes %v, want %v", got, want)
+	}
+	if len(s.programTags.rows) != 2 {
+		t.Fatalf("got program tags %+v, want the 2 tags of the first import", s.programTags.rows)
+	}
+}
+
+func TestFeedImportApi_Import_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        importRequest
+		prepare    func(s importStores)
+		wantFields []string
+		wantErr    error
+	}{
+		{
+			name:       "requires a URL or a file",
+			req:        importRequest{},
+			wantFields: []string{"url"},
+		},
+		{
+			name:       "rejects a URL along with a file",
+			req:        importRequest{url: "https://host.example.com/show.xml", fileName: "show.xml"},
+			wantFields: []string{"url"},
+		},
+		{
+			name:       "rejects a URL that is not http",
+			req:        importRequest{url: "file:///etc/passwd"},
+			wantFields: []string{"url"},
+		},
+		{
+			name:       "reports a file that is not a feed",
+			req:        importRequest{fileName: "show.xml"},
+			prepare:    func(s importStores) { s.reader.err = fmt.Errorf("%w: unexpected EOF", model.ErrInvalidFeed) },
+			wantFields: []string{"file"},
+		},
+		{
+			name:       "requires an identified and titled feed",
+			req:        importRequest{fileName: "show.xml"},
+			prepare:    func(s importStores) { s.reader.feed = model.ImportedFeed{} },
+			wantFields: []string{"feed", "feed"},
+		},
+		{
+			name:    "wraps download failure",
+			req:     importRequest{url: "https://host.example.com/show.xml"},
+			prepare: func(s importStores) { s.reader.err = errAdapter },
+			wantErr: errAdapter,
+		},
+		{
+			name:    "rolls back on tag failure",
+			req:     importRequest{url: "https://host.example.com/show.xml"},
+			prepare: func(s importStores) { s.tags.failOn("Create") },
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newImportStores(importedShow())
+			if tt.prepare != nil {
+				tt.prepare(s)
+			}
+
+			_, err := s.api().Import(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if len(s.programs.rows) != 0 || len(s.episodes.rows) != 0 || len(s.medias.rows) != 0 {
+				t.Fatalf("got programs %+v, episodes %+v and medias %+v, want none", s.programs.rows, s.episodes.rows, s.medias.rows)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Package api provides functionality for listing collections.
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+const (
+	// defaultPageLimit is the number of items of a page when the request sets no limit.
+	defaultPageLimit = 20
+	// maxPageLimit is the largest number of items a page can hold.
+	maxPageLimit = 100
+)
+
+// ListRequest represents the interface for listing a collection one page at a time.
+type ListRequest interface {
+	Limit() int
+	Offset() int
+	Sort() string
+	NameContains() string
+	CreatedFrom() time.Time
+	CreatedTo() time.Time
+}
+
+// listing describes how a collection can be sorted and filtered.
+type listing struct {
+	named    bool     // Whether the items have a name the request can filter on
+	sortable []string // Fields the collection can be sorted on, model.SortBy constants
+}
+
+// trash returns the listing of the items of the collection in the trash, which can also be sorted on their deletion time.
+func (l listing) trash() listing {
+	l.sortable = append(slices.Clip(l.sortable), model.SortByDeletedAt)
+	return l
+}
+
+// listRequestValidation validates the list request against the fields the collection supports.
+// It takes the context, the ListRequest and the collection listing, and returns a slice of ValidationErrors.
+func listRequestValidation(ctx context.Context, req ListRequest, l listing) model.ValidationErrors {
+	var vErrs []model.ValidationError
+	if req.Limit() < 0 || req.Limit() > maxPageLimit {
+		vErrs = append(vErrs, model.ValidationError{Field: "limit", Message: fmt.Sprintf("must be between 0 and %d (0 for the default)", maxPageLimit)})
+	}
+	if req.Offset() < 0 {
+		vErrs = append(vErrs, model.ValidationError{Field: "offset", Message: "cannot be negative"})
+	}
+	if sort := strings.TrimPrefix(req.Sort(), "-"); sort != "" && !slices.Contains(l.sortable, sort) {
+		vErrs = append(vErrs, model.ValidationError{Field: "sort", Message: "must be one of " + strings.Join(l.sortable, ", ")})
+	}
+	if req.NameContains() != "" && !l.named {
+		vErrs = append(vErrs, model.ValidationError{Field: "name", Message: "is not supported"})
+	}
+	if !req.CreatedFrom().IsZero() && !req.CreatedTo().IsZero() && req.CreatedTo().Before(req.CreatedFrom()) {
+		vErrs = append(vErrs, model.ValidationError{Field: "createdTo", Message: "cannot be before createdFrom"})
+	}
+	return vErrs
+}
+
+// listOptions converts a validated list request into the options given to the persisters.
+func listOptions(req ListRequest) model.ListOptions {
+	opts := model.ListOptions{
+		Limit:        req.Limit(),
+		Offset:       req.Offset(),
+		Sort:         strings.TrimPrefix(req.Sort(), "-"),
+		Descending:   strings.HasPrefix(req.Sort(), "-"),
+		NameContains: req.NameContains(),
+		CreatedFrom:  req.CreatedFrom(),
+		CreatedTo:    req.CreatedTo(),
+	}
+	if opts.Limit == 0 {
+		opts.Limit = defaultPageLimit
+	}
+	return opts
+}
+
+// trashOptions converts a validated list request on the trash into the options given to the persisters.
+// The most recently deleted items come first unless the request sorts them otherwise.
+func trashOptions(req ListRequest) model.ListOptions {
+	opts := listOptions(req)
+	opts.Deleted = true
+	if opts.Sort == "" {
+		opts.Sort = model.SortByDeletedAt
+		opts.Descending = true
+	}
+	return opts
+}
+
+// optionalTime returns the time of an optional event of an item, such as its move to the trash,
+// or nil when the event did not happen.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// newPage wraps the items of a page into its response envelope.
+// Links to the neighbouring pages depend on the request URL and are left to the handlers.
+func newPage[T any](items []T, total int, opts model.ListOptions) *pkg.PageResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return &pkg.PageResponse[T]{
+		Items:  items,
+		Total:  total,
+		Limit:  opts.Limit,
+		Offset: opts.Offset,
+	}
+}
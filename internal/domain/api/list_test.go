@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+func TestWallApi_FindAll(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	tests := []struct {
+		name       string
+		req        pkg.ListRequestJSON
+		wantFields []string
+		wantOpts   model.ListOptions
+		wantIDs    []string
+		wantTotal  int
+	}{
+		{
+			name:      "defaults to the first page sorted by creation time",
+			wantOpts:  model.ListOptions{Limit: defaultPageLimit},
+			wantIDs:   []string{"w1", "w2", "w3"},
+			wantTotal: 3,
+		},
+		{
+			name:      "pages the walls",
+			req:       pkg.ListRequestJSON{LimitJSON: 1, OffsetJSON: 1},
+			wantOpts:  model.ListOptions{Limit: 1, Offset: 1},
+			wantIDs:   []string{"w2"},
+			wantTotal: 3,
+		},
+		{
+			name:      "returns an empty page past the last wall",
+			req:       pkg.ListRequestJSON{OffsetJSON: 5},
+			wantOpts:  model.ListOptions{Limit: defaultPageLimit, Offset: 5},
+			wantIDs:   []string{},
+			wantTotal: 3,
+		},
+		{
+			name: "passes sort and filters to the adapter",
+			req: pkg.ListRequestJSON{
+				SortJSON:        "-name",
+				NameJSON:        "news",
+				CreatedFromJSON: from,
+				CreatedToJSON:   to,
+			},
+			wantOpts: model.ListOptions{
+				Limit:        defaultPageLimit,
+				Sort:         model.SortByName,
+				Descending:   true,
+				NameContains: "news",
+				CreatedFrom:  from,
+				CreatedTo:    to,
+			},
+			wantIDs:   []string{"w1", "w2", "w3"},
+			wantTotal: 3,
+		},
+		{
+			name:       "rejects out of range limit and offset",
+			req:        pkg.ListRequestJSON{LimitJSON: maxPageLimit + 1, OffsetJSON: -1},
+			wantFields: []string{"limit", "offset"},
+		},
+		{
+			name:       "rejects unknown sort field",
+			req:        pkg.ListRequestJSON{SortJSON: "-position"},
+			wantFields: []string{"sort"},
+		},
+		{
+			name:       "rejects inverted creation range",
+			req:        pkg.ListRequestJSON{CreatedFromJSON: to, CreatedToJSON: from},
+			wantFields: []string{"createdTo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walls := newFakeWallPersister(model.Wall{ID: "w1"}, model.Wall{ID: "w2"}, model.Wall{ID: "w3"})
+			api := NewWallApi(walls, newFakeWallBlockPersister(), newFakeBlockPersister(), newFakeTxManager())
+
+			page, err := api.FindAll(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, nil)
+			if err != nil {
+				return
+			}
+			if walls.listed != tt.wantOpts {
+				t.Fatalf("got options %+v, want %+v", walls.listed, tt.wantOpts)
+			}
+			var gotIDs []string
+			for _, wall := range page.Items {
+				gotIDs = append(gotIDs, wall.ID)
+			}
+			if page.Items == nil || !equalStrings(gotIDs, tt.wantIDs) {
+				t.Fatalf("got walls %v, want %v", gotIDs, tt.wantIDs)
+			}
+			if page.Total != tt.wantTotal || page.Limit != tt.wantOpts.Limit || page.Offset != tt.wantOpts.Offset {
+				t.Fatalf("got page %d/%d/%d, want total %d with options %+v", page.Total, page.Limit, page.Offset, tt.wantTotal, tt.wantOpts)
+			}
+		})
+	}
+}
+
+func TestMediaApi_FindAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.ListRequestJSON
+		wantFields []string
+	}{
+		{
+			name: "sorts medias by kind",
+			req:  pkg.ListRequestJSON{SortJSON: "kind"},
+		},
+		{
+			name:       "rejects name filter on unnamed medias",
+			req:        pkg.ListRequestJSON{NameJSON: "news"},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "rejects sort by name",
+			req:        pkg.ListRequestJSON{SortJSON: "name"},
+			wantFields: []string{"sort"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMediaApi(newFakeMediaPersister(), newFakeObjectStore(), &fakeAudioProber{}, nil, 0).FindAll(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, nil)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// roundTrip is the simulated latency of a database query in the lookup benchmarks.
+const roundTrip = 50 * time.Microsecond
+
+// lookupSizes are the numbers of associated rows the lookup benchmarks run with.
+var lookupSizes = []int{10, 100, 1000}
+
+// benchmarkLookup runs lookup b.N times and reports the number of persister calls it makes on average.
+func benchmarkLookup(b *testing.B, lookup func() error, calls ...*int) {
+	for _, c := range calls {
+		*c = 0
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := lookup(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	var total int
+	for _, c := range calls {
+		total += *c
+	}
+	b.ReportMetric(float64(total)/float64(b.N), "queries/op")
+}
+
+// The per-row sub-benchmarks replay the lookups the association endpoints made before they were batched:
+// one Find per association row.
+
+func BenchmarkWallApi_FindBlocks(b *testing.B) {
+	ctx := context.Background()
+	for _, size := range lookupSizes {
+		wallBlocks, blocks := newFakeWallBlockPersister(), newFakeBlockPersister()
+		for i := 0; i < size; i++ {
+			blockID := fmt.Sprintf("b%d", i)
+			wallBlocks.rows = append(wallBlocks.rows, model.WallBlock{ID: "wb" + blockID, WallID: "w1", BlockID: blockID, Position: i})
+			blocks.rows = append(blocks.rows, model.Block{ID: blockID})
+		}
+		wallBlocks.latency, blocks.latency = roundTrip, roundTrip
+		api := NewWallApi(newFakeWallPersister(), wallBlocks, blocks, newFakeTxManager())
+
+		b.Run(fmt.Sprintf("rows=%d/per-row", size), func(b *testing.B) {
+			benchmarkLookup(b, func() error {
+				associations, err := wallBlocks.FindByWallID(ctx, "w1")
+				for _, association := range associations {
+					if _, err := blocks.Find(ctx, association.BlockID); err != nil {
+						return err
+					}
+				}
+				return err
+			}, &wallBlocks.calls, &blocks.calls)
+		})
+		b.Run(fmt.Sprintf("rows=%d/batched", size), func(b *testing.B) {
+			benchmarkLookup(b, func() error {
+				_, err := api.FindBlocks(ctx, "w1")
+				return err
+			}, &wallBlocks.calls, &blocks.calls)
+		})
+	}
+}
+
+func BenchmarkProgramApi_FindTags(b *testing.B) {
+	ctx := context.Background()
+	for _, size := range lookupSizes {
+		programTags, tags := newFakeProgramTagPersister(), newFakeTagPersister()
+		for i := 0; i < size; i++ {
+			tagID := fmt.Sprintf("t%d", i)
+			programTags.rows = append(programTags.rows, model.ProgramTag{ID: "pt" + tagID, ProgramID: "p1", TagID: tagID})
+			tags.rows = append(tags.rows, model.Tag{ID: tagID, Name: tagID})
+		}
+		tags.programTags = programTags
+		programTags.latency, tags.latency = roundTrip, roundTrip
+		api := NewProgramApi(newFakeProgramPersister(), newFakeEpisodePersister(), programTags, tags, newFakeProgramCategoryPersister(), newFakeCategoryPersister(), newFakeTxManager())
+
+		b.Run(fmt.Sprintf("rows=%d/per-row", size), func(b *testing.B) {
+			benchmarkLookup(b, func() error {
+				associations, err := programTags.FindByProgramID(ctx, "p1")
+				for _, association := range associations {
+					if _, err := tags.Find(ctx, association.TagID); err != nil {
+						return err
+					}
+				}
+				return err
+			}, &programTags.calls, &tags.calls)
+		})
+		b.Run(fmt.Sprintf("rows=%d/batched", size), func(b *testing.B) {
+			benchmarkLookup(b, func() error {
+				_, err := api.FindTags(ctx, "p1")
+				return err
+			}, &programTags.calls, &tags.calls)
+		})
+	}
+}
+
+func BenchmarkProgramApi_FindCats(b *testing.B) {
+	ctx := context.Background()
+	for _, size := range lookupSizes {
+		programCategories, categories := newFakeProgramCategoryPersister(), newFakeCategoryPersister()
+		for i := 0; i < size; i++ {
+			categoryID := fmt.Sprintf("c%d", i)
+			programCategories.rows = append(programCategories.rows, model.ProgramCategory{ID: "pc" + categoryID, ProgramID: "p1", CategoryID: categoryID})
+			categories.rows = append(categories.rows, model.Category{ID: categoryID, Name: categoryID})
+		}
+		categories.programCategories = programCategories
+		programCategories.latency, categories.latency = roundTrip, roundTrip
+		api := NewProgramApi(newFakeProgramPersister(), newFakeEpisodePersister(), newFakeProgramTagPersister(), newFakeTagPersister(), programCategories, categories, newFakeTxManager())
+
+		b.Run(fmt.Sprintf("rows=%d/per-row", size), func(b *testing.B) {
+			benchmarkLookup(b, func() error {
+				associations, err := programCategories.FindByProgramID(ctx, "p1")
+				for _, association := range associations {
+					if _, err := categories.Find(ctx, association.CategoryID); err != nil {
+						return err
+					}
+				}
+				return err
+			}, &programCategories.calls, &categories.calls)
+		})
+		b.Run(fmt.Sprintf("rows=%d/batched", size), func(b *testing.B) {
+			benchmarkLookup(b, func() error {
+				_, err := api.FindCats(ctx, "p1")
+				return err
+			}, &programCategories.calls, &categories.calls)
+		})
+	}
+}
+
+func BenchmarkTagApi_FindPrograms(b *testing.B) {
+	ctx := context.Background()
+	for _, size := range lookupSizes {
+		programTags, programs := newFakeProgramTagPersister(), newFakeProgramPersister()
+		for i := 0; i < size; i++ {
+			programID := fmt.Sprintf("p%d", i)
+			programTags.rows = append(programTags.rows, model.ProgramTag{ID: "pt" + programID, ProgramID: programID, TagID: "t1"})
+			programs.rows = append(programs.rows, model.Program{ID: programID, Name: programID})
+		}
+		programs.programTags = programTags
+		programTags.latency, programs.latency = roundTrip, roundTrip
+		api := NewTagApi(newFakeTagPersister(), programs, newFakeProgramTagPersister(), newFakeTxManager())
+
+		b.Run(fmt.Sprintf("rows=%d/per-row", size), func(b *testing.B) {
+			benchmarkLookup(b, func() error {
+				associations, err := programTags.FindByTagID(ctx, "t1")
+				for _, association := range associations {
+					if _, err := programs.Find(ctx, association.ProgramID); err != nil {
+						return err
+					}
+				}
+				return err
+			}, &programTags.calls, &programs.calls)
+		})
+		b.Run(fmt.Sprintf("rows=%d/batched", size), func(b *testing.B) {
+			benchmarkLookup(b, func() error {
+				_, err := api.FindPrograms(ctx, "t1")
+				return err
+			}, &programTags.calls, &programs.calls)
+		})
+	}
+}
@@ -2,8 +2,17 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
@@ -13,49 +22,102 @@ import (
 
 // Media represents the interface for managing medias.
 type Media interface {
-	Create(ctx context.Context, media CreateMediaRequest) error
+	Create(ctx context.Context, media CreateMediaRequest) (string, error)
+	Prepare(ctx context.Context, req CreateMediaRequest) CreateMediaRequest
+	Discard(ctx context.Context, req CreateMediaRequest)
+	Upload(ctx context.Context, req UploadMediaRequest) (string, error)
 	Update(ctx context.Context, uuid string, updates UpdateMediaRequest) error
+	PrepareUpdate(ctx context.Context, updates UpdateMediaRequest) UpdateMediaRequest
+	DiscardUpdate(ctx context.Context, updates UpdateMediaRequest, committed bool)
 	Find(ctx context.Context, uuid string) (*pkg.MediaResponse, error)
-	FindAll(ctx context.Context) ([]*pkg.MediaResponse, error)
+	FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.MediaResponse], error)
 	Delete(ctx context.Context, uuid string) error
+	Restore(ctx context.Context, uuid string) error
+	FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.MediaResponse], error)
 }
 
 // mediaApi is an implementation of the Media interface.
 type mediaApi struct {
-	mediaAdapter port.MediaPersister
+	mediaAdapter  port.MediaPersister
+	objectStore   port.ObjectStore
+	prober        port.AudioProber
+	fetcher       port.MediaFetcher
+	maxUploadSize int64
 }
 
 // NewMediaApi creates a new instance of Media.
-// It takes a MediaPersister as a dependency.
-func NewMediaApi(mediaAdapter port.MediaPersister) Media {
+// It takes a MediaPersister, the ObjectStore uploaded files and artworks are kept in, the AudioProber describing
+// the media files, the MediaFetcher reading the files of the medias registered by link, nil to leave them
+// undescribed, and the maximum size of an upload as dependencies.
+func NewMediaApi(mediaAdapter port.MediaPersister, objectStore port.ObjectStore, prober port.AudioProber, fetcher port.MediaFetcher, maxUploadSize int64) Media {
 	return &mediaApi{
-		mediaAdapter: mediaAdapter,
+		mediaAdapter:  mediaAdapter,
+		objectStore:   objectStore,
+		prober:        prober,
+		fetcher:       fetcher,
+		maxUploadSize: maxUploadSize,
 	}
 }
 
 // Create creates a new media.
-// It takes the context and CreateMediaRequest, and returns an error if any.
-func (api mediaApi) Create(ctx context.Context, req CreateMediaRequest) error {
+// It takes the context and CreateMediaRequest, and returns the UUID of the new media or an error.
+func (api mediaApi) Create(ctx context.Context, req CreateMediaRequest) (string, error) {
 	// Validate request
 	vErrs := createMediaRequestValidation(ctx, req)
 	if len(vErrs) > 0 {
 		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
-		return fmt.Errorf("request was not validated: %w", vErrs)
+		return "", fmt.Errorf("request was not validated: %w", vErrs)
 	}
-	// Map to domain model
+	// Map to domain model, describing the linked file unless it was prepared
 	media := model.Media{
 		ID:         uuid.New().String(),
 		DirectLink: req.DirectLink(),
 		Kind:       req.Kind(),
 		EpisodeID:  req.EpisodeID(),
 	}
+	if prepared, ok := req.(preparedMediaRequest); ok {
+		media = prepared.media
+	} else {
+		api.describeLink(ctx, &media)
+	}
 	// Call adapter
 	if err := api.mediaAdapter.Create(ctx, media); err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("media", media).Msg("error while creating media")
-		return fmt.Errorf("error occurred while creating media: %w", err)
+		api.discard(ctx, media)
+		return "", fmt.Errorf("error occurred while creating media: %w", err)
 	}
 
-	return nil
+	return media.ID, nil
+}
+
+// Prepare fetches and describes the linked file of a media ahead of its creation, and stores its artwork.
+// Creating a media from the returned request does not fetch the file again, so that a media can be created
+// in a transaction without waiting on the network. The objects stored for a request whose media is not created
+// are removed by Discard.
+// It takes the context and CreateMediaRequest, and returns the prepared CreateMediaRequest.
+func (api mediaApi) Prepare(ctx context.Context, req CreateMediaRequest) CreateMediaRequest {
+	media := model.Media{
+		ID:         uuid.New().String(),
+		DirectLink: req.DirectLink(),
+		Kind:       req.Kind(),
+		EpisodeID:  req.EpisodeID(),
+	}
+	api.describeLink(ctx, &media)
+	return preparedMediaRequest{CreateMediaRequest: req, media: media}
+}
+
+// Discard removes the objects stored by Prepare for a media that was not created.
+// It takes the context and the prepared CreateMediaRequest, and does nothing for a request that was not prepared.
+func (api mediaApi) Discard(ctx context.Context, req CreateMediaRequest) {
+	if prepared, ok := req.(preparedMediaRequest); ok {
+		api.discard(ctx, prepared.media)
+	}
+}
+
+// preparedMediaRequest is a CreateMediaRequest whose linked file was described by Prepare.
+type preparedMediaRequest struct {
+	CreateMediaRequest
+	media model.Media
 }
 
 // createMediaRequestValidation validates the creation request.
@@ -74,7 +136,240 @@ func createMediaRequestValidation(ctx context.Context, req CreateMediaRequest) m
 	return vErrs
 }
 
+// sniffLen is the number of leading bytes the MIME type of an uploaded file is detected from.
+const sniffLen = 512
+
+// Upload creates a new media from an uploaded file, streamed to the object store as it is received.
+// The direct link, size, checksum and MIME type of the media are taken from the stored file. The content type
+// declared by the client is kept unless it is missing or generic, in which case it is detected from the content.
+// A file growing past the maximum size of an upload is refused without being stored.
+// The audio stream of the file is described from the stored object.
+// It takes the context and UploadMediaRequest, and returns the UUID of the new media or an error.
+func (api mediaApi) Upload(ctx context.Context, req UploadMediaRequest) (string, error) {
+	// Validate request
+	vErrs := uploadMediaRequestValidation(ctx, req)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Str("fileName", req.FileName()).Msg("request was not validated")
+		return "", fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	file := req.File()
+	if api.maxUploadSize > 0 {
+		file = &uploadLimiter{r: file, remaining: api.maxUploadSize}
+	}
+
+	// Detect the MIME type from the head of the file
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", api.uploadError(ctx, req, err)
+	}
+	mimeType := req.ContentType()
+	if mimeType == "" || mimeType == "application/octet-stream" {
+		mimeType = http.DetectContentType(head[:n])
+	}
+
+	// Map to domain model
+	media := model.Media{
+		ID:        uuid.New().String(),
+		Kind:      req.Kind(),
+		EpisodeID: req.EpisodeID(),
+		MimeType:  mimeType,
+	}
+	media.StorageKey = "medias/" + media.ID + uploadExtension(req.FileName())
+
+	// Stream the file to the object store, digesting and counting it on the way
+	digest := sha256.New()
+	var size byteCounter
+	content := io.MultiReader(bytes.NewReader(head[:n]), file)
+	link, err := api.objectStore.Put(ctx, media.StorageKey, io.TeeReader(content, io.MultiWriter(digest, &size)), mimeType)
+	if err != nil {
+		return "", api.uploadError(ctx, req, err)
+	}
+	media.DirectLink = link
+	media.Size = int64(size)
+	media.Checksum = hex.EncodeToString(digest.Sum(nil))
+
+	// Describe the stored file
+	if stored, err := api.objectStore.Open(ctx, media.StorageKey); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("key", media.StorageKey).Msg("could not read stored media file, leaving it undescribed")
+	} else {
+		api.describe(ctx, &media, stored)
+		stored.Close()
+	}
+
+	// Call adapter
+	if err := api.mediaAdapter.Create(ctx, media); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("media", media).Msg("error while creating media")
+		api.discard(ctx, media)
+		return "", fmt.Errorf("error occurred while creating media: %w", err)
+	}
+
+	return media.ID, nil
+}
+
+// uploadError returns the error of an upload whose file could not be received or stored,
+// a validation error when the file is larger than the maximum size of an upload.
+func (api mediaApi) uploadError(ctx context.Context, req UploadMediaRequest, err error) error {
+	if errors.Is(err, errUploadTooLarge) {
+		vErrs := model.ValidationErrors{{Field: "file", Message: fmt.Sprintf("must not be larger than %d bytes", api.maxUploadSize)}}
+		log.Ctx(ctx).Error().Err(vErrs).Str("fileName", req.FileName()).Msg("request was not validated")
+		return fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	log.Ctx(ctx).Error().Err(err).Str("fileName", req.FileName()).Msg("error while storing uploaded file")
+	return fmt.Errorf("error occurred while storing uploaded file: %w", err)
+}
+
+// errUploadTooLarge is returned by an uploadLimiter once the file it reads exceeds the maximum size of an upload.
+var errUploadTooLarge = errors.New("uploaded file too large")
+
+// uploadLimiter reads an uploaded file from r, failing with errUploadTooLarge as soon as more than remaining
+// bytes are read, so that the object store never receives more than the maximum size of an upload.
+type uploadLimiter struct {
+	r         io.Reader
+	remaining int64
+}
+
+// Read reads from the uploaded file, counting the bytes read against the limit.
+func (l *uploadLimiter) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, errUploadTooLarge
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, errUploadTooLarge
+	}
+	return n, err
+}
+
+// describe fills the audio description of media from its file, read from r, and stores the artwork embedded in it.
+// Describing is best effort: a file that cannot be probed is recorded without description.
+func (api mediaApi) describe(ctx context.Context, media *model.Media, r io.ReadSeeker) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("media", media.ID).Msg("could not read media file, leaving it undescribed")
+		return
+	}
+	info, err := api.prober.Probe(ctx, r)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("media", media.ID).Msg("could not probe media file, leaving it undescribed")
+		return
+	}
+	media.Codec = info.Codec
+	media.Duration = info.Duration
+	media.Bitrate = info.Bitrate
+	media.Channels = info.Channels
+	media.SampleRate = info.SampleRate
+	media.Title = info.Title
+	if info.Artwork == nil {
+		return
+	}
+
+	key := "artworks/" + media.ID + artworkExtension(info.Artwork.MimeType)
+	link, err := api.objectStore.Put(ctx, key, bytes.NewReader(info.Artwork.Data), info.Artwork.MimeType)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("key", key).Msg("could not store media artwork, leaving it out")
+		return
+	}
+	media.ArtworkKey = key
+	media.ArtworkLink = link
+}
+
+// describeLink describes the file media links to, when linked files can be fetched.
+func (api mediaApi) describeLink(ctx context.Context, media *model.Media) {
+	if api.fetcher == nil {
+		return
+	}
+	file, err := api.fetcher.Open(ctx, media.DirectLink)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("directLink", media.DirectLink).Msg("could not fetch media file, leaving it undescribed")
+		return
+	}
+	defer file.Close()
+	api.describe(ctx, media, file)
+}
+
+// discard removes the objects stored for a media that could not be created, so that no object is left
+// without a media pointing to it.
+func (api mediaApi) discard(ctx context.Context, media model.Media) {
+	for _, key := range []string{media.StorageKey, media.ArtworkKey} {
+		if key == "" {
+			continue
+		}
+		if err := api.objectStore.Delete(ctx, key); err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("key", key).Msg("error while deleting orphan object")
+		}
+	}
+}
+
+// describeFile describes the file a new direct link points to. The file gets an ID of its own, so that its
+// artwork is never stored under the key of the artwork of the file it replaces.
+func (api mediaApi) describeFile(ctx context.Context, directLink string) model.Media {
+	file := model.Media{ID: uuid.New().String(), DirectLink: directLink}
+	api.describeLink(ctx, &file)
+	return file
+}
+
+// uploadMediaRequestValidation validates the upload request. The size of the file is checked as it is received.
+// It takes the context and UploadMediaRequest, and returns a slice of ValidationErrors.
+func uploadMediaRequestValidation(ctx context.Context, req UploadMediaRequest) model.ValidationErrors {
+	var vErrs []model.ValidationError
+	if req.Kind() == "" {
+		vErrs = append(vErrs, model.ValidationError{Field: "kind", Message: "is required"})
+	}
+	if req.EpisodeID() == "" {
+		vErrs = append(vErrs, model.ValidationError{Field: "episodeID", Message: "cannot be empty"})
+	}
+	if req.FileName() == "" {
+		vErrs = append(vErrs, model.ValidationError{Field: "file", Message: "is required"})
+	}
+	return vErrs
+}
+
+// uploadExtension returns the lower-cased extension of an uploaded file name, used in its storage key,
+// or an empty string when the extension is not made of letters and digits only.
+func uploadExtension(fileName string) string {
+	ext := strings.ToLower(path.Ext(fileName))
+	if len(ext) < 2 || len(ext) > 16 {
+		return ""
+	}
+	for _, r := range ext[1:] {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	return ext
+}
+
+// artworkExtension returns the file extension of the artworks of the given MIME type, used in their storage key.
+func artworkExtension(mimeType string) string {
+	switch mimeType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "image/bmp":
+		return ".bmp"
+	}
+	return ""
+}
+
+// byteCounter is an io.Writer counting the bytes written to it.
+type byteCounter int64
+
+// Write counts the bytes of p.
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
 // Update updates an existing media.
+// A new direct link replaces the file of the media: the linked file is described again, and the description and
+// the uploaded file of the previous one are cleared. The objects stored for the previous file are removed once
+// the media is updated, unless the request was prepared by PrepareUpdate, in which case DiscardUpdate removes them.
 // It takes the context, media UUID, and UpdateMediaRequest, and returns an error if any.
 func (api mediaApi) Update(ctx context.Context, uuid string, updates UpdateMediaRequest) error {
 	// Validate request
@@ -83,10 +378,25 @@ func (api mediaApi) Update(ctx context.Context, uuid string, updates UpdateMedia
 		log.Ctx(ctx).Error().Err(vErrs).Interface("updates", updates).Msg("request was not validated")
 		return fmt.Errorf("request was not validated: %w", vErrs)
 	}
-	// Map to domain model
+	prepared, isPrepared := updates.(*preparedMediaUpdate)
+
+	// Map to domain model, taking the description of the new file when the link changes
 	media := model.Media{}
+	var replaced *model.Media
 	if updates.DirectLink() != "" {
-		media.DirectLink = updates.DirectLink()
+		current, err := api.mediaAdapter.Find(ctx, uuid)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("uuid", uuid).Msg("error while finding media")
+			return fmt.Errorf("error occurred while updating media: %w", err)
+		}
+		if current.DirectLink != updates.DirectLink() {
+			if isPrepared {
+				media = prepared.file
+			} else {
+				media = api.describeFile(ctx, updates.DirectLink())
+			}
+			replaced = current
+		}
 	}
 	if updates.Kind() != "" {
 		media.Kind = updates.Kind()
@@ -98,12 +408,57 @@ func (api mediaApi) Update(ctx context.Context, uuid string, updates UpdateMedia
 	// Call adapter
 	if err := api.mediaAdapter.Update(ctx, uuid, media); err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("media", media).Msg("error while updating media")
+		if replaced != nil && !isPrepared {
+			api.discard(ctx, media)
+		}
 		return fmt.Errorf("error occurred while updating media: %w", err)
 	}
+	switch {
+	case isPrepared:
+		prepared.replaced = replaced
+	case replaced != nil:
+		api.discard(ctx, *replaced)
+	}
 
 	return nil
 }
 
+// PrepareUpdate fetches and describes the file of the new direct link of a media ahead of its update, and stores
+// its artwork, so that a media can be updated in a transaction without waiting on the network.
+// The objects left behind once the transaction ends are removed by DiscardUpdate.
+// It takes the context and UpdateMediaRequest, and returns the prepared UpdateMediaRequest.
+func (api mediaApi) PrepareUpdate(ctx context.Context, updates UpdateMediaRequest) UpdateMediaRequest {
+	if updates.DirectLink() == "" {
+		return updates
+	}
+	return &preparedMediaUpdate{UpdateMediaRequest: updates, file: api.describeFile(ctx, updates.DirectLink())}
+}
+
+// DiscardUpdate removes the objects left behind by an update prepared by PrepareUpdate once its transaction ended:
+// the objects of the replaced file when the transaction was committed, and the objects of the prepared file
+// when it was rolled back or did not replace the file.
+// It takes the context, the prepared UpdateMediaRequest and whether the transaction was committed,
+// and does nothing for a request that was not prepared.
+func (api mediaApi) DiscardUpdate(ctx context.Context, updates UpdateMediaRequest, committed bool) {
+	prepared, ok := updates.(*preparedMediaUpdate)
+	if !ok {
+		return
+	}
+	if committed && prepared.replaced != nil {
+		api.discard(ctx, *prepared.replaced)
+		return
+	}
+	api.discard(ctx, prepared.file)
+}
+
+// preparedMediaUpdate is an UpdateMediaRequest whose linked file was described by PrepareUpdate.
+// Update records the file it replaced, for DiscardUpdate to remove once the update is committed.
+type preparedMediaUpdate struct {
+	UpdateMediaRequest
+	file     model.Media
+	replaced *model.Media
+}
+
 // updateMediaRequestValidation validates the update request.
 // It takes the context, media UUID, and UpdateMediaRequest, and returns a slice of ValidationErrors.
 func updateMediaRequestValidation(ctx context.Context, uuid string, req UpdateMediaRequest) model.ValidationErrors {
@@ -130,16 +485,37 @@ func (api mediaApi) Find(ctx context.Context, uuid string) (*pkg.MediaResponse,
 		DirectLink: media.DirectLink,
 		Kind:       media.Kind,
 		EpisodeID:  media.EpisodeID,
+		Size:       media.Size,
+		Checksum:   media.Checksum,
+		MimeType:   media.MimeType,
+		Codec:      media.Codec,
+		DurationMs: media.Duration.Milliseconds(),
+		Bitrate:    media.Bitrate,
+		Channels:   media.Channels,
+		SampleRate: media.SampleRate,
+		Title:      media.Title,
+		Artwork:    media.ArtworkLink,
 	}
 	// Return result
 	return response, nil
 }
 
-// FindAll finds all medias.
-// It takes the context and returns a slice of MediaResponse or an error.
-func (api mediaApi) FindAll(ctx context.Context) ([]*pkg.MediaResponse, error) {
+// mediaListing describes how medias can be sorted and filtered.
+var mediaListing = listing{named: false, sortable: []string{model.SortByCreatedAt, model.SortByKind}}
+
+// FindAll finds a page of medias.
+// It takes the context and ListRequest, and returns a page of MediaResponse or an error.
+func (api mediaApi) FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.MediaResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, mediaListing)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := listOptions(req)
+
 	// Call adapter
-	mediaSlice, err := api.mediaAdapter.FindAll(ctx)
+	mediaSlice, total, err := api.mediaAdapter.FindAll(ctx, opts)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while finding medias")
 		return nil, fmt.Errorf("error occurred while finding medias: %w", err)
@@ -153,10 +529,20 @@ func (api mediaApi) FindAll(ctx context.Context) ([]*pkg.MediaResponse, error) {
 			DirectLink: media.DirectLink,
 			Kind:       media.Kind,
 			EpisodeID:  media.EpisodeID,
+			Size:       media.Size,
+			Checksum:   media.Checksum,
+			MimeType:   media.MimeType,
+			Codec:      media.Codec,
+			DurationMs: media.Duration.Milliseconds(),
+			Bitrate:    media.Bitrate,
+			Channels:   media.Channels,
+			SampleRate: media.SampleRate,
+			Title:      media.Title,
+			Artwork:    media.ArtworkLink,
 		})
 	}
 	// Return result
-	return response, nil
+	return newPage(response, total, opts), nil
 }
 
 // Delete deletes a media by UUID.
@@ -169,6 +555,60 @@ func (api mediaApi) Delete(ctx context.Context, uuid string) error {
 	return nil
 }
 
+// Restore restores a media from the trash by UUID.
+// It fails with model.ErrConflict while the episode of the media is in the trash.
+// It takes the context and media UUID, and returns an error if any.
+func (api mediaApi) Restore(ctx context.Context, uuid string) error {
+	if err := api.mediaAdapter.Restore(ctx, uuid); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while restoring media")
+		return fmt.Errorf("error occurred while restoring media: %w", err)
+	}
+	return nil
+}
+
+// FindDeleted finds a page of the medias in the trash, the most recently deleted first unless sorted otherwise.
+// It takes the context and ListRequest, and returns a page of MediaResponse or an error.
+func (api mediaApi) FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.MediaResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, mediaListing.trash())
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := trashOptions(req)
+
+	// Call adapter
+	mediaSlice, total, err := api.mediaAdapter.FindAll(ctx, opts)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while finding deleted medias")
+		return nil, fmt.Errorf("error occurred while finding deleted medias: %w", err)
+	}
+
+	// Map to response
+	var response []*pkg.MediaResponse
+	for _, media := range mediaSlice {
+		response = append(response, &pkg.MediaResponse{
+			ID:         media.ID,
+			DirectLink: media.DirectLink,
+			Kind:       media.Kind,
+			EpisodeID:  media.EpisodeID,
+			Size:       media.Size,
+			Checksum:   media.Checksum,
+			MimeType:   media.MimeType,
+			Codec:      media.Codec,
+			DurationMs: media.Duration.Milliseconds(),
+			Bitrate:    media.Bitrate,
+			Channels:   media.Channels,
+			SampleRate: media.SampleRate,
+			Title:      media.Title,
+			Artwork:    media.ArtworkLink,
+			DeletedAt:  optionalTime(media.DeletedAt),
+		})
+	}
+	// Return result
+	return newPage(response, total, opts), nil
+}
+
 // CreateMediaRequest represents the interface for creating medias.
 type CreateMediaRequest interface {
 	DirectLink() string
@@ -176,6 +616,16 @@ type CreateMediaRequest interface {
 	EpisodeID() string
 }
 
+// UploadMediaRequest represents the interface for creating medias from an uploaded file,
+// read from File as it is received.
+type UploadMediaRequest interface {
+	Kind() string
+	EpisodeID() string
+	FileName() string
+	ContentType() string
+	File() io.Reader
+}
+
 // UpdateMediaRequest represents the interface for updating medias.
 type UpdateMediaRequest interface {
 	DirectLink() string
@@ -0,0 +1,491 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+func TestMediaApi_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.CreateMediaRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+	}{
+		{
+			name: "creates the media",
+			req:  pkg.CreateMediaRequestJSON{DirectLinkJSON: "https://cdn/e1.mp3", KindJSON: "audio", EpisodeIDJSON: "e1"},
+		},
+		{
+			name:       "requires every field",
+			req:        pkg.CreateMediaRequestJSON{},
+			wantFields: []string{"directLink", "kind", "episodeID"},
+		},
+		{
+			name:       "requires the episode",
+			req:        pkg.CreateMediaRequestJSON{DirectLinkJSON: "https://cdn/e1.mp3", KindJSON: "audio"},
+			wantFields: []string{"episodeID"},
+		},
+		{
+			name:    "wraps adapter failure",
+			req:     pkg.CreateMediaRequestJSON{DirectLinkJSON: "https://cdn/e1.mp3", KindJSON: "audio", EpisodeIDJSON: "e1"},
+			failOn:  "Create",
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			medias := newFakeMediaPersister()
+			if tt.failOn != "" {
+				medias.failOn(tt.failOn)
+			}
+
+			id, err := NewMediaApi(medias, newFakeObjectStore(), &fakeAudioProber{}, nil, 0).Create(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if err == nil {
+				if len(medias.rows) != 1 || medias.rows[0].ID != id || medias.rows[0].EpisodeID != "e1" {
+					t.Fatalf("unexpected stored medias: %+v", medias.rows)
+				}
+			}
+		})
+	}
+}
+
+// uploadRequest is an UploadMediaRequest reading the uploaded file from memory.
+type uploadRequest struct {
+	kind        string
+	episodeID   string
+	fileName    string
+	contentType string
+	content     []byte
+}
+
+func (req uploadRequest) Kind() string        { return req.kind }
+func (req uploadRequest) EpisodeID() string   { return req.episodeID }
+func (req uploadRequest) FileName() string    { return req.fileName }
+func (req uploadRequest) ContentType() string { return req.contentType }
+func (req uploadRequest) File() io.Reader     { return bytes.NewReader(req.content) }
+
+func TestMediaApi_Upload(t *testing.T) {
+	mp3 := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x00"), bytes.Repeat([]byte{0xff, 0xfb, 0x90, 0x00}, 300)...)
+	tests := []struct {
+		name         string
+		req          uploadRequest
+		failOn       string
+		failStore    string
+		wantFields   []string
+		wantErr      error
+		wantKey      string
+		wantMimeType string
+	}{
+		{
+			name:         "stores the file and records it",
+			req:          uploadRequest{kind: "audio", episodeID: "e1", fileName: "Episode 1.MP3", contentType: "audio/mpeg", content: mp3},
+			wantKey:      ".mp3",
+			wantMimeType: "audio/mpeg",
+		},
+		{
+			name:         "detects the MIME type of a generic upload",
+			req:          uploadRequest{kind: "audio", episodeID: "e1", fileName: "episode", contentType: "application/octet-stream", content: mp3},
+			wantMimeType: "audio/mpeg",
+		},
+		{
+			name:         "drops an unusual extension from the key",
+			req:          uploadRequest{kind: "audio", episodeID: "e1", fileName: "episode.mp3?x", content: []byte("hello")},
+			wantMimeType: "text/plain; charset=utf-8",
+		},
+		{
+			name:       "requires every field",
+			req:        uploadRequest{},
+			wantFields: []string{"kind", "episodeID", "file"},
+		},
+		{
+			name:       "refuses a file above the size limit",
+			req:        uploadRequest{kind: "audio", episodeID: "e1", fileName: "big.mp3", content: make([]byte, 8192)},
+			wantFields: []string{"file"},
+		},
+		{
+			name:      "wraps object store failure",
+			req:       uploadRequest{kind: "audio", episodeID: "e1", fileName: "episode.mp3", content: mp3},
+			failStore: "Put",
+			wantErr:   errAdapter,
+		},
+		{
+			name:    "removes the stored file when the media cannot be created",
+			req:     uploadRequest{kind: "audio", episodeID: "e1", fileName: "episode.mp3", content: mp3},
+			failOn:  "Create",
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			medias := newFakeMediaPersister()
+			if tt.failOn != "" {
+				medias.failOn(tt.failOn)
+			}
+			store := newFakeObjectStore()
+			if tt.failStore != "" {
+				store.errs[tt.failStore] = errAdapter
+			}
+
+			id, err := NewMediaApi(medias, store, &fakeAudioProber{}, nil, 4096).Upload(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if err != nil {
+				if len(medias.rows) != 0 || len(store.objects) != 0 {
+					t.Fatalf("got medias %+v and objects %d, want none", medias.rows, len(store.objects))
+				}
+				return
+			}
+			if len(medias.rows) != 1 {
+				t.Fatalf("got %d medias, want 1", len(medias.rows))
+			}
+			media := medias.rows[0]
+			digest := sha256.Sum256(tt.req.content)
+			wantKey := "medias/" + id + tt.wantKey
+			if media.ID != id || media.StorageKey != wantKey || media.DirectLink != "https://cdn/"+wantKey {
+				t.Fatalf("got media %s stored at %q and linked to %q, want %s at %q", media.ID, media.StorageKey, media.DirectLink, id, wantKey)
+			}
+			if media.Size != int64(len(tt.req.content)) || media.Checksum != hex.EncodeToString(digest[:]) || media.MimeType != tt.wantMimeType {
+				t.Fatalf("got size %d, checksum %s and MIME type %q, want %d, %x and %q", media.Size, media.Checksum, media.MimeType, len(tt.req.content), digest, tt.wantMimeType)
+			}
+			if !bytes.Equal(store.objects[wantKey], tt.req.content) || store.contentTypes[wantKey] != tt.wantMimeType {
+				t.Fatalf("got object of %d bytes typed %q, want the uploaded file typed %q", len(store.objects[wantKey]), store.contentTypes[wantKey], tt.wantMimeType)
+			}
+		})
+	}
+}
+
+func TestMediaApi_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		uuid       string
+		req        pkg.UpdateMediaRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+		want       model.Media
+	}{
+		{
+			name: "updates only the given fields",
+			uuid: "m1",
+			req:  pkg.UpdateMediaRequestJSON{KindJSON: "video"},
+			want: model.Media{ID: "m1", DirectLink: "https://cdn/e1.mp3", Kind: "video", EpisodeID: "e1"},
+		},
+		{
+			name:       "requires the uuid",
+			req:        pkg.UpdateMediaRequestJSON{KindJSON: "video"},
+			wantFields: []string{"uuid"},
+			want:       model.Media{ID: "m1", DirectLink: "https://cdn/e1.mp3", Kind: "audio", EpisodeID: "e1"},
+		},
+		{
+			name:    "wraps adapter failure",
+			uuid:    "m1",
+			req:     pkg.UpdateMediaRequestJSON{KindJSON: "video"},
+			failOn:  "Update",
+			wantErr: errAdapter,
+			want:    model.Media{ID: "m1", DirectLink: "https://cdn/e1.mp3", Kind: "audio", EpisodeID: "e1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			medias := newFakeMediaPersister(model.Media{ID: "m1", DirectLink: "https://cdn/e1.mp3", Kind: "audio", EpisodeID: "e1"})
+			if tt.failOn != "" {
+				medias.failOn(tt.failOn)
+			}
+
+			err := NewMediaApi(medias, newFakeObjectStore(), &fakeAudioProber{}, nil, 0).Update(context.Background(), tt.uuid, tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if got := medias.rows[0]; got != tt.want {
+				t.Fatalf("got media %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMediaApi_UpdateLink(t *testing.T) {
+	uploaded := model.Media{
+		ID: "m1", DirectLink: "https://store/medias/m1.mp3", Kind: "audio", EpisodeID: "e1",
+		StorageKey: "medias/m1.mp3", Size: 2048, Checksum: "abc", MimeType: "audio/mpeg",
+		Codec: "mp3", Duration: time.Minute, Bitrate: 64000, Channels: 1, SampleRate: 22050, Title: "Old",
+		ArtworkKey: "artworks/m1.png", ArtworkLink: "https://store/artworks/m1.png",
+	}
+	probed := model.AudioInfo{
+		Codec:      "aac",
+		Duration:   90 * time.Second,
+		Bitrate:    128000,
+		Channels:   2,
+		SampleRate: 44100,
+		Title:      "Pilot",
+		Artwork:    &model.Artwork{MimeType: "image/jpeg", Data: []byte("cover")},
+	}
+	tests := []struct {
+		name        string
+		link        string
+		failOn      string
+		wantErr     error
+		want        model.Media
+		wantArtwork bool // the new file has an artwork, stored under a key of its own
+		wantObjects []string
+	}{
+		{
+			name: "describes the new file and removes the previous one",
+			link: "https://cdn/pilot.m4a",
+			want: model.Media{
+				ID: "m1", DirectLink: "https://cdn/pilot.m4a", Kind: "audio", EpisodeID: "e1",
+				Codec: "aac", Duration: 90 * time.Second, Bitrate: 128000, Channels: 2, SampleRate: 44100, Title: "Pilot",
+			},
+			wantArtwork: true,
+		},
+		{
+			name:        "keeps the file of an unchanged link",
+			link:        "https://store/medias/m1.mp3",
+			want:        uploaded,
+			wantObjects: []string{"artworks/m1.png", "medias/m1.mp3"},
+		},
+		{
+			name:        "keeps the previous file when the media cannot be updated",
+			link:        "https://cdn/pilot.m4a",
+			failOn:      "Update",
+			wantErr:     errAdapter,
+			want:        uploaded,
+			wantObjects: []string{"artworks/m1.png", "medias/m1.mp3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			medias := newFakeMediaPersister(uploaded)
+			if tt.failOn != "" {
+				medias.failOn(tt.failOn)
+			}
+			store := newFakeObjectStore()
+			store.objects["medias/m1.mp3"] = []byte("audio")
+			store.objects["artworks/m1.png"] = []byte("image")
+			fetcher := &fakeMediaFetcher{files: map[string][]byte{"https://cdn/pilot.m4a": []byte("audio content")}}
+
+			err := NewMediaApi(medias, store, &fakeAudioProber{info: probed}, fetcher, 0).Update(context.Background(), "m1", pkg.UpdateMediaRequestJSON{DirectLinkJSON: tt.link})
+
+			assertError(t, err, nil, tt.wantErr)
+			got, wantObjects := medias.rows[0], tt.wantObjects
+			if tt.wantArtwork {
+				if !strings.HasPrefix(got.ArtworkKey, "artworks/") || got.ArtworkKey == uploaded.ArtworkKey || got.ArtworkLink != "https://cdn/"+got.ArtworkKey {
+					t.Fatalf("got artwork %q at %q, want the artwork of the new file", got.ArtworkKey, got.ArtworkLink)
+				}
+				wantObjects = []string{got.ArtworkKey}
+				got.ArtworkKey, got.ArtworkLink = "", ""
+			}
+			if got != tt.want {
+				t.Fatalf("got media %+v, want %+v", got, tt.want)
+			}
+			var gotObjects []string
+			for key := range store.objects {
+				gotObjects = append(gotObjects, key)
+			}
+			sort.Strings(gotObjects)
+			if !equalStrings(gotObjects, wantObjects) {
+				t.Fatalf("got objects %v, want %v", gotObjects, wantObjects)
+			}
+		})
+	}
+}
+
+func TestMediaApi_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		failOn  string
+		call    func(api Media) (int, error)
+		want    int
+		wantErr error
+	}{
+		{
+			name: "finds a media",
+			call: func(api Media) (int, error) {
+				media, err := api.Find(context.Background(), "m1")
+				if err != nil {
+					return 0, err
+				}
+				if media.EpisodeID != "e1" {
+					t.Fatalf("unexpected media: %+v", media)
+				}
+				return 1, nil
+			},
+			want: 1,
+		},
+		{
+			name:    "wraps find failure",
+			failOn:  "Find",
+			call:    func(api Media) (int, error) { _, err := api.Find(context.Background(), "m1"); return 0, err },
+			wantErr: errAdapter,
+		},
+		{
+			name:    "reports a missing media",
+			call:    func(api Media) (int, error) { _, err := api.Find(context.Background(), "m9"); return 0, err },
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name:    "reports the deletion of a missing media",
+			call:    func(api Media) (int, error) { return 0, api.Delete(context.Background(), "m9") },
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "finds all medias",
+			call: func(api Media) (int, error) {
+				medias, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				if err != nil {
+					return 0, err
+				}
+				return len(medias.Items), nil
+			},
+			want: 1,
+		},
+		{
+			name:   "wraps find all failure",
+			failOn: "FindAll",
+			call: func(api Media) (int, error) {
+				_, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				return 0, err
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name:    "wraps delete failure",
+			failOn:  "Delete",
+			call:    func(api Media) (int, error) { return 0, api.Delete(context.Background(), "m1") },
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			medias := newFakeMediaPersister(model.Media{ID: "m1", DirectLink: "https://cdn/e1.mp3", Kind: "audio", EpisodeID: "e1"})
+			if tt.failOn != "" {
+				medias.failOn(tt.failOn)
+			}
+
+			got, err := tt.call(NewMediaApi(medias, newFakeObjectStore(), &fakeAudioProber{}, nil, 0))
+
+			assertError(t, err, nil, tt.wantErr)
+			if got != tt.want {
+				t.Fatalf("got %d results, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMediaApi_Describe(t *testing.T) {
+	content := []byte("ID3 audio content")
+	probed := model.AudioInfo{
+		Codec:      "mp3",
+		Duration:   90 * time.Second,
+		Bitrate:    128000,
+		Channels:   2,
+		SampleRate: 44100,
+		Title:      "Pilot",
+		Artwork:    &model.Artwork{MimeType: "image/jpeg", Data: []byte("cover")},
+	}
+	upload := func(api Media) (string, error) {
+		return api.Upload(context.Background(), uploadRequest{kind: "audio", episodeID: "e1", fileName: "pilot.mp3", content: content})
+	}
+	register := func(link string) func(api Media) (string, error) {
+		return func(api Media) (string, error) {
+			return api.Create(context.Background(), pkg.CreateMediaRequestJSON{DirectLinkJSON: link, KindJSON: "audio", EpisodeIDJSON: "e1"})
+		}
+	}
+	tests := []struct {
+		name        string
+		call        func(api Media) (string, error)
+		probeErr    error
+		failOn      string
+		wantErr     error
+		wantProbed  bool
+		wantCodec   string
+		wantArtwork bool
+		wantObjects int
+	}{
+		{
+			name:        "describes an uploaded file and stores its artwork",
+			call:        upload,
+			wantProbed:  true,
+			wantCodec:   "mp3",
+			wantArtwork: true,
+			wantObjects: 2,
+		},
+		{
+			name:        "describes the file of a registered link",
+			call:        register("https://cdn/pilot.mp3"),
+			wantProbed:  true,
+			wantCodec:   "mp3",
+			wantArtwork: true,
+			wantObjects: 1,
+		},
+		{
+			name: "registers a link whose file cannot be fetched undescribed",
+			call: register("https://cdn/missing.mp3"),
+		},
+		{
+			name:        "uploads a file that cannot be probed undescribed",
+			call:        upload,
+			probeErr:    errors.New("unknown audio format"),
+			wantProbed:  true,
+			wantObjects: 1,
+		},
+		{
+			name:       "removes the stored file and artwork when the media cannot be created",
+			call:       upload,
+			failOn:     "Create",
+			wantErr:    errAdapter,
+			wantProbed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			medias := newFakeMediaPersister()
+			if tt.failOn != "" {
+				medias.failOn(tt.failOn)
+			}
+			store := newFakeObjectStore()
+			prober := &fakeAudioProber{info: probed, err: tt.probeErr}
+			fetcher := &fakeMediaFetcher{files: map[string][]byte{"https://cdn/pilot.mp3": content}}
+
+			id, err := tt.call(NewMediaApi(medias, store, prober, fetcher, 0))
+
+			assertError(t, err, nil, tt.wantErr)
+			if gotProbed := len(prober.probed) == 1 && bytes.Equal(prober.probed[0], content); gotProbed != tt.wantProbed {
+				t.Fatalf("got file probed %v, want %v", gotProbed, tt.wantProbed)
+			}
+			if len(store.objects) != tt.wantObjects {
+				t.Fatalf("got %d stored objects, want %d", len(store.objects), tt.wantObjects)
+			}
+			if err != nil {
+				return
+			}
+			media := medias.find(id)
+			if media.Codec != tt.wantCodec {
+				t.Fatalf("got codec %q, want %q", media.Codec, tt.wantCodec)
+			}
+			if tt.wantCodec != "" && (media.Duration != probed.Duration || media.Bitrate != probed.Bitrate || media.Channels != 2 || media.SampleRate != 44100 || media.Title != "Pilot") {
+				t.Fatalf("got media %+v, want it described by %+v", media, probed)
+			}
+			wantKey := ""
+			if tt.wantArtwork {
+				wantKey = "artworks/" + id + ".jpg"
+			}
+			if media.ArtworkKey != wantKey || (wantKey != "" && (media.ArtworkLink != "https://cdn/"+wantKey || string(store.objects[wantKey]) != "cover")) {
+				t.Fatalf("got artwork %q linked to %q, want %q", media.ArtworkKey, media.ArtworkLink, wantKey)
+			}
+		})
+	}
+}
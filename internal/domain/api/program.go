@@ -3,6 +3,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
@@ -25,16 +26,23 @@ type UpdateProgramRequest interface {
 
 // Program represents the interface for managing programs.
 type Program interface {
-	Create(ctx context.Context, program CreateProgramRequest) error
+	Create(ctx context.Context, program CreateProgramRequest) (string, error)
 	Update(ctx context.Context, uuid string, updates UpdateProgramRequest) error
 	Find(ctx context.Context, uuid string) (*pkg.ProgramResponse, error)
-	FindAll(ctx context.Context) ([]*pkg.ProgramResponse, error)
-	Delete(ctx context.Context, uuid string) error
+	FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.ProgramResponse], error)
+	Delete(ctx context.Context, uuid string, req DeleteRequest) error
+	Restore(ctx context.Context, uuid string) error
+	FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.ProgramResponse], error)
 	FindEpisodes(ctx context.Context, uuid string) ([]*pkg.EpisodeResponse, error)
 	FindTags(ctx context.Context, uuid string) ([]*pkg.TagResponse, error)
 	FindCats(ctx context.Context, uuid string) ([]*pkg.CategoryResponse, error)
 	OverwriteCategories(ctx context.Context, programID string, cats []string) error
 	OverwriteTags(ctx context.Context, programID string, tags []string) error
+	ReorderEpisodes(ctx context.Context, programID string, req ReorderRequest) error
+	AddTag(ctx context.Context, programID string, tagID string) error
+	RemoveTag(ctx context.Context, programID string, tagID string) error
+	AddCategory(ctx context.Context, programID string, categoryID string) error
+	RemoveCategory(ctx context.Context, programID string, categoryID string) error
 }
 
 // programApi is an implementation of the Program interface.
@@ -45,10 +53,11 @@ type programApi struct {
 	tagAdapter        port.TagPersister
 	programCatAdapter port.ProgramCategoryPersister
 	catAdapter        port.CategoryPersister
+	txManager         port.TxManager
 }
 
 // NewProgramApi creates a new instance of Program.
-// It takes adapters for program, episode, tag, and category persistence as dependencies.
+// It takes adapters for program, episode, tag, and category persistence, and the txManager as dependencies.
 func NewProgramApi(
 	programAdapter port.ProgramPersister,
 	episodeAdapter port.EpisodePersister,
@@ -56,6 +65,7 @@ func NewProgramApi(
 	tagAdapter port.TagPersister,
 	programCatAdapter port.ProgramCategoryPersister,
 	catAdapter port.CategoryPersister,
+	txManager port.TxManager,
 ) Program {
 	return programApi{
 		programAdapter:    programAdapter,
@@ -64,17 +74,18 @@ func NewProgramApi(
 		tagAdapter:        tagAdapter,
 		programCatAdapter: programCatAdapter,
 		catAdapter:        catAdapter,
+		txManager:         txManager,
 	}
 }
 
 // Create creates a new program.
-// It takes the context and CreateProgramRequest, and returns an error if any.
-func (api programApi) Create(ctx context.Context, req CreateProgramRequest) error {
+// It takes the context and CreateProgramRequest, and returns the UUID of the new program or an error.
+func (api programApi) Create(ctx context.Context, req CreateProgramRequest) (string, error) {
 	// Validate request
 	vErrs := createProgramRequestValidation(ctx, req)
 	if len(vErrs) > 0 {
 		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
-		return fmt.Errorf("request was not validated: %w", vErrs)
+		return "", fmt.Errorf("request was not validated: %w", vErrs)
 	}
 	// Map to domain model
 	program := model.Program{
@@ -85,10 +96,10 @@ func (api programApi) Create(ctx context.Context, req CreateProgramRequest) erro
 	// Call adapter
 	if err := api.programAdapter.Create(ctx, program); err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("program", program).Msg("error while creating program")
-		return fmt.Errorf("error occurred while creating program: %w", err)
+		return "", fmt.Errorf("error occurred while creating program: %w", err)
 	}
 
-	return nil
+	return program.ID, nil
 }
 
 // createProgramRequestValidation validates the creation request.
@@ -163,11 +174,22 @@ func (api programApi) Find(ctx context.Context, uuid string) (*pkg.ProgramRespon
 	return response, nil
 }
 
-// FindAll finds all programs.
-// It takes the context and returns a slice of ProgramResponse or an error.
-func (api programApi) FindAll(ctx context.Context) ([]*pkg.ProgramResponse, error) {
+// programListing describes how programs can be sorted and filtered.
+var programListing = listing{named: true, sortable: []string{model.SortByName, model.SortByCreatedAt}}
+
+// FindAll finds a page of programs.
+// It takes the context and ListRequest, and returns a page of ProgramResponse or an error.
+func (api programApi) FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.ProgramResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, programListing)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := listOptions(req)
+
 	// Call adapter
-	programSlice, err := api.programAdapter.FindAll(ctx)
+	programSlice, total, err := api.programAdapter.FindAll(ctx, opts)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while finding programs")
 		return nil, fmt.Errorf("error occurred while finding programs: %w", err)
@@ -183,19 +205,81 @@ func (api programApi) FindAll(ctx context.Context) ([]*pkg.ProgramResponse, erro
 		})
 	}
 	// Return result
-	return response, nil
+	return newPage(response, total, opts), nil
 }
 
-// Delete deletes a program by UUID.
-// It takes the context and program UUID, and returns an error if any.
-func (api programApi) Delete(ctx context.Context, uuid string) error {
-	if err := api.programAdapter.Delete(ctx, uuid); err != nil {
+// Delete moves a program to the trash by UUID, along with its episodes and their medias.
+// A program that still has episodes is refused with a model.DependentsError listing them,
+// unless the request cascades.
+// It takes the context, program UUID and DeleteRequest, and returns an error if any.
+func (api programApi) Delete(ctx context.Context, uuid string, req DeleteRequest) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if !req.Cascade() {
+			// Refuse while the program still has live episodes
+			episodes, err := api.episodeAdapter.FindByProgramID(ctx, uuid)
+			if err != nil {
+				return err
+			}
+			var dependents []model.Dependent
+			for _, episode := range episodes {
+				dependents = append(dependents, model.Dependent{Entity: model.AuditEntityEpisode, ID: episode.ID, Name: episode.Name})
+			}
+			if err := dependentsError(model.AuditEntityProgram, uuid, dependents); err != nil {
+				return err
+			}
+		}
+
+		return api.programAdapter.Delete(ctx, uuid)
+	})
+	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while deleting program")
 		return fmt.Errorf("error occurred while deleting program: %w", err)
 	}
 	return nil
 }
 
+// Restore restores a program from the trash by UUID along with the episodes and medias moved to the trash with it.
+// It takes the context and program UUID, and returns an error if any.
+func (api programApi) Restore(ctx context.Context, uuid string) error {
+	if err := api.programAdapter.Restore(ctx, uuid); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while restoring program")
+		return fmt.Errorf("error occurred while restoring program: %w", err)
+	}
+	return nil
+}
+
+// FindDeleted finds a page of the programs in the trash, the most recently deleted first unless sorted otherwise.
+// It takes the context and ListRequest, and returns a page of ProgramResponse or an error.
+func (api programApi) FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.ProgramResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, programListing.trash())
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := trashOptions(req)
+
+	// Call adapter
+	programSlice, total, err := api.programAdapter.FindAll(ctx, opts)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while finding deleted programs")
+		return nil, fmt.Errorf("error occurred while finding deleted programs: %w", err)
+	}
+
+	// Map to response
+	var response []*pkg.ProgramResponse
+	for _, program := range programSlice {
+		response = append(response, &pkg.ProgramResponse{
+			ID:          program.ID,
+			Name:        program.Name,
+			Description: program.Description,
+			DeletedAt:   optionalTime(program.DeletedAt),
+		})
+	}
+	// Return result
+	return newPage(response, total, opts), nil
+}
+
 // FindEpisodes finds a program's episodes.
 // It takes the context and program UUID, and returns a slice of EpisodeResponse or an error.
 func (api programApi) FindEpisodes(ctx context.Context, uuid string) ([]*pkg.EpisodeResponse, error) {
@@ -224,7 +308,7 @@ func (api programApi) FindEpisodes(ctx context.Context, uuid string) ([]*pkg.Epi
 // It takes the context and program UUID, and returns a slice of TagResponse or an error.
 func (api programApi) FindTags(ctx context.Context, uuid string) ([]*pkg.TagResponse, error) {
 	// Call adapter
-	associations, err := api.programTagAdapter.FindByProgramID(ctx, uuid)
+	tags, err := api.tagAdapter.FindByProgramID(ctx, uuid)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while finding program tags")
 		return nil, fmt.Errorf("error occurred while finding program's tags: %w", err)
@@ -232,11 +316,7 @@ func (api programApi) FindTags(ctx context.Context, uuid string) ([]*pkg.TagResp
 
 	// Map to response
 	var response []*pkg.TagResponse
-	for _, association := range associations {
-		tag, err := api.tagAdapter.Find(ctx, association.TagID)
-		if err != nil {
-			return nil, err
-		}
+	for _, tag := range tags {
 		response = append(response, &pkg.TagResponse{
 			ID:          tag.ID,
 			Name:        tag.Name,
@@ -252,7 +332,7 @@ func (api programApi) FindTags(ctx context.Context, uuid string) ([]*pkg.TagResp
 // It takes the context and program UUID, and returns a slice of CategoryResponse or an error.
 func (api programApi) FindCats(ctx context.Context, uuid string) ([]*pkg.CategoryResponse, error) {
 	// Call adapter
-	associations, err := api.programCatAdapter.FindByProgramID(ctx, uuid)
+	cats, err := api.catAdapter.FindByProgramID(ctx, uuid)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while finding program categories")
 		return nil, fmt.Errorf("error occurred while finding program's categories: %w", err)
@@ -260,11 +340,7 @@ func (api programApi) FindCats(ctx context.Context, uuid string) ([]*pkg.Categor
 
 	// Map to response
 	var response []*pkg.CategoryResponse
-	for _, association := range associations {
-		cat, err := api.catAdapter.Find(ctx, association.CategoryID)
-		if err != nil {
-			return nil, err
-		}
+	for _, cat := range cats {
 		response = append(response, &pkg.CategoryResponse{
 			ID:          cat.ID,
 			Name:        cat.Name,
@@ -278,68 +354,215 @@ func (api programApi) FindCats(ctx context.Context, uuid string) ([]*pkg.Categor
 
 // OverwriteCategories overwrites the categories associated with a program.
 // It takes the context, program ID, and a slice of category IDs, and returns an error if any.
+// The existing associations are removed and the new ones created in a single transaction.
 func (api programApi) OverwriteCategories(ctx context.Context, programID string, catIDs []string) error {
-	// Find all existing associations by programID
-	associations, err := api.programCatAdapter.FindByProgramID(ctx, programID)
-	if err != nil {
-		return err
-	}
-
-	// Remove all existing associations for the program
-	for _, association := range associations {
-		if err = api.programCatAdapter.Delete(ctx, association.ID); err != nil {
+	return api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Find all existing associations by programID
+		associations, err := api.programCatAdapter.FindByProgramID(ctx, programID)
+		if err != nil {
 			return err
 		}
-	}
 
-	// Create all new associations
-	for _, catID := range catIDs {
-		programCategory := model.ProgramCategory{
-			ID:         uuid.New().String(),
-			ProgramID:  programID,
-			CategoryID: catID,
+		// Remove all existing associations for the program
+		for _, association := range associations {
+			if err = api.programCatAdapter.Delete(ctx, association.ID); err != nil {
+				return err
+			}
 		}
 
-		// Call adapter to create program category
-		if err := api.programCatAdapter.Create(ctx, programCategory); err != nil {
-			log.Ctx(ctx).Error().Err(err).Interface("programCategory", programCategory).Msg("error while creating program category")
-			return fmt.Errorf("error occurred while creating program category: %w", err)
+		// Create all new associations
+		for _, catID := range catIDs {
+			programCategory := model.ProgramCategory{
+				ID:         uuid.New().String(),
+				ProgramID:  programID,
+				CategoryID: catID,
+			}
+
+			// Call adapter to create program category
+			if err := api.programCatAdapter.Create(ctx, programCategory); err != nil {
+				log.Ctx(ctx).Error().Err(err).Interface("programCategory", programCategory).Msg("error while creating program category")
+				return fmt.Errorf("error occurred while creating program category: %w", err)
+			}
 		}
-	}
 
-	return nil
+		return nil
+	})
 }
 
 // OverwriteTags overwrites the tags associated with a program.
 // It takes the context, program ID, and a slice of tag IDs, and returns an error if any.
+// The existing associations are removed and the new ones created in a single transaction.
 func (api programApi) OverwriteTags(ctx context.Context, programID string, tagIDs []string) error {
-	// Find all existing associations by programID
-	associations, err := api.programTagAdapter.FindByProgramID(ctx, programID)
-	if err != nil {
-		return err
-	}
+	return api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Find all existing associations by programID
+		associations, err := api.programTagAdapter.FindByProgramID(ctx, programID)
+		if err != nil {
+			return err
+		}
+
+		// Remove all existing associations for the program
+		for _, association := range associations {
+			if err = api.programTagAdapter.Delete(ctx, association.ID); err != nil {
+				return err
+			}
+		}
+
+		// Create all new associations
+		for _, tagID := range tagIDs {
+			programTag := model.ProgramTag{
+				ID:        uuid.New().String(),
+				ProgramID: programID,
+				TagID:     tagID,
+			}
+
+			// Call adapter to create program tag
+			if err := api.programTagAdapter.Create(ctx, programTag); err != nil {
+				log.Ctx(ctx).Error().Err(err).Interface("programTag", programTag).Msg("error while creating program tag")
+				return fmt.Errorf("error occurred while creating program tag: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
 
-	// Remove all existing associations for the program
-	for _, association := range associations {
-		if err = api.programTagAdapter.Delete(ctx, association.ID); err != nil {
+// ReorderEpisodes reorders the episodes of a program as requested.
+// It takes the context, program ID, and ReorderRequest, and returns an error if any.
+// The positions are renumbered densely from 1 in a single transaction.
+func (api programApi) ReorderEpisodes(ctx context.Context, programID string, req ReorderRequest) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.programAdapter.Find(ctx, programID); err != nil {
+			return err
+		}
+		episodes, err := api.episodeAdapter.FindByProgramID(ctx, programID)
+		if err != nil {
 			return err
 		}
+		return renumber(episodes,
+			func(episode *model.Episode) string { return episode.ID },
+			func(episode *model.Episode) *int { return &episode.Position },
+			req,
+			func(episode *model.Episode) error {
+				return api.episodeAdapter.Update(ctx, episode.ID, model.Episode{Position: episode.Position})
+			},
+		)
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", programID).Interface("request", req).Msg("error while reordering program episodes")
+		return fmt.Errorf("error occurred while reordering program episodes: %w", err)
 	}
+	return nil
+}
 
-	// Create all new associations
-	for _, tagID := range tagIDs {
-		programTag := model.ProgramTag{
+// AddTag associates a tag with a program, doing nothing when they are already associated.
+// It takes the context, program ID and tag ID, and returns an error if any, model.ErrNotFound when either does not exist.
+func (api programApi) AddTag(ctx context.Context, programID string, tagID string) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.programAdapter.Find(ctx, programID); err != nil {
+			return err
+		}
+		if _, err := api.tagAdapter.Find(ctx, tagID); err != nil {
+			return err
+		}
+		associations, err := api.programTagAdapter.FindByTagIDAndProgramID(ctx, tagID, programID)
+		if err != nil || len(associations) > 0 {
+			return err
+		}
+		return associated(api.programTagAdapter.Create(ctx, model.ProgramTag{
 			ID:        uuid.New().String(),
 			ProgramID: programID,
 			TagID:     tagID,
+		}))
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", programID).Interface("tagID", tagID).Msg("error while adding program tag")
+		return fmt.Errorf("error occurred while adding program tag: %w", err)
+	}
+	return nil
+}
+
+// associated returns the error of the creation of an association, ignoring the model.ErrConflict of an association
+// created meanwhile by a concurrent request, so that adding an association stays idempotent.
+func associated(err error) error {
+	if errors.Is(err, model.ErrConflict) {
+		return nil
+	}
+	return err
+}
+
+// RemoveTag dissociates a tag from a program, doing nothing when they are not associated.
+// It takes the context, program ID and tag ID, and returns an error if any, model.ErrNotFound when the program does not exist.
+func (api programApi) RemoveTag(ctx context.Context, programID string, tagID string) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.programAdapter.Find(ctx, programID); err != nil {
+			return err
+		}
+		associations, err := api.programTagAdapter.FindByTagIDAndProgramID(ctx, tagID, programID)
+		if err != nil {
+			return err
 		}
+		for _, association := range associations {
+			if err := api.programTagAdapter.Delete(ctx, association.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", programID).Interface("tagID", tagID).Msg("error while removing program tag")
+		return fmt.Errorf("error occurred while removing program tag: %w", err)
+	}
+	return nil
+}
 
-		// Call adapter to create program tag
-		if err := api.programTagAdapter.Create(ctx, programTag); err != nil {
-			log.Ctx(ctx).Error().Err(err).Interface("programTag", programTag).Msg("error while creating program tag")
-			return fmt.Errorf("error occurred while creating program tag: %w", err)
+// AddCategory associates a category with a program, doing nothing when they are already associated.
+// It takes the context, program ID and category ID, and returns an error if any, model.ErrNotFound when either does not exist.
+func (api programApi) AddCategory(ctx context.Context, programID string, categoryID string) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.programAdapter.Find(ctx, programID); err != nil {
+			return err
+		}
+		if _, err := api.catAdapter.Find(ctx, categoryID); err != nil {
+			return err
+		}
+		associations, err := api.programCatAdapter.FindByCategoryIDAndProgramID(ctx, categoryID, programID)
+		if err != nil || len(associations) > 0 {
+			return err
 		}
+		return associated(api.programCatAdapter.Create(ctx, model.ProgramCategory{
+			ID:         uuid.New().String(),
+			ProgramID:  programID,
+			CategoryID: categoryID,
+		}))
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", programID).Interface("categoryID", categoryID).Msg("error while adding program category")
+		return fmt.Errorf("error occurred while adding program category: %w", err)
 	}
+	return nil
+}
 
+// RemoveCategory dissociates a category from a program, doing nothing when they are not associated.
+// It takes the context, program ID and category ID, and returns an error if any, model.ErrNotFound when the program does not exist.
+func (api programApi) RemoveCategory(ctx context.Context, programID string, categoryID string) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.programAdapter.Find(ctx, programID); err != nil {
+			return err
+		}
+		associations, err := api.programCatAdapter.FindByCategoryIDAndProgramID(ctx, categoryID, programID)
+		if err != nil {
+			return err
+		}
+		for _, association := range associations {
+			if err := api.programCatAdapter.Delete(ctx, association.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", programID).Interface("categoryID", categoryID).Msg("error while removing program category")
+		return fmt.Errorf("error occurred while removing program category: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,599 @@
+package api
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+// programFakes groups the fake persisters a program api is built from.
+type programFakes struct {
+	programs          *fakeProgramPersister
+	episodes          *fakeEpisodePersister
+	programTags       *fakeProgramTagPersister
+	tags              *fakeTagPersister
+	programCategories *fakeProgramCategoryPersister
+	categories        *fakeCategoryPersister
+}
+
+func newProgramFakes() programFakes {
+	fakes := programFakes{
+		programs: newFakeProgramPersister(
+			model.Program{ID: "p1", Name: "morning", Description: "morning show"},
+			model.Program{ID: "p2", Name: "evening", Description: "evening show"},
+		),
+		episodes: newFakeEpisodePersister(
+			model.Episode{ID: "e1", ProgramID: "p1", Name: "second", Position: 2},
+			model.Episode{ID: "e2", ProgramID: "p1", Name: "first", Position: 1},
+			model.Episode{ID: "e3", ProgramID: "p2", Name: "other", Position: 1},
+		),
+		programTags: newFakeProgramTagPersister(
+			model.ProgramTag{ID: "pt1", ProgramID: "p1", TagID: "t1"},
+			model.ProgramTag{ID: "pt2", ProgramID: "p1", TagID: "t2"},
+			model.ProgramTag{ID: "pt3", ProgramID: "p2", TagID: "t1"},
+		),
+		tags: newFakeTagPersister(
+			model.Tag{ID: "t1", Name: "news"},
+			model.Tag{ID: "t2", Name: "culture"},
+		),
+		programCategories: newFakeProgramCategoryPersister(
+			model.ProgramCategory{ID: "pc1", ProgramID: "p1", CategoryID: "c1"},
+			model.ProgramCategory{ID: "pc2", ProgramID: "p2", CategoryID: "c1"},
+		),
+		categories: newFakeCategoryPersister(
+			model.Category{ID: "c1", Name: "talk"},
+		),
+	}
+	fakes.tags.programTags = fakes.programTags
+	fakes.categories.programCategories = fakes.programCategories
+	return fakes
+}
+
+func (f programFakes) api() Program {
+	return NewProgramApi(f.programs, f.episodes, f.programTags, f.tags, f.programCategories, f.categories, newFakeTxManager(f.programTags, f.programCategories, f.episodes))
+}
+
+func TestProgramApi_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.CreateProgramRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+	}{
+		{
+			name: "creates the program",
+			req:  pkg.CreateProgramRequestJSON{NameJSON: "night", DescriptionJSON: "night show"},
+		},
+		{
+			name:       "requires name and description",
+			req:        pkg.CreateProgramRequestJSON{},
+			wantFields: []string{"name", "description"},
+		},
+		{
+			name:       "requires name",
+			req:        pkg.CreateProgramRequestJSON{DescriptionJSON: "night show"},
+			wantFields: []string{"name"},
+		},
+		{
+			name:    "wraps adapter failure",
+			req:     pkg.CreateProgramRequestJSON{NameJSON: "night", DescriptionJSON: "night show"},
+			failOn:  "Create",
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes := newProgramFakes()
+			if tt.failOn != "" {
+				fakes.programs.failOn(tt.failOn)
+			}
+
+			id, err := fakes.api().Create(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			created := fakes.programs.where(func(p model.Program) bool { return p.Name == "night" })
+			if wantCreated := err == nil; (len(created) == 1) != wantCreated {
+				t.Fatalf("got %d created programs, want created=%t", len(created), wantCreated)
+			}
+			if err == nil && created[0].ID != id {
+				t.Fatalf("got UUID %q, want the UUID of the created program %q", id, created[0].ID)
+			}
+		})
+	}
+}
+
+func TestProgramApi_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		uuid       string
+		req        pkg.UpdateProgramRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+		wantName   string
+	}{
+		{
+			name:     "updates the program",
+			uuid:     "p1",
+			req:      pkg.UpdateProgramRequestJSON{NameJSON: "early", DescriptionJSON: "early show"},
+			wantName: "early",
+		},
+		{
+			name:       "requires every field",
+			req:        pkg.UpdateProgramRequestJSON{},
+			wantFields: []string{"uuid", "name", "description"},
+			wantName:   "morning",
+		},
+		{
+			name:       "requires description",
+			uuid:       "p1",
+			req:        pkg.UpdateProgramRequestJSON{NameJSON: "early"},
+			wantFields: []string{"description"},
+			wantName:   "morning",
+		},
+		{
+			name:     "wraps adapter failure",
+			uuid:     "p1",
+			req:      pkg.UpdateProgramRequestJSON{NameJSON: "early", DescriptionJSON: "early show"},
+			failOn:   "Update",
+			wantErr:  errAdapter,
+			wantName: "morning",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes := newProgramFakes()
+			if tt.failOn != "" {
+				fakes.programs.failOn(tt.failOn)
+			}
+
+			err := fakes.api().Update(context.Background(), tt.uuid, tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if got := fakes.programs.find("p1").Name; got != tt.wantName {
+				t.Fatalf("got name %q, want %q", got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestProgramApi_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		fail    func(f programFakes)
+		call    func(api Program) ([]string, error)
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "finds a program",
+			call: func(api Program) ([]string, error) {
+				program, err := api.Find(context.Background(), "p1")
+				if err != nil {
+					return nil, err
+				}
+				return []string{program.ID}, nil
+			},
+			want: []string{"p1"},
+		},
+		{
+			name:    "wraps find failure",
+			fail:    func(f programFakes) { f.programs.failOn("Find") },
+			call:    func(api Program) ([]string, error) { _, err := api.Find(context.Background(), "p1"); return nil, err },
+			wantErr: errAdapter,
+		},
+		{
+			name:    "reports a missing program",
+			call:    func(api Program) ([]string, error) { _, err := api.Find(context.Background(), "p9"); return nil, err },
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "reports the deletion of a missing program",
+			call: func(api Program) ([]string, error) {
+				return nil, api.Delete(context.Background(), "p9", pkg.DeleteRequestJSON{})
+			},
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "finds all programs",
+			call: func(api Program) ([]string, error) {
+				programs, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				if err != nil {
+					return nil, err
+				}
+				var ids []string
+				for _, program := range programs.Items {
+					ids = append(ids, program.ID)
+				}
+				return ids, nil
+			},
+			want: []string{"p1", "p2"},
+		},
+		{
+			name: "wraps find all failure",
+			fail: func(f programFakes) { f.programs.failOn("FindAll") },
+			call: func(api Program) ([]string, error) {
+				_, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				return nil, err
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name: "wraps delete failure",
+			fail: func(f programFakes) { f.programs.failOn("Delete") },
+			call: func(api Program) ([]string, error) {
+				return nil, api.Delete(context.Background(), "p1", pkg.DeleteRequestJSON{CascadeJSON: true})
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name: "finds the episodes ordered by position",
+			call: func(api Program) ([]string, error) {
+				episodes, err := api.FindEpisodes(context.Background(), "p1")
+				var ids []string
+				for _, episode := range episodes {
+					ids = append(ids, episode.ID)
+				}
+				return ids, err
+			},
+			want: []string{"e2", "e1"},
+		},
+		{
+			name: "wraps episodes failure",
+			fail: func(f programFakes) { f.episodes.failOn("FindByProgramID") },
+			call: func(api Program) ([]string, error) {
+				_, err := api.FindEpisodes(context.Background(), "p1")
+				return nil, err
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name: "finds the tags",
+			call: func(api Program) ([]string, error) {
+				tags, err := api.FindTags(context.Background(), "p1")
+				var ids []string
+				for _, tag := range tags {
+					ids = append(ids, tag.ID)
+				}
+				return ids, err
+			},
+			want: []string{"t2", "t1"},
+		},
+		{
+			name: "wraps tags failure",
+			fail: func(f programFakes) { f.tags.failOn("FindByProgramID") },
+			call: func(api Program) ([]string, error) {
+				_, err := api.FindTags(context.Background(), "p1")
+				return nil, err
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name: "finds the categories",
+			call: func(api Program) ([]string, error) {
+				categories, err := api.FindCats(context.Background(), "p1")
+				var ids []string
+				for _, category := range categories {
+					ids = append(ids, category.ID)
+				}
+				return ids, err
+			},
+			want: []string{"c1"},
+		},
+		{
+			name: "wraps categories failure",
+			fail: func(f programFakes) { f.categories.failOn("FindByProgramID") },
+			call: func(api Program) ([]string, error) {
+				_, err := api.FindCats(context.Background(), "p1")
+				return nil, err
+			},
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes := newProgramFakes()
+			if tt.fail != nil {
+				tt.fail(fakes)
+			}
+
+			got, err := tt.call(fakes.api())
+
+			assertError(t, err, nil, tt.wantErr)
+			if !equalStrings(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgramApi_OverwriteTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tagIDs   []string
+		failOn   string
+		wantErr  error
+		wantTags []string
+	}{
+		{
+			name:     "replaces the program tags",
+			tagIDs:   []string{"t2", "t3"},
+			wantTags: []string{"t2", "t3"},
+		},
+		{
+			name:     "clears the program tags",
+			tagIDs:   nil,
+			wantTags: nil,
+		},
+		{
+			name:     "fails when associations cannot be read",
+			tagIDs:   []string{"t3"},
+			failOn:   "FindByProgramID",
+			wantErr:  errAdapter,
+			wantTags: []string{"t1", "t2"},
+		},
+		{
+			name:     "fails when an association cannot be removed",
+			tagIDs:   []string{"t3"},
+			failOn:   "Delete",
+			wantErr:  errAdapter,
+			wantTags: []string{"t1", "t2"},
+		},
+		{
+			name:     "rolls back when an association cannot be created",
+			tagIDs:   []string{"t3"},
+			failOn:   "Create",
+			wantErr:  errAdapter,
+			wantTags: []string{"t1", "t2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes := newProgramFakes()
+			if tt.failOn != "" {
+				fakes.programTags.failOn(tt.failOn)
+			}
+
+			err := fakes.api().OverwriteTags(context.Background(), "p1", tt.tagIDs)
+
+			assertError(t, err, nil, tt.wantErr)
+			var got []string
+			for _, programTag := range fakes.programTags.where(func(pt model.ProgramTag) bool { return pt.ProgramID == "p1" }) {
+				got = append(got, programTag.TagID)
+			}
+			sort.Strings(got)
+			if !equalStrings(got, tt.wantTags) {
+				t.Fatalf("got tags %v, want %v", got, tt.wantTags)
+			}
+			if len(fakes.programTags.where(func(pt model.ProgramTag) bool { return pt.ProgramID == "p2" })) != 1 {
+				t.Fatal("tags of another program were rewritten")
+			}
+		})
+	}
+}
+
+func TestProgramApi_OverwriteCategories(t *testing.T) {
+	tests := []struct {
+		name           string
+		categoryIDs    []string
+		failOn         string
+		wantErr        error
+		wantCategories []string
+	}{
+		{
+			name:           "replaces the program categories",
+			categoryIDs:    []string{"c2", "c3"},
+			wantCategories: []string{"c2", "c3"},
+		},
+		{
+			name:           "clears the program categories",
+			categoryIDs:    nil,
+			wantCategories: nil,
+		},
+		{
+			name:           "fails when associations cannot be read",
+			categoryIDs:    []string{"c2"},
+			failOn:         "FindByProgramID",
+			wantErr:        errAdapter,
+			wantCategories: []string{"c1"},
+		},
+		{
+			name:           "fails when an association cannot be removed",
+			categoryIDs:    []string{"c2"},
+			failOn:         "Delete",
+			wantErr:        errAdapter,
+			wantCategories: []string{"c1"},
+		},
+		{
+			name:           "rolls back when an association cannot be created",
+			categoryIDs:    []string{"c2"},
+			failOn:         "Create",
+			wantErr:        errAdapter,
+			wantCategories: []string{"c1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes := newProgramFakes()
+			if tt.failOn != "" {
+				fakes.programCategories.failOn(tt.failOn)
+			}
+
+			err := fakes.api().OverwriteCategories(context.Background(), "p1", tt.categoryIDs)
+
+			assertError(t, err, nil, tt.wantErr)
+			var got []string
+			for _, programCategory := range fakes.programCategories.where(func(pc model.ProgramCategory) bool { return pc.ProgramID == "p1" }) {
+				got = append(got, programCategory.CategoryID)
+			}
+			sort.Strings(got)
+			if !equalStrings(got, tt.wantCategories) {
+				t.Fatalf("got categories %v, want %v", got, tt.wantCategories)
+			}
+			if len(fakes.programCategories.where(func(pc model.ProgramCategory) bool { return pc.ProgramID == "p2" })) != 1 {
+				t.Fatal("categories of another program were rewritten")
+			}
+		})
+	}
+}
+
+func TestProgramApi_ReorderEpisodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.ReorderRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+		wantOrder  []string
+	}{
+		{
+			name:      "reorders the listed episodes",
+			req:       pkg.ReorderRequestJSON{IDsJSON: []string{"e1", "e2"}},
+			wantOrder: []string{"e1", "e2"},
+		},
+		{
+			name:      "moves an episode",
+			req:       pkg.ReorderRequestJSON{FromJSON: 2, ToJSON: 1},
+			wantOrder: []string{"e1", "e2"},
+		},
+		{
+			name:       "rejects the episodes of another program",
+			req:        pkg.ReorderRequestJSON{IDsJSON: []string{"e1", "e3"}},
+			wantFields: []string{"ids"},
+			wantOrder:  []string{"e2", "e1"},
+		},
+		{
+			name:      "rolls back when a position cannot be saved",
+			req:       pkg.ReorderRequestJSON{IDsJSON: []string{"e1", "e2"}},
+			failOn:    "Update",
+			wantErr:   errAdapter,
+			wantOrder: []string{"e2", "e1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes := newProgramFakes()
+			if tt.failOn != "" {
+				fakes.episodes.failOn(tt.failOn)
+			}
+
+			err := fakes.api().ReorderEpisodes(context.Background(), "p1", tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			episodes := fakes.episodes.where(func(e model.Episode) bool { return e.ProgramID == "p1" })
+			sort.Slice(episodes, func(i, j int) bool { return episodes[i].Position < episodes[j].Position })
+			var got []string
+			for i, episode := range episodes {
+				got = append(got, episode.ID)
+				if episode.Position != i+1 {
+					t.Fatalf("episode %s has position %d, want %d", episode.ID, episode.Position, i+1)
+				}
+			}
+			if !equalStrings(got, tt.wantOrder) {
+				t.Fatalf("got episodes %v, want %v", got, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestProgramApi_AddAndRemoveTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		remove   bool
+		program  string
+		tag      string
+		failOn   string
+		failWith error
+		wantErr  error
+		wantTags []string
+	}{
+		{
+			name:     "adds a tag",
+			program:  "p2",
+			tag:      "t2",
+			wantTags: []string{"t1", "t2"},
+		},
+		{
+			name:     "adding a tag twice does nothing",
+			program:  "p1",
+			tag:      "t1",
+			wantTags: []string{"t1", "t2"},
+		},
+		{
+			name:     "refuses an unknown tag",
+			program:  "p2",
+			tag:      "unknown",
+			wantErr:  model.ErrNotFound,
+			wantTags: []string{"t1"},
+		},
+		{
+			name:     "removes a tag",
+			remove:   true,
+			program:  "p1",
+			tag:      "t1",
+			wantTags: []string{"t2"},
+		},
+		{
+			name:     "removing a missing tag does nothing",
+			remove:   true,
+			program:  "p2",
+			tag:      "t2",
+			wantTags: []string{"t1"},
+		},
+		{
+			name:    "refuses an unknown program",
+			remove:  true,
+			program: "unknown",
+			tag:     "t1",
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name:     "fails when the association cannot be created",
+			program:  "p2",
+			tag:      "t2",
+			failOn:   "Create",
+			wantErr:  errAdapter,
+			wantTags: []string{"t1"},
+		},
+		{
+			name:     "adding a tag associated meanwhile does nothing",
+			program:  "p2",
+			tag:      "t2",
+			failOn:   "Create",
+			failWith: model.ErrConflict,
+			wantTags: []string{"t1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes := newProgramFakes()
+			if tt.failOn != "" {
+				fakes.programTags.failOn(tt.failOn)
+			}
+			if tt.failWith != nil {
+				fakes.programTags.errs[tt.failOn] = tt.failWith
+			}
+
+			var err error
+			if tt.remove {
+				err = fakes.api().RemoveTag(context.Background(), tt.program, tt.tag)
+			} else {
+				err = fakes.api().AddTag(context.Background(), tt.program, tt.tag)
+			}
+
+			assertError(t, err, nil, tt.wantErr)
+			var got []string
+			for _, programTag := range fakes.programTags.where(func(pt model.ProgramTag) bool { return pt.ProgramID == tt.program }) {
+				got = append(got, programTag.TagID)
+			}
+			sort.Strings(got)
+			if !equalStrings(got, tt.wantTags) {
+				t.Fatalf("got tags %v, want %v", got, tt.wantTags)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Package api provides the reordering of the positioned items of a collection.
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// ReorderRequest represents the interface for reordering the items of a collection, either by listing the IDs of
+// every item in their new order, or by moving the item at a position to another, positions starting at 1.
+type ReorderRequest interface {
+	IDs() []string
+	From() int
+	To() int
+}
+
+// reorder validates the request against the IDs of the items in their current order.
+// It returns the IDs in their new order, their position being their index plus one, or the ValidationErrors of the request.
+func reorder(current []string, req ReorderRequest) ([]string, model.ValidationErrors) {
+	var vErrs []model.ValidationError
+	if len(req.IDs()) > 0 {
+		if req.From() != 0 || req.To() != 0 {
+			return nil, append(vErrs, model.ValidationError{Field: "ids", Message: "cannot be combined with from and to"})
+		}
+		ids := slices.Clone(req.IDs())
+		slices.Sort(ids)
+		if len(slices.Compact(ids)) != len(req.IDs()) {
+			return nil, append(vErrs, model.ValidationError{Field: "ids", Message: "contains duplicates"})
+		}
+		sorted := slices.Clone(current)
+		slices.Sort(sorted)
+		if !slices.Equal(ids, sorted) {
+			return nil, append(vErrs, model.ValidationError{Field: "ids", Message: "must list every item of the collection exactly once"})
+		}
+		return req.IDs(), nil
+	}
+
+	if req.From() == 0 && req.To() == 0 {
+		return nil, append(vErrs, model.ValidationError{Field: "ids", Message: "is required unless from and to are given"})
+	}
+	if req.From() < 1 || req.From() > len(current) {
+		vErrs = append(vErrs, model.ValidationError{Field: "from", Message: fmt.Sprintf("must be between 1 and %d", len(current))})
+	}
+	if req.To() < 1 || req.To() > len(current) {
+		vErrs = append(vErrs, model.ValidationError{Field: "to", Message: fmt.Sprintf("must be between 1 and %d", len(current))})
+	}
+	if len(vErrs) > 0 {
+		return nil, vErrs
+	}
+	moved := current[req.From()-1]
+	ordered := slices.Delete(slices.Clone(current), req.From()-1, req.From())
+	return slices.Insert(ordered, req.To()-1, moved), nil
+}
+
+// renumber reorders the items, listed in their current order, as requested, and saves with update every item
+// whose position changed. Positions are renumbered densely from 1, which also closes the gaps and duplicates left
+// by earlier updates.
+func renumber[T any](items []*T, id func(*T) string, position func(*T) *int, req ReorderRequest, update func(*T) error) error {
+	current := make([]string, 0, len(items))
+	byID := make(map[string]*T, len(items))
+	for _, item := range items {
+		current = append(current, id(item))
+		byID[id(item)] = item
+	}
+	ordered, vErrs := reorder(current, req)
+	if len(vErrs) > 0 {
+		return vErrs
+	}
+	orderedItems := make([]*T, 0, len(ordered))
+	for _, itemID := range ordered {
+		orderedItems = append(orderedItems, byID[itemID])
+	}
+	return closeGaps(orderedItems, position, update)
+}
+
+// closeGaps renumbers the items densely from 1 in the given order, and saves with update every item whose position changed.
+func closeGaps[T any](items []*T, position func(*T) *int, update func(*T) error) error {
+	for i, item := range items {
+		if *position(item) == i+1 {
+			continue
+		}
+		*position(item) = i + 1
+		if err := update(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// liveAssociations keeps the associations whose target, identified by target, is found by findByIDs,
+// which skips the entities in the trash, so that the hidden associations are neither listed nor renumbered.
+func liveAssociations[T, E any](ctx context.Context, associations []*T, target func(*T) string, findByIDs func(context.Context, []string) ([]*E, error), id func(*E) string) ([]*T, error) {
+	var ids []string
+	for _, association := range associations {
+		ids = append(ids, target(association))
+	}
+	entities, err := findByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[string]bool, len(entities))
+	for _, entity := range entities {
+		live[id(entity)] = true
+	}
+	var kept []*T
+	for _, association := range associations {
+		if live[target(association)] {
+			kept = append(kept, association)
+		}
+	}
+	return kept, nil
+}
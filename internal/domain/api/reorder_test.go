@@ -0,0 +1,81 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+func TestReorder(t *testing.T) {
+	current := []string{"a", "b", "c", "d"}
+	tests := []struct {
+		name       string
+		req        pkg.ReorderRequestJSON
+		want       []string
+		wantFields []string
+	}{
+		{
+			name: "orders the items as listed",
+			req:  pkg.ReorderRequestJSON{IDsJSON: []string{"d", "a", "c", "b"}},
+			want: []string{"d", "a", "c", "b"},
+		},
+		{
+			name: "moves an item down",
+			req:  pkg.ReorderRequestJSON{FromJSON: 1, ToJSON: 3},
+			want: []string{"b", "c", "a", "d"},
+		},
+		{
+			name: "moves an item up",
+			req:  pkg.ReorderRequestJSON{FromJSON: 4, ToJSON: 2},
+			want: []string{"a", "d", "b", "c"},
+		},
+		{
+			name:       "rejects duplicates",
+			req:        pkg.ReorderRequestJSON{IDsJSON: []string{"a", "b", "b", "c", "d"}},
+			wantFields: []string{"ids"},
+		},
+		{
+			name:       "rejects a partial list",
+			req:        pkg.ReorderRequestJSON{IDsJSON: []string{"a", "b", "c"}},
+			wantFields: []string{"ids"},
+		},
+		{
+			name:       "rejects unknown items",
+			req:        pkg.ReorderRequestJSON{IDsJSON: []string{"a", "b", "c", "e"}},
+			wantFields: []string{"ids"},
+		},
+		{
+			name:       "rejects a list combined with a move",
+			req:        pkg.ReorderRequestJSON{IDsJSON: []string{"d", "c", "b", "a"}, FromJSON: 1, ToJSON: 2},
+			wantFields: []string{"ids"},
+		},
+		{
+			name:       "requires a list or a move",
+			wantFields: []string{"ids"},
+		},
+		{
+			name:       "rejects positions out of range",
+			req:        pkg.ReorderRequestJSON{FromJSON: 5, ToJSON: -1},
+			wantFields: []string{"from", "to"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, vErrs := reorder(current, tt.req)
+
+			var gotFields []string
+			for _, vErr := range vErrs {
+				gotFields = append(gotFields, vErr.Field)
+			}
+			if !equalStrings(gotFields, tt.wantFields) {
+				t.Fatalf("got invalid fields %v, want %v", gotFields, tt.wantFields)
+			}
+			if !equalStrings(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if !equalStrings(current, []string{"a", "b", "c", "d"}) {
+				t.Fatalf("current order was altered: %v", current)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Package api provides functionality for searching the catalogue.
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"github.com/rs/zerolog/log"
+)
+
+// Search represents the interface for searching the catalogue.
+type Search interface {
+	Search(ctx context.Context, req SearchRequest) (*pkg.SearchResponse, error)
+}
+
+// searchApi is an implementation of the Search interface.
+type searchApi struct {
+	searcher port.Searcher
+}
+
+// NewSearchApi creates a new instance of Search.
+// It takes the searcher as dependency.
+func NewSearchApi(searcher port.Searcher) Search {
+	return &searchApi{
+		searcher: searcher,
+	}
+}
+
+// Search finds the programs, episodes, tags and categories matching the query.
+// It takes the context and SearchRequest, and returns a SearchResponse or an error.
+func (api searchApi) Search(ctx context.Context, req SearchRequest) (*pkg.SearchResponse, error) {
+	// Validate request
+	vErrs := searchRequestValidation(ctx, req)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	query := strings.TrimSpace(req.Query())
+	limit := req.Limit()
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+
+	// Call adapter
+	hits, err := api.searcher.Search(ctx, query, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("query", query).Msg("error while searching")
+		return nil, fmt.Errorf("error occurred while searching: %w", err)
+	}
+
+	// Map to response, grouping hits by kind
+	response := &pkg.SearchResponse{
+		Query:      query,
+		Programs:   []*pkg.SearchHitResponse{},
+		Episodes:   []*pkg.SearchHitResponse{},
+		Tags:       []*pkg.SearchHitResponse{},
+		Categories: []*pkg.SearchHitResponse{},
+	}
+	for _, hit := range hits {
+		hitResponse := &pkg.SearchHitResponse{
+			ID:          hit.ID,
+			Kind:        hit.Kind,
+			Name:        hit.Name,
+			Description: hit.Description,
+			Score:       hit.Score,
+		}
+		switch hit.Kind {
+		case model.SearchKindProgram:
+			response.Programs = append(response.Programs, hitResponse)
+		case model.SearchKindEpisode:
+			response.Episodes = append(response.Episodes, hitResponse)
+		case model.SearchKindTag:
+			response.Tags = append(response.Tags, hitResponse)
+		case model.SearchKindCategory:
+			response.Categories = append(response.Categories, hitResponse)
+		}
+	}
+
+	// Return result
+	return response, nil
+}
+
+// searchRequestValidation validates the search request.
+// It takes the context and SearchRequest, and returns a slice of ValidationErrors.
+func searchRequestValidation(ctx context.Context, req SearchRequest) model.ValidationErrors {
+	var vErrs []model.ValidationError
+	if strings.TrimSpace(req.Query()) == "" {
+		vErrs = append(vErrs, model.ValidationError{Field: "q", Message: "is required"})
+	}
+	if req.Limit() < 0 || req.Limit() > maxPageLimit {
+		vErrs = append(vErrs, model.ValidationError{Field: "limit", Message: fmt.Sprintf("must be between 0 and %d (0 for the default)", maxPageLimit)})
+	}
+	return vErrs
+}
+
+// SearchRequest represents the interface for searching the catalogue.
+type SearchRequest interface {
+	Query() string
+	Limit() int
+}
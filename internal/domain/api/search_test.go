@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+// fakeSearcher returns canned hits and records the query it was given.
+type fakeSearcher struct {
+	hits  []*model.SearchHit
+	err   error
+	query string
+	limit int
+}
+
+func (f *fakeSearcher) Search(_ context.Context, query string, limit int) ([]*model.SearchHit, error) {
+	f.query, f.limit = query, limit
+	return f.hits, f.err
+}
+
+func TestSearchApi_Search(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.SearchRequestJSON
+		err        error
+		wantFields []string
+		wantErr    error
+		wantQuery  string
+		wantLimit  int
+	}{
+		{
+			name:      "groups the hits by kind",
+			req:       pkg.SearchRequestJSON{QueryJSON: "  news  "},
+			wantQuery: "news",
+			wantLimit: defaultPageLimit,
+		},
+		{
+			name:      "passes the limit to the searcher",
+			req:       pkg.SearchRequestJSON{QueryJSON: "news", LimitJSON: 5},
+			wantQuery: "news",
+			wantLimit: 5,
+		},
+		{
+			name:       "requires a query and a limit in range",
+			req:        pkg.SearchRequestJSON{QueryJSON: " ", LimitJSON: maxPageLimit + 1},
+			wantFields: []string{"q", "limit"},
+		},
+		{
+			name:    "wraps searcher failure",
+			req:     pkg.SearchRequestJSON{QueryJSON: "news"},
+			err:     errAdapter,
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searcher := &fakeSearcher{
+				hits: []*model.SearchHit{
+					{Kind: model.SearchKindProgram, ID: "p1", Score: 2},
+					{Kind: model.SearchKindEpisode, ID: "e1", Score: 3},
+					{Kind: model.SearchKindProgram, ID: "p2", Score: 1},
+					{Kind: model.SearchKindTag, ID: "t1", Score: 1},
+				},
+				err: tt.err,
+			}
+
+			res, err := NewSearchApi(searcher).Search(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if err != nil {
+				return
+			}
+			if searcher.query != tt.wantQuery || searcher.limit != tt.wantLimit {
+				t.Fatalf("searched %q with limit %d, want %q with limit %d", searcher.query, searcher.limit, tt.wantQuery, tt.wantLimit)
+			}
+			ids := func(hits []*pkg.SearchHitResponse) []string {
+				var ids []string
+				for _, hit := range hits {
+					ids = append(ids, hit.ID)
+				}
+				return ids
+			}
+			if !equalStrings(ids(res.Programs), []string{"p1", "p2"}) || !equalStrings(ids(res.Episodes), []string{"e1"}) ||
+				!equalStrings(ids(res.Tags), []string{"t1"}) || res.Categories == nil || len(res.Categories) != 0 {
+				t.Fatalf("unexpected grouping: %+v", res)
+			}
+		})
+	}
+}
@@ -25,39 +25,43 @@ type UpdateTagRequest interface {
 
 // Tag represents the interface for managing tags.
 type Tag interface {
-	Create(ctx context.Context, tag CreateTagRequest) error
+	Create(ctx context.Context, tag CreateTagRequest) (string, error)
 	Update(ctx context.Context, uuid string, updates UpdateTagRequest) error
 	Find(ctx context.Context, uuid string) (*pkg.TagResponse, error)
-	FindAll(ctx context.Context) ([]*pkg.TagResponse, error)
-	Delete(ctx context.Context, uuid string) error
+	FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.TagResponse], error)
+	Delete(ctx context.Context, uuid string, req DeleteRequest) error
+	Restore(ctx context.Context, uuid string) error
+	FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.TagResponse], error)
 	FindPrograms(ctx context.Context, uuid string) ([]*pkg.ProgramResponse, error)
 }
 
 // tagApi is an implementation of the Tag interface.
 type tagApi struct {
 	tagAdapter        port.TagPersister
-	programTagAdapter port.ProgramTagPersister
 	programAdapter    port.ProgramPersister
+	programTagAdapter port.ProgramTagPersister
+	txManager         port.TxManager
 }
 
 // NewTagApi creates a new instance of Tag.
-// It takes adapters for tag, program-tag, and program persistence as dependencies.
-func NewTagApi(tagAdapter port.TagPersister, programTagAdapter port.ProgramTagPersister, programAdapter port.ProgramPersister) Tag {
+// It takes adapters for tag, program and program-tag association persistence, and txManager as dependencies.
+func NewTagApi(tagAdapter port.TagPersister, programAdapter port.ProgramPersister, programTagAdapter port.ProgramTagPersister, txManager port.TxManager) Tag {
 	return &tagApi{
 		tagAdapter:        tagAdapter,
-		programTagAdapter: programTagAdapter,
 		programAdapter:    programAdapter,
+		programTagAdapter: programTagAdapter,
+		txManager:         txManager,
 	}
 }
 
 // Create creates a new tag.
-// It takes the context and CreateTagRequest, and returns an error if any.
-func (api tagApi) Create(ctx context.Context, req CreateTagRequest) error {
+// It takes the context and CreateTagRequest, and returns the UUID of the new tag or an error.
+func (api tagApi) Create(ctx context.Context, req CreateTagRequest) (string, error) {
 	// Validate request
 	vErrs := createTagRequestValidation(ctx, req)
 	if len(vErrs) > 0 {
 		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
-		return fmt.Errorf("request was not validated: %w", vErrs)
+		return "", fmt.Errorf("request was not validated: %w", vErrs)
 	}
 	// Map to domain model
 	tag := model.Tag{
@@ -68,10 +72,10 @@ func (api tagApi) Create(ctx context.Context, req CreateTagRequest) error {
 	// Call adapter
 	if err := api.tagAdapter.Create(ctx, tag); err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("tag", tag).Msg("error while creating tag")
-		return fmt.Errorf("error occurred while creating tag: %w", err)
+		return "", fmt.Errorf("error occurred while creating tag: %w", err)
 	}
 
-	return nil
+	return tag.ID, nil
 }
 
 // createTagRequestValidation validates the creation request.
@@ -146,11 +150,22 @@ func (api tagApi) Find(ctx context.Context, uuid string) (*pkg.TagResponse, erro
 	return response, nil
 }
 
-// FindAll finds all tags.
-// It takes the context and returns a slice of TagResponse or an error.
-func (api tagApi) FindAll(ctx context.Context) ([]*pkg.TagResponse, error) {
+// tagListing describes how tags can be sorted and filtered.
+var tagListing = listing{named: true, sortable: []string{model.SortByName, model.SortByCreatedAt}}
+
+// FindAll finds a page of tags.
+// It takes the context and ListRequest, and returns a page of TagResponse or an error.
+func (api tagApi) FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.TagResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, tagListing)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := listOptions(req)
+
 	// Call adapter
-	tagSlice, err := api.tagAdapter.FindAll(ctx)
+	tagSlice, total, err := api.tagAdapter.FindAll(ctx, opts)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while finding tags")
 		return nil, fmt.Errorf("error occurred while finding tags: %w", err)
@@ -166,34 +181,102 @@ func (api tagApi) FindAll(ctx context.Context) ([]*pkg.TagResponse, error) {
 		})
 	}
 	// Return result
-	return response, nil
+	return newPage(response, total, opts), nil
 }
 
-// Delete deletes a tag by UUID.
-// It takes the context and tag UUID, and returns an error if any.
-func (api tagApi) Delete(ctx context.Context, uuid string) error {
-	if err := api.tagAdapter.Delete(ctx, uuid); err != nil {
+// Delete moves a tag to the trash by UUID.
+// A tag still attached to programs is refused with a model.DependentsError listing them,
+// unless the request cascades, in which case the tag is detached from the programs first.
+// It takes the context, tag UUID and DeleteRequest, and returns an error if any.
+func (api tagApi) Delete(ctx context.Context, uuid string, req DeleteRequest) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if req.Cascade() {
+			// Detach the tag from the programs
+			associations, err := api.programTagAdapter.FindByTagID(ctx, uuid)
+			if err != nil {
+				return err
+			}
+			for _, association := range associations {
+				if err := api.programTagAdapter.Delete(ctx, association.ID); err != nil {
+					return err
+				}
+			}
+		} else {
+			// Refuse while live programs are still attached to the tag
+			programs, err := api.programAdapter.FindByTagID(ctx, uuid)
+			if err != nil {
+				return err
+			}
+			var dependents []model.Dependent
+			for _, program := range programs {
+				dependents = append(dependents, model.Dependent{Entity: model.AuditEntityProgram, ID: program.ID, Name: program.Name})
+			}
+			if err := dependentsError(model.AuditEntityTag, uuid, dependents); err != nil {
+				return err
+			}
+		}
+
+		return api.tagAdapter.Delete(ctx, uuid)
+	})
+	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while deleting tag")
 		return fmt.Errorf("error occurred while deleting tag: %w", err)
 	}
 	return nil
 }
 
+// Restore restores a tag from the trash by UUID.
+// It takes the context and tag UUID, and returns an error if any.
+func (api tagApi) Restore(ctx context.Context, uuid string) error {
+	if err := api.tagAdapter.Restore(ctx, uuid); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while restoring tag")
+		return fmt.Errorf("error occurred while restoring tag: %w", err)
+	}
+	return nil
+}
+
+// FindDeleted finds a page of the tags in the trash, the most recently deleted first unless sorted otherwise.
+// It takes the context and ListRequest, and returns a page of TagResponse or an error.
+func (api tagApi) FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.TagResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, tagListing.trash())
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := trashOptions(req)
+
+	// Call adapter
+	tagSlice, total, err := api.tagAdapter.FindAll(ctx, opts)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while finding deleted tags")
+		return nil, fmt.Errorf("error occurred while finding deleted tags: %w", err)
+	}
+
+	// Map to response
+	var response []*pkg.TagResponse
+	for _, tag := range tagSlice {
+		response = append(response, &pkg.TagResponse{
+			ID:          tag.ID,
+			Name:        tag.Name,
+			Description: tag.Description,
+			DeletedAt:   optionalTime(tag.DeletedAt),
+		})
+	}
+	// Return result
+	return newPage(response, total, opts), nil
+}
+
 // FindPrograms finds programs associated with a tag.
 // It takes the context and tag UUID, and returns a slice of ProgramResponse or an error.
 func (api tagApi) FindPrograms(ctx context.Context, uuid string) ([]*pkg.ProgramResponse, error) {
-	associations, err := api.programTagAdapter.FindByTagID(ctx, uuid)
+	programs, err := api.programAdapter.FindByTagID(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
 
 	var response []*pkg.ProgramResponse
-	for _, association := range associations {
-		program, err := api.programAdapter.Find(ctx, association.ProgramID)
-		if err != nil {
-			return nil, err
-		}
-
+	for _, program := range programs {
 		response = append(response, &pkg.ProgramResponse{
 			ID:          program.ID,
 			Name:        program.Name,
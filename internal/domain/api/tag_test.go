@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+func TestTagApi_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.CreateTagRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+	}{
+		{
+			name: "creates the tag",
+			req:  pkg.CreateTagRequestJSON{NameJSON: "news", DescriptionJSON: "daily news"},
+		},
+		{
+			name:       "requires name and description",
+			req:        pkg.CreateTagRequestJSON{},
+			wantFields: []string{"name", "description"},
+		},
+		{
+			name:    "wraps adapter failure",
+			req:     pkg.CreateTagRequestJSON{NameJSON: "news", DescriptionJSON: "daily news"},
+			failOn:  "Create",
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := newFakeTagPersister()
+			if tt.failOn != "" {
+				tags.failOn(tt.failOn)
+			}
+			api := NewTagApi(tags, newFakeProgramPersister(), newFakeProgramTagPersister(), newFakeTxManager())
+
+			id, err := api.Create(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if err == nil {
+				if len(tags.rows) != 1 || tags.rows[0].ID != id || tags.rows[0].Name != "news" {
+					t.Fatalf("unexpected stored tags: %+v", tags.rows)
+				}
+			}
+		})
+	}
+}
+
+func TestTagApi_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		uuid       string
+		req        pkg.UpdateTagRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+		wantName   string
+	}{
+		{
+			name:     "updates the tag",
+			uuid:     "t1",
+			req:      pkg.UpdateTagRequestJSON{NameJSON: "politics", DescriptionJSON: "politics news"},
+			wantName: "politics",
+		},
+		{
+			name:       "requires every field",
+			req:        pkg.UpdateTagRequestJSON{},
+			wantFields: []string{"uuid", "name", "description"},
+			wantName:   "news",
+		},
+		{
+			name:     "wraps adapter failure",
+			uuid:     "t1",
+			req:      pkg.UpdateTagRequestJSON{NameJSON: "politics", DescriptionJSON: "politics news"},
+			failOn:   "Update",
+			wantErr:  errAdapter,
+			wantName: "news",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := newFakeTagPersister(model.Tag{ID: "t1", Name: "news", Description: "daily news"})
+			if tt.failOn != "" {
+				tags.failOn(tt.failOn)
+			}
+			api := NewTagApi(tags, newFakeProgramPersister(), newFakeProgramTagPersister(), newFakeTxManager())
+
+			err := api.Update(context.Background(), tt.uuid, tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if got := tags.rows[0].Name; got != tt.wantName {
+				t.Fatalf("got name %q, want %q", got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestTagApi_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		fail    func(tags *fakeTagPersister, programTags *fakeProgramTagPersister, programs *fakeProgramPersister)
+		call    func(api Tag) ([]string, error)
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "finds a tag",
+			call: func(api Tag) ([]string, error) {
+				tag, err := api.Find(context.Background(), "t1")
+				if err != nil {
+					return nil, err
+				}
+				return []string{tag.ID}, nil
+			},
+			want: []string{"t1"},
+		},
+		{
+			name: "wraps find failure",
+			fail: func(tags *fakeTagPersister, _ *fakeProgramTagPersister, _ *fakeProgramPersister) {
+				tags.failOn("Find")
+			},
+			call:    func(api Tag) ([]string, error) { _, err := api.Find(context.Background(), "t1"); return nil, err },
+			wantErr: errAdapter,
+		},
+		{
+			name:    "reports a missing tag",
+			call:    func(api Tag) ([]string, error) { _, err := api.Find(context.Background(), "t9"); return nil, err },
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "reports the deletion of a missing tag",
+			call: func(api Tag) ([]string, error) {
+				return nil, api.Delete(context.Background(), "t9", pkg.DeleteRequestJSON{})
+			},
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "finds all tags",
+			call: func(api Tag) ([]string, error) {
+				tags, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				if err != nil {
+					return nil, err
+				}
+				var ids []string
+				for _, tag := range tags.Items {
+					ids = append(ids, tag.ID)
+				}
+				return ids, nil
+			},
+			want: []string{"t1", "t2"},
+		},
+		{
+			name: "wraps find all failure",
+			fail: func(tags *fakeTagPersister, _ *fakeProgramTagPersister, _ *fakeProgramPersister) {
+				tags.failOn("FindAll")
+			},
+			call: func(api Tag) ([]string, error) {
+				_, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				return nil, err
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name: "wraps delete failure",
+			fail: func(tags *fakeTagPersister, _ *fakeProgramTagPersister, _ *fakeProgramPersister) {
+				tags.failOn("Delete")
+			},
+			call: func(api Tag) ([]string, error) {
+				return nil, api.Delete(context.Background(), "t1", pkg.DeleteRequestJSON{CascadeJSON: true})
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name: "finds the tagged programs",
+			call: func(api Tag) ([]string, error) {
+				programs, err := api.FindPrograms(context.Background(), "t1")
+				var ids []string
+				for _, program := range programs {
+					ids = append(ids, program.ID)
+				}
+				return ids, err
+			},
+			want: []string{"p2", "p1"},
+		},
+		{
+			name: "fails when the tagged programs cannot be read",
+			fail: func(_ *fakeTagPersister, _ *fakeProgramTagPersister, programs *fakeProgramPersister) {
+				programs.failOn("FindByTagID")
+			},
+			call: func(api Tag) ([]string, error) {
+				_, err := api.FindPrograms(context.Background(), "t1")
+				return nil, err
+			},
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := newFakeTagPersister(model.Tag{ID: "t1", Name: "news"}, model.Tag{ID: "t2", Name: "culture"})
+			programTags := newFakeProgramTagPersister(
+				model.ProgramTag{ID: "pt1", ProgramID: "p1", TagID: "t1"},
+				model.ProgramTag{ID: "pt2", ProgramID: "p2", TagID: "t1"},
+				model.ProgramTag{ID: "pt3", ProgramID: "p1", TagID: "t2"},
+			)
+			programs := newFakeProgramPersister(model.Program{ID: "p1", Name: "morning"}, model.Program{ID: "p2", Name: "evening"})
+			programs.programTags = programTags
+			if tt.fail != nil {
+				tt.fail(tags, programTags, programs)
+			}
+
+			got, err := tt.call(NewTagApi(tags, programs, newFakeProgramTagPersister(), newFakeTxManager()))
+
+			assertError(t, err, nil, tt.wantErr)
+			if !equalStrings(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
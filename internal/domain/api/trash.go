@@ -0,0 +1,114 @@
+// Package api provides functionality for emptying the trash.
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/rs/zerolog/log"
+)
+
+// Trash represents the interface for emptying the trash of the catalogue.
+type Trash interface {
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+// trashApi is an implementation of the Trash interface.
+type trashApi struct {
+	wallAdapter     port.WallPersister
+	blockAdapter    port.BlockPersister
+	programAdapter  port.ProgramPersister
+	episodeAdapter  port.EpisodePersister
+	mediaAdapter    port.MediaPersister
+	tagAdapter      port.TagPersister
+	categoryAdapter port.CategoryPersister
+	objectStore     port.ObjectStore
+	txManager       port.TxManager
+}
+
+// NewTrashApi creates a new instance of Trash.
+// It takes adapters for the persistence of every catalogue entity, the store of the uploaded files
+// and a transaction manager as dependencies.
+func NewTrashApi(
+	wallAdapter port.WallPersister,
+	blockAdapter port.BlockPersister,
+	programAdapter port.ProgramPersister,
+	episodeAdapter port.EpisodePersister,
+	mediaAdapter port.MediaPersister,
+	tagAdapter port.TagPersister,
+	categoryAdapter port.CategoryPersister,
+	objectStore port.ObjectStore,
+	txManager port.TxManager,
+) Trash {
+	return &trashApi{
+		wallAdapter:     wallAdapter,
+		blockAdapter:    blockAdapter,
+		programAdapter:  programAdapter,
+		episodeAdapter:  episodeAdapter,
+		mediaAdapter:    mediaAdapter,
+		tagAdapter:      tagAdapter,
+		categoryAdapter: categoryAdapter,
+		objectStore:     objectStore,
+		txManager:       txManager,
+	}
+}
+
+// Purge permanently removes the entities moved to the trash before the given time.
+// Medias are purged before episodes and episodes before programs, so that a parent purged in the same run
+// is not kept for children that were just removed.
+// It takes the context and the time, and returns the number of entities removed or an error.
+func (api trashApi) Purge(ctx context.Context, before time.Time) (int, error) {
+	purges := []struct {
+		entities string
+		purge    func(ctx context.Context, before time.Time) (int, error)
+	}{
+		{"medias", api.purgeMedias},
+		{"episodes", api.episodeAdapter.Purge},
+		{"programs", api.programAdapter.Purge},
+		{"walls", api.wallAdapter.Purge},
+		{"blocks", api.blockAdapter.Purge},
+		{"tags", api.tagAdapter.Purge},
+		{"categories", api.categoryAdapter.Purge},
+	}
+
+	total := 0
+	for _, p := range purges {
+		count, err := p.purge(ctx, before)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Time("before", before).Msg("error while purging " + p.entities)
+			return total, fmt.Errorf("error occurred while purging %s: %w", p.entities, err)
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// purgeMedias permanently removes the medias moved to the trash before the given time, then deletes their
+// uploaded files and artworks from the object store.
+// The medias are locked while they are removed, so that a media restored meanwhile keeps its objects.
+// A failed deletion is logged and leaves an orphan object behind, as the medias are already gone.
+func (api trashApi) purgeMedias(ctx context.Context, before time.Time) (int, error) {
+	var medias []*model.Media
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		medias, err = api.mediaAdapter.Purge(ctx, before)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, media := range medias {
+		for _, key := range []string{media.StorageKey, media.ArtworkKey} {
+			if key == "" {
+				continue
+			}
+			if err := api.objectStore.Delete(ctx, key); err != nil {
+				log.Ctx(ctx).Error().Err(err).Str("key", key).Msg("error while deleting purged object")
+			}
+		}
+	}
+	return len(medias), nil
+}
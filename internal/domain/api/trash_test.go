@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+func TestTagApi_Restore(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		failOn   string
+		wantErr  error
+		wantLive []string
+	}{
+		{
+			name:     "moves the tag back from the trash",
+			id:       "t2",
+			wantLive: []string{"t1", "t2"},
+		},
+		{
+			name:     "fails for a tag that is not in the trash",
+			id:       "t1",
+			wantErr:  model.ErrNotFound,
+			wantLive: []string{"t1"},
+		},
+		{
+			name:     "wraps adapter failures",
+			id:       "t2",
+			failOn:   "Restore",
+			wantErr:  errAdapter,
+			wantLive: []string{"t1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := newFakeTagPersister(model.Tag{ID: "t1"})
+			tags.trash = []model.Tag{{ID: "t2"}}
+			if tt.failOn != "" {
+				tags.failOn(tt.failOn)
+			}
+
+			err := NewTagApi(tags, newFakeProgramPersister(), newFakeProgramTagPersister(), newFakeTxManager()).Restore(context.Background(), tt.id)
+
+			assertError(t, err, nil, tt.wantErr)
+			var gotLive []string
+			for _, tag := range tags.rows {
+				gotLive = append(gotLive, tag.ID)
+			}
+			if !equalStrings(gotLive, tt.wantLive) {
+				t.Fatalf("got live tags %v, want %v", gotLive, tt.wantLive)
+			}
+		})
+	}
+}
+
+func TestTagApi_FindDeleted(t *testing.T) {
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		req        pkg.ListRequestJSON
+		wantFields []string
+		wantOpts   model.ListOptions
+	}{
+		{
+			name:     "defaults to the most recently deleted first",
+			wantOpts: model.ListOptions{Limit: defaultPageLimit, Sort: model.SortByDeletedAt, Descending: true, Deleted: true},
+		},
+		{
+			name:     "keeps the requested sort",
+			req:      pkg.ListRequestJSON{SortJSON: "name", NameJSON: "news"},
+			wantOpts: model.ListOptions{Limit: defaultPageLimit, Sort: model.SortByName, NameContains: "news", Deleted: true},
+		},
+		{
+			name:       "rejects unknown sort field",
+			req:        pkg.ListRequestJSON{SortJSON: "position"},
+			wantFields: []string{"sort"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := newFakeTagPersister(model.Tag{ID: "t1"})
+			tags.trash = []model.Tag{{ID: "t2", Name: "news", DeletedAt: deletedAt}}
+
+			page, err := NewTagApi(tags, newFakeProgramPersister(), newFakeProgramTagPersister(), newFakeTxManager()).FindDeleted(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, nil)
+			if err != nil {
+				return
+			}
+			if tags.listed != tt.wantOpts {
+				t.Fatalf("got options %+v, want %+v", tags.listed, tt.wantOpts)
+			}
+			if page.Total != 1 || len(page.Items) != 1 || page.Items[0].ID != "t2" {
+				t.Fatalf("got page %+v, want the deleted tag t2", page)
+			}
+			if got := page.Items[0].DeletedAt; got == nil || !got.Equal(deletedAt) {
+				t.Fatalf("got deletion time %v, want %v", got, deletedAt)
+			}
+		})
+	}
+}
+
+func TestTagApi_FindAll_RejectsDeletionSort(t *testing.T) {
+	_, err := NewTagApi(newFakeTagPersister(), newFakeProgramPersister(), newFakeProgramTagPersister(), newFakeTxManager()).FindAll(context.Background(), pkg.ListRequestJSON{SortJSON: "-deletedAt"})
+
+	assertError(t, err, []string{"sort"}, nil)
+}
+
+func TestAuditedTagApi_Restore(t *testing.T) {
+	tags := newFakeTagPersister()
+	tags.trash = []model.Tag{{ID: "t1", Name: "news"}}
+	audits := newFakeAuditPersister()
+	api := NewAuditedTagApi(NewTagApi(tags, newFakeProgramPersister(), newFakeProgramTagPersister(), newFakeTxManager()), audits)
+
+	if err := api.Restore(auditContext(), "t1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(audits.rows) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(audits.rows))
+	}
+	entry := audits.rows[0]
+	if entry.Action != model.AuditActionRestore || entry.EntityID != "t1" || entry.Before != "" || entry.After == "" {
+		t.Fatalf("got entry %s %s with before %q and after %q, want restore t1 with its restored state", entry.Action, entry.EntityID, entry.Before, entry.After)
+	}
+}
+
+func TestTrashApi_Purge(t *testing.T) {
+	before := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		failOn     string
+		wantErr    error
+		wantCount  int
+		wantPurged []string
+	}{
+		{
+			name:       "purges every catalogue entity",
+			wantCount:  7,
+			wantPurged: []string{"medias", "episodes", "programs", "walls", "blocks", "tags", "categories"},
+		},
+		{
+			name:       "purges children before their parents and stops at the first failure",
+			failOn:     "episodes",
+			wantErr:    errAdapter,
+			wantCount:  1,
+			wantPurged: []string{"medias"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walls := newFakeWallPersister()
+			walls.trash = []model.Wall{{ID: "w1"}}
+			blocks := newFakeBlockPersister()
+			blocks.trash = []model.Block{{ID: "b1"}}
+			programs := newFakeProgramPersister()
+			programs.trash = []model.Program{{ID: "p1"}}
+			episodes := newFakeEpisodePersister()
+			episodes.trash = []model.Episode{{ID: "e1"}}
+			medias := newFakeMediaPersister()
+			medias.trash = []model.Media{{ID: "m1", StorageKey: "medias/m1", ArtworkKey: "artworks/m1"}}
+			tags := newFakeTagPersister()
+			tags.trash = []model.Tag{{ID: "t1"}}
+			categories := newFakeCategoryPersister()
+			categories.trash = []model.Category{{ID: "c1"}}
+			if tt.failOn == "episodes" {
+				episodes.failOn("Purge")
+			}
+
+			objectStore := newFakeObjectStore()
+			objectStore.objects["medias/m1"] = []byte("audio")
+			objectStore.objects["artworks/m1"] = []byte("image")
+			objectStore.objects["medias/m2"] = []byte("audio")
+
+			count, err := NewTrashApi(walls, blocks, programs, episodes, medias, tags, categories, objectStore, newFakeTxManager(medias)).Purge(context.Background(), before)
+
+			assertError(t, err, nil, tt.wantErr)
+			if count != tt.wantCount {
+				t.Fatalf("got %d purged, want %d", count, tt.wantCount)
+			}
+			purgedBefore := map[string]time.Time{
+				"walls":      walls.purged,
+				"blocks":     blocks.purged,
+				"programs":   programs.purged,
+				"episodes":   episodes.purged,
+				"medias":     medias.purged,
+				"tags":       tags.purged,
+				"categories": categories.purged,
+			}
+			var gotPurged []string
+			for _, entities := range []string{"medias", "episodes", "programs", "walls", "blocks", "tags", "categories"} {
+				if purgedBefore[entities].Equal(before) {
+					gotPurged = append(gotPurged, entities)
+				}
+			}
+			if !equalStrings(gotPurged, tt.wantPurged) {
+				t.Fatalf("got %v purged, want %v", gotPurged, tt.wantPurged)
+			}
+			if len(objectStore.objects) != 1 || objectStore.objects["medias/m2"] == nil {
+				t.Fatalf("got objects %v, want only the objects of the live medias", objectStore.objects)
+			}
+		})
+	}
+}
@@ -14,13 +14,18 @@ import (
 
 // Wall represents the interface for managing walls.
 type Wall interface {
-	Create(ctx context.Context, wall CreateWallRequest) error
+	Create(ctx context.Context, wall CreateWallRequest) (string, error)
 	Update(ctx context.Context, uuid string, updates UpdateWallRequest) error
 	Find(ctx context.Context, uuid string) (*pkg.WallResponse, error)
-	FindAll(ctx context.Context) ([]*pkg.WallResponse, error)
+	FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.WallResponse], error)
 	Delete(ctx context.Context, uuid string) error
+	Restore(ctx context.Context, uuid string) error
+	FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.WallResponse], error)
 	FindBlocks(ctx context.Context, uuid string) ([]*pkg.WallBlocksResponse, error)
 	OverwriteBlocks(ctx context.Context, wallID string, req OverwriteBlocksRequest) error
+	ReorderBlocks(ctx context.Context, wallID string, req ReorderRequest) error
+	AddBlock(ctx context.Context, wallID string, blockID string) error
+	RemoveBlock(ctx context.Context, wallID string, blockID string) error
 }
 
 // wallApi is an implementation of the Wall interface.
@@ -28,15 +33,17 @@ type wallApi struct {
 	wallAdapter      port.WallPersister
 	wallBlockAdapter port.WallBlockPersister
 	blockAdapter     port.BlockPersister
+	txManager        port.TxManager
 }
 
 // NewWallApi creates a new instance of Wall.
-// It takes wallAdapter, wallBlockAdapter, and blockAdapter as dependencies.
-func NewWallApi(wallAdapter port.WallPersister, wallBlockAdapter port.WallBlockPersister, blockAdapter port.BlockPersister) Wall {
+// It takes wallAdapter, wallBlockAdapter, blockAdapter, and txManager as dependencies.
+func NewWallApi(wallAdapter port.WallPersister, wallBlockAdapter port.WallBlockPersister, blockAdapter port.BlockPersister, txManager port.TxManager) Wall {
 	return &wallApi{
 		wallAdapter:      wallAdapter,
 		wallBlockAdapter: wallBlockAdapter,
 		blockAdapter:     blockAdapter,
+		txManager:        txManager,
 	}
 }
 
@@ -49,12 +56,25 @@ func (api wallApi) FindBlocks(ctx context.Context, uuid string) ([]*pkg.WallBloc
 		return nil, err
 	}
 
+	// Find the associated blocks in a single lookup
+	var blockIDs []string
+	for _, association := range associations {
+		blockIDs = append(blockIDs, association.BlockID)
+	}
+	blocks, err := api.blockAdapter.FindByIDs(ctx, blockIDs)
+	if err != nil {
+		return nil, err
+	}
+	blocksByID := make(map[string]*model.Block, len(blocks))
+	for _, block := range blocks {
+		blocksByID[block.ID] = block
+	}
+
 	var response []*pkg.WallBlocksResponse
 	for _, association := range associations {
-		// Find block by ID
-		block, err := api.blockAdapter.Find(ctx, association.BlockID)
-		if err != nil {
-			return nil, err
+		block, ok := blocksByID[association.BlockID]
+		if !ok {
+			continue
 		}
 
 		// Map block information to response
@@ -75,13 +95,13 @@ func (api wallApi) FindBlocks(ctx context.Context, uuid string) ([]*pkg.WallBloc
 }
 
 // Create creates a new wall.
-// It takes the context and CreateWallRequest, and returns an error if any.
-func (api wallApi) Create(ctx context.Context, req CreateWallRequest) error {
+// It takes the context and CreateWallRequest, and returns the UUID of the new wall or an error.
+func (api wallApi) Create(ctx context.Context, req CreateWallRequest) (string, error) {
 	// Validate request
 	vErrs := createWallRequestValidation(ctx, req)
 	if len(vErrs) > 0 {
 		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
-		return fmt.Errorf("request was not validated: %w", vErrs)
+		return "", fmt.Errorf("request was not validated: %w", vErrs)
 	}
 
 	// Map request to domain model
@@ -94,10 +114,10 @@ func (api wallApi) Create(ctx context.Context, req CreateWallRequest) error {
 	// Call adapter to create wall
 	if err := api.wallAdapter.Create(ctx, wall); err != nil {
 		log.Ctx(ctx).Error().Err(err).Interface("wall", wall).Msg("error while creating wall")
-		return fmt.Errorf("error occurred while creating wall: %w", err)
+		return "", fmt.Errorf("error occurred while creating wall: %w", err)
 	}
 
-	return nil
+	return wall.ID, nil
 }
 
 // createWallRequestValidation validates the creation request.
@@ -169,11 +189,22 @@ func (api wallApi) Find(ctx context.Context, uuid string) (*pkg.WallResponse, er
 	return response, nil
 }
 
-// FindAll finds all walls.
-// It takes the context and returns a slice of WallResponse or an error.
-func (api wallApi) FindAll(ctx context.Context) ([]*pkg.WallResponse, error) {
+// wallListing describes how walls can be sorted and filtered.
+var wallListing = listing{named: true, sortable: []string{model.SortByName, model.SortByCreatedAt}}
+
+// FindAll finds a page of walls.
+// It takes the context and ListRequest, and returns a page of WallResponse or an error.
+func (api wallApi) FindAll(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.WallResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, wallListing)
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := listOptions(req)
+
 	// Call adapter
-	wallSlice, err := api.wallAdapter.FindAll(ctx)
+	wallSlice, total, err := api.wallAdapter.FindAll(ctx, opts)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while finding walls")
 		return nil, fmt.Errorf("error occurred while finding walls: %w", err)
@@ -190,7 +221,7 @@ func (api wallApi) FindAll(ctx context.Context) ([]*pkg.WallResponse, error) {
 	}
 
 	// Return result
-	return response, nil
+	return newPage(response, total, opts), nil
 }
 
 // Delete deletes a wall by UUID.
@@ -203,38 +234,191 @@ func (api wallApi) Delete(ctx context.Context, uuid string) error {
 	return nil
 }
 
+// Restore restores a wall from the trash by UUID.
+// It takes the context and wall UUID, and returns an error if any.
+func (api wallApi) Restore(ctx context.Context, uuid string) error {
+	if err := api.wallAdapter.Restore(ctx, uuid); err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while restoring wall")
+		return fmt.Errorf("error occurred while restoring wall: %w", err)
+	}
+	return nil
+}
+
+// FindDeleted finds a page of the walls in the trash, the most recently deleted first unless sorted otherwise.
+// It takes the context and ListRequest, and returns a page of WallResponse or an error.
+func (api wallApi) FindDeleted(ctx context.Context, req ListRequest) (*pkg.PageResponse[*pkg.WallResponse], error) {
+	// Validate request
+	vErrs := listRequestValidation(ctx, req, wallListing.trash())
+	if len(vErrs) > 0 {
+		log.Ctx(ctx).Error().Err(vErrs).Interface("request", req).Msg("request was not validated")
+		return nil, fmt.Errorf("request was not validated: %w", vErrs)
+	}
+	opts := trashOptions(req)
+
+	// Call adapter
+	wallSlice, total, err := api.wallAdapter.FindAll(ctx, opts)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while finding deleted walls")
+		return nil, fmt.Errorf("error occurred while finding deleted walls: %w", err)
+	}
+
+	// Map to response
+	var response []*pkg.WallResponse
+	for _, wall := range wallSlice {
+		response = append(response, &pkg.WallResponse{
+			ID:          wall.ID,
+			Name:        wall.Name,
+			Description: wall.Description,
+			DeletedAt:   optionalTime(wall.DeletedAt),
+		})
+	}
+
+	// Return result
+	return newPage(response, total, opts), nil
+}
+
 // OverwriteBlocks overwrites the blocks associated with a wall.
 // It takes the context, wall ID, and OverwriteBlocksRequest, and returns an error if any.
+// The existing associations are removed and the new ones created in a single transaction.
 func (api wallApi) OverwriteBlocks(ctx context.Context, wallID string, req OverwriteBlocksRequest) error {
-	// Find all existing associations by wall ID
-	associations, err := api.wallBlockAdapter.FindByWallID(ctx, wallID)
-	if err != nil {
-		return err
-	}
+	return api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Find all existing associations by wall ID
+		associations, err := api.wallBlockAdapter.FindByWallID(ctx, wallID)
+		if err != nil {
+			return err
+		}
 
-	// Remove all existing associations for the wall
-	for _, association := range associations {
-		if err = api.wallBlockAdapter.Delete(ctx, association.ID); err != nil {
+		// Remove all existing associations for the wall
+		for _, association := range associations {
+			if err = api.wallBlockAdapter.Delete(ctx, association.ID); err != nil {
+				return err
+			}
+		}
+
+		// Create all new associations
+		for blockID, position := range req.OrderedBlocks() {
+			wallBlock := model.WallBlock{
+				ID:       uuid.New().String(),
+				WallID:   wallID,
+				BlockID:  blockID,
+				Position: position,
+			}
+
+			// Call adapter to create wall block
+			if err := api.wallBlockAdapter.Create(ctx, wallBlock); err != nil {
+				log.Ctx(ctx).Error().Err(err).Interface("wallBlock", wallBlock).Msg("error while creating wall block")
+				return fmt.Errorf("error occurred while creating wall block: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// ReorderBlocks reorders the blocks of a wall, identified by their block ID, as requested.
+// It takes the context, wall ID, and ReorderRequest, and returns an error if any.
+// The positions are renumbered densely from 1 in a single transaction.
+func (api wallApi) ReorderBlocks(ctx context.Context, wallID string, req ReorderRequest) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.wallAdapter.Find(ctx, wallID); err != nil {
 			return err
 		}
+		associations, err := api.wallBlockAdapter.FindByWallID(ctx, wallID)
+		if err != nil {
+			return err
+		}
+		associations, err = liveAssociations(ctx, associations,
+			func(association *model.WallBlock) string { return association.BlockID },
+			api.blockAdapter.FindByIDs,
+			func(block *model.Block) string { return block.ID },
+		)
+		if err != nil {
+			return err
+		}
+		return renumber(associations,
+			func(association *model.WallBlock) string { return association.BlockID },
+			func(association *model.WallBlock) *int { return &association.Position },
+			req,
+			func(association *model.WallBlock) error {
+				return api.wallBlockAdapter.Update(ctx, association.ID, *association)
+			},
+		)
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", wallID).Interface("request", req).Msg("error while reordering wall blocks")
+		return fmt.Errorf("error occurred while reordering wall blocks: %w", err)
 	}
+	return nil
+}
 
-	// Create all new associations
-	for blockID, position := range req.OrderedBlocks() {
-		wallBlock := model.WallBlock{
+// AddBlock appends a block to a wall, after its last block, doing nothing when the wall already holds it.
+// It takes the context, wall ID and block ID, and returns an error if any, model.ErrNotFound when either does not exist.
+func (api wallApi) AddBlock(ctx context.Context, wallID string, blockID string) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.wallAdapter.Find(ctx, wallID); err != nil {
+			return err
+		}
+		if _, err := api.blockAdapter.Find(ctx, blockID); err != nil {
+			return err
+		}
+		existing, err := api.wallBlockAdapter.FindByWallIDAndBlockID(ctx, wallID, blockID)
+		if err != nil || len(existing) > 0 {
+			return err
+		}
+		associations, err := api.wallBlockAdapter.FindByWallID(ctx, wallID)
+		if err != nil {
+			return err
+		}
+		position := 1
+		for _, association := range associations {
+			position = max(position, association.Position+1)
+		}
+		return associated(api.wallBlockAdapter.Create(ctx, model.WallBlock{
 			ID:       uuid.New().String(),
 			WallID:   wallID,
 			BlockID:  blockID,
 			Position: position,
-		}
+		}))
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", wallID).Interface("blockID", blockID).Msg("error while adding wall block")
+		return fmt.Errorf("error occurred while adding wall block: %w", err)
+	}
+	return nil
+}
 
-		// Call adapter to create wall block
-		if err := api.wallBlockAdapter.Create(ctx, wallBlock); err != nil {
-			log.Ctx(ctx).Error().Err(err).Interface("wallBlock", wallBlock).Msg("error while creating wall block")
-			return fmt.Errorf("error occurred while creating wall block: %w", err)
+// RemoveBlock removes a block from a wall, doing nothing when the wall does not hold it.
+// The positions of the remaining blocks are renumbered densely from 1, in the same transaction.
+// It takes the context, wall ID and block ID, and returns an error if any, model.ErrNotFound when the wall does not exist.
+func (api wallApi) RemoveBlock(ctx context.Context, wallID string, blockID string) error {
+	err := api.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := api.wallAdapter.Find(ctx, wallID); err != nil {
+			return err
+		}
+		existing, err := api.wallBlockAdapter.FindByWallIDAndBlockID(ctx, wallID, blockID)
+		if err != nil || len(existing) == 0 {
+			return err
+		}
+		for _, association := range existing {
+			if err := api.wallBlockAdapter.Delete(ctx, association.ID); err != nil {
+				return err
+			}
 		}
+		associations, err := api.wallBlockAdapter.FindByWallID(ctx, wallID)
+		if err != nil {
+			return err
+		}
+		return closeGaps(associations,
+			func(association *model.WallBlock) *int { return &association.Position },
+			func(association *model.WallBlock) error {
+				return api.wallBlockAdapter.Update(ctx, association.ID, *association)
+			},
+		)
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", wallID).Interface("blockID", blockID).Msg("error while removing wall block")
+		return fmt.Errorf("error occurred while removing wall block: %w", err)
 	}
-
 	return nil
 }
 
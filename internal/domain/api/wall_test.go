@@ -0,0 +1,317 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+func TestWallApi_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        pkg.CreateWallRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+	}{
+		{
+			name: "creates the wall",
+			req:  pkg.CreateWallRequestJSON{NameJSON: "home", DescriptionJSON: "home page"},
+		},
+		{
+			name:       "requires name and description",
+			req:        pkg.CreateWallRequestJSON{},
+			wantFields: []string{"name", "description"},
+		},
+		{
+			name:    "wraps adapter failure",
+			req:     pkg.CreateWallRequestJSON{NameJSON: "home", DescriptionJSON: "home page"},
+			failOn:  "Create",
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walls := newFakeWallPersister()
+			if tt.failOn != "" {
+				walls.failOn(tt.failOn)
+			}
+			api := NewWallApi(walls, newFakeWallBlockPersister(), newFakeBlockPersister(), newFakeTxManager())
+
+			id, err := api.Create(context.Background(), tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if err == nil {
+				if len(walls.rows) != 1 || walls.rows[0].ID != id || walls.rows[0].Name != tt.req.NameJSON {
+					t.Fatalf("unexpected stored walls: %+v", walls.rows)
+				}
+			}
+		})
+	}
+}
+
+func TestWallApi_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		uuid       string
+		req        pkg.UpdateWallRequestJSON
+		failOn     string
+		wantFields []string
+		wantErr    error
+		wantName   string
+	}{
+		{
+			name:     "updates the wall",
+			uuid:     "w1",
+			req:      pkg.UpdateWallRequestJSON{NameJSON: "renamed", DescriptionJSON: "new"},
+			wantName: "renamed",
+		},
+		{
+			name:       "requires the uuid",
+			req:        pkg.UpdateWallRequestJSON{NameJSON: "renamed"},
+			wantFields: []string{"uuid"},
+			wantName:   "home",
+		},
+		{
+			name:     "wraps adapter failure",
+			uuid:     "w1",
+			req:      pkg.UpdateWallRequestJSON{NameJSON: "renamed"},
+			failOn:   "Update",
+			wantErr:  errAdapter,
+			wantName: "home",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walls := newFakeWallPersister(model.Wall{ID: "w1", Name: "home", Description: "home page"})
+			if tt.failOn != "" {
+				walls.failOn(tt.failOn)
+			}
+			api := NewWallApi(walls, newFakeWallBlockPersister(), newFakeBlockPersister(), newFakeTxManager())
+
+			err := api.Update(context.Background(), tt.uuid, tt.req)
+
+			assertError(t, err, tt.wantFields, tt.wantErr)
+			if got := walls.rows[0].Name; got != tt.wantName {
+				t.Fatalf("got name %q, want %q", got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestWallApi_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		failOn  string
+		call    func(api Wall) (int, error)
+		want    int
+		wantErr error
+	}{
+		{
+			name: "finds a wall",
+			call: func(api Wall) (int, error) {
+				wall, err := api.Find(context.Background(), "w1")
+				if err != nil {
+					return 0, err
+				}
+				if wall.Name != "home" {
+					t.Fatalf("unexpected wall: %+v", wall)
+				}
+				return 1, nil
+			},
+			want: 1,
+		},
+		{
+			name:    "wraps find failure",
+			failOn:  "Find",
+			call:    func(api Wall) (int, error) { _, err := api.Find(context.Background(), "w1"); return 0, err },
+			wantErr: errAdapter,
+		},
+		{
+			name:    "reports a missing wall",
+			call:    func(api Wall) (int, error) { _, err := api.Find(context.Background(), "w9"); return 0, err },
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name:    "reports the deletion of a missing wall",
+			call:    func(api Wall) (int, error) { return 0, api.Delete(context.Background(), "w9") },
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name: "finds all walls",
+			call: func(api Wall) (int, error) {
+				walls, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				if err != nil {
+					return 0, err
+				}
+				return len(walls.Items), nil
+			},
+			want: 2,
+		},
+		{
+			name:   "wraps find all failure",
+			failOn: "FindAll",
+			call: func(api Wall) (int, error) {
+				_, err := api.FindAll(context.Background(), pkg.ListRequestJSON{})
+				return 0, err
+			},
+			wantErr: errAdapter,
+		},
+		{
+			name:    "wraps delete failure",
+			failOn:  "Delete",
+			call:    func(api Wall) (int, error) { return 0, api.Delete(context.Background(), "w1") },
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walls := newFakeWallPersister(model.Wall{ID: "w1", Name: "home"}, model.Wall{ID: "w2", Name: "kids"})
+			if tt.failOn != "" {
+				walls.failOn(tt.failOn)
+			}
+			api := NewWallApi(walls, newFakeWallBlockPersister(), newFakeBlockPersister(), newFakeTxManager())
+
+			got, err := tt.call(api)
+
+			assertError(t, err, nil, tt.wantErr)
+			if got != tt.want {
+				t.Fatalf("got %d results, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWallApi_FindBlocks(t *testing.T) {
+	tests := []struct {
+		name       string
+		failOn     string
+		failBlocks bool
+		wantIDs    []string
+		wantErr    error
+	}{
+		{
+			name:    "returns the blocks ordered by position",
+			wantIDs: []string{"b2", "b1"},
+		},
+		{
+			name:    "fails when associations cannot be read",
+			failOn:  "FindByWallID",
+			wantErr: errAdapter,
+		},
+		{
+			name:       "fails when a block cannot be read",
+			failBlocks: true,
+			wantErr:    errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallBlocks := newFakeWallBlockPersister(
+				model.WallBlock{ID: "wb1", WallID: "w1", BlockID: "b1", Position: 2},
+				model.WallBlock{ID: "wb2", WallID: "w1", BlockID: "b2", Position: 1},
+				model.WallBlock{ID: "wb3", WallID: "w2", BlockID: "b1", Position: 1},
+			)
+			blocks := newFakeBlockPersister(model.Block{ID: "b1", Name: "news"}, model.Block{ID: "b2", Name: "sport"})
+			if tt.failOn != "" {
+				wallBlocks.failOn(tt.failOn)
+			}
+			if tt.failBlocks {
+				blocks.failOn("FindByIDs")
+			}
+			api := NewWallApi(newFakeWallPersister(), wallBlocks, blocks, newFakeTxManager())
+
+			response, err := api.FindBlocks(context.Background(), "w1")
+
+			assertError(t, err, nil, tt.wantErr)
+			var gotIDs []string
+			for i, block := range response {
+				gotIDs = append(gotIDs, block.ID)
+				if block.Position != i+1 {
+					t.Fatalf("block %s has position %d, want %d", block.ID, block.Position, i+1)
+				}
+			}
+			if !equalStrings(gotIDs, tt.wantIDs) {
+				t.Fatalf("got blocks %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestWallApi_OverwriteBlocks(t *testing.T) {
+	tests := []struct {
+		name      string
+		ordered   map[string]int
+		failOn    string
+		wantErr   error
+		wantBlock map[string]int
+	}{
+		{
+			name:      "replaces the wall blocks",
+			ordered:   map[string]int{"b3": 1, "b1": 2},
+			wantBlock: map[string]int{"b3": 1, "b1": 2},
+		},
+		{
+			name:      "clears the wall blocks",
+			ordered:   map[string]int{},
+			wantBlock: map[string]int{},
+		},
+		{
+			name:      "fails when associations cannot be read",
+			ordered:   map[string]int{"b3": 1},
+			failOn:    "FindByWallID",
+			wantErr:   errAdapter,
+			wantBlock: map[string]int{"b1": 1, "b2": 2},
+		},
+		{
+			name:      "fails when an association cannot be removed",
+			ordered:   map[string]int{"b3": 1},
+			failOn:    "Delete",
+			wantErr:   errAdapter,
+			wantBlock: map[string]int{"b1": 1, "b2": 2},
+		},
+		{
+			name:      "rolls back when an association cannot be created",
+			ordered:   map[string]int{"b3": 1},
+			failOn:    "Create",
+			wantErr:   errAdapter,
+			wantBlock: map[string]int{"b1": 1, "b2": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallBlocks := newFakeWallBlockPersister(
+				model.WallBlock{ID: "wb1", WallID: "w1", BlockID: "b1", Position: 1},
+				model.WallBlock{ID: "wb2", WallID: "w1", BlockID: "b2", Position: 2},
+				model.WallBlock{ID: "wb3", WallID: "w2", BlockID: "b1", Position: 1},
+			)
+			if tt.failOn != "" {
+				wallBlocks.failOn(tt.failOn)
+			}
+			api := NewWallApi(newFakeWallPersister(), wallBlocks, newFakeBlockPersister(), newFakeTxManager(wallBlocks))
+
+			err := api.OverwriteBlocks(context.Background(), "w1", pkg.OverwriteBlocksRequestJSON{OrderedBlocksJSON: tt.ordered})
+
+			assertError(t, err, nil, tt.wantErr)
+			got := make(map[string]int)
+			for _, wallBlock := range wallBlocks.rows {
+				if wallBlock.WallID == "w1" {
+					got[wallBlock.BlockID] = wallBlock.Position
+				}
+			}
+			if len(got) != len(tt.wantBlock) {
+				t.Fatalf("got blocks %v, want %v", got, tt.wantBlock)
+			}
+			for blockID, position := range tt.wantBlock {
+				if got[blockID] != position {
+					t.Fatalf("got blocks %v, want %v", got, tt.wantBlock)
+				}
+			}
+			if len(wallBlocks.where(func(wb model.WallBlock) bool { return wb.WallID == "w2" })) != 1 {
+				t.Fatal("blocks of another wall were rewritten")
+			}
+		})
+	}
+}
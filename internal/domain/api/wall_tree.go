@@ -0,0 +1,232 @@
+// Package api provides functionality for rendering walls with their whole content.
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"github.com/rs/zerolog/log"
+)
+
+// WallTree represents the interface for reading a wall along with its blocks, programs, episodes and medias.
+type WallTree interface {
+	FindTree(ctx context.Context, uuid string) (*pkg.WallTreeResponse, error)
+	FindPublishedTree(ctx context.Context, uuid string) (*pkg.WallTreeResponse, error)
+}
+
+// wallTreeApi is an implementation of the WallTree interface.
+type wallTreeApi struct {
+	wallAdapter         port.WallPersister
+	wallBlockAdapter    port.WallBlockPersister
+	blockAdapter        port.BlockPersister
+	blockProgramAdapter port.BlockProgramPersister
+	programAdapter      port.ProgramPersister
+	episodeAdapter      port.EpisodePersister
+	mediaAdapter        port.MediaPersister
+}
+
+// NewWallTreeApi creates a new instance of WallTree.
+// It takes the adapters of every level of the tree as dependencies.
+func NewWallTreeApi(wallAdapter port.WallPersister, wallBlockAdapter port.WallBlockPersister, blockAdapter port.BlockPersister, blockProgramAdapter port.BlockProgramPersister, programAdapter port.ProgramPersister, episodeAdapter port.EpisodePersister, mediaAdapter port.MediaPersister) WallTree {
+	return &wallTreeApi{
+		wallAdapter:         wallAdapter,
+		wallBlockAdapter:    wallBlockAdapter,
+		blockAdapter:        blockAdapter,
+		blockProgramAdapter: blockProgramAdapter,
+		programAdapter:      programAdapter,
+		episodeAdapter:      episodeAdapter,
+		mediaAdapter:        mediaAdapter,
+	}
+}
+
+// FindTree finds a wall along with its ordered blocks, their ordered programs, the episodes of these
+// programs and their medias.
+// Each level is loaded with a single batched lookup, so the number of queries does not depend on the size of the wall.
+// It takes the context and wall UUID, and returns a WallTreeResponse or an error.
+func (api wallTreeApi) FindTree(ctx context.Context, uuid string) (*pkg.WallTreeResponse, error) {
+	tree, err := api.findTree(ctx, uuid, false)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while finding wall tree")
+		return nil, fmt.Errorf("error occurred while finding wall tree: %w", err)
+	}
+	return tree, nil
+}
+
+// FindPublishedTree finds the tree of a wall like FindTree, keeping only the published episodes, as shown to
+// the listeners.
+// It takes the context and wall UUID, and returns a WallTreeResponse or an error.
+func (api wallTreeApi) FindPublishedTree(ctx context.Context, uuid string) (*pkg.WallTreeResponse, error) {
+	tree, err := api.findTree(ctx, uuid, true)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Interface("uuid", uuid).Msg("error while finding published wall tree")
+		return nil, fmt.Errorf("error occurred while finding published wall tree: %w", err)
+	}
+	return tree, nil
+}
+
+// findTree loads the levels of the tree one after the other and assembles them, keeping only the published
+// episodes when publishedOnly is set.
+func (api wallTreeApi) findTree(ctx context.Context, uuid string, publishedOnly bool) (*pkg.WallTreeResponse, error) {
+	// Find the wall and its block associations
+	wall, err := api.wallAdapter.Find(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	wallBlocks, err := api.wallBlockAdapter.FindByWallID(ctx, wall.ID)
+	if err != nil {
+		return nil, err
+	}
+	var blockIDs []string
+	for _, wallBlock := range wallBlocks {
+		blockIDs = append(blockIDs, wallBlock.BlockID)
+	}
+
+	// Find the blocks and their program associations
+	blocks, err := api.blockAdapter.FindByIDs(ctx, blockIDs)
+	if err != nil {
+		return nil, err
+	}
+	blockPrograms, err := api.blockProgramAdapter.FindByBlockIDs(ctx, blockIDs)
+	if err != nil {
+		return nil, err
+	}
+	programsByBlock := make(map[string][]*model.BlockProgram)
+	var programIDs []string
+	seenPrograms := make(map[string]bool)
+	for _, blockProgram := range blockPrograms {
+		programsByBlock[blockProgram.BlockID] = append(programsByBlock[blockProgram.BlockID], blockProgram)
+		if !seenPrograms[blockProgram.ProgramID] {
+			seenPrograms[blockProgram.ProgramID] = true
+			programIDs = append(programIDs, blockProgram.ProgramID)
+		}
+	}
+
+	// Find the programs and their episodes
+	programs, err := api.programAdapter.FindByIDs(ctx, programIDs)
+	if err != nil {
+		return nil, err
+	}
+	episodes, err := api.episodeAdapter.FindByProgramIDs(ctx, programIDs)
+	if err != nil {
+		return nil, err
+	}
+	if publishedOnly {
+		episodes = slices.DeleteFunc(episodes, func(episode *model.Episode) bool {
+			return episode.Status != model.EpisodeStatusPublished
+		})
+	}
+	var episodeIDs []string
+	for _, episode := range episodes {
+		episodeIDs = append(episodeIDs, episode.ID)
+	}
+
+	// Find the medias of the episodes
+	medias, err := api.mediaAdapter.FindByEpisodeIDs(ctx, episodeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assemble the tree from the leaves up
+	mediasByEpisode := make(map[string][]*pkg.MediaResponse)
+	for _, media := range medias {
+		mediasByEpisode[media.EpisodeID] = append(mediasByEpisode[media.EpisodeID], &pkg.MediaResponse{
+			ID:         media.ID,
+			DirectLink: media.DirectLink,
+			Kind:       media.Kind,
+			EpisodeID:  media.EpisodeID,
+			Size:       media.Size,
+			Checksum:   media.Checksum,
+			MimeType:   media.MimeType,
+			Codec:      media.Codec,
+			DurationMs: media.Duration.Milliseconds(),
+			Bitrate:    media.Bitrate,
+			Channels:   media.Channels,
+			SampleRate: media.SampleRate,
+			Title:      media.Title,
+			Artwork:    media.ArtworkLink,
+		})
+	}
+	episodesByProgram := make(map[string][]*pkg.WallTreeEpisodeResponse)
+	for _, episode := range episodes {
+		episodeMedias := mediasByEpisode[episode.ID]
+		if episodeMedias == nil {
+			episodeMedias = []*pkg.MediaResponse{}
+		}
+		episodesByProgram[episode.ProgramID] = append(episodesByProgram[episode.ProgramID], &pkg.WallTreeEpisodeResponse{
+			EpisodeResponse: pkg.EpisodeResponse{
+				ID:          episode.ID,
+				Name:        episode.Name,
+				Description: episode.Description,
+				ProgramID:   episode.ProgramID,
+				Position:    episode.Position,
+				Status:      string(episode.Status),
+				PublishAt:   optionalTime(episode.PublishAt),
+			},
+			Medias: episodeMedias,
+		})
+	}
+	programsByID := make(map[string]*model.Program, len(programs))
+	for _, program := range programs {
+		programsByID[program.ID] = program
+	}
+	blocksByID := make(map[string]*model.Block, len(blocks))
+	for _, block := range blocks {
+		blocksByID[block.ID] = block
+	}
+
+	response := &pkg.WallTreeResponse{
+		WallResponse: pkg.WallResponse{
+			ID:          wall.ID,
+			Name:        wall.Name,
+			Description: wall.Description,
+		},
+		Blocks: []*pkg.WallTreeBlockResponse{},
+	}
+	for _, wallBlock := range wallBlocks {
+		block, ok := blocksByID[wallBlock.BlockID]
+		if !ok {
+			continue
+		}
+		blockResponse := &pkg.WallTreeBlockResponse{
+			WallBlocksResponse: pkg.WallBlocksResponse{
+				BlockResponse: pkg.BlockResponse{
+					ID:          block.ID,
+					Name:        block.Name,
+					Kind:        block.Kind,
+					Description: block.Description,
+				},
+				Position: wallBlock.Position,
+			},
+			Programs: []*pkg.WallTreeProgramResponse{},
+		}
+		for _, blockProgram := range programsByBlock[block.ID] {
+			program, ok := programsByID[blockProgram.ProgramID]
+			if !ok {
+				continue
+			}
+			programEpisodes := episodesByProgram[program.ID]
+			if programEpisodes == nil {
+				programEpisodes = []*pkg.WallTreeEpisodeResponse{}
+			}
+			blockResponse.Programs = append(blockResponse.Programs, &pkg.WallTreeProgramResponse{
+				BlockProgramsResponse: pkg.BlockProgramsResponse{
+					ProgramResponse: pkg.ProgramResponse{
+						ID:          program.ID,
+						Name:        program.Name,
+						Description: program.Description,
+					},
+					Position: blockProgram.Position,
+				},
+				Episodes: programEpisodes,
+			})
+		}
+		response.Blocks = append(response.Blocks, blockResponse)
+	}
+
+	// Return result
+	return response, nil
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+// wallTreeStores holds the fake persisters behind a WallTree api.
+type wallTreeStores struct {
+	walls         *fakeWallPersister
+	wallBlocks    *fakeWallBlockPersister
+	blocks        *fakeBlockPersister
+	blockPrograms *fakeBlockProgramPersister
+	programs      *fakeProgramPersister
+	episodes      *fakeEpisodePersister
+	medias        *fakeMediaPersister
+}
+
+func (s wallTreeStores) api() WallTree {
+	return NewWallTreeApi(s.walls, s.wallBlocks, s.blocks, s.blockPrograms, s.programs, s.episodes, s.medias)
+}
+
+// calls returns the number of persister calls made by every store.
+func (s wallTreeStores) calls() int {
+	return s.walls.calls + s.wallBlocks.calls + s.blocks.calls + s.blockPrograms.calls + s.programs.calls + s.episodes.calls + s.medias.calls
+}
+
+// newWallTreeStores builds a wall w1 with two blocks, each showing the given number of programs,
+// each program having two published episodes with a media each. Block b2 comes first on the wall.
+func newWallTreeStores(programsPerBlock int) wallTreeStores {
+	s := wallTreeStores{
+		walls:         newFakeWallPersister(model.Wall{ID: "w1", Name: "home"}),
+		wallBlocks:    newFakeWallBlockPersister(model.WallBlock{ID: "wb1", WallID: "w1", BlockID: "b1", Position: 2}, model.WallBlock{ID: "wb2", WallID: "w1", BlockID: "b2", Position: 1}),
+		blocks:        newFakeBlockPersister(model.Block{ID: "b1", Name: "news"}, model.Block{ID: "b2", Name: "culture"}),
+		blockPrograms: newFakeBlockProgramPersister(),
+		programs:      newFakeProgramPersister(),
+		episodes:      newFakeEpisodePersister(),
+		medias:        newFakeMediaPersister(),
+	}
+	for _, blockID := range []string{"b1", "b2"} {
+		for i := programsPerBlock; i > 0; i-- {
+			programID := fmt.Sprintf("%s-p%d", blockID, i)
+			s.blockPrograms.rows = append(s.blockPrograms.rows, model.BlockProgram{ID: "bp-" + programID, BlockID: blockID, ProgramID: programID, Position: i})
+			s.programs.rows = append(s.programs.rows, model.Program{ID: programID})
+			for j := 2; j > 0; j-- {
+				episodeID := fmt.Sprintf("%s-e%d", programID, j)
+				s.episodes.rows = append(s.episodes.rows, model.Episode{ID: episodeID, ProgramID: programID, Position: j, Status: model.EpisodeStatusPublished})
+				s.medias.rows = append(s.medias.rows, model.Media{ID: episodeID + "-m", EpisodeID: episodeID})
+			}
+		}
+	}
+	return s
+}
+
+// flatten lists the IDs of the tree depth first, nesting levels being separated by slashes.
+func flatten(tree *pkg.WallTreeResponse) []string {
+	var ids []string
+	for _, block := range tree.Blocks {
+		ids = append(ids, block.ID)
+		for _, program := range block.Programs {
+			ids = append(ids, block.ID+"/"+program.ID)
+			for _, episode := range program.Episodes {
+				var medias []string
+				for _, media := range episode.Medias {
+					medias = append(medias, media.ID)
+				}
+				ids = append(ids, block.ID+"/"+program.ID+"/"+episode.ID+"/"+strings.Join(medias, ","))
+			}
+		}
+	}
+	return ids
+}
+
+func TestWallTreeApi_FindTree(t *testing.T) {
+	tests := []struct {
+		name    string
+		uuid    string
+		prepare func(s wallTreeStores)
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "renders the whole wall in order",
+			uuid: "w1",
+			want: []string{
+				"b2",
+				"b2/b2-p1",
+				"b2/b2-p1/b2-p1-e1/b2-p1-e1-m",
+				"b2/b2-p1/b2-p1-e2/b2-p1-e2-m",
+				"b1",
+				"b1/b1-p1",
+				"b1/b1-p1/b1-p1-e1/b1-p1-e1-m",
+				"b1/b1-p1/b1-p1-e2/b1-p1-e2-m",
+			},
+		},
+		{
+			name: "renders empty levels as empty lists",
+			uuid: "w1",
+			prepare: func(s wallTreeStores) {
+				s.medias.rows = nil
+				s.blockPrograms.rows = s.blockPrograms.rows[:1]
+			},
+			want: []string{"b2", "b1", "b1/b1-p1", "b1/b1-p1/b1-p1-e1/", "b1/b1-p1/b1-p1-e2/"},
+		},
+		{
+			name:    "reports a missing wall",
+			uuid:    "w9",
+			wantErr: model.ErrNotFound,
+		},
+		{
+			name:    "wraps program lookup failure",
+			uuid:    "w1",
+			prepare: func(s wallTreeStores) { s.programs.failOn("FindByIDs") },
+			wantErr: errAdapter,
+		},
+		{
+			name:    "wraps media lookup failure",
+			uuid:    "w1",
+			prepare: func(s wallTreeStores) { s.medias.failOn("FindByEpisodeIDs") },
+			wantErr: errAdapter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stores := newWallTreeStores(1)
+			if tt.prepare != nil {
+				tt.prepare(stores)
+			}
+
+			tree, err := stores.api().FindTree(context.Background(), tt.uuid)
+
+			assertError(t, err, nil, tt.wantErr)
+			if err != nil {
+				return
+			}
+			if got := flatten(tree); !equalStrings(got, tt.want) {
+				t.Fatalf("got tree %v, want %v", got, tt.want)
+			}
+			for _, block := range tree.Blocks {
+				if block.Programs == nil {
+					t.Fatalf("block %s has nil programs", block.ID)
+				}
+				for _, program := range block.Programs {
+					for _, episode := range program.Episodes {
+						if episode.Medias == nil {
+							t.Fatalf("episode %s has nil medias", episode.ID)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWallTreeApi_FindTree_Batched(t *testing.T) {
+	small, large := newWallTreeStores(1), newWallTreeStores(25)
+
+	if _, err := small.api().FindTree(context.Background(), "w1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := large.api().FindTree(context.Background(), "w1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if small.calls() != 7 || large.calls() != small.calls() {
+		t.Fatalf("got %d persister calls for a small wall and %d for a large one, want 7 for both", small.calls(), large.calls())
+	}
+}
+
+func TestWallTreeApi_FindPublishedTree(t *testing.T) {
+	stores := newWallTreeStores(1)
+	for i := range stores.episodes.rows {
+		switch stores.episodes.rows[i].ID {
+		case "b1-p1-e2":
+			stores.episodes.rows[i].Status = model.EpisodeStatusDraft
+		case "b2-p1-e1":
+			stores.episodes.rows[i].Status = model.EpisodeStatusUnpublished
+		}
+	}
+
+	published, err := stores.api().FindPublishedTree(context.Background(), "w1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	all, err := stores.api().FindTree(context.Background(), "w1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"b2",
+		"b2/b2-p1",
+		"b2/b2-p1/b2-p1-e2/b2-p1-e2-m",
+		"b1",
+		"b1/b1-p1",
+		"b1/b1-p1/b1-p1-e1/b1-p1-e1-m",
+	}
+	if got := flatten(published); !equalStrings(got, want) {
+		t.Fatalf("got published tree %v, want %v", got, want)
+	}
+	if got := flatten(all); len(got) != 8 {
+		t.Fatalf("got tree %v, want every episode", got)
+	}
+}
@@ -0,0 +1,71 @@
+// Package model defines the data structures for the application domain.
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditActionCreate    = "create"    // An entity was created
+	AuditActionUpdate    = "update"    // An entity was updated
+	AuditActionDelete    = "delete"    // An entity was moved to the trash
+	AuditActionRestore   = "restore"   // An entity was restored from the trash
+	AuditActionOverwrite = "overwrite" // The associations of an entity were overwritten
+	AuditActionImport    = "import"    // A program was created or updated from a podcast feed
+	AuditActionPublish   = "publish"   // The publication status of an episode changed
+	AuditActionMove      = "move"      // A category was moved under another parent, along with its subcategories
+	AuditActionReorder   = "reorder"   // The positioned items of an entity were reordered
+	AuditActionAttach    = "attach"    // An item was associated with an entity
+	AuditActionDetach    = "detach"    // An item was dissociated from an entity
+)
+
+// Kinds of entities recorded in the audit log.
+const (
+	AuditEntityWall     = "wall"
+	AuditEntityBlock    = "block"
+	AuditEntityProgram  = "program"
+	AuditEntityEpisode  = "episode"
+	AuditEntityMedia    = "media"
+	AuditEntityTag      = "tag"
+	AuditEntityCategory = "category"
+)
+
+// AuditEntry represents a mutating operation recorded in the audit log.
+type AuditEntry struct {
+	ID        string    // Unique identifier for the entry
+	Actor     string    // Subject of the access token of the caller, empty when unknown
+	Action    string    // Operation performed, one of the AuditAction constants
+	Entity    string    // Kind of the entity, one of the AuditEntity constants
+	EntityID  string    // Identifier of the entity
+	Field     string    // Associations that were overwritten, for the overwrite action
+	Before    string    // JSON object of the fields changed by the operation, with their previous values
+	After     string    // JSON object of the fields changed by the operation, with their new values
+	RequestID string    // Identifier of the HTTP request that performed the operation
+	CreatedAt time.Time // Time the operation was performed
+}
+
+// AuditFilter narrows and pages the entries returned by the audit log.
+// The zero value returns every entry, most recent first.
+type AuditFilter struct {
+	Limit    int    // Maximum number of entries to return, 0 meaning no limit
+	Offset   int    // Number of matching entries to skip
+	Entity   string // Only keep entries about this kind of entity, ignored when empty
+	EntityID string // Only keep entries about this entity, ignored when empty
+	Actor    string // Only keep entries recorded for this actor, ignored when empty
+}
+
+// requestIDKey is the context key under which the identifier of the HTTP request is stored.
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the identifier of the HTTP request.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the identifier of the HTTP request stored in ctx, or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,88 @@
+// Package model defines the data structures for the application domain.
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Role represents the level of access of a back-office user.
+// Roles are ordered: every role is granted the permissions of the roles below it.
+type Role int
+
+const (
+	RoleNone      Role = iota // No back-office access
+	RoleViewer                // Can read the catalogue
+	RoleEditor                // Can also create, update and delete the catalogue
+	RolePublisher             // Can also publish episodes
+	RoleAdmin                 // Can do anything
+)
+
+// roleNames maps the roles to the names they are given in the access tokens.
+var roleNames = map[Role]string{
+	RoleNone:      "none",
+	RoleViewer:    "viewer",
+	RoleEditor:    "editor",
+	RolePublisher: "publisher",
+	RoleAdmin:     "admin",
+}
+
+// String returns the name of the role.
+func (r Role) String() string {
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+	return roleNames[RoleNone]
+}
+
+// ParseRole returns the role with the given name, ignoring case.
+// It returns false when the name is not a back-office role.
+func ParseRole(name string) (Role, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for role, roleName := range roleNames {
+		if role != RoleNone && roleName == name {
+			return role, true
+		}
+	}
+	return RoleNone, false
+}
+
+// Claims represents the identity carried by a verified access token.
+type Claims struct {
+	Subject   string    // Identifier of the user the token was issued to
+	Email     string    // Email address of the user, when the token carries it
+	Roles     []string  // Roles granted to the user
+	ExpiresAt time.Time // Time the token expires, zero when unknown
+}
+
+// Role returns the highest back-office role among the roles of the claims, ignoring the roles that are not back-office roles.
+func (c *Claims) Role() Role {
+	highest := RoleNone
+	for _, name := range c.Roles {
+		if role, ok := ParseRole(name); ok && role > highest {
+			highest = role
+		}
+	}
+	return highest
+}
+
+// Tokens represents the tokens issued by the account API when refreshing an expired access token.
+type Tokens struct {
+	AccessToken  string // New access token
+	RefreshToken string // New refresh token, empty when the refresh token is not rotated
+}
+
+// claimsKey is the context key under which the claims of the authenticated caller are stored.
+type claimsKey struct{}
+
+// ContextWithClaims returns a copy of ctx carrying the claims of the authenticated caller.
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims of the authenticated caller stored in ctx, if any.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok && claims != nil
+}
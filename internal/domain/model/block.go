@@ -1,6 +1,8 @@
 // Package model defines the data structures for the application domain.
 package model
 
+import "time"
+
 // Block represents a block entity in the system.
 // A Block is a logical grouping that can contain multiple Programs.
 type Block struct {
@@ -9,4 +11,6 @@ type Block struct {
 	Description string    // Description of the block
 	Kind        string    // Type or category of the block
 	Programs    []Program // List of programs associated with the block
+	CreatedAt   time.Time // Time the block was created
+	DeletedAt   time.Time // Time the block was moved to the trash, zero while it is live
 }
@@ -1,6 +1,8 @@
 // Package model defines the data structures for the application domain.
 package model
 
+import "time"
+
 // Category represents a category entity in the system.
 // A Category can have a hierarchical relationship with other categories, allowing for nested structures.
 type Category struct {
@@ -9,4 +11,7 @@ type Category struct {
 	Description string      // Description of the category
 	Parent      *Category   // Reference to the parent category, if any
 	Children    []*Category // List of child categories
+	CreatedAt   time.Time   // Time the category was created
+	UpdatedAt   time.Time   // Time the category was last updated
+	DeletedAt   time.Time   // Time the category was moved to the trash, zero while it is live
 }
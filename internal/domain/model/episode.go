@@ -1,13 +1,49 @@
 // Package model defines the data structures for the application domain.
 package model
 
+import (
+	"slices"
+	"time"
+)
+
+// EpisodeStatus represents the stage of an episode in the publication workflow.
+type EpisodeStatus string
+
+// Publication statuses of an episode.
+const (
+	EpisodeStatusDraft       EpisodeStatus = "draft"       // Being prepared, not shown to the listeners
+	EpisodeStatusScheduled   EpisodeStatus = "scheduled"   // Published automatically once its publication time is reached
+	EpisodeStatusPublished   EpisodeStatus = "published"   // Shown to the listeners
+	EpisodeStatusUnpublished EpisodeStatus = "unpublished" // Withdrawn from the listeners after being published
+)
+
+// episodeTransitions lists the statuses each status can move to.
+var episodeTransitions = map[EpisodeStatus][]EpisodeStatus{
+	EpisodeStatusDraft:       {EpisodeStatusScheduled, EpisodeStatusPublished},
+	EpisodeStatusScheduled:   {EpisodeStatusDraft, EpisodeStatusScheduled, EpisodeStatusPublished},
+	EpisodeStatusPublished:   {EpisodeStatusUnpublished},
+	EpisodeStatusUnpublished: {EpisodeStatusDraft, EpisodeStatusScheduled, EpisodeStatusPublished},
+}
+
+// CanBecome reports whether an episode with status s can move to status to.
+// A scheduled episode can be scheduled again, to change its publication time.
+func (s EpisodeStatus) CanBecome(to EpisodeStatus) bool {
+	return slices.Contains(episodeTransitions[s], to)
+}
+
 // Episode represents an episode entity in the system.
 // An Episode is a part of a Program and contains media content.
 type Episode struct {
-	ID          string // Unique identifier for the episode
-	Name        string // Name of the episode
-	Description string // Description of the episode
-	Position    int    // Position of the episode within its program
-	Media       Media  // Media content associated with the episode
-	ProgramID   string // Unique identifier for the associated program
+	ID          string        // Unique identifier for the episode
+	Name        string        // Name of the episode
+	Description string        // Description of the episode
+	Position    int           // Position of the episode within its program
+	Media       Media         // Media content associated with the episode
+	ProgramID   string        // Unique identifier for the associated program
+	SourceGUID  string        // GUID of the feed item the episode was imported from, empty when created by hand
+	Status      EpisodeStatus // Stage of the episode in the publication workflow
+	PublishAt   time.Time     // Time the episode is scheduled for or was published at, zero for drafts
+	CreatedAt   time.Time     // Time the episode was created
+	UpdatedAt   time.Time     // Time the episode was last updated
+	DeletedAt   time.Time     // Time the episode was moved to the trash, zero while it is live
 }
@@ -4,6 +4,21 @@ package model
 import (
 	"errors"
 	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when the requested entity, or an entity it references, does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when an operation conflicts with the stored data, such as a duplicate entry
+	// or the removal of an entity that is still referenced.
+	ErrConflict = errors.New("conflict")
+	// ErrInvalidToken is returned when an access token is malformed, badly signed or rejected by the account API.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired is returned when an access token is well formed and correctly signed but has expired.
+	ErrTokenExpired = errors.New("token expired")
+	// ErrInvalidFeed is returned when a podcast feed to import is not a well-formed RSS feed.
+	ErrInvalidFeed = errors.New("invalid feed")
 )
 
 // ValidationError represents an error that occurs due to invalid data in a specific field of a struct or input form.
@@ -29,3 +44,89 @@ func (vs ValidationErrors) Error() string {
 	}
 	return vErrs.Error()
 }
+
+// AsValidationErrors reports whether err wraps ValidationErrors and returns them if so.
+func AsValidationErrors(err error) (ValidationErrors, bool) {
+	var vErrs ValidationErrors
+	if errors.As(err, &vErrs) {
+		return vErrs, true
+	}
+	return nil, false
+}
+
+// Dependent identifies an entity that still references an entity being deleted.
+type Dependent struct {
+	Entity string // Kind of the dependent entity, one of the AuditEntity constants
+	ID     string // Unique identifier of the dependent entity
+	Name   string // Name of the dependent entity
+}
+
+// DependentsError is returned when an entity cannot be deleted because other entities still reference it.
+// It wraps ErrConflict.
+type DependentsError struct {
+	Entity     string      // Kind of the entity being deleted, one of the AuditEntity constants
+	ID         string      // Unique identifier of the entity being deleted
+	Dependents []Dependent // Entities still referencing it
+}
+
+// Error returns a string representation of the DependentsError, listing the dependents.
+func (e *DependentsError) Error() string {
+	dependents := make([]string, 0, len(e.Dependents))
+	for _, dependent := range e.Dependents {
+		dependents = append(dependents, dependent.Entity+" "+dependent.ID)
+	}
+	return fmt.Sprintf("%s %s is still referenced by %s: %v", e.Entity, e.ID, strings.Join(dependents, ", "), ErrConflict)
+}
+
+// Unwrap returns ErrConflict, so that errors.Is classifies a DependentsError as a conflict.
+func (e *DependentsError) Unwrap() error {
+	return ErrConflict
+}
+
+// AsDependentsError reports whether err wraps a DependentsError and returns it if so.
+func AsDependentsError(err error) (*DependentsError, bool) {
+	var dErr *DependentsError
+	if errors.As(err, &dErr) {
+		return dErr, true
+	}
+	return nil, false
+}
+
+// BulkItemError is the error of a single item of a bulk operation.
+type BulkItemError struct {
+	Index int   // Position of the item in the request, starting at 0
+	Err   error // Why the item failed
+}
+
+// BulkError is returned when items of a bulk operation fail, in which case none of the items is saved.
+// It wraps the errors of the failed items.
+type BulkError struct {
+	Items []BulkItemError // Failed items, in the order of the request
+}
+
+// Error returns a string representation of the BulkError, listing the failed items.
+func (e *BulkError) Error() string {
+	items := make([]string, 0, len(e.Items))
+	for _, item := range e.Items {
+		items = append(items, fmt.Sprintf("item %d: %v", item.Index, item.Err))
+	}
+	return strings.Join(items, "; ")
+}
+
+// Unwrap returns the errors of the failed items, so that errors.Is and errors.As look into each of them.
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Items))
+	for _, item := range e.Items {
+		errs = append(errs, item.Err)
+	}
+	return errs
+}
+
+// AsBulkError reports whether err wraps a BulkError and returns it if so.
+func AsBulkError(err error) (*BulkError, bool) {
+	var bErr *BulkError
+	if errors.As(err, &bErr) {
+		return bErr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,30 @@
+// Package model defines the data structures for the application domain.
+package model
+
+import "time"
+
+// ImportedFeed is a podcast feed published by another host, read to import its show as a program.
+type ImportedFeed struct {
+	GUID        string         // Identifier of the podcast: its podcast:guid, else the URL of the feed, else its website
+	Title       string         // Title of the podcast
+	Description string         // Description of the podcast
+	Keywords    []string       // iTunes keywords of the podcast
+	Items       []ImportedItem // Items in the order of the feed, skipping those with neither a GUID nor an enclosure
+}
+
+// ImportedItem is an episode of a podcast feed published by another host.
+type ImportedItem struct {
+	GUID        string              // GUID of the item, else the URL of its enclosure
+	Title       string              // Title of the item
+	Description string              // Description of the item
+	PublishedAt time.Time           // Publication date of the item, zero when missing or malformed
+	Duration    time.Duration       // iTunes duration of the item
+	Enclosures  []ImportedEnclosure // Enclosures of the item, the first one being its main media file
+}
+
+// ImportedEnclosure is a media file of an episode of a podcast feed published by another host.
+type ImportedEnclosure struct {
+	URL      string // URL the file is served from
+	MimeType string // MIME type of the file
+	Size     int64  // Size of the file in bytes, 0 when unknown
+}
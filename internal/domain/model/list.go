@@ -0,0 +1,26 @@
+// Package model defines the data structures for the application domain.
+package model
+
+import "time"
+
+// Fields collections can be sorted on.
+const (
+	SortByName      = "name"      // Sort by name
+	SortByCreatedAt = "createdAt" // Sort by creation time
+	SortByPosition  = "position"  // Sort by position, for episodes within their program
+	SortByKind      = "kind"      // Sort by kind, for blocks and medias
+	SortByDeletedAt = "deletedAt" // Sort by deletion time, for the rows in the trash
+)
+
+// ListOptions narrows, orders and pages the rows returned by the FindAll persister methods.
+// The zero value returns every live row, oldest first.
+type ListOptions struct {
+	Limit        int       // Maximum number of rows to return, 0 meaning no limit
+	Offset       int       // Number of matching rows to skip
+	Sort         string    // Field to sort on, one of the SortBy constants, defaults to SortByCreatedAt
+	Descending   bool      // Whether to sort in descending order
+	NameContains string    // Only keep rows whose name contains this text, ignored when empty
+	CreatedFrom  time.Time // Only keep rows created at or after this time, ignored when zero
+	CreatedTo    time.Time // Only keep rows created at or before this time, ignored when zero
+	Deleted      bool      // Return the rows in the trash instead of the live ones
+}
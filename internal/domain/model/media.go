@@ -1,11 +1,46 @@
 // Package model defines the data structures for the application domain.
 package model
 
+import "time"
+
 // Media represents a media entity in the system.
-// Media is associated with an episode and contains a direct link to the media content.
+// Media is associated with an episode and contains a direct link to the media content,
+// either set by hand or pointing to a file uploaded to the object store.
 type Media struct {
-	ID         string // Unique identifier for the media
-	DirectLink string // Direct link to the media content
-	Kind       string // Type or category of the media (e.g., audio, video)
-	EpisodeID  string // Unique identifier for the associated episode
+	ID          string        // Unique identifier for the media
+	DirectLink  string        // Direct link to the media content
+	Kind        string        // Type or category of the media (e.g., audio, video)
+	EpisodeID   string        // Unique identifier for the associated episode
+	StorageKey  string        // Key of the uploaded file in the object store, empty when the link is set by hand
+	Size        int64         // Size of the uploaded file in bytes
+	Checksum    string        // Hex encoded SHA-256 digest of the uploaded file
+	MimeType    string        // MIME type of the uploaded file
+	Codec       string        // Codec of the audio stream (e.g., mp3, aac, opus), empty when the file could not be probed
+	Duration    time.Duration // Duration of the audio stream
+	Bitrate     int           // Average bitrate of the audio stream in bits per second
+	Channels    int           // Number of audio channels
+	SampleRate  int           // Sample rate of the audio stream in hertz
+	Title       string        // Title embedded in the file tags
+	ArtworkKey  string        // Key of the artwork extracted from the file tags in the object store
+	ArtworkLink string        // Direct link to the artwork extracted from the file tags
+	CreatedAt   time.Time     // Time the media was created
+	UpdatedAt   time.Time     // Time the media was last updated
+	DeletedAt   time.Time     // Time the media was moved to the trash, zero while it is live
+}
+
+// AudioInfo describes the audio stream of a media file and the tags embedded in it, as probed from its content.
+type AudioInfo struct {
+	Codec      string        // Codec of the audio stream (e.g., mp3, aac, vorbis, opus)
+	Duration   time.Duration // Duration of the audio stream
+	Bitrate    int           // Average bitrate in bits per second
+	Channels   int           // Number of audio channels
+	SampleRate int           // Sample rate in hertz
+	Title      string        // Title tag, if any
+	Artwork    *Artwork      // Front cover or first picture tag, if any
+}
+
+// Artwork is a picture embedded in the tags of a media file.
+type Artwork struct {
+	MimeType string // MIME type of the picture (e.g., image/jpeg)
+	Data     []byte // Encoded picture
 }
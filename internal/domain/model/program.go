@@ -1,6 +1,8 @@
 // Package model defines the data structures for the application domain.
 package model
 
+import "time"
+
 // Program represents a program entity in the system.
 // A Program is a collection of episodes and contains metadata about the program.
 type Program struct {
@@ -8,4 +10,8 @@ type Program struct {
 	Name        string    // Name of the program
 	Description string    // Description of the program
 	Episodes    []Episode // List of episodes associated with the program
+	SourceGUID  string    // Identifier of the feed the program was imported from, empty when created by hand
+	CreatedAt   time.Time // Time the program was created
+	UpdatedAt   time.Time // Time the program was last updated
+	DeletedAt   time.Time // Time the program was moved to the trash, zero while it is live
 }
@@ -0,0 +1,19 @@
+// Package model defines the data structures for the application domain.
+package model
+
+// Kinds of entities a search looks into.
+const (
+	SearchKindProgram  = "program"  // Programs, matched on name and description
+	SearchKindEpisode  = "episode"  // Episodes, matched on name and description
+	SearchKindTag      = "tag"      // Tags, matched on name
+	SearchKindCategory = "category" // Categories, matched on name
+)
+
+// SearchHit represents an entity matching a search query.
+type SearchHit struct {
+	Kind        string  // Kind of the matching entity, one of the SearchKind constants
+	ID          string  // Unique identifier of the matching entity
+	Name        string  // Name of the matching entity
+	Description string  // Description of the matching entity
+	Score       float64 // Relevance of the entity for the query, higher is better
+}
@@ -1,10 +1,14 @@
 // Package model defines the data structures for the application domain.
 package model
 
+import "time"
+
 // Tag represents a tag entity in the system.
 // A Tag is used to categorize or label programs.
 type Tag struct {
-	ID          string // Unique identifier for the tag
-	Name        string // Name of the tag
-	Description string // Description of the tag
+	ID          string    // Unique identifier for the tag
+	Name        string    // Name of the tag
+	Description string    // Description of the tag
+	CreatedAt   time.Time // Time the tag was created
+	DeletedAt   time.Time // Time the tag was moved to the trash, zero while it is live
 }
@@ -1,11 +1,15 @@
 // Package model defines the data structures for the application domain.
 package model
 
+import "time"
+
 // Wall represents a wall entity in the system.
 // A Wall is a collection of blocks, each containing content and organizational metadata.
 type Wall struct {
-	ID          string  // Unique identifier for the wall
-	Name        string  // Name of the wall
-	Description string  // Description of the wall
-	Blocks      []Block // List of blocks associated with the wall
+	ID          string    // Unique identifier for the wall
+	Name        string    // Name of the wall
+	Description string    // Description of the wall
+	Blocks      []Block   // List of blocks associated with the wall
+	CreatedAt   time.Time // Time the wall was created
+	DeletedAt   time.Time // Time the wall was moved to the trash, zero while it is live
 }
@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"io"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// AudioProber defines the interface for describing the audio stream of media files from their content.
+type AudioProber interface {
+	// Probe reads the file from r, seeking as needed, and returns the description of its audio stream.
+	Probe(ctx context.Context, r io.ReadSeeker) (model.AudioInfo, error)
+}
+
+// MediaFetcher defines the interface for reading the files of medias registered by their direct link.
+type MediaFetcher interface {
+	// Open returns a reader of the file served at link, fetching the parts of the file as they are read.
+	Open(ctx context.Context, link string) (io.ReadSeekCloser, error)
+}
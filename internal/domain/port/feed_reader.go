@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+	"io"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// FeedReader defines the interface for reading the podcast feeds published by other hosts.
+type FeedReader interface {
+	// Read parses the RSS feed read from r.
+	Read(ctx context.Context, r io.Reader) (model.ImportedFeed, error)
+	// Fetch downloads and parses the RSS feed published at link.
+	Fetch(ctx context.Context, link string) (model.ImportedFeed, error)
+}
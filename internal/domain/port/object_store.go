@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+	"io"
+)
+
+// ObjectStore defines the interface for storing the files of the catalogue, such as uploaded medias.
+type ObjectStore interface {
+	// Put stores the content read from r under key, replacing any object with the same key,
+	// and returns the URL the object is served from.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) (string, error)
+	// Open returns a reader of the object stored under key, fails with model.ErrNotFound when there is none.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// Delete removes the object stored under key. Removing a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -4,6 +4,8 @@ package port
 
 import (
 	"context"
+	"time"
+
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 )
 
@@ -15,10 +17,17 @@ type WallPersister interface {
 	Update(ctx context.Context, id string, updates model.Wall) error
 	// Find retrieves a wall from the persistence layer by its ID.
 	Find(ctx context.Context, id string) (*model.Wall, error)
-	// FindAll retrieves all walls from the persistence layer.
-	FindAll(ctx context.Context) ([]*model.Wall, error)
-	// Delete removes a wall from the persistence layer by its ID.
+	// FindByIDs retrieves the walls with the given IDs in a single lookup, skipping unknown IDs.
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Wall, error)
+	// FindAll retrieves the walls matching the options from the persistence layer,
+	// along with the number of walls matching the filters regardless of the limit and offset.
+	FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Wall, int, error)
+	// Delete moves a wall to the trash by its ID. Trashed walls are ignored by the other lookups.
 	Delete(ctx context.Context, id string) error
+	// Restore brings back a wall from the trash by its ID.
+	Restore(ctx context.Context, id string) error
+	// Purge permanently removes the walls moved to the trash before the given time, and returns how many were removed.
+	Purge(ctx context.Context, before time.Time) (int, error)
 }
 
 // WallBlockPersister defines the interface for wall-block association persistence operations.
@@ -47,10 +56,17 @@ type BlockPersister interface {
 	Update(ctx context.Context, id string, updates model.Block) error
 	// Find retrieves a block from the persistence layer by its ID.
 	Find(ctx context.Context, id string) (*model.Block, error)
-	// FindAll retrieves all blocks from the persistence layer.
-	FindAll(ctx context.Context) ([]*model.Block, error)
-	// Delete removes a block from the persistence layer by its ID.
+	// FindByIDs retrieves the blocks with the given IDs in a single lookup, skipping unknown IDs.
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Block, error)
+	// FindAll retrieves the blocks matching the options from the persistence layer,
+	// along with the number of blocks matching the filters regardless of the limit and offset.
+	FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Block, int, error)
+	// Delete moves a block to the trash by its ID. Trashed blocks are ignored by the other lookups.
 	Delete(ctx context.Context, id string) error
+	// Restore brings back a block from the trash by its ID.
+	Restore(ctx context.Context, id string) error
+	// Purge permanently removes the blocks moved to the trash before the given time, and returns how many were removed.
+	Purge(ctx context.Context, before time.Time) (int, error)
 }
 
 // BlockProgramPersister defines the interface for block-program association persistence operations.
@@ -63,6 +79,8 @@ type BlockProgramPersister interface {
 	Find(ctx context.Context, id string) (*model.BlockProgram, error)
 	// FindByBlockID retrieves block-program associations by block ID.
 	FindByBlockID(ctx context.Context, id string) ([]*model.BlockProgram, error)
+	// FindByBlockIDs retrieves the block-program associations of several blocks in a single lookup, ordered by position.
+	FindByBlockIDs(ctx context.Context, ids []string) ([]*model.BlockProgram, error)
 	// FindByProgramID retrieves block-program associations by program ID.
 	FindByProgramID(ctx context.Context, id string) ([]*model.BlockProgram, error)
 	// FindByBlockIDAndProgramID retrieves block-program associations by both block ID and program ID.
@@ -79,10 +97,27 @@ type ProgramPersister interface {
 	Update(ctx context.Context, id string, updates model.Program) error
 	// Find retrieves a program from the persistence layer by its ID.
 	Find(ctx context.Context, id string) (*model.Program, error)
-	// FindAll retrieves all programs from the persistence layer.
-	FindAll(ctx context.Context) ([]*model.Program, error)
-	// Delete removes a program from the persistence layer by its ID.
+	// FindByIDs retrieves the programs with the given IDs in a single lookup, skipping unknown IDs.
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Program, error)
+	// FindBySourceGUID retrieves the program imported from the feed with the given identifier.
+	FindBySourceGUID(ctx context.Context, guid string) (*model.Program, error)
+	// FindByTagID retrieves the programs associated with a tag in a single lookup, ordered by name.
+	FindByTagID(ctx context.Context, id string) ([]*model.Program, error)
+	// FindByCategoryID retrieves the programs associated with a category in a single lookup, ordered by name.
+	FindByCategoryID(ctx context.Context, id string) ([]*model.Program, error)
+	// FindByCategoryIDs retrieves the programs associated with any of the given categories in a single lookup,
+	// each program once, ordered by name.
+	FindByCategoryIDs(ctx context.Context, ids []string) ([]*model.Program, error)
+	// FindAll retrieves the programs matching the options from the persistence layer,
+	// along with the number of programs matching the filters regardless of the limit and offset.
+	FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Program, int, error)
+	// Delete moves a program to the trash by its ID, along with its episodes and their medias.
+	// Trashed programs are ignored by the other lookups.
 	Delete(ctx context.Context, id string) error
+	// Restore brings back a program from the trash by its ID, along with the episodes and medias trashed with it.
+	Restore(ctx context.Context, id string) error
+	// Purge permanently removes the programs moved to the trash before the given time, and returns how many were removed.
+	Purge(ctx context.Context, before time.Time) (int, error)
 }
 
 // EpisodePersister defines the interface for episode persistence operations.
@@ -93,12 +128,28 @@ type EpisodePersister interface {
 	Update(ctx context.Context, id string, updates model.Episode) error
 	// Find retrieves an episode from the persistence layer by its ID.
 	Find(ctx context.Context, id string) (*model.Episode, error)
+	// FindByIDs retrieves the episodes with the given IDs in a single lookup, skipping unknown IDs.
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Episode, error)
 	// FindByProgramID retrieves episodes by program ID.
 	FindByProgramID(ctx context.Context, id string) ([]*model.Episode, error)
-	// FindAll retrieves all episodes from the persistence layer.
-	FindAll(ctx context.Context) ([]*model.Episode, error)
-	// Delete removes an episode from the persistence layer by its ID.
+	// FindByProgramIDs retrieves the episodes of several programs in a single lookup, ordered by position.
+	FindByProgramIDs(ctx context.Context, ids []string) ([]*model.Episode, error)
+	// FindAll retrieves the episodes matching the options from the persistence layer,
+	// along with the number of episodes matching the filters regardless of the limit and offset.
+	FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Episode, int, error)
+	// Delete moves an episode to the trash by its ID, along with its medias.
+	// Trashed episodes are ignored by the other lookups.
 	Delete(ctx context.Context, id string) error
+	// Restore brings back an episode from the trash by its ID, along with the medias trashed with it.
+	// It fails with model.ErrConflict while the program of the episode is in the trash.
+	Restore(ctx context.Context, id string) error
+	// Purge permanently removes the episodes moved to the trash before the given time, and returns how many were removed.
+	Purge(ctx context.Context, before time.Time) (int, error)
+	// UpdateStatus moves an episode from one publication status to another, along with its publication time.
+	// It fails with model.ErrConflict when the episode is no longer in the from status.
+	UpdateStatus(ctx context.Context, id string, from, to model.EpisodeStatus, publishAt time.Time) error
+	// FindDue retrieves the scheduled episodes whose publication time is at or before the given time, oldest first.
+	FindDue(ctx context.Context, now time.Time) ([]*model.Episode, error)
 }
 
 // MediaPersister defines the interface for media persistence operations.
@@ -106,13 +157,26 @@ type MediaPersister interface {
 	// Create creates a new media in the persistence layer.
 	Create(ctx context.Context, wall model.Media) error
 	// Update updates an existing media in the persistence layer identified by its ID.
+	// When updates has a direct link, the file of the media is replaced: its storage key, size, checksum,
+	// MIME type, audio description and artwork are set from updates, and cleared when empty there.
 	Update(ctx context.Context, id string, updates model.Media) error
 	// Find retrieves a media from the persistence layer by its ID.
 	Find(ctx context.Context, id string) (*model.Media, error)
-	// FindAll retrieves all medias from the persistence layer.
-	FindAll(ctx context.Context) ([]*model.Media, error)
-	// Delete removes a media from the persistence layer by its ID.
+	// FindByIDs retrieves the medias with the given IDs in a single lookup, skipping unknown IDs.
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Media, error)
+	// FindByEpisodeIDs retrieves the medias of several episodes in a single lookup, oldest first.
+	FindByEpisodeIDs(ctx context.Context, ids []string) ([]*model.Media, error)
+	// FindAll retrieves the medias matching the options from the persistence layer,
+	// along with the number of medias matching the filters regardless of the limit and offset.
+	FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Media, int, error)
+	// Delete moves a media to the trash by its ID. Trashed medias are ignored by the other lookups.
 	Delete(ctx context.Context, id string) error
+	// Restore brings back a media from the trash by its ID.
+	// It fails with model.ErrConflict while the episode of the media is in the trash.
+	Restore(ctx context.Context, id string) error
+	// Purge permanently removes the medias moved to the trash before the given time, and returns the removed medias,
+	// whose stored objects are left to the caller.
+	Purge(ctx context.Context, before time.Time) ([]*model.Media, error)
 }
 
 // TagPersister defines the interface for tag persistence operations.
@@ -123,10 +187,22 @@ type TagPersister interface {
 	Update(ctx context.Context, id string, updates model.Tag) error
 	// Find retrieves a tag from the persistence layer by its ID.
 	Find(ctx context.Context, id string) (*model.Tag, error)
-	// FindAll retrieves all tags from the persistence layer.
-	FindAll(ctx context.Context) ([]*model.Tag, error)
-	// Delete removes a tag from the persistence layer by its ID.
+	// FindByIDs retrieves the tags with the given IDs in a single lookup, skipping unknown IDs.
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Tag, error)
+	// FindByNames retrieves the tags with the given names in a single lookup, skipping unknown names.
+	// The tags in the trash are retrieved too, with their deletion time, as they still hold their name.
+	FindByNames(ctx context.Context, names []string) ([]*model.Tag, error)
+	// FindByProgramID retrieves the tags associated with a program in a single lookup, ordered by name.
+	FindByProgramID(ctx context.Context, id string) ([]*model.Tag, error)
+	// FindAll retrieves the tags matching the options from the persistence layer,
+	// along with the number of tags matching the filters regardless of the limit and offset.
+	FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Tag, int, error)
+	// Delete moves a tag to the trash by its ID. Trashed tags are ignored by the other lookups.
 	Delete(ctx context.Context, id string) error
+	// Restore brings back a tag from the trash by its ID.
+	Restore(ctx context.Context, id string) error
+	// Purge permanently removes the tags moved to the trash before the given time, and returns how many were removed.
+	Purge(ctx context.Context, before time.Time) (int, error)
 }
 
 // ProgramTagPersister defines the interface for program-tag association persistence operations.
@@ -155,10 +231,28 @@ type CategoryPersister interface {
 	Update(ctx context.Context, id string, updates model.Category) error
 	// Find retrieves a category from the persistence layer by its ID.
 	Find(ctx context.Context, id string) (*model.Category, error)
-	// FindAll retrieves all categories from the persistence layer.
-	FindAll(ctx context.Context) ([]*model.Category, error)
-	// Delete removes a category from the persistence layer by its ID.
+	// FindByIDs retrieves the categories with the given IDs in a single lookup, skipping unknown IDs.
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Category, error)
+	// FindByProgramID retrieves the categories associated with a program in a single lookup, ordered by name.
+	FindByProgramID(ctx context.Context, id string) ([]*model.Category, error)
+	// FindHierarchy retrieves every live category in a single lookup, ordered by name,
+	// each with the ID of its parent, so that the hierarchy can be assembled without a query per level.
+	FindHierarchy(ctx context.Context) ([]*model.Category, error)
+	// LockHierarchy locks the categories until the end of the ongoing transaction, so that the changes of the hierarchy
+	// checked against it are made one after the other.
+	LockHierarchy(ctx context.Context) error
+	// FindAll retrieves the categories matching the options from the persistence layer,
+	// along with the number of categories matching the filters regardless of the limit and offset.
+	FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Category, int, error)
+	// Move sets the parent of a category identified by its ID, making it a root category when parentID is empty.
+	// Its subcategories follow it, as they keep referencing it.
+	Move(ctx context.Context, id string, parentID string) error
+	// Delete moves a category to the trash by its ID. Trashed categories are ignored by the other lookups.
 	Delete(ctx context.Context, id string) error
+	// Restore brings back a category from the trash by its ID.
+	Restore(ctx context.Context, id string) error
+	// Purge permanently removes the categories moved to the trash before the given time, and returns how many were removed.
+	Purge(ctx context.Context, before time.Time) (int, error)
 }
 
 // ProgramCategoryPersister defines the interface for program-category association persistence operations.
@@ -178,3 +272,12 @@ type ProgramCategoryPersister interface {
 	// Delete removes a program-category association from the persistence layer by its ID.
 	Delete(ctx context.Context, id string) error
 }
+
+// AuditPersister defines the interface for audit log persistence operations.
+type AuditPersister interface {
+	// Create records a new audit entry in the persistence layer.
+	Create(ctx context.Context, entry model.AuditEntry) error
+	// FindAll retrieves the audit entries matching the filter from the persistence layer, most recent first,
+	// along with the number of entries matching the filter regardless of the limit and offset.
+	FindAll(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, int, error)
+}
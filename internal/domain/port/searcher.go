@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// Searcher defines the interface for full-text search across the catalogue.
+type Searcher interface {
+	// Search retrieves the programs, episodes, tags and categories matching the query,
+	// at most limit of each kind, each kind ordered from the most to the least relevant.
+	Search(ctx context.Context, query string, limit int) ([]*model.SearchHit, error)
+}
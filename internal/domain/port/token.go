@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// TokenVerifier defines the interface for verifying the access tokens of the callers.
+type TokenVerifier interface {
+	// Verify checks the access token and returns the claims it carries.
+	// It returns model.ErrInvalidToken or model.ErrTokenExpired when the token must be rejected.
+	Verify(ctx context.Context, token string) (*model.Claims, error)
+}
+
+// TokenRefresher defines the interface for exchanging a refresh token for new tokens.
+type TokenRefresher interface {
+	// Refresh exchanges the refresh token for a new access token, and possibly a new refresh token.
+	// It returns model.ErrInvalidToken when the refresh token is rejected.
+	Refresh(ctx context.Context, refreshToken string) (*model.Tokens, error)
+}
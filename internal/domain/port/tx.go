@@ -0,0 +1,10 @@
+package port
+
+import "context"
+
+// TxManager defines the interface for running several persistence operations as a single unit of work.
+type TxManager interface {
+	// WithinTx runs fn atomically: every change made through the persisters with the context given to fn
+	// is kept when fn returns nil and discarded when it returns an error.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,385 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+const (
+	// mpegSyncWindow is how far past the ID3v2 tag the first MPEG audio frame is looked for.
+	mpegSyncWindow = 64 << 10
+	// vbriOffset is the offset of the VBRI header in the first frame of the files encoded by Fraunhofer encoders.
+	vbriOffset = 4 + 32
+)
+
+// mpegBitrates lists the bitrates in kbit/s of MPEG audio frames, by version (1, or 2 and 2.5), layer and index.
+var mpegBitrates = [2][3][15]int{
+	{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	},
+	{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	},
+}
+
+// mpegSampleRates lists the sample rates of MPEG audio frames, by version (1, 2 and 2.5) and index.
+var mpegSampleRates = [3][3]int{
+	{44100, 48000, 32000},
+	{22050, 24000, 16000},
+	{11025, 12000, 8000},
+}
+
+// mpegFrame is the header of an MPEG audio frame.
+type mpegFrame struct {
+	version    int // 0 for MPEG-1, 1 for MPEG-2, 2 for MPEG-2.5
+	layer      int // 1 to 3
+	bitrate    int // bits per second
+	sampleRate int
+	channels   int
+	samples    int // samples per frame
+	length     int // bytes, header included
+}
+
+// parseMPEGFrame parses the MPEG audio frame header at the start of b.
+func parseMPEGFrame(b []byte) (mpegFrame, bool) {
+	if len(b) < 4 || b[0] != 0xff || b[1]&0xe0 != 0xe0 {
+		return mpegFrame{}, false
+	}
+	var frame mpegFrame
+	switch (b[1] >> 3) & 3 {
+	case 0:
+		frame.version = 2
+	case 2:
+		frame.version = 1
+	case 3:
+		frame.version = 0
+	default:
+		return mpegFrame{}, false
+	}
+	frame.layer = 4 - int((b[1]>>1)&3)
+	bitrateIndex, rateIndex, padding := int(b[2]>>4), int((b[2]>>2)&3), int((b[2]>>1)&1)
+	if frame.layer == 4 || bitrateIndex == 0 || bitrateIndex == 15 || rateIndex == 3 {
+		return mpegFrame{}, false
+	}
+	frame.bitrate = mpegBitrates[min(frame.version, 1)][frame.layer-1][bitrateIndex] * 1000
+	frame.sampleRate = mpegSampleRates[frame.version][rateIndex]
+	frame.channels = 2
+	if b[3]>>6 == 3 {
+		frame.channels = 1
+	}
+	switch {
+	case frame.layer == 1:
+		frame.samples = 384
+		frame.length = (12*frame.bitrate/frame.sampleRate + padding) * 4
+	case frame.layer == 3 && frame.version > 0:
+		frame.samples = 576
+	default:
+		frame.samples = 1152
+	}
+	if frame.layer > 1 {
+		frame.length = frame.samples/8*frame.bitrate/frame.sampleRate + padding
+	}
+	return frame, frame.length > 4
+}
+
+// sideInfoLength returns the length of the side information following the header of a layer III frame.
+func (frame mpegFrame) sideInfoLength() int {
+	switch {
+	case frame.version == 0 && frame.channels == 1:
+		return 17
+	case frame.version == 0:
+		return 32
+	case frame.channels == 1:
+		return 9
+	default:
+		return 17
+	}
+}
+
+// probeMP3 describes an MPEG audio file, optionally tagged with ID3v2 and ID3v1 tags.
+// The duration is taken from the Xing or VBRI header of variable bitrate files, and from the file size otherwise.
+func probeMP3(r io.ReadSeeker, size int64) (model.AudioInfo, error) {
+	var info model.AudioInfo
+
+	// Parse the ID3v2 tag heading the file, if any
+	start := int64(0)
+	header, err := readAtMost(r, 0, 10)
+	if err != nil {
+		return model.AudioInfo{}, err
+	}
+	if len(header) == 10 && string(header[:3]) == "ID3" {
+		tagSize := int64(syncsafe(header[6:10]))
+		if header[5]&0x10 != 0 {
+			tagSize += 10 // footer
+		}
+		if tag, err := readAt(r, 10, tagSize); err == nil {
+			parseID3v2(header[3], header[5], tag, &info)
+		}
+		start = 10 + tagSize
+	}
+
+	// Find the first frame, confirmed by the frame following it
+	window, err := readAtMost(r, start, mpegSyncWindow)
+	if err != nil {
+		return model.AudioInfo{}, err
+	}
+	offset := -1
+	var frame mpegFrame
+	for i := 0; i+4 <= len(window); i++ {
+		candidate, ok := parseMPEGFrame(window[i:])
+		if !ok {
+			continue
+		}
+		if next := i + candidate.length; next+4 <= len(window) {
+			if _, ok := parseMPEGFrame(window[next:]); !ok {
+				continue
+			}
+		}
+		offset, frame = i, candidate
+		break
+	}
+	if offset < 0 {
+		return model.AudioInfo{}, ErrUnknownFormat
+	}
+	info.Codec = []string{"mp1", "mp2", "mp3"}[frame.layer-1]
+	info.Channels = frame.channels
+	info.SampleRate = frame.sampleRate
+
+	// Deduce the size of the audio stream, excluding the ID3v1 tag ending the file, if any
+	audioSize := size - start - int64(offset)
+	if size >= 128 {
+		if trailer, err := readAt(r, size-128, 128); err == nil && string(trailer[:3]) == "TAG" {
+			audioSize -= 128
+			if info.Title == "" {
+				info.Title = strings.TrimRight(latin1(trailer[3:33]), " \x00")
+			}
+		}
+	}
+
+	// Prefer the frame count of the variable bitrate headers over the bitrate of the first frame
+	frames, streamSize := vbrHeader(window[offset:], frame)
+	if streamSize > 0 {
+		audioSize = streamSize
+	}
+	if frames > 0 {
+		info.Duration = samplesDuration(frames*int64(frame.samples), frame.sampleRate)
+		info.Bitrate = averageBitrate(audioSize, info.Duration)
+	} else {
+		info.Bitrate = frame.bitrate
+		info.Duration = samplesDuration(audioSize*8, frame.bitrate)
+	}
+	return info, nil
+}
+
+// vbrHeader returns the number of frames and the size of the stream recorded in the Xing, Info or VBRI header
+// carried by the first frame of b, or zeros when there is none.
+func vbrHeader(b []byte, frame mpegFrame) (int64, int64) {
+	if frame.layer != 3 {
+		return 0, 0
+	}
+	if xing := 4 + frame.sideInfoLength(); len(b) >= xing+16 {
+		if tag := string(b[xing : xing+4]); tag == "Xing" || tag == "Info" {
+			flags := binary.BigEndian.Uint32(b[xing+4:])
+			var frames, size int64
+			fields := b[xing+8:]
+			if flags&1 != 0 {
+				frames = int64(binary.BigEndian.Uint32(fields))
+				fields = fields[4:]
+			}
+			if flags&2 != 0 && len(fields) >= 4 {
+				size = int64(binary.BigEndian.Uint32(fields))
+			}
+			return frames, size
+		}
+	}
+	if len(b) >= vbriOffset+18 && string(b[vbriOffset:vbriOffset+4]) == "VBRI" {
+		return int64(binary.BigEndian.Uint32(b[vbriOffset+14:])), int64(binary.BigEndian.Uint32(b[vbriOffset+10:]))
+	}
+	return 0, 0
+}
+
+// syncsafe decodes an ID3v2 syncsafe integer, made of 7 bits per byte.
+func syncsafe(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n = n<<7 | int(c&0x7f)
+	}
+	return n
+}
+
+// unsynchronise reverts the ID3v2 unsynchronisation scheme, which inserts a zero byte after every 0xff.
+func unsynchronise(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte{0xff, 0x00}, []byte{0xff})
+}
+
+// parseID3v2 reads the title and the artwork of the ID3v2 tag of the given major version and flags.
+// The front cover is preferred over the other pictures.
+func parseID3v2(version, flags byte, tag []byte, info *model.AudioInfo) {
+	if version < 2 || version > 4 {
+		return
+	}
+	if version < 4 && flags&0x80 != 0 {
+		tag = unsynchronise(tag)
+	}
+	if flags&0x40 != 0 && version > 2 && len(tag) >= 4 {
+		extended := int(binary.BigEndian.Uint32(tag)) + 4
+		if version == 4 {
+			extended = syncsafe(tag[:4])
+		}
+		if extended > len(tag) {
+			return
+		}
+		tag = tag[extended:]
+	}
+
+	idLength, headerLength := 4, 10
+	if version == 2 {
+		idLength, headerLength = 3, 6
+	}
+	frontCover := false
+	for len(tag) >= headerLength && tag[0] != 0 {
+		id := string(tag[:idLength])
+		var frameSize int
+		switch version {
+		case 2:
+			frameSize = int(tag[3])<<16 | int(tag[4])<<8 | int(tag[5])
+		case 3:
+			frameSize = int(binary.BigEndian.Uint32(tag[4:]))
+		default:
+			frameSize = syncsafe(tag[4:8])
+		}
+		if frameSize < 0 || frameSize > len(tag)-headerLength {
+			return
+		}
+		data := tag[headerLength : headerLength+frameSize]
+		if version == 4 {
+			if tag[9]&0x01 != 0 && len(data) >= 4 {
+				data = data[4:] // data length indicator
+			}
+			if tag[9]&0x02 != 0 {
+				data = unsynchronise(data)
+			}
+		}
+		tag = tag[headerLength+frameSize:]
+
+		switch id {
+		case "TIT2", "TT2":
+			if len(data) > 1 && info.Title == "" {
+				info.Title, _ = id3Text(data[0], data[1:])
+			}
+		case "APIC", "PIC":
+			if frontCover {
+				continue
+			}
+			if artwork, cover := id3Picture(id, data); artwork != nil && (info.Artwork == nil || cover) {
+				info.Artwork, frontCover = artwork, cover
+			}
+		}
+	}
+}
+
+// id3Picture decodes an APIC frame, or a PIC frame of ID3v2.2, and reports whether the picture is the front cover.
+// It returns a nil artwork when the frame is malformed.
+func id3Picture(id string, data []byte) (*model.Artwork, bool) {
+	if len(data) < 2 {
+		return nil, false
+	}
+	encoding, data := data[0], data[1:]
+	var mimeType string
+	if id == "PIC" {
+		if len(data) < 3 {
+			return nil, false
+		}
+		mimeType, data = "image/"+strings.ToLower(string(data[:3])), data[3:]
+	} else {
+		end := bytes.IndexByte(data, 0)
+		if end < 0 {
+			return nil, false
+		}
+		mimeType, data = strings.ToLower(latin1(data[:end])), data[end+1:]
+	}
+	if len(data) < 1 {
+		return nil, false
+	}
+	pictureType, data := data[0], data[1:]
+	_, picture := id3Text(encoding, data)
+	if len(picture) == 0 {
+		return nil, false
+	}
+	return &model.Artwork{MimeType: pictureMimeType(mimeType), Data: picture}, pictureType == 3
+}
+
+// pictureMimeType normalizes the MIME type of an embedded picture, as some taggers write a bare format.
+func pictureMimeType(mimeType string) string {
+	switch mimeType {
+	case "image/jpg", "jpg", "jpeg":
+		return "image/jpeg"
+	case "png":
+		return "image/png"
+	}
+	if !strings.Contains(mimeType, "/") {
+		return "image/" + mimeType
+	}
+	return mimeType
+}
+
+// id3Text decodes the first string of an ID3v2 text field in the given encoding,
+// and returns the bytes following its terminator.
+func id3Text(encoding byte, b []byte) (string, []byte) {
+	switch encoding {
+	case 1, 2:
+		end := len(b) &^ 1
+		for i := 0; i+1 < len(b); i += 2 {
+			if b[i] == 0 && b[i+1] == 0 {
+				end = i
+				break
+			}
+		}
+		rest := b[min(end+2, len(b)):]
+		text := b[:end]
+		order := binary.ByteOrder(binary.BigEndian)
+		if encoding == 1 && len(text) >= 2 {
+			if text[0] == 0xff && text[1] == 0xfe {
+				order = binary.LittleEndian
+			}
+			if (text[0] == 0xff && text[1] == 0xfe) || (text[0] == 0xfe && text[1] == 0xff) {
+				text = text[2:]
+			}
+		}
+		units := make([]uint16, len(text)/2)
+		for i := range units {
+			units[i] = order.Uint16(text[2*i:])
+		}
+		return string(utf16.Decode(units)), rest
+	default:
+		end := bytes.IndexByte(b, 0)
+		rest := []byte(nil)
+		if end < 0 {
+			end = len(b)
+		} else {
+			rest = b[end+1:]
+		}
+		if encoding == 3 {
+			return string(b[:end]), rest
+		}
+		return latin1(b[:end]), rest
+	}
+}
+
+// latin1 decodes ISO-8859-1 text.
+func latin1(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
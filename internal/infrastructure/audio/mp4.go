@@ -0,0 +1,305 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// mp4Codecs maps the sample entry formats of the MP4 audio tracks to codec names.
+var mp4Codecs = map[string]string{
+	"mp4a": "aac",
+	"alac": "alac",
+	"Opus": "opus",
+	"fLaC": "flac",
+	"ac-3": "ac3",
+	"ec-3": "eac3",
+	".mp3": "mp3",
+}
+
+// mp4Track is the description of a track, gathered while walking its boxes.
+type mp4Track struct {
+	handler    string
+	timescale  uint32
+	duration   uint64
+	codec      string
+	channels   int
+	sampleRate int
+	bitrate    int
+}
+
+// mp4Probe gathers the description of an MP4 file while walking its boxes.
+type mp4Probe struct {
+	timescale uint32
+	duration  uint64
+	mdat      int64
+	audio     *mp4Track
+	title     string
+	artwork   *model.Artwork
+}
+
+// probeMP4 describes the first audio track of an MP4 or M4A file, along with the title and cover of its iTunes tags.
+// Only the movie box is read in memory; the media data is skipped.
+func probeMP4(r io.ReadSeeker, size int64) (model.AudioInfo, error) {
+	var p mp4Probe
+	for offset := int64(0); offset+8 <= size; {
+		header, err := readAt(r, offset, 8)
+		if err != nil {
+			return model.AudioInfo{}, err
+		}
+		boxSize, headerSize := int64(binary.BigEndian.Uint32(header)), int64(8)
+		switch boxSize {
+		case 0:
+			boxSize = size - offset
+		case 1:
+			large, err := readAt(r, offset+8, 8)
+			if err != nil {
+				return model.AudioInfo{}, err
+			}
+			boxSize, headerSize = int64(binary.BigEndian.Uint64(large)), 16
+		}
+		if boxSize < headerSize || boxSize > size-offset {
+			return model.AudioInfo{}, errors.New("invalid MP4 box size")
+		}
+		switch string(header[4:8]) {
+		case "moov":
+			moov, err := readAt(r, offset+headerSize, boxSize-headerSize)
+			if err != nil {
+				return model.AudioInfo{}, err
+			}
+			p.walk(moov, nil)
+		case "mdat":
+			p.mdat += boxSize - headerSize
+		}
+		offset += boxSize
+	}
+	if p.audio == nil {
+		return model.AudioInfo{}, ErrUnknownFormat
+	}
+
+	info := model.AudioInfo{
+		Codec:      p.audio.codec,
+		Channels:   p.audio.channels,
+		SampleRate: p.audio.sampleRate,
+		Bitrate:    p.audio.bitrate,
+		Title:      p.title,
+		Artwork:    p.artwork,
+	}
+	switch {
+	case p.audio.timescale > 0:
+		info.Duration = scaledDuration(p.audio.duration, p.audio.timescale)
+	case p.timescale > 0:
+		info.Duration = scaledDuration(p.duration, p.timescale)
+	}
+	if info.Bitrate == 0 {
+		info.Bitrate = averageBitrate(p.mdat, info.Duration)
+	}
+	return info, nil
+}
+
+// scaledDuration returns the duration of the given number of timescale units.
+func scaledDuration(duration uint64, timescale uint32) time.Duration {
+	return time.Duration(float64(duration) / float64(timescale) * float64(time.Second))
+}
+
+// walk goes through the boxes of b, the content of a container box.
+// track is the track being described, nil outside of a track box.
+func (p *mp4Probe) walk(b []byte, track *mp4Track) {
+	for len(b) >= 8 {
+		size, header := uint64(binary.BigEndian.Uint32(b)), uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(b))
+		case 1:
+			if len(b) < 16 {
+				return
+			}
+			size, header = binary.BigEndian.Uint64(b[8:]), 16
+		}
+		if size < header || size > uint64(len(b)) {
+			return
+		}
+		kind, body := string(b[4:8]), b[header:size]
+		b = b[size:]
+
+		switch kind {
+		case "trak":
+			t := &mp4Track{}
+			p.walk(body, t)
+			if t.handler == "soun" && t.codec != "" && p.audio == nil {
+				p.audio = t
+			}
+		case "mdia", "minf", "stbl":
+			p.walk(body, track)
+		case "udta":
+			p.walk(body, nil)
+		case "meta":
+			// The meta box is a full box in ISO files, and a plain container in QuickTime files
+			if len(body) >= 8 && string(body[4:8]) != "hdlr" {
+				body = body[4:]
+			}
+			p.walk(body, nil)
+		case "ilst":
+			p.items(body)
+		case "mvhd":
+			p.timescale, p.duration = mediaHeader(body)
+		case "mdhd":
+			if track != nil {
+				track.timescale, track.duration = mediaHeader(body)
+			}
+		case "hdlr":
+			if track != nil && len(body) >= 12 {
+				track.handler = string(body[8:12])
+			}
+		case "stsd":
+			if track != nil {
+				sampleDescription(body, track)
+			}
+		}
+	}
+}
+
+// mediaHeader returns the timescale and the duration recorded in a movie or media header box.
+func mediaHeader(b []byte) (uint32, uint64) {
+	switch {
+	case len(b) >= 32 && b[0] == 1:
+		return binary.BigEndian.Uint32(b[20:]), binary.BigEndian.Uint64(b[24:])
+	case len(b) >= 20 && b[0] == 0:
+		return binary.BigEndian.Uint32(b[12:]), uint64(binary.BigEndian.Uint32(b[16:]))
+	}
+	return 0, 0
+}
+
+// sampleDescription reads the codec, the channels and the sample rate of the first entry of a sample description box,
+// and the average bitrate of its elementary stream descriptor, if any.
+func sampleDescription(b []byte, track *mp4Track) {
+	if len(b) < 8+36 {
+		return
+	}
+	entry := b[8:]
+	size := binary.BigEndian.Uint32(entry)
+	if size < 36 || uint64(size) > uint64(len(entry)) {
+		return
+	}
+	entry = entry[:size]
+	format := string(entry[4:8])
+	track.codec = format
+	if codec, ok := mp4Codecs[format]; ok {
+		track.codec = codec
+	}
+	track.channels = int(binary.BigEndian.Uint16(entry[24:]))
+	track.sampleRate = int(binary.BigEndian.Uint32(entry[32:]) >> 16)
+
+	// Version 1 of the QuickTime sound description carries 16 more bytes before the child boxes
+	children := entry[36:]
+	if binary.BigEndian.Uint16(entry[16:]) == 1 && len(children) >= 16 {
+		children = children[16:]
+	}
+	for len(children) >= 8 {
+		childSize := binary.BigEndian.Uint32(children)
+		if childSize < 8 || uint64(childSize) > uint64(len(children)) {
+			return
+		}
+		if string(children[4:8]) == "esds" {
+			track.bitrate = esdsBitrate(children[8:childSize])
+		}
+		children = children[childSize:]
+	}
+}
+
+// esdsBitrate returns the average bitrate recorded in the decoder configuration of an elementary stream descriptor box.
+func esdsBitrate(b []byte) int {
+	if len(b) < 4 {
+		return 0
+	}
+	b = b[4:] // version and flags
+	tag, body := descriptor(b)
+	if tag != 0x03 || len(body) < 3 {
+		return 0
+	}
+	flags := body[2]
+	body = body[3:]
+	if flags&0x80 != 0 && len(body) >= 2 {
+		body = body[2:]
+	}
+	if flags&0x40 != 0 && len(body) >= 1 {
+		body = body[min(1+int(body[0]), len(body)):]
+	}
+	if flags&0x20 != 0 && len(body) >= 2 {
+		body = body[2:]
+	}
+	tag, body = descriptor(body)
+	if tag != 0x04 || len(body) < 13 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint32(body[9:]))
+}
+
+// descriptor returns the tag and the content of the MPEG-4 descriptor at the start of b.
+func descriptor(b []byte) (byte, []byte) {
+	if len(b) < 2 {
+		return 0, nil
+	}
+	tag, length, i := b[0], 0, 1
+	for ; i < len(b) && i <= 4; i++ {
+		length = length<<7 | int(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			i++
+			break
+		}
+	}
+	if i+length > len(b) {
+		return tag, b[i:]
+	}
+	return tag, b[i : i+length]
+}
+
+// items reads the title and the cover of an iTunes item list box.
+func (p *mp4Probe) items(b []byte) {
+	for len(b) >= 8 {
+		size := binary.BigEndian.Uint32(b)
+		if size < 8 || uint64(size) > uint64(len(b)) {
+			return
+		}
+		kind, item := string(b[4:8]), b[8:size]
+		b = b[size:]
+
+		// The value of an item is held by its data box: a type indicator and a locale heading the payload
+		if len(item) < 16 || string(item[4:8]) != "data" {
+			continue
+		}
+		dataSize := binary.BigEndian.Uint32(item)
+		if dataSize < 16 || uint64(dataSize) > uint64(len(item)) {
+			continue
+		}
+		dataType, payload := binary.BigEndian.Uint32(item[8:])&0xffffff, item[16:dataSize]
+		switch kind {
+		case "\xa9nam":
+			if p.title == "" {
+				p.title = string(payload)
+			}
+		case "covr":
+			if p.artwork == nil && len(payload) > 0 {
+				p.artwork = &model.Artwork{MimeType: coverMimeType(dataType, payload), Data: payload}
+			}
+		}
+	}
+}
+
+// coverMimeType returns the MIME type of a cover from the type indicator of its data box,
+// or from its content when the indicator is not an image type.
+func coverMimeType(dataType uint32, payload []byte) string {
+	switch dataType {
+	case 13:
+		return "image/jpeg"
+	case 14:
+		return "image/png"
+	case 27:
+		return "image/bmp"
+	}
+	return http.DetectContentType(payload)
+}
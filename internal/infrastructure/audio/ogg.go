@@ -0,0 +1,241 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+const (
+	// oggTailWindow is how far from the end of an Ogg file the last page is looked for.
+	oggTailWindow = 64 << 10
+	// opusGranuleRate is the rate of the granule positions of Opus streams, whatever their input sample rate.
+	opusGranuleRate = 48000
+)
+
+// oggPage is a page of an Ogg bitstream.
+type oggPage struct {
+	granule int64
+	serial  uint32
+	lacing  []byte
+	data    []byte
+}
+
+// readOggPage reads the Ogg page at the current position of r.
+func readOggPage(r io.Reader) (oggPage, error) {
+	header := make([]byte, 27)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return oggPage{}, err
+	}
+	if string(header[:4]) != "OggS" {
+		return oggPage{}, errors.New("invalid Ogg page")
+	}
+	page := oggPage{
+		granule: int64(binary.LittleEndian.Uint64(header[6:])),
+		serial:  binary.LittleEndian.Uint32(header[14:]),
+		lacing:  make([]byte, header[26]),
+	}
+	if _, err := io.ReadFull(r, page.lacing); err != nil {
+		return oggPage{}, err
+	}
+	size := 0
+	for _, lace := range page.lacing {
+		size += int(lace)
+	}
+	page.data = make([]byte, size)
+	if _, err := io.ReadFull(r, page.data); err != nil {
+		return oggPage{}, err
+	}
+	return page, nil
+}
+
+// probeOgg describes the first logical bitstream of an Ogg file, either Vorbis or Opus, along with the title
+// and the cover of its comments. The duration is taken from the granule position of the last page.
+func probeOgg(r io.ReadSeeker, size int64) (model.AudioInfo, error) {
+	identification, comments, serial, err := oggHeaders(r)
+	if err != nil {
+		return model.AudioInfo{}, err
+	}
+
+	var info model.AudioInfo
+	var rate int
+	var preSkip int64
+	switch {
+	case bytes.HasPrefix(identification, []byte("OpusHead")) && len(identification) >= 19:
+		info.Codec = "opus"
+		info.Channels = int(identification[9])
+		preSkip = int64(binary.LittleEndian.Uint16(identification[10:]))
+		info.SampleRate = int(binary.LittleEndian.Uint32(identification[12:]))
+		if info.SampleRate == 0 {
+			info.SampleRate = opusGranuleRate
+		}
+		rate = opusGranuleRate
+		comments = bytes.TrimPrefix(comments, []byte("OpusTags"))
+	case bytes.HasPrefix(identification, []byte("\x01vorbis")) && len(identification) >= 28:
+		info.Codec = "vorbis"
+		info.Channels = int(identification[11])
+		info.SampleRate = int(binary.LittleEndian.Uint32(identification[12:]))
+		info.Bitrate = int(int32(binary.LittleEndian.Uint32(identification[20:])))
+		rate = info.SampleRate
+		comments = bytes.TrimPrefix(comments, []byte("\x03vorbis"))
+	default:
+		return model.AudioInfo{}, ErrUnknownFormat
+	}
+	vorbisComments(comments, &info)
+
+	granule, err := lastGranule(r, size, serial)
+	if err != nil {
+		return model.AudioInfo{}, err
+	}
+	info.Duration = samplesDuration(granule-preSkip, rate)
+	if bitrate := averageBitrate(size, info.Duration); bitrate > 0 {
+		info.Bitrate = bitrate
+	}
+	return info, nil
+}
+
+// oggHeaders returns the first two packets of the first logical bitstream of r, the identification and the
+// comment headers of Vorbis and Opus streams, along with the serial number of the bitstream.
+func oggHeaders(r io.ReadSeeker) ([]byte, []byte, uint32, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, 0, err
+	}
+	br := bufio.NewReader(r)
+	var packets [][]byte
+	var packet []byte
+	var serial uint32
+	for pages, read := 0, 0; len(packets) < 2; pages++ {
+		page, err := readOggPage(br)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if pages == 0 {
+			serial = page.serial
+		}
+		if read += len(page.data); read > maxMetadata {
+			return nil, nil, 0, errors.New("Ogg headers too large")
+		}
+		if page.serial != serial {
+			continue
+		}
+		data := page.data
+		for _, lace := range page.lacing {
+			packet, data = append(packet, data[:lace]...), data[lace:]
+			if lace < 255 {
+				packets, packet = append(packets, packet), nil
+				if len(packets) == 2 {
+					break
+				}
+			}
+		}
+	}
+	return packets[0], packets[1], serial, nil
+}
+
+// lastGranule returns the granule position of the last page of the bitstream with the given serial number.
+func lastGranule(r io.ReadSeeker, size int64, serial uint32) (int64, error) {
+	offset := max(size-oggTailWindow, 0)
+	tail, err := readAtMost(r, offset, size-offset)
+	if err != nil {
+		return 0, err
+	}
+	for i := bytes.LastIndex(tail, []byte("OggS")); i >= 0; i = bytes.LastIndex(tail[:i], []byte("OggS")) {
+		if i+27 > len(tail) || binary.LittleEndian.Uint32(tail[i+14:]) != serial {
+			continue
+		}
+		if granule := int64(binary.LittleEndian.Uint64(tail[i+6:])); granule >= 0 {
+			return granule, nil
+		}
+	}
+	return 0, errors.New("no Ogg page with a granule position at the end of the file")
+}
+
+// vorbisComments reads the title and the cover of a Vorbis comment header, shared by Vorbis and Opus streams.
+func vorbisComments(b []byte, info *model.AudioInfo) {
+	field := func() ([]byte, bool) {
+		if len(b) < 4 {
+			return nil, false
+		}
+		length := binary.LittleEndian.Uint32(b)
+		if uint64(length) > uint64(len(b)-4) {
+			return nil, false
+		}
+		value := b[4 : 4+length]
+		b = b[4+length:]
+		return value, true
+	}
+	if _, ok := field(); !ok { // vendor
+		return
+	}
+	if len(b) < 4 {
+		return
+	}
+	count := binary.LittleEndian.Uint32(b)
+	b = b[4:]
+	for ; count > 0; count-- {
+		comment, ok := field()
+		if !ok {
+			return
+		}
+		key, value, ok := strings.Cut(string(comment), "=")
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(key) {
+		case "TITLE":
+			if info.Title == "" {
+				info.Title = value
+			}
+		case "METADATA_BLOCK_PICTURE":
+			if picture, err := base64.StdEncoding.DecodeString(value); err == nil {
+				if artwork, cover := flacPicture(picture); artwork != nil && (info.Artwork == nil || cover) {
+					info.Artwork = artwork
+				}
+			}
+		}
+	}
+}
+
+// flacPicture decodes a FLAC picture block and reports whether the picture is the front cover.
+// It returns a nil artwork when the block is malformed.
+func flacPicture(b []byte) (*model.Artwork, bool) {
+	field := func() ([]byte, bool) {
+		if len(b) < 4 {
+			return nil, false
+		}
+		length := binary.BigEndian.Uint32(b)
+		if uint64(length) > uint64(len(b)-4) {
+			return nil, false
+		}
+		value := b[4 : 4+length]
+		b = b[4+length:]
+		return value, true
+	}
+	if len(b) < 4 {
+		return nil, false
+	}
+	pictureType := binary.BigEndian.Uint32(b)
+	b = b[4:]
+	mimeType, ok := field()
+	if !ok {
+		return nil, false
+	}
+	if _, ok := field(); !ok { // description
+		return nil, false
+	}
+	if len(b) < 16 {
+		return nil, false
+	}
+	b = b[16:] // width, height, depth and colors
+	data, ok := field()
+	if !ok || len(data) == 0 {
+		return nil, false
+	}
+	return &model.Artwork{MimeType: pictureMimeType(strings.ToLower(string(mimeType))), Data: data}, pictureType == 3
+}
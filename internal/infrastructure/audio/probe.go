@@ -0,0 +1,97 @@
+// Package audio provides a pure Go prober of the audio files the medias point to.
+// It reads the stream parameters and the embedded tags of MP3, MP4/M4A and Ogg (Vorbis and Opus) files,
+// without decoding any audio.
+package audio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// ErrUnknownFormat is returned when a file is not in one of the supported audio formats.
+var ErrUnknownFormat = errors.New("unknown audio format")
+
+// maxMetadata is the largest metadata structure read in memory, which guards against corrupted sizes.
+const maxMetadata = 32 << 20
+
+// prober is an implementation of the AudioProber interface recognizing the format of a file from its first bytes.
+type prober struct{}
+
+// NewProber creates a new instance of AudioProber.
+func NewProber() port.AudioProber {
+	return &prober{}
+}
+
+// Probe describes the audio stream of the file read from r.
+// It returns ErrUnknownFormat when the file is neither an MP3, an MP4/M4A nor an Ogg file.
+func (p *prober) Probe(_ context.Context, r io.ReadSeeker) (model.AudioInfo, error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return model.AudioInfo{}, err
+	}
+	head, err := readAtMost(r, 0, 12)
+	if err != nil {
+		return model.AudioInfo{}, err
+	}
+
+	switch {
+	case bytes.HasPrefix(head, []byte("OggS")):
+		return probeOgg(r, size)
+	case len(head) >= 8 && string(head[4:8]) == "ftyp":
+		return probeMP4(r, size)
+	case bytes.HasPrefix(head, []byte("ID3")), len(head) >= 2 && head[0] == 0xff && head[1]&0xe0 == 0xe0:
+		return probeMP3(r, size)
+	}
+	return model.AudioInfo{}, ErrUnknownFormat
+}
+
+// readAt reads exactly n bytes of r from offset.
+func readAt(r io.ReadSeeker, offset, n int64) ([]byte, error) {
+	if n < 0 || n > maxMetadata {
+		return nil, fmt.Errorf("invalid metadata size %d", n)
+	}
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// readAtMost reads up to n bytes of r from offset, less when the file ends first.
+func readAtMost(r io.ReadSeeker, offset, n int64) ([]byte, error) {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	read, err := io.ReadFull(r, b)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return b[:read], nil
+}
+
+// samplesDuration returns the duration of the given number of samples played at rate.
+func samplesDuration(samples int64, rate int) time.Duration {
+	if samples <= 0 || rate <= 0 {
+		return 0
+	}
+	return time.Duration(samples * int64(time.Second) / int64(rate))
+}
+
+// averageBitrate returns the bitrate, in bits per second, of the given number of bytes played over d.
+func averageBitrate(size int64, d time.Duration) int {
+	if size <= 0 || d <= 0 {
+		return 0
+	}
+	return int(float64(size*8) / d.Seconds())
+}
@@ -0,0 +1,226 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// be32 encodes n as a big-endian 32-bit integer.
+func be32(n int) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(n))
+}
+
+// le32 encodes n as a little-endian 32-bit integer.
+func le32(n int) []byte {
+	return binary.LittleEndian.AppendUint32(nil, uint32(n))
+}
+
+// join concatenates byte slices.
+func join(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+// id3Frame builds an ID3v2.3 frame.
+func id3Frame(id string, data []byte) []byte {
+	return join([]byte(id), be32(len(data)), []byte{0, 0}, data)
+}
+
+// id3Tag builds an ID3v2.3 tag holding the given frames.
+func id3Tag(frames ...[]byte) []byte {
+	body := join(frames...)
+	size := len(body)
+	return join([]byte{'I', 'D', '3', 3, 0, 0}, []byte{byte(size >> 21 & 0x7f), byte(size >> 14 & 0x7f), byte(size >> 7 & 0x7f), byte(size & 0x7f)}, body)
+}
+
+// utf16LE encodes s as UTF-16 with a little-endian byte order mark, as written by ID3v2.3 taggers.
+func utf16LE(s string) []byte {
+	b := []byte{0xff, 0xfe}
+	for _, unit := range utf16.Encode([]rune(s)) {
+		b = binary.LittleEndian.AppendUint16(b, unit)
+	}
+	return b
+}
+
+// mpegFrames builds count MPEG-1 layer III frames of 128 kbit/s at 44.1 kHz in stereo, the first one carrying first.
+func mpegFrames(count int, first []byte) []byte {
+	var b []byte
+	for i := 0; i < count; i++ {
+		frame := make([]byte, 417)
+		copy(frame, []byte{0xff, 0xfb, 0x90, 0x00})
+		if i == 0 {
+			copy(frame[4:], first)
+		}
+		b = append(b, frame...)
+	}
+	return b
+}
+
+// box builds an MP4 box.
+func box(kind string, payload ...[]byte) []byte {
+	body := join(payload...)
+	return join(be32(8+len(body)), []byte(kind), body)
+}
+
+// buildOggPage builds an Ogg page holding data, split into segments by the given lacing values.
+func buildOggPage(granule int64, serial int, lacing []byte, data []byte) []byte {
+	header := join([]byte("OggS"), []byte{0, 0}, binary.LittleEndian.AppendUint64(nil, uint64(granule)), le32(serial), le32(0), le32(0), []byte{byte(len(lacing))})
+	return join(header, lacing, data)
+}
+
+// lacing returns the lacing values of a packet of the given size.
+func lacing(size int) []byte {
+	b := bytes.Repeat([]byte{255}, size/255)
+	return append(b, byte(size%255))
+}
+
+func TestProber_Probe(t *testing.T) {
+	jpeg := []byte("\xff\xd8\xff\xe0jpeg cover")
+	png := []byte("\x89PNG\r\n\x1a\npng cover")
+
+	// An Opus stream whose comment header spans two pages, ending 10 seconds after its pre-skip
+	cover := append(append([]byte{}, jpeg...), make([]byte, 600)...)
+	picture := join(be32(3), be32(len("image/jpeg")), []byte("image/jpeg"), be32(0), make([]byte, 16), be32(len(cover)), cover)
+	head := join([]byte("OpusHead"), []byte{1, 2}, []byte{0x38, 0x01}, le32(44100), []byte{0, 0, 0})
+	comments := join([]byte("OpusTags"), le32(4), []byte("test"), le32(2),
+		le32(len("TITLE=Pilot")), []byte("TITLE=Pilot"),
+		le32(len("METADATA_BLOCK_PICTURE=")+base64.StdEncoding.EncodedLen(len(picture))), []byte("METADATA_BLOCK_PICTURE="), []byte(base64.StdEncoding.EncodeToString(picture)),
+	)
+	commentLacing := lacing(len(comments))
+	opus := join(
+		buildOggPage(0, 7, lacing(len(head)), head),
+		buildOggPage(-1, 7, commentLacing[:2], comments[:510]),
+		buildOggPage(0, 7, commentLacing[2:], comments[510:]),
+		buildOggPage(24000, 7, lacing(100), make([]byte, 100)),
+		buildOggPage(312+480000, 7, lacing(100), make([]byte, 100)),
+	)
+
+	tests := []struct {
+		name string
+		file []byte
+		want model.AudioInfo
+	}{
+		{
+			name: "describes a constant bitrate MP3 and its ID3v2 tag",
+			file: join(
+				id3Tag(
+					id3Frame("TIT2", join([]byte{1}, utf16LE("Pilot"), []byte{0, 0})),
+					id3Frame("APIC", join([]byte{0}, []byte("image/png\x00"), []byte{0}, []byte("back\x00"), png)),
+					id3Frame("APIC", join([]byte{0}, []byte("image/jpeg\x00"), []byte{3}, []byte("front\x00"), jpeg)),
+				),
+				mpegFrames(100, nil),
+			),
+			want: model.AudioInfo{
+				Codec:      "mp3",
+				Duration:   2606250 * time.Microsecond,
+				Bitrate:    128000,
+				Channels:   2,
+				SampleRate: 44100,
+				Title:      "Pilot",
+				Artwork:    &model.Artwork{MimeType: "image/jpeg", Data: jpeg},
+			},
+		},
+		{
+			name: "takes the duration of a variable bitrate MP3 from its Xing header",
+			file: mpegFrames(2, join(make([]byte, 32), []byte("Xing"), be32(3), be32(1225), be32(512000))),
+			want: model.AudioInfo{
+				Codec:      "mp3",
+				Duration:   32 * time.Second,
+				Bitrate:    128000,
+				Channels:   2,
+				SampleRate: 44100,
+			},
+		},
+		{
+			name: "describes the audio track and the iTunes tags of an M4A file",
+			file: join(
+				box("ftyp", []byte("M4A "), be32(0), []byte("M4A isom")),
+				box("mdat", make([]byte, 64)),
+				box("moov",
+					box("mvhd", be32(0), be32(0), be32(0), be32(1000), be32(90000), make([]byte, 80)),
+					box("trak",
+						box("mdia",
+							box("mdhd", be32(0), be32(0), be32(0), be32(44100), be32(44100*90), make([]byte, 4)),
+							box("hdlr", be32(0), be32(0), []byte("soun"), make([]byte, 13)),
+							box("minf",
+								box("stbl",
+									box("stsd", be32(0), be32(1),
+										box("mp4a", make([]byte, 6), []byte{0, 1}, make([]byte, 8), []byte{0, 2, 0, 16, 0, 0, 0, 0}, be32(44100<<16),
+											box("esds", be32(0),
+												[]byte{0x03, 18, 0, 1, 0},
+												[]byte{0x04, 13, 0x40, 0x15, 0, 0, 0}, be32(128000), be32(96000),
+											),
+										),
+									),
+								),
+							),
+						),
+					),
+					box("udta",
+						box("meta", be32(0),
+							box("hdlr", be32(0), be32(0), []byte("mdir"), make([]byte, 13)),
+							box("ilst",
+								box("\xa9nam", box("data", be32(1), be32(0), []byte("Pilot"))),
+								box("covr", box("data", be32(14), be32(0), png)),
+							),
+						),
+					),
+				),
+			),
+			want: model.AudioInfo{
+				Codec:      "aac",
+				Duration:   90 * time.Second,
+				Bitrate:    96000,
+				Channels:   2,
+				SampleRate: 44100,
+				Title:      "Pilot",
+				Artwork:    &model.Artwork{MimeType: "image/png", Data: png},
+			},
+		},
+		{
+			name: "describes an Opus stream and its comments",
+			file: opus,
+			want: model.AudioInfo{
+				Codec:      "opus",
+				Duration:   10 * time.Second,
+				Bitrate:    len(opus) * 8 / 10,
+				Channels:   2,
+				SampleRate: 44100,
+				Title:      "Pilot",
+				Artwork:    &model.Artwork{MimeType: "image/jpeg", Data: cover},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewProber().Probe(context.Background(), bytes.NewReader(tt.file))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			gotArtwork, wantArtwork := got.Artwork, tt.want.Artwork
+			got.Artwork, tt.want.Artwork = nil, nil
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			if (gotArtwork == nil) != (wantArtwork == nil) || gotArtwork != nil && (gotArtwork.MimeType != wantArtwork.MimeType || !bytes.Equal(gotArtwork.Data, wantArtwork.Data)) {
+				t.Fatalf("got artwork %+v, want %+v", gotArtwork, wantArtwork)
+			}
+		})
+	}
+}
+
+func TestProber_Probe_UnknownFormat(t *testing.T) {
+	_, err := NewProber().Probe(context.Background(), bytes.NewReader([]byte("plain text, not audio")))
+
+	if !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("got error %v, want %v", err, ErrUnknownFormat)
+	}
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// leeway is the clock skew tolerated between the account API and this service when checking token times.
+const leeway = 30 * time.Second
+
+// signingMethods are the asymmetric algorithms accepted for access tokens.
+// Symmetric algorithms are refused: the public keys of the account API must never be usable as HMAC secrets.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// localVerifier verifies the signature and the registered claims of JWT access tokens against the keys of the account API.
+type localVerifier struct {
+	keys   keySource
+	parser *jwt.Parser
+}
+
+// newLocalVerifier creates a verifier checking tokens against keys.
+// The issuer and audience of the tokens are only checked when not empty.
+func newLocalVerifier(keys keySource, issuer, audience string) *localVerifier {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+	return &localVerifier{
+		keys:   keys,
+		parser: jwt.NewParser(options...),
+	}
+}
+
+// Verify checks the signature of the token and its registered claims, and returns the claims it carries.
+// Tokens that are not JWTs or are signed with an unknown key are reported as unverifiable, along with model.ErrInvalidToken.
+func (v *localVerifier) Verify(ctx context.Context, token string) (*model.Claims, error) {
+	var claims tokenClaims
+	_, err := v.parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.key(ctx, kid)
+	})
+	switch {
+	case err == nil:
+		return claims.toDomainModel(), nil
+	case errors.Is(err, errUnverifiable):
+		return nil, err
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return nil, fmt.Errorf("%w: %w: %w", model.ErrInvalidToken, errUnverifiable, err)
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return nil, fmt.Errorf("%w: %w", model.ErrTokenExpired, err)
+	default:
+		return nil, fmt.Errorf("%w: %w", model.ErrInvalidToken, err)
+	}
+}
+
+// tokenClaims is the payload of the access tokens issued by the account API.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+	Role  string   `json:"role"`
+}
+
+// toDomainModel converts the token payload to a model.Claims domain model.
+// A single role given in the role claim is merged into the roles.
+func (c tokenClaims) toDomainModel() *model.Claims {
+	claims := &model.Claims{
+		Subject: c.Subject,
+		Email:   c.Email,
+		Roles:   c.Roles,
+	}
+	if c.Role != "" {
+		claims.Roles = append(claims.Roles, c.Role)
+	}
+	if c.ExpiresAt != nil {
+		claims.ExpiresAt = c.ExpiresAt.Time
+	}
+	return claims
+}
@@ -0,0 +1,231 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// minRefreshInterval bounds how often tokens signed with an unknown key ID trigger a new fetch of the JWKS,
+// so that forged key IDs cannot be used to flood the account API.
+const minRefreshInterval = 30 * time.Second
+
+// keySource provides the public keys the access tokens are signed with.
+type keySource interface {
+	key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+// staticKey is a keySource made of a single configured public key, used whatever the key ID of the token.
+type staticKey struct {
+	publicKey crypto.PublicKey
+}
+
+// key returns the configured key.
+func (s staticKey) key(_ context.Context, _ string) (crypto.PublicKey, error) {
+	return s.publicKey, nil
+}
+
+// parsePublicKey parses a PEM encoded PKIX or PKCS #1 public key.
+// Escaped new lines are accepted so that the key can be given in a single line environment variable.
+func parsePublicKey(data string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(strings.ReplaceAll(data, `\n`, "\n")))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// jwks is a keySource backed by the JSON Web Key Set published by the account API.
+// The key set is cached for ttl, and fetched again early when a token is signed with a key it does not hold,
+// which happens when the account API rotates its keys.
+type jwks struct {
+	client *http.Client
+	url    string
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// newJWKS creates a keySource fetching the key set at url with client and caching it for ttl.
+func newJWKS(client *http.Client, url string, ttl time.Duration) *jwks {
+	return &jwks{
+		client: client,
+		url:    url,
+		ttl:    ttl,
+	}
+}
+
+// key returns the key identified by kid, fetching the key set when it is stale or does not hold the key.
+// Tokens without key ID are accepted when the key set holds a single key.
+// A key that is already cached keeps being served when the key set cannot be fetched again.
+func (s *jwks) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, known := s.lookup(kid)
+	age := time.Since(s.fetchedAt)
+	if known && age < s.ttl {
+		return key, nil
+	}
+	if age >= s.ttl || age >= minRefreshInterval {
+		if err := s.refresh(ctx); err != nil {
+			if known {
+				return key, nil
+			}
+			return nil, fmt.Errorf("%w: %w", errUnverifiable, err)
+		}
+		key, known = s.lookup(kid)
+	}
+	if !known {
+		return nil, fmt.Errorf("%w: %w: unknown key %q", model.ErrInvalidToken, errUnverifiable, kid)
+	}
+	return key, nil
+}
+
+// lookup finds the key identified by kid in the cached key set.
+func (s *jwks) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// refresh fetches the key set and replaces the cached one.
+// Keys that are not meant for signatures or cannot be parsed are skipped.
+func (s *jwks) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("error occurred while creating the JWKS request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error occurred while fetching the JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error occurred while fetching the JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("error occurred while decoding the JWKS: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	return nil
+}
+
+// jsonWebKey is a public key of a JWKS, as defined by RFC 7517 and RFC 8037.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey decodes the RSA, elliptic curve or Ed25519 public key held by the JWK.
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent out of range")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// decodeBigInt decodes a base64url encoded big-endian unsigned integer.
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
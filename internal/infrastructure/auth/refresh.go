@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// refreshEndpoint is the account API endpoint exchanging refresh tokens for new tokens.
+const refreshEndpoint = "/private/token/refresh"
+
+// NewRefresher creates a token refresher calling the account API described by the configuration.
+// It returns an error when the base URL of the account API is not configured.
+func NewRefresher(config configuration.AccountApi) (port.TokenRefresher, error) {
+	if config.BaseURL == "" {
+		return nil, errors.New("no account api base URL configured")
+	}
+	return &remoteRefresher{
+		client: &http.Client{Timeout: config.Timeout},
+		url:    config.BaseURL + refreshEndpoint,
+	}, nil
+}
+
+// remoteRefresher exchanges refresh tokens by calling the refresh endpoint of the account API.
+type remoteRefresher struct {
+	client *http.Client
+	url    string
+}
+
+// Refresh sends the refresh token to the account API and returns the tokens it issues.
+// The account API answers either with a JSON object holding the tokens or with the new access token as plain text.
+func (r *remoteRefresher) Refresh(ctx context.Context, refreshToken string) (*model.Tokens, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred while creating the token refresh request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("refresh_token", "Bearer "+refreshToken)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred while refreshing the token with the account api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("%w: refresh token rejected by the account api", model.ErrInvalidToken)
+	default:
+		return nil, fmt.Errorf("error occurred while refreshing the token with the account api: unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("error occurred while reading the token refresh response: %w", err)
+	}
+	tokens := parseTokens(body)
+	if tokens.AccessToken == "" {
+		return nil, errors.New("error occurred while refreshing the token with the account api: no access token in response")
+	}
+	return tokens, nil
+}
+
+// refreshResponse is the JSON token refresh response of the account API.
+type refreshResponse struct {
+	AccessToken       string `json:"access_token"`
+	AccessTokenCamel  string `json:"accessToken"`
+	Token             string `json:"token"`
+	RefreshToken      string `json:"refresh_token"`
+	RefreshTokenCamel string `json:"refreshToken"`
+}
+
+// parseTokens reads the tokens from a token refresh response, which is either JSON or the plain access token.
+func parseTokens(body []byte) *model.Tokens {
+	body = bytes.TrimSpace(body)
+	var resp refreshResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return &model.Tokens{AccessToken: string(bytes.Trim(body, `"`))}
+	}
+	return &model.Tokens{
+		AccessToken:  firstNonEmpty(resp.AccessToken, resp.AccessTokenCamel, resp.Token),
+		RefreshToken: firstNonEmpty(resp.RefreshToken, resp.RefreshTokenCamel),
+	}
+}
+
+// firstNonEmpty returns the first of the values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
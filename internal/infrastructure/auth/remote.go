@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// validationEndpoint is the account API endpoint validating access tokens.
+const validationEndpoint = "/private/token/validate"
+
+// maxResponseSize bounds the size of the responses read from the account API.
+const maxResponseSize = 1 << 20
+
+// remoteVerifier verifies tokens by calling the validation endpoint of the account API.
+type remoteVerifier struct {
+	client *http.Client
+	url    string
+}
+
+// newRemoteVerifier creates a verifier calling the account API at baseURL with client.
+func newRemoteVerifier(client *http.Client, baseURL string) *remoteVerifier {
+	return &remoteVerifier{
+		client: client,
+		url:    baseURL + validationEndpoint,
+	}
+}
+
+// Verify asks the account API whether the token is valid.
+// The account API answers 401 for an expired token as well as for an invalid one, so that answer is reported
+// as model.ErrTokenExpired for the token to be refreshed, while a 403 is reported as model.ErrInvalidToken.
+// The claims are read from the validation response when the account API sends them: an empty response
+// validates the token without claims, while a response that cannot be decoded fails the verification.
+func (v *remoteVerifier) Verify(ctx context.Context, token string) (*model.Claims, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred while creating the token validation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred while validating the token with the account api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("%w: rejected by the account api", model.ErrTokenExpired)
+	case http.StatusForbidden:
+		return nil, fmt.Errorf("%w: rejected by the account api", model.ErrInvalidToken)
+	default:
+		return nil, fmt.Errorf("error occurred while validating the token with the account api: unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("error occurred while reading the token validation response: %w", err)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return &model.Claims{}, nil
+	}
+	var claims remoteClaims
+	if err := json.Unmarshal(body, &claims); err != nil {
+		return nil, fmt.Errorf("error occurred while decoding the token validation response: %w", err)
+	}
+	return claims.toDomainModel(), nil
+}
+
+// remoteClaims is the validation response of the account API.
+type remoteClaims struct {
+	Subject string   `json:"sub"`
+	ID      string   `json:"id"`
+	Email   string   `json:"email"`
+	Roles   []string `json:"roles"`
+	Role    string   `json:"role"`
+}
+
+// toDomainModel converts the validation response to a model.Claims domain model.
+func (c remoteClaims) toDomainModel() *model.Claims {
+	claims := &model.Claims{
+		Subject: c.Subject,
+		Email:   c.Email,
+		Roles:   c.Roles,
+	}
+	if claims.Subject == "" {
+		claims.Subject = c.ID
+	}
+	if c.Role != "" {
+		claims.Roles = append(claims.Roles, c.Role)
+	}
+	return claims
+}
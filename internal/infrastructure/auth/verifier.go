@@ -0,0 +1,74 @@
+// Package auth provides the verification of the access tokens issued by the account API.
+// Tokens are verified locally against the signing keys of the account API when they are configured,
+// which avoids a call to the account API on every request, and handed to the account API otherwise.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/rs/zerolog/log"
+)
+
+// errUnverifiable is wrapped by the errors of the local verifier when it cannot decide on a token,
+// such as a token that is not a JWT or is signed with a key the JWKS does not publish.
+// The fallback verifier hands such tokens to the account API.
+var errUnverifiable = errors.New("token cannot be verified locally")
+
+// NewVerifier creates the token verifier described by the account API configuration.
+// Tokens are verified locally when a public key or a JWKS URL is configured, and by the account API otherwise
+// or, when RemoteFallback is enabled, whenever the local verification cannot decide.
+// It returns an error when no verification method is configured or the public key cannot be parsed.
+func NewVerifier(config configuration.AccountApi) (port.TokenVerifier, error) {
+	client := &http.Client{Timeout: config.Timeout}
+
+	var remote port.TokenVerifier
+	if config.BaseURL != "" {
+		remote = newRemoteVerifier(client, config.BaseURL)
+	}
+
+	var keys keySource
+	switch {
+	case config.PublicKey != "":
+		key, err := parsePublicKey(config.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("error occurred while parsing the account api public key: %w", err)
+		}
+		keys = staticKey{publicKey: key}
+	case config.JWKSURL != "":
+		keys = newJWKS(client, config.JWKSURL, config.JWKSCacheTTL)
+	}
+
+	switch {
+	case keys == nil && remote == nil:
+		return nil, errors.New("no token verification configured: set the account api public key, JWKS URL or base URL")
+	case keys == nil:
+		return remote, nil
+	}
+	local := newLocalVerifier(keys, config.Issuer, config.Audience)
+	if remote == nil || !config.RemoteFallback {
+		return local, nil
+	}
+	return fallbackVerifier{local: local, remote: remote}, nil
+}
+
+// fallbackVerifier verifies tokens locally and hands the tokens the local verification cannot decide on to the account API.
+type fallbackVerifier struct {
+	local  port.TokenVerifier
+	remote port.TokenVerifier
+}
+
+// Verify verifies the token locally, falling back to the account API when the local verifier cannot decide.
+func (v fallbackVerifier) Verify(ctx context.Context, token string) (*model.Claims, error) {
+	claims, err := v.local.Verify(ctx, token)
+	if err == nil || !errors.Is(err, errUnverifiable) {
+		return claims, err
+	}
+	log.Ctx(ctx).Debug().Err(err).Msg("falling back to the account api to validate the token")
+	return v.remote.Verify(ctx, token)
+}
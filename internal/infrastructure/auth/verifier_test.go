@@ -0,0 +1,315 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// accountApi fakes the JWKS and token validation endpoints of the account API.
+type accountApi struct {
+	*httptest.Server
+	keys        map[string]*rsa.PublicKey
+	jwksCalls   atomic.Int32
+	remoteCalls atomic.Int32
+	remoteValid bool
+}
+
+func newAccountApi(t *testing.T, keys map[string]*rsa.PublicKey) *accountApi {
+	a := &accountApi{keys: keys, remoteValid: true}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		a.jwksCalls.Add(1)
+		var set struct {
+			Keys []jsonWebKey `json:"keys"`
+		}
+		for kid, key := range a.keys {
+			set.Keys = append(set.Keys, jsonWebKey{
+				Kty: "RSA",
+				Kid: kid,
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	})
+	mux.HandleFunc(validationEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		a.remoteCalls.Add(1)
+		if !a.remoteValid || r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"remote-user","email":"remote@example.com","role":"editor"}`))
+	})
+	a.Server = httptest.NewServer(mux)
+	t.Cleanup(a.Close)
+	return a
+}
+
+// config returns the configuration verifying tokens against the JWKS of the fake account API.
+func (a *accountApi) config() configuration.AccountApi {
+	return configuration.AccountApi{
+		BaseURL:        a.URL,
+		JWKSURL:        a.URL + "/.well-known/jwks.json",
+		Issuer:         "account-api",
+		Audience:       "backoffice",
+		JWKSCacheTTL:   time.Minute,
+		Timeout:        time.Second,
+		RemoteFallback: true,
+	}
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key
+}
+
+// sign issues a token for user-1 signed by key, with the given key ID and expiry.
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, expiresIn time.Duration) string {
+	token := jwt.NewWithClaims(method, tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			Issuer:    "account-api",
+			Audience:  jwt.ClaimStrings{"backoffice"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		},
+		Email: "user-1@example.com",
+		Roles: []string{"editor"},
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return signed
+}
+
+func TestVerifier_JWKS(t *testing.T) {
+	signing, other := newRSAKey(t), newRSAKey(t)
+	tests := []struct {
+		name        string
+		token       func(t *testing.T) string
+		configure   func(a *accountApi, config *configuration.AccountApi)
+		wantSubject string
+		wantErr     error
+		wantRemote  int32
+	}{
+		{
+			name:        "accepts a token signed with a published key",
+			token:       func(t *testing.T) string { return sign(t, jwt.SigningMethodRS256, signing, "k1", time.Hour) },
+			wantSubject: "user-1",
+		},
+		{
+			name:    "rejects an expired token",
+			token:   func(t *testing.T) string { return sign(t, jwt.SigningMethodRS256, signing, "k1", -time.Hour) },
+			wantErr: model.ErrTokenExpired,
+		},
+		{
+			name:    "rejects a token with a forged signature",
+			token:   func(t *testing.T) string { return sign(t, jwt.SigningMethodRS256, other, "k1", time.Hour) },
+			wantErr: model.ErrInvalidToken,
+		},
+		{
+			name:      "rejects a token for another audience",
+			token:     func(t *testing.T) string { return sign(t, jwt.SigningMethodRS256, signing, "k1", time.Hour) },
+			configure: func(a *accountApi, config *configuration.AccountApi) { config.Audience = "front-office" },
+			wantErr:   model.ErrInvalidToken,
+		},
+		{
+			name:    "rejects a token signed with a symmetric algorithm",
+			token:   func(t *testing.T) string { return sign(t, jwt.SigningMethodHS256, []byte("secret"), "k1", time.Hour) },
+			wantErr: model.ErrInvalidToken,
+		},
+		{
+			name:        "falls back to the account api for an unknown key",
+			token:       func(t *testing.T) string { return sign(t, jwt.SigningMethodRS256, other, "k2", time.Hour) },
+			wantSubject: "remote-user",
+			wantRemote:  1,
+		},
+		{
+			name:        "falls back to the account api for an opaque token",
+			token:       func(t *testing.T) string { return "opaque" },
+			wantSubject: "remote-user",
+			wantRemote:  1,
+		},
+		{
+			name:       "reports the rejection of the account api as an expired token",
+			token:      func(t *testing.T) string { return "opaque" },
+			configure:  func(a *accountApi, config *configuration.AccountApi) { a.remoteValid = false },
+			wantErr:    model.ErrTokenExpired,
+			wantRemote: 1,
+		},
+		{
+			name:      "rejects an unknown key without fallback",
+			token:     func(t *testing.T) string { return sign(t, jwt.SigningMethodRS256, other, "k2", time.Hour) },
+			configure: func(a *accountApi, config *configuration.AccountApi) { config.RemoteFallback = false },
+			wantErr:   model.ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newAccountApi(t, map[string]*rsa.PublicKey{"k1": &signing.PublicKey})
+			config := account.config()
+			if tt.configure != nil {
+				tt.configure(account, &config)
+			}
+			verifier, err := NewVerifier(config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			claims, err := verifier.Verify(context.Background(), tt.token(t))
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && claims.Subject != tt.wantSubject {
+				t.Fatalf("got subject %q, want %q", claims.Subject, tt.wantSubject)
+			}
+			if got := account.remoteCalls.Load(); got != tt.wantRemote {
+				t.Fatalf("got %d calls to the account api, want %d", got, tt.wantRemote)
+			}
+		})
+	}
+}
+
+func TestVerifier_JWKSCache(t *testing.T) {
+	first, second := newRSAKey(t), newRSAKey(t)
+	account := newAccountApi(t, map[string]*rsa.PublicKey{"k1": &first.PublicKey})
+	verifier, err := NewVerifier(account.config())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		claims, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, first, "k1", time.Hour))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if claims.Email != "user-1@example.com" || len(claims.Roles) != 1 || claims.Roles[0] != "editor" {
+			t.Fatalf("got claims %+v", claims)
+		}
+	}
+	if got := account.jwksCalls.Load(); got != 1 {
+		t.Fatalf("got %d JWKS fetches for cached keys, want 1", got)
+	}
+
+	// A rotated key is only picked up once the refresh interval has elapsed
+	account.keys = map[string]*rsa.PublicKey{"k1": &first.PublicKey, "k2": &second.PublicKey}
+	rotated := sign(t, jwt.SigningMethodRS256, second, "k2", time.Hour)
+	if _, err := verifier.Verify(context.Background(), rotated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := account.remoteCalls.Load(); got != 1 {
+		t.Fatalf("got %d calls to the account api within the refresh interval, want 1", got)
+	}
+	keys := verifier.(fallbackVerifier).local.(*localVerifier).keys.(*jwks)
+	keys.fetchedAt = keys.fetchedAt.Add(-minRefreshInterval)
+	if _, err := verifier.Verify(context.Background(), rotated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := account.jwksCalls.Load(); got != 2 || account.remoteCalls.Load() != 1 {
+		t.Fatalf("got %d JWKS fetches and %d account api calls after rotation, want 2 and 1", got, account.remoteCalls.Load())
+	}
+}
+
+func TestVerifier_PublicKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifier, err := NewVerifier(configuration.AccountApi{
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodES256, key, "", time.Hour))
+	if err != nil || claims.Subject != "user-1" {
+		t.Fatalf("got claims %+v and error %v, want user-1", claims, err)
+	}
+	if _, err := verifier.Verify(context.Background(), "opaque"); !errors.Is(err, model.ErrInvalidToken) {
+		t.Fatalf("got error %v, want %v", err, model.ErrInvalidToken)
+	}
+}
+
+func TestNewVerifier_Unconfigured(t *testing.T) {
+	if _, err := NewVerifier(configuration.AccountApi{}); err == nil {
+		t.Fatal("expected an error without any verification configured")
+	}
+	if _, err := NewVerifier(configuration.AccountApi{PublicKey: "not a key"}); err == nil {
+		t.Fatal("expected an error for an invalid public key")
+	}
+}
+
+func TestRemoteVerifier_Response(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantSubject string
+		wantErr     bool
+	}{
+		{
+			name:        "reads the claims of the response",
+			body:        `{"sub":"user-1","roles":["editor"]}`,
+			wantSubject: "user-1",
+		},
+		{
+			name: "validates the token of an empty response without claims",
+			body: " \n",
+		},
+		{
+			name:    "fails on a response that cannot be decoded",
+			body:    "<html>ok</html>",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(server.Close)
+
+			claims, err := newRemoteVerifier(server.Client(), server.URL).Verify(context.Background(), "opaque")
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want one: %v", err, tt.wantErr)
+			}
+			if err == nil && claims.Subject != tt.wantSubject {
+				t.Fatalf("got subject %q, want %q", claims.Subject, tt.wantSubject)
+			}
+			if err != nil && (errors.Is(err, model.ErrInvalidToken) || errors.Is(err, model.ErrTokenExpired)) {
+				t.Fatalf("got error %v, want a failure of the account api", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// auditAdapter is a struct that acts as an adapter for interacting with
+// the audit log kept in memory.
+type auditAdapter struct {
+	client *client
+}
+
+// NewAuditAdapter creates a new audit adapter with the provided in-memory client.
+// It returns an implementation of the AuditPersister interface.
+func NewAuditAdapter(client *client) port.AuditPersister {
+	return &auditAdapter{
+		client: client,
+	}
+}
+
+// Create records a new audit entry.
+func (adapter *auditAdapter) Create(ctx context.Context, entry model.AuditEntry) error {
+	defer adapter.client.lock(ctx)()
+	entry.CreatedAt = time.Now()
+	return adapter.client.audits.insert(entry.ID, entry)
+}
+
+// FindAll retrieves the audit entries matching the filter, most recent first,
+// along with the number of entries matching the filter.
+func (adapter *auditAdapter) FindAll(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, int, error) {
+	defer adapter.client.rlock(ctx)()
+	entries := adapter.client.audits.filter(func(entry model.AuditEntry) bool {
+		return (filter.Entity == "" || entry.Entity == filter.Entity) &&
+			(filter.EntityID == "" || entry.EntityID == filter.EntityID) &&
+			(filter.Actor == "" || entry.Actor == filter.Actor)
+	})
+	slices.Reverse(entries)
+
+	total := len(entries)
+	entries = entries[min(filter.Offset, total):]
+	if filter.Limit > 0 && filter.Limit < len(entries) {
+		entries = entries[:filter.Limit]
+	}
+	return pointers(entries), total, nil
+}
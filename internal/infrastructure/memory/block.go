@@ -0,0 +1,109 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// blockAdapter is a struct that acts as an adapter for interacting with
+// the block data kept in memory.
+type blockAdapter struct {
+	client *client
+}
+
+// NewBlockAdapter creates a new block adapter with the provided in-memory client.
+// It returns an implementation of the BlockPersister interface.
+func NewBlockAdapter(client *client) port.BlockPersister {
+	return &blockAdapter{
+		client: client,
+	}
+}
+
+// Create stores a new block.
+// It returns an error if a block with the same ID already exists.
+func (adapter *blockAdapter) Create(ctx context.Context, block model.Block) error {
+	defer adapter.client.lock(ctx)()
+	block.Programs = nil
+	block.CreatedAt = time.Now()
+	return adapter.client.blocks.insert(block.ID, block)
+}
+
+// Delete moves a block to the trash by its UUID.
+// It returns model.ErrNotFound when the block does not exist or is already in the trash.
+func (adapter *blockAdapter) Delete(ctx context.Context, blockUUID string) error {
+	defer adapter.client.lock(ctx)()
+	block, ok := adapter.client.blocks.get(blockUUID)
+	if !ok {
+		return adapter.client.blocks.notFound(blockUUID)
+	}
+	block.DeletedAt = time.Now()
+	adapter.client.blocks.set(blockUUID, block)
+	return nil
+}
+
+// Restore brings back a block from the trash by its UUID.
+// It returns model.ErrNotFound when the block is not in the trash.
+func (adapter *blockAdapter) Restore(ctx context.Context, blockUUID string) error {
+	defer adapter.client.lock(ctx)()
+	block, ok := adapter.client.blocks.getTrashed(blockUUID)
+	if !ok {
+		return adapter.client.blocks.notFound(blockUUID)
+	}
+	block.DeletedAt = time.Time{}
+	adapter.client.blocks.set(blockUUID, block)
+	return nil
+}
+
+// Purge permanently removes the blocks moved to the trash before the given time, along with their associations.
+func (adapter *blockAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	defer adapter.client.lock(ctx)()
+	purged := setOf(adapter.client.blocks.purge(before, func(model.Block) bool { return true }))
+	adapter.client.wallBlocks.removeAll(func(wallBlock model.WallBlock) bool { return purged[wallBlock.BlockID] })
+	adapter.client.blockPrograms.removeAll(func(blockProgram model.BlockProgram) bool { return purged[blockProgram.BlockID] })
+	return len(purged), nil
+}
+
+// Update updates an existing block, keeping the current value of every empty field.
+func (adapter *blockAdapter) Update(ctx context.Context, blockUUID string, updates model.Block) error {
+	defer adapter.client.lock(ctx)()
+	block, ok := adapter.client.blocks.get(blockUUID)
+	if !ok {
+		return adapter.client.blocks.notFound(blockUUID)
+	}
+	block.Name = coalesce(updates.Name, block.Name)
+	block.Description = coalesce(updates.Description, block.Description)
+	block.Kind = coalesce(updates.Kind, block.Kind)
+	adapter.client.blocks.set(blockUUID, block)
+	return nil
+}
+
+// FindAll retrieves the blocks matching the options, oldest first unless sorted otherwise,
+// along with the number of blocks matching the filters.
+func (adapter *blockAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Block, int, error) {
+	defer adapter.client.rlock(ctx)()
+	blocks, total := page(adapter.client.blocks.listed(opts), opts, func(block model.Block) columns {
+		return columns{name: block.Name, kind: block.Kind, createdAt: block.CreatedAt, deletedAt: block.DeletedAt}
+	})
+	return pointers(blocks), total, nil
+}
+
+// Find retrieves a block by its UUID.
+// It returns model.ErrNotFound when the block does not exist.
+func (adapter *blockAdapter) Find(ctx context.Context, blockUUID string) (*model.Block, error) {
+	defer adapter.client.rlock(ctx)()
+	block, ok := adapter.client.blocks.get(blockUUID)
+	if !ok {
+		return nil, adapter.client.blocks.notFound(blockUUID)
+	}
+	return &block, nil
+}
+
+// FindByIDs retrieves the blocks with the given UUIDs, skipping unknown ones.
+func (adapter *blockAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Block, error) {
+	defer adapter.client.rlock(ctx)()
+	return pointers(adapter.client.blocks.getAll(ids)), nil
+}
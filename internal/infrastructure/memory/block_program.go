@@ -0,0 +1,117 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// blockProgramAdapter is a struct that acts as an adapter for interacting with
+// the block_program data kept in memory.
+type blockProgramAdapter struct {
+	client *client
+}
+
+// NewBlockProgramAdapter creates a new blockProgram adapter with the provided in-memory client.
+// It returns an implementation of the BlockProgramPersister interface.
+func NewBlockProgramAdapter(client *client) port.BlockProgramPersister {
+	return &blockProgramAdapter{
+		client: client,
+	}
+}
+
+// Create stores a new blockProgram association.
+// It returns an error if the ID is already used or the program is already placed in the block.
+func (adapter *blockProgramAdapter) Create(ctx context.Context, blockProgram model.BlockProgram) error {
+	defer adapter.client.lock(ctx)()
+	if err := adapter.checkUniquePair(blockProgram); err != nil {
+		return err
+	}
+	return adapter.client.blockPrograms.insert(blockProgram.ID, blockProgram)
+}
+
+// Delete removes a blockProgram association by its UUID. Deleting an unknown association is not an error.
+func (adapter *blockProgramAdapter) Delete(ctx context.Context, blockProgramUUID string) error {
+	defer adapter.client.lock(ctx)()
+	adapter.client.blockPrograms.remove(blockProgramUUID)
+	return nil
+}
+
+// Update replaces every field of an existing blockProgram association.
+func (adapter *blockProgramAdapter) Update(ctx context.Context, blockProgramUUID string, updates model.BlockProgram) error {
+	defer adapter.client.lock(ctx)()
+	if _, ok := adapter.client.blockPrograms.get(blockProgramUUID); !ok {
+		return adapter.client.blockPrograms.notFound(blockProgramUUID)
+	}
+	updates.ID = blockProgramUUID
+	if err := adapter.checkUniquePair(updates); err != nil {
+		return err
+	}
+	adapter.client.blockPrograms.set(blockProgramUUID, updates)
+	return nil
+}
+
+// Find retrieves a blockProgram association by its UUID.
+// It returns model.ErrNotFound when the association does not exist.
+func (adapter *blockProgramAdapter) Find(ctx context.Context, blockProgramUUID string) (*model.BlockProgram, error) {
+	defer adapter.client.rlock(ctx)()
+	blockProgram, ok := adapter.client.blockPrograms.get(blockProgramUUID)
+	if !ok {
+		return nil, adapter.client.blockPrograms.notFound(blockProgramUUID)
+	}
+	return &blockProgram, nil
+}
+
+// FindByBlockID retrieves the blockProgram associations of a block, ordered by position.
+func (adapter *blockProgramAdapter) FindByBlockID(ctx context.Context, blockID string) ([]*model.BlockProgram, error) {
+	return adapter.findBy(ctx, func(blockProgram model.BlockProgram) bool {
+		return blockProgram.BlockID == blockID
+	}), nil
+}
+
+// FindByBlockIDs retrieves the blockProgram associations of several blocks, ordered by position.
+func (adapter *blockProgramAdapter) FindByBlockIDs(ctx context.Context, ids []string) ([]*model.BlockProgram, error) {
+	blockIDs := setOf(ids)
+	return adapter.findBy(ctx, func(blockProgram model.BlockProgram) bool {
+		return blockIDs[blockProgram.BlockID]
+	}), nil
+}
+
+// FindByProgramID retrieves the blockProgram associations of a program, ordered by position.
+func (adapter *blockProgramAdapter) FindByProgramID(ctx context.Context, programID string) ([]*model.BlockProgram, error) {
+	return adapter.findBy(ctx, func(blockProgram model.BlockProgram) bool {
+		return blockProgram.ProgramID == programID
+	}), nil
+}
+
+// FindByBlockIDAndProgramID retrieves the blockProgram associations for a given block ID and program ID.
+func (adapter *blockProgramAdapter) FindByBlockIDAndProgramID(ctx context.Context, blockID, programID string) ([]*model.BlockProgram, error) {
+	return adapter.findBy(ctx, func(blockProgram model.BlockProgram) bool {
+		return blockProgram.BlockID == blockID && blockProgram.ProgramID == programID
+	}), nil
+}
+
+// findBy returns the associations accepted by match, ordered by position.
+func (adapter *blockProgramAdapter) findBy(ctx context.Context, match func(model.BlockProgram) bool) []*model.BlockProgram {
+	defer adapter.client.rlock(ctx)()
+	blockPrograms := adapter.client.blockPrograms.filter(match)
+	sort.SliceStable(blockPrograms, func(i, j int) bool {
+		return blockPrograms[i].Position < blockPrograms[j].Position
+	})
+	return pointers(blockPrograms)
+}
+
+// checkUniquePair enforces the unique (blockUUID, programUUID) constraint of the block_program table.
+func (adapter *blockProgramAdapter) checkUniquePair(blockProgram model.BlockProgram) error {
+	taken := adapter.client.blockPrograms.exists(func(existing model.BlockProgram) bool {
+		return existing.BlockID == blockProgram.BlockID && existing.ProgramID == blockProgram.ProgramID && existing.ID != blockProgram.ID
+	})
+	if taken {
+		return fmt.Errorf("%w: duplicate entry '%s-%s' for key 'block_program.uq_block_program'", model.ErrConflict, blockProgram.BlockID, blockProgram.ProgramID)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// categoryAdapter is a struct that acts as an adapter for interacting with
+// the category data kept in memory.
+type categoryAdapter struct {
+	client *client
+}
+
+// NewCategoryAdapter creates a new category adapter with the provided in-memory client.
+// It returns an implementation of the CategoryPersister interface.
+func NewCategoryAdapter(client *client) port.CategoryPersister {
+	return &categoryAdapter{
+		client: client,
+	}
+}
+
+// Create stores a new category.
+// Like the MySQL adapter, a category without parent is stored with the nil UUID as parent ID.
+func (adapter *categoryAdapter) Create(ctx context.Context, category model.Category) error {
+	defer adapter.client.lock(ctx)()
+	parentID := uuid.Nil.String()
+	if category.Parent != nil && category.Parent.ID != "" {
+		parentID = category.Parent.ID
+	}
+	category.Parent = &model.Category{ID: parentID}
+	category.Children = nil
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = category.CreatedAt
+	return adapter.client.categories.insert(category.ID, category)
+}
+
+// Delete moves a category to the trash by its UUID.
+// It returns model.ErrNotFound when the category does not exist or is already in the trash.
+func (adapter *categoryAdapter) Delete(ctx context.Context, categoryUUID string) error {
+	defer adapter.client.lock(ctx)()
+	category, ok := adapter.client.categories.get(categoryUUID)
+	if !ok {
+		return adapter.client.categories.notFound(categoryUUID)
+	}
+	category.DeletedAt = time.Now()
+	adapter.client.categories.set(categoryUUID, category)
+	return nil
+}
+
+// Restore brings back a category from the trash by its UUID.
+// It returns model.ErrNotFound when the category is not in the trash.
+func (adapter *categoryAdapter) Restore(ctx context.Context, categoryUUID string) error {
+	defer adapter.client.lock(ctx)()
+	category, ok := adapter.client.categories.getTrashed(categoryUUID)
+	if !ok {
+		return adapter.client.categories.notFound(categoryUUID)
+	}
+	category.DeletedAt = time.Time{}
+	adapter.client.categories.set(categoryUUID, category)
+	return nil
+}
+
+// Purge permanently removes the categories moved to the trash before the given time, along with their associations.
+func (adapter *categoryAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	defer adapter.client.lock(ctx)()
+	purged := setOf(adapter.client.categories.purge(before, func(model.Category) bool { return true }))
+	adapter.client.programCategories.removeAll(func(programCategory model.ProgramCategory) bool { return purged[programCategory.CategoryID] })
+	return len(purged), nil
+}
+
+// Update updates an existing category, keeping the current value of every empty field.
+func (adapter *categoryAdapter) Update(ctx context.Context, categoryUUID string, updates model.Category) error {
+	defer adapter.client.lock(ctx)()
+	category, ok := adapter.client.categories.get(categoryUUID)
+	if !ok {
+		return adapter.client.categories.notFound(categoryUUID)
+	}
+	category.Name = coalesce(updates.Name, category.Name)
+	category.Description = coalesce(updates.Description, category.Description)
+	if updates.Parent != nil && updates.Parent.ID != "" {
+		category.Parent = &model.Category{ID: updates.Parent.ID}
+	}
+	category.UpdatedAt = time.Now()
+	adapter.client.categories.set(categoryUUID, category)
+	return nil
+}
+
+// Move sets the parent of a category, storing the nil UUID as parent of a root category.
+func (adapter *categoryAdapter) Move(ctx context.Context, categoryUUID string, parentID string) error {
+	defer adapter.client.lock(ctx)()
+	category, ok := adapter.client.categories.get(categoryUUID)
+	if !ok {
+		return adapter.client.categories.notFound(categoryUUID)
+	}
+	category.Parent = &model.Category{ID: coalesce(parentID, uuid.Nil.String())}
+	category.UpdatedAt = time.Now()
+	adapter.client.categories.set(categoryUUID, category)
+	return nil
+}
+
+// FindAll retrieves the categories matching the options, oldest first unless sorted otherwise,
+// along with the number of categories matching the filters.
+func (adapter *categoryAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Category, int, error) {
+	defer adapter.client.rlock(ctx)()
+	categories, total := page(adapter.client.categories.listed(opts), opts, func(category model.Category) columns {
+		return columns{name: category.Name, createdAt: category.CreatedAt, deletedAt: category.DeletedAt}
+	})
+	for i := range categories {
+		categories[i] = detachCategory(categories[i])
+	}
+	return pointers(categories), total, nil
+}
+
+// Find retrieves a category by its UUID.
+// It returns model.ErrNotFound when the category does not exist.
+func (adapter *categoryAdapter) Find(ctx context.Context, categoryUUID string) (*model.Category, error) {
+	defer adapter.client.rlock(ctx)()
+	category, ok := adapter.client.categories.get(categoryUUID)
+	if !ok {
+		return nil, adapter.client.categories.notFound(categoryUUID)
+	}
+	category = detachCategory(category)
+	return &category, nil
+}
+
+// FindByIDs retrieves the categories with the given UUIDs, skipping unknown ones.
+func (adapter *categoryAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Category, error) {
+	defer adapter.client.rlock(ctx)()
+	return pointers(adapter.client.categories.getAll(ids)), nil
+}
+
+// FindByProgramID retrieves the categories associated with a program, ordered by name.
+func (adapter *categoryAdapter) FindByProgramID(ctx context.Context, id string) ([]*model.Category, error) {
+	defer adapter.client.rlock(ctx)()
+	var categoryIDs []string
+	for _, programCategory := range adapter.client.programCategories.all() {
+		if programCategory.ProgramID == id {
+			categoryIDs = append(categoryIDs, programCategory.CategoryID)
+		}
+	}
+	categories := adapter.client.categories.getAll(categoryIDs)
+	sortByName(categories, func(category model.Category) (string, string) { return category.Name, category.ID })
+	return pointers(categories), nil
+}
+
+// LockHierarchy does nothing: the transactions of the client already hold its write lock until they end.
+func (adapter *categoryAdapter) LockHierarchy(_ context.Context) error {
+	return nil
+}
+
+// FindHierarchy retrieves every live category, ordered by name.
+func (adapter *categoryAdapter) FindHierarchy(ctx context.Context) ([]*model.Category, error) {
+	defer adapter.client.rlock(ctx)()
+	categories := adapter.client.categories.all()
+	for i := range categories {
+		categories[i] = detachCategory(categories[i])
+	}
+	sortByName(categories, func(category model.Category) (string, string) { return category.Name, category.ID })
+	return pointers(categories), nil
+}
+
+// detachCategory copies the parent reference so callers cannot alter the stored category.
+func detachCategory(category model.Category) model.Category {
+	if category.Parent != nil {
+		category.Parent = &model.Category{ID: category.Parent.ID}
+	}
+	return category
+}
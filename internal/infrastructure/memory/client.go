@@ -0,0 +1,311 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+// It is meant for tests and local development, when no database is available.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// client holds every in-memory table behind a single lock, playing the role of the database connection.
+type client struct {
+	mu                sync.RWMutex
+	walls             *table[model.Wall]
+	wallBlocks        *table[model.WallBlock]
+	blocks            *table[model.Block]
+	blockPrograms     *table[model.BlockProgram]
+	programs          *table[model.Program]
+	episodes          *table[model.Episode]
+	medias            *table[model.Media]
+	tags              *table[model.Tag]
+	programTags       *table[model.ProgramTag]
+	categories        *table[model.Category]
+	programCategories *table[model.ProgramCategory]
+	audits            *table[model.AuditEntry]
+}
+
+// NewClient creates a new, empty in-memory store shared by the adapters of this package.
+func NewClient() *client {
+	return &client{
+		walls:             newTable[model.Wall]("wall").withTrash(func(wall model.Wall) time.Time { return wall.DeletedAt }),
+		wallBlocks:        newTable[model.WallBlock]("wall_block"),
+		blocks:            newTable[model.Block]("block").withTrash(func(block model.Block) time.Time { return block.DeletedAt }),
+		blockPrograms:     newTable[model.BlockProgram]("block_program"),
+		programs:          newTable[model.Program]("program").withTrash(func(program model.Program) time.Time { return program.DeletedAt }),
+		episodes:          newTable[model.Episode]("episode").withTrash(func(episode model.Episode) time.Time { return episode.DeletedAt }),
+		medias:            newTable[model.Media]("media").withTrash(func(media model.Media) time.Time { return media.DeletedAt }),
+		tags:              newTable[model.Tag]("tag").withTrash(func(tag model.Tag) time.Time { return tag.DeletedAt }),
+		programTags:       newTable[model.ProgramTag]("program_tag"),
+		categories:        newTable[model.Category]("category").withTrash(func(category model.Category) time.Time { return category.DeletedAt }),
+		programCategories: newTable[model.ProgramCategory]("program_category"),
+		audits:            newTable[model.AuditEntry]("audit_log"),
+	}
+}
+
+// txKey is the context key marking the units of work run by the txManager of a client.
+type txKey struct{}
+
+// inTx reports whether ctx belongs to a unit of work of the client, which already holds its write lock.
+func (c *client) inTx(ctx context.Context) bool {
+	return ctx.Value(txKey{}) == c
+}
+
+// lock acquires the write lock of the client, unless ctx belongs to a unit of work,
+// and returns the function releasing it.
+func (c *client) lock(ctx context.Context) func() {
+	if c.inTx(ctx) {
+		return func() {}
+	}
+	c.mu.Lock()
+	return c.mu.Unlock
+}
+
+// rlock acquires the read lock of the client, unless ctx belongs to a unit of work,
+// and returns the function releasing it.
+func (c *client) rlock(ctx context.Context) func() {
+	if c.inTx(ctx) {
+		return func() {}
+	}
+	c.mu.RLock()
+	return c.mu.RUnlock
+}
+
+// snapshot copies every table and returns the function putting the copies back,
+// undoing the changes made in between. The caller must hold the write lock.
+func (c *client) snapshot() func() {
+	walls, wallBlocks := c.walls.clone(), c.wallBlocks.clone()
+	blocks, blockPrograms := c.blocks.clone(), c.blockPrograms.clone()
+	programs, episodes, medias := c.programs.clone(), c.episodes.clone(), c.medias.clone()
+	tags, programTags := c.tags.clone(), c.programTags.clone()
+	categories, programCategories := c.categories.clone(), c.programCategories.clone()
+	audits := c.audits.clone()
+	return func() {
+		c.walls, c.wallBlocks = walls, wallBlocks
+		c.blocks, c.blockPrograms = blocks, blockPrograms
+		c.programs, c.episodes, c.medias = programs, episodes, medias
+		c.tags, c.programTags = tags, programTags
+		c.categories, c.programCategories = categories, programCategories
+		c.audits = audits
+	}
+}
+
+// table stores rows by ID and remembers insertion order so listings are deterministic.
+// The rows of a table with a trash are hidden from the lookups while their deletion time is set,
+// like the soft deleted rows of the MySQL tables.
+type table[T any] struct {
+	name      string
+	rows      map[string]T
+	order     []string
+	deletedAt func(T) time.Time // Deletion time of a row, nil when the table has no trash
+}
+
+// newTable creates an empty table with the given name, used in error messages.
+func newTable[T any](name string) *table[T] {
+	return &table[T]{
+		name: name,
+		rows: make(map[string]T),
+	}
+}
+
+// withTrash gives the table a trash, deletedAt telling the time a row was moved to it.
+func (t *table[T]) withTrash(deletedAt func(T) time.Time) *table[T] {
+	t.deletedAt = deletedAt
+	return t
+}
+
+// clone returns a copy of the table that later changes to t do not affect.
+func (t *table[T]) clone() *table[T] {
+	return &table[T]{
+		name:      t.name,
+		rows:      maps.Clone(t.rows),
+		order:     slices.Clone(t.order),
+		deletedAt: t.deletedAt,
+	}
+}
+
+// live reports whether row is out of the trash.
+func (t *table[T]) live(row T) bool {
+	return t.deletedAt == nil || t.deletedAt(row).IsZero()
+}
+
+// insert adds a new row, failing like a primary key violation when the ID is already used.
+func (t *table[T]) insert(id string, row T) error {
+	if _, ok := t.rows[id]; ok {
+		return fmt.Errorf("%w: duplicate entry '%s' for key '%s.PRIMARY'", model.ErrConflict, id, t.name)
+	}
+	t.rows[id] = row
+	t.order = append(t.order, id)
+	return nil
+}
+
+// get returns the live row identified by id and whether it exists.
+func (t *table[T]) get(id string) (T, bool) {
+	row, ok := t.rows[id]
+	if !ok || !t.live(row) {
+		var zero T
+		return zero, false
+	}
+	return row, true
+}
+
+// getTrashed returns the row identified by id and whether it exists in the trash.
+func (t *table[T]) getTrashed(id string) (T, bool) {
+	row, ok := t.rows[id]
+	if !ok || t.live(row) {
+		var zero T
+		return zero, false
+	}
+	return row, true
+}
+
+// set replaces an existing row, live or in the trash, keeping its position in the insertion order.
+func (t *table[T]) set(id string, row T) {
+	if _, ok := t.rows[id]; ok {
+		t.rows[id] = row
+	}
+}
+
+// remove deletes the row identified by id, if any, and reports whether it existed.
+func (t *table[T]) remove(id string) bool {
+	if _, ok := t.rows[id]; !ok {
+		return false
+	}
+	delete(t.rows, id)
+	for i, existing := range t.order {
+		if existing == id {
+			t.order = append(t.order[:i], t.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// getAll returns the live rows identified by ids, in the order of ids, skipping unknown IDs.
+func (t *table[T]) getAll(ids []string) []T {
+	var rows []T
+	for _, id := range ids {
+		if row, ok := t.get(id); ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// notFound returns the model.ErrNotFound error reported when the row identified by id does not exist.
+func (t *table[T]) notFound(id string) error {
+	return fmt.Errorf("%s %s: %w", t.name, id, model.ErrNotFound)
+}
+
+// filter returns, in insertion order, every live row accepted by keep.
+func (t *table[T]) filter(keep func(T) bool) []T {
+	var rows []T
+	for _, id := range t.order {
+		row := t.rows[id]
+		if t.live(row) && keep(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// all returns every live row in insertion order.
+func (t *table[T]) all() []T {
+	return t.filter(func(T) bool { return true })
+}
+
+// trashed returns every row in the trash in insertion order.
+func (t *table[T]) trashed() []T {
+	var rows []T
+	for _, id := range t.order {
+		if row := t.rows[id]; !t.live(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// listed returns the rows FindAll pages through: the rows in the trash when opts.Deleted is set, the live ones otherwise.
+func (t *table[T]) listed(opts model.ListOptions) []T {
+	if opts.Deleted {
+		return t.trashed()
+	}
+	return t.all()
+}
+
+// purge removes the rows moved to the trash before the given time that keep accepts, and returns their IDs.
+func (t *table[T]) purge(before time.Time, keep func(T) bool) []string {
+	var ids []string
+	for _, id := range t.order {
+		row := t.rows[id]
+		if !t.live(row) && t.deletedAt(row).Before(before) && keep(row) {
+			ids = append(ids, id)
+		}
+	}
+	for _, id := range ids {
+		t.remove(id)
+	}
+	return ids
+}
+
+// removeAll removes every row, live or in the trash, accepted by match, like the ON DELETE CASCADE foreign keys
+// of the MySQL tables.
+func (t *table[T]) removeAll(match func(T) bool) {
+	for _, id := range slices.Clone(t.order) {
+		if match(t.rows[id]) {
+			t.remove(id)
+		}
+	}
+}
+
+// exists reports whether at least one row, live or in the trash, is accepted by match.
+// Trashed rows are kept in the MySQL tables, where they still take part in the unique keys.
+func (t *table[T]) exists(match func(T) bool) bool {
+	for _, row := range t.rows {
+		if match(row) {
+			return true
+		}
+	}
+	return false
+}
+
+// setOf returns the set of the given IDs, for batched lookups on foreign keys.
+func setOf(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// sortByName orders rows by name, then by ID, like the association lookups of the MySQL adapters.
+func sortByName[T any](rows []T, key func(T) (name string, id string)) {
+	slices.SortStableFunc(rows, func(a, b T) int {
+		nameA, idA := key(a)
+		nameB, idB := key(b)
+		return cmp.Or(cmp.Compare(nameA, nameB), cmp.Compare(idA, idB))
+	})
+}
+
+// pointers converts rows into the slice of pointers returned by the persister interfaces.
+func pointers[T any](rows []T) []*T {
+	var result []*T
+	for i := range rows {
+		result = append(result, &rows[i])
+	}
+	return result
+}
+
+// coalesce returns update unless it is empty, mirroring the COALESCE updates of the MySQL adapters.
+func coalesce(update, current string) string {
+	if update != "" {
+		return update
+	}
+	return current
+}
@@ -0,0 +1,179 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// episodeAdapter is a struct that acts as an adapter for interacting with
+// the episode data kept in memory.
+type episodeAdapter struct {
+	client *client
+}
+
+// NewEpisodeAdapter creates a new episode adapter with the provided in-memory client.
+// It returns an implementation of the EpisodePersister interface.
+func NewEpisodeAdapter(client *client) port.EpisodePersister {
+	return &episodeAdapter{
+		client: client,
+	}
+}
+
+// FindByProgramID retrieves the episodes of a program, ordered by position.
+func (adapter *episodeAdapter) FindByProgramID(ctx context.Context, id string) ([]*model.Episode, error) {
+	defer adapter.client.rlock(ctx)()
+	episodes := adapter.client.episodes.filter(func(episode model.Episode) bool {
+		return episode.ProgramID == id
+	})
+	sort.SliceStable(episodes, func(i, j int) bool {
+		return episodes[i].Position < episodes[j].Position
+	})
+	return pointers(episodes), nil
+}
+
+// FindByProgramIDs retrieves the episodes of several programs, ordered by position.
+func (adapter *episodeAdapter) FindByProgramIDs(ctx context.Context, ids []string) ([]*model.Episode, error) {
+	defer adapter.client.rlock(ctx)()
+	programIDs := setOf(ids)
+	episodes := adapter.client.episodes.filter(func(episode model.Episode) bool {
+		return programIDs[episode.ProgramID]
+	})
+	sort.SliceStable(episodes, func(i, j int) bool {
+		return episodes[i].Position < episodes[j].Position
+	})
+	return pointers(episodes), nil
+}
+
+// Create stores a new episode.
+// It returns an error if an episode with the same ID already exists.
+func (adapter *episodeAdapter) Create(ctx context.Context, episode model.Episode) error {
+	defer adapter.client.lock(ctx)()
+	episode.Media = model.Media{}
+	if episode.Status == "" {
+		episode.Status = model.EpisodeStatusDraft
+	}
+	episode.CreatedAt = time.Now()
+	episode.UpdatedAt = episode.CreatedAt
+	return adapter.client.episodes.insert(episode.ID, episode)
+}
+
+// Delete moves an episode to the trash by its UUID, along with its medias.
+// It returns model.ErrNotFound when the episode does not exist or is already in the trash.
+func (adapter *episodeAdapter) Delete(ctx context.Context, episodeUUID string) error {
+	defer adapter.client.lock(ctx)()
+	episode, ok := adapter.client.episodes.get(episodeUUID)
+	if !ok {
+		return adapter.client.episodes.notFound(episodeUUID)
+	}
+	episode.DeletedAt = time.Now()
+	adapter.client.episodes.set(episodeUUID, episode)
+	adapter.client.trashMedias(episodeUUID, episode.DeletedAt)
+	return nil
+}
+
+// Restore brings back an episode from the trash by its UUID, along with the medias deleted with it.
+// It returns model.ErrNotFound when the episode is not in the trash, and model.ErrConflict while its program is.
+func (adapter *episodeAdapter) Restore(ctx context.Context, episodeUUID string) error {
+	defer adapter.client.lock(ctx)()
+	episode, ok := adapter.client.episodes.getTrashed(episodeUUID)
+	if !ok {
+		return adapter.client.episodes.notFound(episodeUUID)
+	}
+	if _, ok := adapter.client.programs.get(episode.ProgramID); !ok {
+		return fmt.Errorf("%w: the program of episode %s is in the trash", model.ErrConflict, episodeUUID)
+	}
+	deletedAt := episode.DeletedAt
+	episode.DeletedAt = time.Time{}
+	adapter.client.episodes.set(episodeUUID, episode)
+	adapter.client.restoreMedias(episodeUUID, deletedAt)
+	return nil
+}
+
+// Purge permanently removes the episodes moved to the trash before the given time.
+// Episodes whose medias have not been purged yet are kept, as the medias still reference them.
+func (adapter *episodeAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	defer adapter.client.lock(ctx)()
+	ids := adapter.client.episodes.purge(before, func(episode model.Episode) bool {
+		return !adapter.client.medias.exists(func(media model.Media) bool { return media.EpisodeID == episode.ID })
+	})
+	return len(ids), nil
+}
+
+// Update updates an existing episode, keeping the current value of every empty field.
+// A zero position and an empty program ID are treated as empty.
+func (adapter *episodeAdapter) Update(ctx context.Context, episodeUUID string, updates model.Episode) error {
+	defer adapter.client.lock(ctx)()
+	episode, ok := adapter.client.episodes.get(episodeUUID)
+	if !ok {
+		return adapter.client.episodes.notFound(episodeUUID)
+	}
+	episode.Name = coalesce(updates.Name, episode.Name)
+	episode.Description = coalesce(updates.Description, episode.Description)
+	episode.ProgramID = coalesce(updates.ProgramID, episode.ProgramID)
+	if updates.Position != 0 {
+		episode.Position = updates.Position
+	}
+	episode.UpdatedAt = time.Now()
+	adapter.client.episodes.set(episodeUUID, episode)
+	return nil
+}
+
+// UpdateStatus moves an episode from one publication status to another, along with its publication time.
+// It returns model.ErrConflict when no live episode with the given UUID has the from status.
+func (adapter *episodeAdapter) UpdateStatus(ctx context.Context, episodeUUID string, from, to model.EpisodeStatus, publishAt time.Time) error {
+	defer adapter.client.lock(ctx)()
+	episode, ok := adapter.client.episodes.get(episodeUUID)
+	if !ok || episode.Status != from {
+		return fmt.Errorf("%w: episode %s is no longer %s", model.ErrConflict, episodeUUID, from)
+	}
+	episode.Status = to
+	episode.PublishAt = publishAt
+	episode.UpdatedAt = time.Now()
+	adapter.client.episodes.set(episodeUUID, episode)
+	return nil
+}
+
+// FindDue retrieves the scheduled episodes whose publication time is at or before now, oldest first.
+func (adapter *episodeAdapter) FindDue(ctx context.Context, now time.Time) ([]*model.Episode, error) {
+	defer adapter.client.rlock(ctx)()
+	episodes := adapter.client.episodes.filter(func(episode model.Episode) bool {
+		return episode.Status == model.EpisodeStatusScheduled && !episode.PublishAt.After(now)
+	})
+	sort.SliceStable(episodes, func(i, j int) bool {
+		return episodes[i].PublishAt.Before(episodes[j].PublishAt)
+	})
+	return pointers(episodes), nil
+}
+
+// FindAll retrieves the episodes matching the options, oldest first unless sorted otherwise,
+// along with the number of episodes matching the filters.
+func (adapter *episodeAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Episode, int, error) {
+	defer adapter.client.rlock(ctx)()
+	episodes, total := page(adapter.client.episodes.listed(opts), opts, func(episode model.Episode) columns {
+		return columns{name: episode.Name, position: episode.Position, createdAt: episode.CreatedAt, deletedAt: episode.DeletedAt}
+	})
+	return pointers(episodes), total, nil
+}
+
+// Find retrieves an episode by its UUID.
+// It returns model.ErrNotFound when the episode does not exist.
+func (adapter *episodeAdapter) Find(ctx context.Context, episodeUUID string) (*model.Episode, error) {
+	defer adapter.client.rlock(ctx)()
+	episode, ok := adapter.client.episodes.get(episodeUUID)
+	if !ok {
+		return nil, adapter.client.episodes.notFound(episodeUUID)
+	}
+	return &episode, nil
+}
+
+// FindByIDs retrieves the episodes with the given UUIDs, skipping unknown ones.
+func (adapter *episodeAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Episode, error) {
+	defer adapter.client.rlock(ctx)()
+	return pointers(adapter.client.episodes.getAll(ids)), nil
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// newEpisodeClient builds a client holding the program p1 with the episodes e1 to e3 inserted out of position,
+// e3 being in the trash, and the episode e4 of another program.
+func newEpisodeClient(t *testing.T) *client {
+	t.Helper()
+	c := NewClient()
+	if err := c.programs.insert("p1", model.Program{ID: "p1", Name: "Morning"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, episode := range []model.Episode{
+		{ID: "e1", ProgramID: "p1", Position: 3},
+		{ID: "e2", ProgramID: "p1", Position: 1},
+		{ID: "e3", ProgramID: "p1", Position: 2, DeletedAt: time.Now().Add(-time.Hour)},
+		{ID: "e4", ProgramID: "p2", Position: 2},
+	} {
+		if err := c.episodes.insert(episode.ID, episode); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return c
+}
+
+func TestEpisodeAdapter_FindByProgramID(t *testing.T) {
+	adapter := NewEpisodeAdapter(newEpisodeClient(t))
+
+	episodes, err := adapter.FindByProgramID(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, episode := range episodes {
+		got = append(got, episode.ID)
+	}
+	if len(got) != 2 || got[0] != "e2" || got[1] != "e1" {
+		t.Fatalf("got episodes %v, want the live episodes of the program by position [e2 e1]", got)
+	}
+}
+
+func TestEpisodeAdapter_Create(t *testing.T) {
+	adapter := NewEpisodeAdapter(newEpisodeClient(t))
+	ctx := context.Background()
+
+	if err := adapter.Create(ctx, model.Episode{ID: "e5", ProgramID: "p1", Position: 4}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	episode, err := adapter.Find(ctx, "e5")
+	if err != nil || episode.Status != model.EpisodeStatusDraft || episode.CreatedAt.IsZero() {
+		t.Fatalf("got episode %+v and error %v, want a dated draft", episode, err)
+	}
+	if err := adapter.Create(ctx, model.Episode{ID: "e3", ProgramID: "p1"}); !errors.Is(err, model.ErrConflict) {
+		t.Fatalf("got error %v creating an episode with the ID of an episode in the trash, want %v", err, model.ErrConflict)
+	}
+}
+
+func TestEpisodeAdapter_Restore(t *testing.T) {
+	c := newEpisodeClient(t)
+	adapter := NewEpisodeAdapter(c)
+	ctx := context.Background()
+
+	if err := NewProgramAdapter(c).Delete(ctx, "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := adapter.Restore(ctx, "e1"); !errors.Is(err, model.ErrConflict) {
+		t.Fatalf("got error %v restoring an episode of a program in the trash, want %v", err, model.ErrConflict)
+	}
+	if err := NewProgramAdapter(c).Restore(ctx, "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := adapter.Find(ctx, "e1"); err != nil {
+		t.Fatalf("got error %v, want the episode restored along with its program", err)
+	}
+	if _, err := adapter.Find(ctx, "e3"); !errors.Is(err, model.ErrNotFound) {
+		t.Fatalf("got error %v, want the episode trashed before its program kept in the trash", err)
+	}
+}
+
+func TestEpisodeAdapter_Purge(t *testing.T) {
+	c := newEpisodeClient(t)
+	if err := c.medias.insert("m1", model.Media{ID: "m1", EpisodeID: "e3", DeletedAt: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	purged, err := NewEpisodeAdapter(c).Purge(ctx, time.Now())
+	if err != nil || purged != 0 {
+		t.Fatalf("got %d purged and error %v, want the episode still holding a media kept", purged, err)
+	}
+	medias, err := NewMediaAdapter(c).Purge(ctx, time.Now())
+	if err != nil || len(medias) != 1 || medias[0].ID != "m1" {
+		t.Fatalf("got purged medias %v and error %v, want [m1]", medias, err)
+	}
+	purged, err = NewEpisodeAdapter(c).Purge(ctx, time.Now())
+	if err != nil || purged != 1 {
+		t.Fatalf("got %d purged and error %v, want the episode purged once its medias are", purged, err)
+	}
+	if _, ok := c.episodes.rows["e3"]; ok {
+		t.Fatalf("got episode e3 kept, want it purged")
+	}
+}
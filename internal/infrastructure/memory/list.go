@@ -0,0 +1,73 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// columns exposes the values of a row the list options filter and sort on.
+// name is left empty for rows without a name, which never match a name filter.
+type columns struct {
+	name      string
+	kind      string
+	position  int
+	createdAt time.Time
+	deletedAt time.Time
+}
+
+// page filters, sorts and pages rows as the MySQL adapters do, and returns the page along with the number
+// of rows matching the filters. Names are matched regardless of case, like the MySQL collation does.
+// Rows sharing the same sort value keep their insertion order.
+func page[T any](rows []T, opts model.ListOptions, columnsOf func(T) columns) ([]T, int) {
+	var matching []T
+	nameContains := strings.ToLower(opts.NameContains)
+	for _, row := range rows {
+		c := columnsOf(row)
+		if nameContains != "" && !strings.Contains(strings.ToLower(c.name), nameContains) {
+			continue
+		}
+		if !opts.CreatedFrom.IsZero() && c.createdAt.Before(opts.CreatedFrom) {
+			continue
+		}
+		if !opts.CreatedTo.IsZero() && c.createdAt.After(opts.CreatedTo) {
+			continue
+		}
+		matching = append(matching, row)
+	}
+
+	slices.SortStableFunc(matching, func(a, b T) int {
+		ca, cb := columnsOf(a), columnsOf(b)
+		var order int
+		switch opts.Sort {
+		case model.SortByName:
+			order = cmp.Compare(ca.name, cb.name)
+		case model.SortByKind:
+			order = cmp.Compare(ca.kind, cb.kind)
+		case model.SortByPosition:
+			order = cmp.Compare(ca.position, cb.position)
+		case model.SortByDeletedAt:
+			order = ca.deletedAt.Compare(cb.deletedAt)
+		default:
+			order = ca.createdAt.Compare(cb.createdAt)
+		}
+		if opts.Descending {
+			return -order
+		}
+		return order
+	})
+
+	total := len(matching)
+	if opts.Offset >= total {
+		return nil, total
+	}
+	matching = matching[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < len(matching) {
+		matching = matching[:opts.Limit]
+	}
+	return matching, total
+}
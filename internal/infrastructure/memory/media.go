@@ -0,0 +1,145 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// mediaAdapter is a struct that acts as an adapter for interacting with
+// the media data kept in memory.
+type mediaAdapter struct {
+	client *client
+}
+
+// NewMediaAdapter creates a new media adapter with the provided in-memory client.
+// It returns an implementation of the MediaPersister interface.
+func NewMediaAdapter(client *client) port.MediaPersister {
+	return &mediaAdapter{
+		client: client,
+	}
+}
+
+// Create stores a new media.
+// It returns an error if a media with the same ID already exists.
+func (adapter *mediaAdapter) Create(ctx context.Context, media model.Media) error {
+	defer adapter.client.lock(ctx)()
+	media.CreatedAt = time.Now()
+	media.UpdatedAt = media.CreatedAt
+	return adapter.client.medias.insert(media.ID, media)
+}
+
+// Delete moves a media to the trash by its UUID.
+// It returns model.ErrNotFound when the media does not exist or is already in the trash.
+func (adapter *mediaAdapter) Delete(ctx context.Context, mediaUUID string) error {
+	defer adapter.client.lock(ctx)()
+	media, ok := adapter.client.medias.get(mediaUUID)
+	if !ok {
+		return adapter.client.medias.notFound(mediaUUID)
+	}
+	media.DeletedAt = time.Now()
+	adapter.client.medias.set(mediaUUID, media)
+	return nil
+}
+
+// Restore brings back a media from the trash by its UUID.
+// It returns model.ErrNotFound when the media is not in the trash, and model.ErrConflict while its episode is.
+func (adapter *mediaAdapter) Restore(ctx context.Context, mediaUUID string) error {
+	defer adapter.client.lock(ctx)()
+	media, ok := adapter.client.medias.getTrashed(mediaUUID)
+	if !ok {
+		return adapter.client.medias.notFound(mediaUUID)
+	}
+	if _, ok := adapter.client.episodes.get(media.EpisodeID); !ok {
+		return fmt.Errorf("%w: the episode of media %s is in the trash", model.ErrConflict, mediaUUID)
+	}
+	media.DeletedAt = time.Time{}
+	adapter.client.medias.set(mediaUUID, media)
+	return nil
+}
+
+// Purge permanently removes the medias moved to the trash before the given time, and returns them.
+func (adapter *mediaAdapter) Purge(ctx context.Context, before time.Time) ([]*model.Media, error) {
+	defer adapter.client.lock(ctx)()
+	var medias []*model.Media
+	adapter.client.medias.purge(before, func(media model.Media) bool {
+		medias = append(medias, &media)
+		return true
+	})
+	return medias, nil
+}
+
+// Update updates an existing media, keeping the current value of every empty field.
+// A new direct link replaces the whole file of the media, along with its description and artwork.
+func (adapter *mediaAdapter) Update(ctx context.Context, mediaUUID string, updates model.Media) error {
+	defer adapter.client.lock(ctx)()
+	media, ok := adapter.client.medias.get(mediaUUID)
+	if !ok {
+		return adapter.client.medias.notFound(mediaUUID)
+	}
+	if updates.DirectLink != "" {
+		replaceFile(&media, updates)
+	}
+	media.DirectLink = coalesce(updates.DirectLink, media.DirectLink)
+	media.Kind = coalesce(updates.Kind, media.Kind)
+	media.EpisodeID = coalesce(updates.EpisodeID, media.EpisodeID)
+	media.UpdatedAt = time.Now()
+	adapter.client.medias.set(mediaUUID, media)
+	return nil
+}
+
+// FindAll retrieves the medias matching the options, oldest first unless sorted otherwise,
+// along with the number of medias matching the filters.
+func (adapter *mediaAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Media, int, error) {
+	defer adapter.client.rlock(ctx)()
+	medias, total := page(adapter.client.medias.listed(opts), opts, func(media model.Media) columns {
+		return columns{kind: media.Kind, createdAt: media.CreatedAt, deletedAt: media.DeletedAt}
+	})
+	return pointers(medias), total, nil
+}
+
+// Find retrieves a media by its UUID.
+// It returns model.ErrNotFound when the media does not exist.
+func (adapter *mediaAdapter) Find(ctx context.Context, mediaUUID string) (*model.Media, error) {
+	defer adapter.client.rlock(ctx)()
+	media, ok := adapter.client.medias.get(mediaUUID)
+	if !ok {
+		return nil, adapter.client.medias.notFound(mediaUUID)
+	}
+	return &media, nil
+}
+
+// FindByIDs retrieves the medias with the given UUIDs, skipping unknown ones.
+func (adapter *mediaAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Media, error) {
+	defer adapter.client.rlock(ctx)()
+	return pointers(adapter.client.medias.getAll(ids)), nil
+}
+
+// FindByEpisodeIDs retrieves the medias of several episodes, oldest first.
+func (adapter *mediaAdapter) FindByEpisodeIDs(ctx context.Context, ids []string) ([]*model.Media, error) {
+	defer adapter.client.rlock(ctx)()
+	episodeIDs := setOf(ids)
+	return pointers(adapter.client.medias.filter(func(media model.Media) bool {
+		return episodeIDs[media.EpisodeID]
+	})), nil
+}
+
+// replaceFile sets the uploaded file, audio description and artwork of media from file.
+func replaceFile(media *model.Media, file model.Media) {
+	media.StorageKey = file.StorageKey
+	media.Size = file.Size
+	media.Checksum = file.Checksum
+	media.MimeType = file.MimeType
+	media.Codec = file.Codec
+	media.Duration = file.Duration
+	media.Bitrate = file.Bitrate
+	media.Channels = file.Channels
+	media.SampleRate = file.SampleRate
+	media.Title = file.Title
+	media.ArtworkKey = file.ArtworkKey
+	media.ArtworkLink = file.ArtworkLink
+}
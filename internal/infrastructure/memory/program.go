@@ -0,0 +1,180 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// programAdapter is a struct that acts as an adapter for interacting with
+// the program data kept in memory.
+type programAdapter struct {
+	client *client
+}
+
+// NewProgramAdapter creates a new program adapter with the provided in-memory client.
+// It returns an implementation of the ProgramPersister interface.
+func NewProgramAdapter(client *client) port.ProgramPersister {
+	return &programAdapter{
+		client: client,
+	}
+}
+
+// Create stores a new program.
+// It returns an error if a program with the same ID already exists.
+func (adapter *programAdapter) Create(ctx context.Context, program model.Program) error {
+	defer adapter.client.lock(ctx)()
+	program.Episodes = nil
+	program.CreatedAt = time.Now()
+	program.UpdatedAt = program.CreatedAt
+	return adapter.client.programs.insert(program.ID, program)
+}
+
+// Delete moves a program to the trash by its UUID, along with its episodes and their medias.
+// It returns model.ErrNotFound when the program does not exist or is already in the trash.
+func (adapter *programAdapter) Delete(ctx context.Context, programUUID string) error {
+	defer adapter.client.lock(ctx)()
+	program, ok := adapter.client.programs.get(programUUID)
+	if !ok {
+		return adapter.client.programs.notFound(programUUID)
+	}
+	program.DeletedAt = time.Now()
+	adapter.client.programs.set(programUUID, program)
+	adapter.client.trashEpisodes(programUUID, program.DeletedAt)
+	return nil
+}
+
+// Restore brings back a program from the trash by its UUID, along with the episodes and medias deleted with it.
+// It returns model.ErrNotFound when the program is not in the trash.
+func (adapter *programAdapter) Restore(ctx context.Context, programUUID string) error {
+	defer adapter.client.lock(ctx)()
+	program, ok := adapter.client.programs.getTrashed(programUUID)
+	if !ok {
+		return adapter.client.programs.notFound(programUUID)
+	}
+	deletedAt := program.DeletedAt
+	program.DeletedAt = time.Time{}
+	adapter.client.programs.set(programUUID, program)
+	adapter.client.restoreEpisodes(programUUID, deletedAt)
+	return nil
+}
+
+// Purge permanently removes the programs moved to the trash before the given time, along with their associations.
+// Programs whose episodes have not been purged yet are kept, as the episodes still reference them.
+func (adapter *programAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	defer adapter.client.lock(ctx)()
+	purged := setOf(adapter.client.programs.purge(before, func(program model.Program) bool {
+		return !adapter.client.episodes.exists(func(episode model.Episode) bool { return episode.ProgramID == program.ID })
+	}))
+	adapter.client.blockPrograms.removeAll(func(blockProgram model.BlockProgram) bool { return purged[blockProgram.ProgramID] })
+	adapter.client.programTags.removeAll(func(programTag model.ProgramTag) bool { return purged[programTag.ProgramID] })
+	adapter.client.programCategories.removeAll(func(programCategory model.ProgramCategory) bool { return purged[programCategory.ProgramID] })
+	return len(purged), nil
+}
+
+// Update updates an existing program, keeping the current value of every empty field.
+func (adapter *programAdapter) Update(ctx context.Context, programUUID string, updates model.Program) error {
+	defer adapter.client.lock(ctx)()
+	program, ok := adapter.client.programs.get(programUUID)
+	if !ok {
+		return adapter.client.programs.notFound(programUUID)
+	}
+	program.Name = coalesce(updates.Name, program.Name)
+	program.Description = coalesce(updates.Description, program.Description)
+	program.UpdatedAt = time.Now()
+	adapter.client.programs.set(programUUID, program)
+	return nil
+}
+
+// FindAll retrieves the programs matching the options, oldest first unless sorted otherwise,
+// along with the number of programs matching the filters.
+func (adapter *programAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Program, int, error) {
+	defer adapter.client.rlock(ctx)()
+	programs, total := page(adapter.client.programs.listed(opts), opts, func(program model.Program) columns {
+		return columns{name: program.Name, createdAt: program.CreatedAt, deletedAt: program.DeletedAt}
+	})
+	return pointers(programs), total, nil
+}
+
+// Find retrieves a program by its UUID.
+// It returns model.ErrNotFound when the program does not exist.
+func (adapter *programAdapter) Find(ctx context.Context, programUUID string) (*model.Program, error) {
+	defer adapter.client.rlock(ctx)()
+	program, ok := adapter.client.programs.get(programUUID)
+	if !ok {
+		return nil, adapter.client.programs.notFound(programUUID)
+	}
+	return &program, nil
+}
+
+// FindBySourceGUID retrieves the program imported from the feed with the given identifier, the oldest one should
+// several have been imported from it.
+// It returns model.ErrNotFound when no program was imported from the feed.
+func (adapter *programAdapter) FindBySourceGUID(ctx context.Context, guid string) (*model.Program, error) {
+	defer adapter.client.rlock(ctx)()
+	var found *model.Program
+	for _, program := range adapter.client.programs.filter(func(program model.Program) bool { return program.SourceGUID == guid }) {
+		if found == nil || program.CreatedAt.Before(found.CreatedAt) {
+			found = &program
+		}
+	}
+	if found == nil {
+		return nil, adapter.client.programs.notFound(guid)
+	}
+	return found, nil
+}
+
+// FindByIDs retrieves the programs with the given UUIDs, skipping unknown ones.
+func (adapter *programAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Program, error) {
+	defer adapter.client.rlock(ctx)()
+	return pointers(adapter.client.programs.getAll(ids)), nil
+}
+
+// FindByTagID retrieves the programs associated with a tag, ordered by name.
+func (adapter *programAdapter) FindByTagID(ctx context.Context, id string) ([]*model.Program, error) {
+	defer adapter.client.rlock(ctx)()
+	var programIDs []string
+	for _, programTag := range adapter.client.programTags.all() {
+		if programTag.TagID == id {
+			programIDs = append(programIDs, programTag.ProgramID)
+		}
+	}
+	return adapter.sortedByName(programIDs), nil
+}
+
+// FindByCategoryID retrieves the programs associated with a category, ordered by name.
+func (adapter *programAdapter) FindByCategoryID(ctx context.Context, id string) ([]*model.Program, error) {
+	defer adapter.client.rlock(ctx)()
+	var programIDs []string
+	for _, programCategory := range adapter.client.programCategories.all() {
+		if programCategory.CategoryID == id {
+			programIDs = append(programIDs, programCategory.ProgramID)
+		}
+	}
+	return adapter.sortedByName(programIDs), nil
+}
+
+// FindByCategoryIDs retrieves the programs associated with any of the given categories, each once, ordered by name.
+func (adapter *programAdapter) FindByCategoryIDs(ctx context.Context, ids []string) ([]*model.Program, error) {
+	defer adapter.client.rlock(ctx)()
+	categoryIDs := setOf(ids)
+	seen := make(map[string]bool)
+	var programIDs []string
+	for _, programCategory := range adapter.client.programCategories.all() {
+		if categoryIDs[programCategory.CategoryID] && !seen[programCategory.ProgramID] {
+			seen[programCategory.ProgramID] = true
+			programIDs = append(programIDs, programCategory.ProgramID)
+		}
+	}
+	return adapter.sortedByName(programIDs), nil
+}
+
+// sortedByName returns the programs with the given UUIDs, ordered by name. The caller must hold the read lock.
+func (adapter *programAdapter) sortedByName(ids []string) []*model.Program {
+	programs := adapter.client.programs.getAll(ids)
+	sortByName(programs, func(program model.Program) (string, string) { return program.Name, program.ID })
+	return pointers(programs)
+}
@@ -0,0 +1,104 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// programCategoryAdapter is a struct that acts as an adapter for interacting with
+// the program_category data kept in memory.
+type programCategoryAdapter struct {
+	client *client
+}
+
+// NewProgramCategoryAdapter creates a new programCategory adapter with the provided in-memory client.
+// It returns an implementation of the ProgramCategoryPersister interface.
+func NewProgramCategoryAdapter(client *client) port.ProgramCategoryPersister {
+	return &programCategoryAdapter{
+		client: client,
+	}
+}
+
+// Create stores a new programCategory association.
+// It returns an error if the ID is already used or the category is already attached to the program.
+func (adapter *programCategoryAdapter) Create(ctx context.Context, programCategory model.ProgramCategory) error {
+	defer adapter.client.lock(ctx)()
+	if err := adapter.checkUniquePair(programCategory); err != nil {
+		return err
+	}
+	return adapter.client.programCategories.insert(programCategory.ID, programCategory)
+}
+
+// Delete removes a programCategory association by its UUID. Deleting an unknown association is not an error.
+func (adapter *programCategoryAdapter) Delete(ctx context.Context, programCategoryUUID string) error {
+	defer adapter.client.lock(ctx)()
+	adapter.client.programCategories.remove(programCategoryUUID)
+	return nil
+}
+
+// Update replaces every field of an existing programCategory association.
+func (adapter *programCategoryAdapter) Update(ctx context.Context, programCategoryUUID string, updates model.ProgramCategory) error {
+	defer adapter.client.lock(ctx)()
+	if _, ok := adapter.client.programCategories.get(programCategoryUUID); !ok {
+		return adapter.client.programCategories.notFound(programCategoryUUID)
+	}
+	updates.ID = programCategoryUUID
+	if err := adapter.checkUniquePair(updates); err != nil {
+		return err
+	}
+	adapter.client.programCategories.set(programCategoryUUID, updates)
+	return nil
+}
+
+// Find retrieves a programCategory association by its UUID.
+// It returns model.ErrNotFound when the association does not exist.
+func (adapter *programCategoryAdapter) Find(ctx context.Context, programCategoryUUID string) (*model.ProgramCategory, error) {
+	defer adapter.client.rlock(ctx)()
+	programCategory, ok := adapter.client.programCategories.get(programCategoryUUID)
+	if !ok {
+		return nil, adapter.client.programCategories.notFound(programCategoryUUID)
+	}
+	return &programCategory, nil
+}
+
+// FindByProgramID retrieves the programCategory associations of a program.
+func (adapter *programCategoryAdapter) FindByProgramID(ctx context.Context, programID string) ([]*model.ProgramCategory, error) {
+	return adapter.findBy(ctx, func(programCategory model.ProgramCategory) bool {
+		return programCategory.ProgramID == programID
+	}), nil
+}
+
+// FindByCategoryID retrieves the programCategory associations of a category.
+func (adapter *programCategoryAdapter) FindByCategoryID(ctx context.Context, categoryID string) ([]*model.ProgramCategory, error) {
+	return adapter.findBy(ctx, func(programCategory model.ProgramCategory) bool {
+		return programCategory.CategoryID == categoryID
+	}), nil
+}
+
+// FindByCategoryIDAndProgramID retrieves the programCategory associations for a given category ID and program ID.
+func (adapter *programCategoryAdapter) FindByCategoryIDAndProgramID(ctx context.Context, categoryID, programID string) ([]*model.ProgramCategory, error) {
+	return adapter.findBy(ctx, func(programCategory model.ProgramCategory) bool {
+		return programCategory.CategoryID == categoryID && programCategory.ProgramID == programID
+	}), nil
+}
+
+// findBy returns the associations accepted by match, in creation order.
+func (adapter *programCategoryAdapter) findBy(ctx context.Context, match func(model.ProgramCategory) bool) []*model.ProgramCategory {
+	defer adapter.client.rlock(ctx)()
+	return pointers(adapter.client.programCategories.filter(match))
+}
+
+// checkUniquePair enforces the unique (programUUID, categoryUUID) constraint of the program_category table.
+func (adapter *programCategoryAdapter) checkUniquePair(programCategory model.ProgramCategory) error {
+	taken := adapter.client.programCategories.exists(func(existing model.ProgramCategory) bool {
+		return existing.ProgramID == programCategory.ProgramID && existing.CategoryID == programCategory.CategoryID && existing.ID != programCategory.ID
+	})
+	if taken {
+		return fmt.Errorf("%w: duplicate entry '%s-%s' for key 'program_category.uq_program_category'", model.ErrConflict, programCategory.ProgramID, programCategory.CategoryID)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// programTagAdapter is a struct that acts as an adapter for interacting with
+// the program_tag data kept in memory.
+type programTagAdapter struct {
+	client *client
+}
+
+// NewProgramTagAdapter creates a new programTag adapter with the provided in-memory client.
+// It returns an implementation of the ProgramTagPersister interface.
+func NewProgramTagAdapter(client *client) port.ProgramTagPersister {
+	return &programTagAdapter{
+		client: client,
+	}
+}
+
+// Create stores a new programTag association.
+// It returns an error if the ID is already used or the tag is already attached to the program.
+func (adapter *programTagAdapter) Create(ctx context.Context, programTag model.ProgramTag) error {
+	defer adapter.client.lock(ctx)()
+	if err := adapter.checkUniquePair(programTag); err != nil {
+		return err
+	}
+	return adapter.client.programTags.insert(programTag.ID, programTag)
+}
+
+// Delete removes a programTag association by its UUID. Deleting an unknown association is not an error.
+func (adapter *programTagAdapter) Delete(ctx context.Context, programTagUUID string) error {
+	defer adapter.client.lock(ctx)()
+	adapter.client.programTags.remove(programTagUUID)
+	return nil
+}
+
+// Update replaces every field of an existing programTag association.
+func (adapter *programTagAdapter) Update(ctx context.Context, programTagUUID string, updates model.ProgramTag) error {
+	defer adapter.client.lock(ctx)()
+	if _, ok := adapter.client.programTags.get(programTagUUID); !ok {
+		return adapter.client.programTags.notFound(programTagUUID)
+	}
+	updates.ID = programTagUUID
+	if err := adapter.checkUniquePair(updates); err != nil {
+		return err
+	}
+	adapter.client.programTags.set(programTagUUID, updates)
+	return nil
+}
+
+// Find retrieves a programTag association by its UUID.
+// It returns model.ErrNotFound when the association does not exist.
+func (adapter *programTagAdapter) Find(ctx context.Context, programTagUUID string) (*model.ProgramTag, error) {
+	defer adapter.client.rlock(ctx)()
+	programTag, ok := adapter.client.programTags.get(programTagUUID)
+	if !ok {
+		return nil, adapter.client.programTags.notFound(programTagUUID)
+	}
+	return &programTag, nil
+}
+
+// FindByProgramID retrieves the programTag associations of a program.
+func (adapter *programTagAdapter) FindByProgramID(ctx context.Context, programID string) ([]*model.ProgramTag, error) {
+	return adapter.findBy(ctx, func(programTag model.ProgramTag) bool {
+		return programTag.ProgramID == programID
+	}), nil
+}
+
+// FindByTagID retrieves the programTag associations of a tag.
+func (adapter *programTagAdapter) FindByTagID(ctx context.Context, tagID string) ([]*model.ProgramTag, error) {
+	return adapter.findBy(ctx, func(programTag model.ProgramTag) bool {
+		return programTag.TagID == tagID
+	}), nil
+}
+
+// FindByTagIDAndProgramID retrieves the programTag associations for a given tag ID and program ID.
+func (adapter *programTagAdapter) FindByTagIDAndProgramID(ctx context.Context, tagID, programID string) ([]*model.ProgramTag, error) {
+	return adapter.findBy(ctx, func(programTag model.ProgramTag) bool {
+		return programTag.TagID == tagID && programTag.ProgramID == programID
+	}), nil
+}
+
+// findBy returns the associations accepted by match, in creation order.
+func (adapter *programTagAdapter) findBy(ctx context.Context, match func(model.ProgramTag) bool) []*model.ProgramTag {
+	defer adapter.client.rlock(ctx)()
+	return pointers(adapter.client.programTags.filter(match))
+}
+
+// checkUniquePair enforces the unique (programUUID, tagUUID) constraint of the program_tag table.
+func (adapter *programTagAdapter) checkUniquePair(programTag model.ProgramTag) error {
+	taken := adapter.client.programTags.exists(func(existing model.ProgramTag) bool {
+		return existing.ProgramID == programTag.ProgramID && existing.TagID == programTag.TagID && existing.ID != programTag.ID
+	})
+	if taken {
+		return fmt.Errorf("%w: duplicate entry '%s-%s' for key 'program_tag.uq_program_tag'", model.ErrConflict, programTag.ProgramID, programTag.TagID)
+	}
+	return nil
+}
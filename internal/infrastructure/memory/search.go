@@ -0,0 +1,78 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// searchAdapter is a struct that acts as an adapter for searching
+// the catalogue kept in memory.
+type searchAdapter struct {
+	client *client
+}
+
+// NewSearchAdapter creates a new search adapter with the provided in-memory client.
+// It returns an implementation of the Searcher interface.
+func NewSearchAdapter(client *client) port.Searcher {
+	return &searchAdapter{
+		client: client,
+	}
+}
+
+// Search approximates the MySQL natural language search: every word of the query found in
+// the name of an entity scores 2, and 1 in its description. Programs and episodes are matched
+// on name and description, tags and categories on name only, like the FULLTEXT indexes.
+func (adapter *searchAdapter) Search(ctx context.Context, query string, limit int) ([]*model.SearchHit, error) {
+	defer adapter.client.rlock(ctx)()
+	terms := strings.Fields(strings.ToLower(query))
+
+	var programs, episodes, tags, categories []*model.SearchHit
+	for _, program := range adapter.client.programs.all() {
+		programs = appendHit(programs, terms, model.SearchHit{Kind: model.SearchKindProgram, ID: program.ID, Name: program.Name, Description: program.Description}, true)
+	}
+	for _, episode := range adapter.client.episodes.all() {
+		episodes = appendHit(episodes, terms, model.SearchHit{Kind: model.SearchKindEpisode, ID: episode.ID, Name: episode.Name, Description: episode.Description}, true)
+	}
+	for _, tag := range adapter.client.tags.all() {
+		tags = appendHit(tags, terms, model.SearchHit{Kind: model.SearchKindTag, ID: tag.ID, Name: tag.Name, Description: tag.Description}, false)
+	}
+	for _, category := range adapter.client.categories.all() {
+		categories = appendHit(categories, terms, model.SearchHit{Kind: model.SearchKindCategory, ID: category.ID, Name: category.Name, Description: category.Description}, false)
+	}
+
+	var hits []*model.SearchHit
+	for _, kindHits := range [][]*model.SearchHit{programs, episodes, tags, categories} {
+		slices.SortStableFunc(kindHits, func(a, b *model.SearchHit) int {
+			return cmp.Compare(b.Score, a.Score)
+		})
+		if len(kindHits) > limit {
+			kindHits = kindHits[:limit]
+		}
+		hits = append(hits, kindHits...)
+	}
+	return hits, nil
+}
+
+// appendHit scores hit against the query terms and appends it to hits when it matches.
+// The description only counts when withDescription is set.
+func appendHit(hits []*model.SearchHit, terms []string, hit model.SearchHit, withDescription bool) []*model.SearchHit {
+	name, description := strings.ToLower(hit.Name), strings.ToLower(hit.Description)
+	for _, term := range terms {
+		if strings.Contains(name, term) {
+			hit.Score += 2
+		}
+		if withDescription && strings.Contains(description, term) {
+			hit.Score++
+		}
+	}
+	if hit.Score == 0 {
+		return hits
+	}
+	return append(hits, &hit)
+}
@@ -0,0 +1,158 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// tagAdapter is a struct that acts as an adapter for interacting with
+// the tag data kept in memory.
+type tagAdapter struct {
+	client *client
+}
+
+// NewTagAdapter creates a new tag adapter with the provided in-memory client.
+// It returns an implementation of the TagPersister interface.
+func NewTagAdapter(client *client) port.TagPersister {
+	return &tagAdapter{
+		client: client,
+	}
+}
+
+// Create stores a new tag.
+// It returns an error if a tag with the same ID or name already exists.
+func (adapter *tagAdapter) Create(ctx context.Context, tag model.Tag) error {
+	defer adapter.client.lock(ctx)()
+	if err := adapter.checkUniqueName(tag.ID, tag.Name); err != nil {
+		return err
+	}
+	tag.CreatedAt = time.Now()
+	return adapter.client.tags.insert(tag.ID, tag)
+}
+
+// Delete moves a tag to the trash by its UUID.
+// It returns model.ErrNotFound when the tag does not exist or is already in the trash.
+func (adapter *tagAdapter) Delete(ctx context.Context, tagUUID string) error {
+	defer adapter.client.lock(ctx)()
+	tag, ok := adapter.client.tags.get(tagUUID)
+	if !ok {
+		return adapter.client.tags.notFound(tagUUID)
+	}
+	tag.DeletedAt = time.Now()
+	adapter.client.tags.set(tagUUID, tag)
+	return nil
+}
+
+// Restore brings back a tag from the trash by its UUID.
+// It returns model.ErrNotFound when the tag is not in the trash.
+func (adapter *tagAdapter) Restore(ctx context.Context, tagUUID string) error {
+	defer adapter.client.lock(ctx)()
+	tag, ok := adapter.client.tags.getTrashed(tagUUID)
+	if !ok {
+		return adapter.client.tags.notFound(tagUUID)
+	}
+	tag.DeletedAt = time.Time{}
+	adapter.client.tags.set(tagUUID, tag)
+	return nil
+}
+
+// Purge permanently removes the tags moved to the trash before the given time, along with their associations.
+func (adapter *tagAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	defer adapter.client.lock(ctx)()
+	purged := setOf(adapter.client.tags.purge(before, func(model.Tag) bool { return true }))
+	adapter.client.programTags.removeAll(func(programTag model.ProgramTag) bool { return purged[programTag.TagID] })
+	return len(purged), nil
+}
+
+// Update updates an existing tag, keeping the current value of every empty field.
+func (adapter *tagAdapter) Update(ctx context.Context, tagUUID string, updates model.Tag) error {
+	defer adapter.client.lock(ctx)()
+	tag, ok := adapter.client.tags.get(tagUUID)
+	if !ok {
+		return adapter.client.tags.notFound(tagUUID)
+	}
+	tag.Name = coalesce(updates.Name, tag.Name)
+	tag.Description = coalesce(updates.Description, tag.Description)
+	if err := adapter.checkUniqueName(tagUUID, tag.Name); err != nil {
+		return err
+	}
+	adapter.client.tags.set(tagUUID, tag)
+	return nil
+}
+
+// FindAll retrieves the tags matching the options, oldest first unless sorted otherwise,
+// along with the number of tags matching the filters.
+func (adapter *tagAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Tag, int, error) {
+	defer adapter.client.rlock(ctx)()
+	tags, total := page(adapter.client.tags.listed(opts), opts, func(tag model.Tag) columns {
+		return columns{name: tag.Name, createdAt: tag.CreatedAt, deletedAt: tag.DeletedAt}
+	})
+	return pointers(tags), total, nil
+}
+
+// Find retrieves a tag by its UUID.
+// It returns model.ErrNotFound when the tag does not exist.
+func (adapter *tagAdapter) Find(ctx context.Context, tagUUID string) (*model.Tag, error) {
+	defer adapter.client.rlock(ctx)()
+	tag, ok := adapter.client.tags.get(tagUUID)
+	if !ok {
+		return nil, adapter.client.tags.notFound(tagUUID)
+	}
+	return &tag, nil
+}
+
+// FindByIDs retrieves the tags with the given UUIDs, skipping unknown ones.
+func (adapter *tagAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Tag, error) {
+	defer adapter.client.rlock(ctx)()
+	return pointers(adapter.client.tags.getAll(ids)), nil
+}
+
+// FindByNames retrieves the tags with the given names regardless of case, live or in the trash, skipping unknown ones.
+func (adapter *tagAdapter) FindByNames(ctx context.Context, names []string) ([]*model.Tag, error) {
+	defer adapter.client.rlock(ctx)()
+	match := func(tag model.Tag) bool {
+		return slices.ContainsFunc(names, func(name string) bool { return strings.EqualFold(name, tag.Name) })
+	}
+	tags := adapter.client.tags.filter(match)
+	for _, tag := range adapter.client.tags.trashed() {
+		if match(tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return pointers(tags), nil
+}
+
+// FindByProgramID retrieves the tags associated with a program, ordered by name.
+func (adapter *tagAdapter) FindByProgramID(ctx context.Context, id string) ([]*model.Tag, error) {
+	defer adapter.client.rlock(ctx)()
+	var tagIDs []string
+	for _, programTag := range adapter.client.programTags.all() {
+		if programTag.ProgramID == id {
+			tagIDs = append(tagIDs, programTag.TagID)
+		}
+	}
+	tags := adapter.client.tags.getAll(tagIDs)
+	sortByName(tags, func(tag model.Tag) (string, string) { return tag.Name, tag.ID })
+	return pointers(tags), nil
+}
+
+// checkUniqueName enforces the unique tag name constraint of the tag table, which ignores case.
+func (adapter *tagAdapter) checkUniqueName(tagUUID, name string) error {
+	if name == "" {
+		return nil
+	}
+	taken := adapter.client.tags.exists(func(tag model.Tag) bool {
+		return strings.EqualFold(tag.Name, name) && tag.ID != tagUUID
+	})
+	if taken {
+		return fmt.Errorf("%w: duplicate entry '%s' for key 'tag.uq_tag_name'", model.ErrConflict, name)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// newTagClient builds a client holding the live tag t1 named News, associated with the program p1,
+// and the tag t2 named Culture, moved to the trash a day ago.
+func newTagClient(t *testing.T) *client {
+	t.Helper()
+	c := NewClient()
+	for _, tag := range []model.Tag{
+		{ID: "t1", Name: "News"},
+		{ID: "t2", Name: "Culture", DeletedAt: time.Now().Add(-24 * time.Hour)},
+	} {
+		if err := c.tags.insert(tag.ID, tag); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := c.programTags.insert("pt1", model.ProgramTag{ID: "pt1", ProgramID: "p1", TagID: "t1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestTagAdapter_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     model.Tag
+		wantErr error
+	}{
+		{
+			name: "creates a tag",
+			tag:  model.Tag{ID: "t3", Name: "Sports"},
+		},
+		{
+			name:    "refuses a used ID",
+			tag:     model.Tag{ID: "t1", Name: "Sports"},
+			wantErr: model.ErrConflict,
+		},
+		{
+			name:    "refuses the name of a tag differing by case",
+			tag:     model.Tag{ID: "t3", Name: "NEWS"},
+			wantErr: model.ErrConflict,
+		},
+		{
+			name:    "refuses the name of a tag in the trash",
+			tag:     model.Tag{ID: "t3", Name: "culture"},
+			wantErr: model.ErrConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := NewTagAdapter(newTagClient(t))
+
+			err := adapter.Create(context.Background(), tt.tag)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				if _, err := adapter.Find(context.Background(), tt.tag.ID); err != nil {
+					t.Fatalf("got error %v finding the new tag", err)
+				}
+			}
+		})
+	}
+}
+
+func TestTagAdapter_Update(t *testing.T) {
+	adapter := NewTagAdapter(newTagClient(t))
+	ctx := context.Background()
+
+	if err := adapter.Update(ctx, "t1", model.Tag{Name: "news"}); err != nil {
+		t.Fatalf("got error %v renaming a tag to its own name in another case", err)
+	}
+	if err := adapter.Update(ctx, "t1", model.Tag{Name: "Culture"}); !errors.Is(err, model.ErrConflict) {
+		t.Fatalf("got error %v renaming a tag to the name of a tag in the trash, want %v", err, model.ErrConflict)
+	}
+	if err := adapter.Update(ctx, "t2", model.Tag{Name: "Arts"}); !errors.Is(err, model.ErrNotFound) {
+		t.Fatalf("got error %v updating a tag in the trash, want %v", err, model.ErrNotFound)
+	}
+	tag, err := adapter.Find(ctx, "t1")
+	if err != nil || tag.Name != "news" {
+		t.Fatalf("got tag %+v and error %v, want the tag renamed once", tag, err)
+	}
+}
+
+func TestTagAdapter_NotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(ctx context.Context, adapter *tagAdapter) error
+	}{
+		{
+			name: "finds an unknown tag",
+			call: func(ctx context.Context, adapter *tagAdapter) error {
+				_, err := adapter.Find(ctx, "unknown")
+				return err
+			},
+		},
+		{
+			name: "finds a tag in the trash",
+			call: func(ctx context.Context, adapter *tagAdapter) error {
+				_, err := adapter.Find(ctx, "t2")
+				return err
+			},
+		},
+		{
+			name: "deletes a tag already in the trash",
+			call: func(ctx context.Context, adapter *tagAdapter) error { return adapter.Delete(ctx, "t2") },
+		},
+		{
+			name: "restores a live tag",
+			call: func(ctx context.Context, adapter *tagAdapter) error { return adapter.Restore(ctx, "t1") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &tagAdapter{client: newTagClient(t)}
+
+			if err := tt.call(context.Background(), adapter); !errors.Is(err, model.ErrNotFound) {
+				t.Fatalf("got error %v, want %v", err, model.ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestTagAdapter_DeleteAndRestore(t *testing.T) {
+	c := newTagClient(t)
+	adapter := NewTagAdapter(c)
+	ctx := context.Background()
+
+	if err := adapter.Delete(ctx, "t1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags, err := adapter.FindByProgramID(ctx, "p1"); err != nil || len(tags) != 0 {
+		t.Fatalf("got tags %v and error %v, want the tag in the trash hidden", tags, err)
+	}
+	if err := adapter.Restore(ctx, "t1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags, err := adapter.FindByProgramID(ctx, "p1"); err != nil || len(tags) != 1 || tags[0].ID != "t1" {
+		t.Fatalf("got tags %v and error %v, want the restored tag along with its association", tags, err)
+	}
+}
+
+func TestTagAdapter_Purge(t *testing.T) {
+	c := newTagClient(t)
+	if err := c.programTags.insert("pt2", model.ProgramTag{ID: "pt2", ProgramID: "p1", TagID: "t2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	adapter := NewTagAdapter(c)
+
+	purged, err := adapter.Purge(context.Background(), time.Now().Add(-48*time.Hour))
+	if err != nil || purged != 0 {
+		t.Fatalf("got %d purged and error %v, want the tag trashed after the retention kept", purged, err)
+	}
+	purged, err = adapter.Purge(context.Background(), time.Now())
+	if err != nil || purged != 1 {
+		t.Fatalf("got %d purged and error %v, want the tag in the trash purged", purged, err)
+	}
+	if _, ok := c.tags.rows["t2"]; ok {
+		t.Fatalf("got tag t2 kept, want it purged")
+	}
+	if _, ok := c.tags.rows["t1"]; !ok {
+		t.Fatalf("got live tag t1 purged, want it kept")
+	}
+	if _, ok := c.programTags.rows["pt2"]; ok {
+		t.Fatalf("got the association of the purged tag kept, want it removed")
+	}
+	if _, ok := c.programTags.rows["pt1"]; !ok {
+		t.Fatalf("got the association of the live tag removed, want it kept")
+	}
+}
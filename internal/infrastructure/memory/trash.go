@@ -0,0 +1,51 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// trashEpisodes moves the live episodes of a program to the trash along with their medias, stamping them
+// with the deletion time of the program. The caller must hold the write lock.
+func (c *client) trashEpisodes(programID string, deletedAt time.Time) {
+	for _, episode := range c.episodes.filter(func(episode model.Episode) bool { return episode.ProgramID == programID }) {
+		episode.DeletedAt = deletedAt
+		c.episodes.set(episode.ID, episode)
+		c.trashMedias(episode.ID, deletedAt)
+	}
+}
+
+// trashMedias moves the live medias of an episode to the trash, stamping them with the deletion time of the episode.
+// The caller must hold the write lock.
+func (c *client) trashMedias(episodeID string, deletedAt time.Time) {
+	for _, media := range c.medias.filter(func(media model.Media) bool { return media.EpisodeID == episodeID }) {
+		media.DeletedAt = deletedAt
+		c.medias.set(media.ID, media)
+	}
+}
+
+// restoreEpisodes brings back the episodes of a program moved to the trash along with it, which share
+// its deletion time, and their medias. The caller must hold the write lock.
+func (c *client) restoreEpisodes(programID string, deletedAt time.Time) {
+	for _, episode := range c.episodes.trashed() {
+		if episode.ProgramID != programID || !episode.DeletedAt.Equal(deletedAt) {
+			continue
+		}
+		episode.DeletedAt = time.Time{}
+		c.episodes.set(episode.ID, episode)
+		c.restoreMedias(episode.ID, deletedAt)
+	}
+}
+
+// restoreMedias brings back the medias of an episode moved to the trash along with it, which share its deletion time.
+// The caller must hold the write lock.
+func (c *client) restoreMedias(episodeID string, deletedAt time.Time) {
+	for _, media := range c.medias.trashed() {
+		if media.EpisodeID == episodeID && media.DeletedAt.Equal(deletedAt) {
+			media.DeletedAt = time.Time{}
+			c.medias.set(media.ID, media)
+		}
+	}
+}
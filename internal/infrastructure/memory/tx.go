@@ -0,0 +1,46 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// txManager runs units of work against the in-memory store.
+type txManager struct {
+	client *client
+}
+
+// NewTxManager creates a new transaction manager with the provided in-memory client.
+// It returns an implementation of the TxManager interface.
+func NewTxManager(client *client) port.TxManager {
+	return &txManager{
+		client: client,
+	}
+}
+
+// WithinTx runs fn while holding the write lock of the client, and puts every table back
+// as it was when fn returns an error. Units of work are therefore serialized with every other call.
+// A call made within an ongoing unit of work joins it instead of starting a new one.
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.client.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	m.client.mu.Lock()
+	defer m.client.mu.Unlock()
+	restore := m.client.snapshot()
+	defer func() {
+		if p := recover(); p != nil {
+			restore()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, m.client)); err != nil {
+		restore()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+func TestTxManager_WithinTx(t *testing.T) {
+	errFailed := errors.New("failed")
+	tests := []struct {
+		name     string
+		fn       func(ctx context.Context, tx *txManager, tags *tagAdapter) error
+		wantErr  error
+		wantTags []string
+	}{
+		{
+			name: "keeps the changes of a unit of work",
+			fn: func(ctx context.Context, _ *txManager, tags *tagAdapter) error {
+				if err := tags.Create(ctx, model.Tag{ID: "t2", Name: "Culture"}); err != nil {
+					return err
+				}
+				return tags.Update(ctx, "t1", model.Tag{Name: "Politics"})
+			},
+			wantTags: []string{"Politics", "Culture"},
+		},
+		{
+			name: "rolls back every change of a failed unit of work",
+			fn: func(ctx context.Context, _ *txManager, tags *tagAdapter) error {
+				if err := tags.Create(ctx, model.Tag{ID: "t2", Name: "Culture"}); err != nil {
+					return err
+				}
+				if err := tags.Update(ctx, "t1", model.Tag{Name: "Politics"}); err != nil {
+					return err
+				}
+				return errFailed
+			},
+			wantErr:  errFailed,
+			wantTags: []string{"News"},
+		},
+		{
+			name: "rolls back on the error of an adapter",
+			fn: func(ctx context.Context, _ *txManager, tags *tagAdapter) error {
+				if err := tags.Delete(ctx, "t1"); err != nil {
+					return err
+				}
+				return tags.Create(ctx, model.Tag{ID: "t1", Name: "Culture"})
+			},
+			wantErr:  model.ErrConflict,
+			wantTags: []string{"News"},
+		},
+		{
+			name: "joins the ongoing unit of work, rolled back as a whole",
+			fn: func(ctx context.Context, tx *txManager, tags *tagAdapter) error {
+				err := tx.WithinTx(ctx, func(ctx context.Context) error {
+					return tags.Create(ctx, model.Tag{ID: "t2", Name: "Culture"})
+				})
+				if err != nil {
+					return err
+				}
+				return errFailed
+			},
+			wantErr:  errFailed,
+			wantTags: []string{"News"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient()
+			if err := c.tags.insert("t1", model.Tag{ID: "t1", Name: "News"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tx := &txManager{client: c}
+			tags := &tagAdapter{client: c}
+
+			err := tx.WithinTx(context.Background(), func(ctx context.Context) error {
+				return tt.fn(ctx, tx, tags)
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, tag := range c.tags.all() {
+				got = append(got, tag.Name)
+			}
+			if len(got) != len(tt.wantTags) {
+				t.Fatalf("got tags %v, want %v", got, tt.wantTags)
+			}
+			for i := range got {
+				if got[i] != tt.wantTags[i] {
+					t.Fatalf("got tags %v, want %v", got, tt.wantTags)
+				}
+			}
+		})
+	}
+}
+
+func TestTxManager_WithinTx_Panic(t *testing.T) {
+	c := NewClient()
+	tx := NewTxManager(c)
+
+	func() {
+		defer func() { _ = recover() }()
+		_ = tx.WithinTx(context.Background(), func(ctx context.Context) error {
+			if err := NewTagAdapter(c).Create(ctx, model.Tag{ID: "t1", Name: "News"}); err != nil {
+				return err
+			}
+			panic("failed")
+		})
+	}()
+
+	if tags := c.tags.all(); len(tags) != 0 {
+		t.Fatalf("got tags %v, want the changes of the panicking unit of work rolled back", tags)
+	}
+	if _, err := NewTagAdapter(c).Find(context.Background(), "t1"); !errors.Is(err, model.ErrNotFound) {
+		t.Fatalf("got error %v, want %v once the lock is released", err, model.ErrNotFound)
+	}
+}
@@ -0,0 +1,107 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// wallAdapter is a struct that acts as an adapter for interacting with
+// the wall data kept in memory.
+type wallAdapter struct {
+	client *client
+}
+
+// NewWallAdapter creates a new wall adapter with the provided in-memory client.
+// It returns an implementation of the WallPersister interface.
+func NewWallAdapter(client *client) port.WallPersister {
+	return &wallAdapter{
+		client: client,
+	}
+}
+
+// Create stores a new wall.
+// It returns an error if a wall with the same ID already exists.
+func (adapter *wallAdapter) Create(ctx context.Context, wall model.Wall) error {
+	defer adapter.client.lock(ctx)()
+	wall.Blocks = nil
+	wall.CreatedAt = time.Now()
+	return adapter.client.walls.insert(wall.ID, wall)
+}
+
+// Delete moves a wall to the trash by its UUID.
+// It returns model.ErrNotFound when the wall does not exist or is already in the trash.
+func (adapter *wallAdapter) Delete(ctx context.Context, wallUUID string) error {
+	defer adapter.client.lock(ctx)()
+	wall, ok := adapter.client.walls.get(wallUUID)
+	if !ok {
+		return adapter.client.walls.notFound(wallUUID)
+	}
+	wall.DeletedAt = time.Now()
+	adapter.client.walls.set(wallUUID, wall)
+	return nil
+}
+
+// Restore brings back a wall from the trash by its UUID.
+// It returns model.ErrNotFound when the wall is not in the trash.
+func (adapter *wallAdapter) Restore(ctx context.Context, wallUUID string) error {
+	defer adapter.client.lock(ctx)()
+	wall, ok := adapter.client.walls.getTrashed(wallUUID)
+	if !ok {
+		return adapter.client.walls.notFound(wallUUID)
+	}
+	wall.DeletedAt = time.Time{}
+	adapter.client.walls.set(wallUUID, wall)
+	return nil
+}
+
+// Purge permanently removes the walls moved to the trash before the given time, along with their associations.
+func (adapter *wallAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	defer adapter.client.lock(ctx)()
+	purged := setOf(adapter.client.walls.purge(before, func(model.Wall) bool { return true }))
+	adapter.client.wallBlocks.removeAll(func(wallBlock model.WallBlock) bool { return purged[wallBlock.WallID] })
+	return len(purged), nil
+}
+
+// Update updates an existing wall, keeping the current value of every empty field.
+func (adapter *wallAdapter) Update(ctx context.Context, wallUUID string, updates model.Wall) error {
+	defer adapter.client.lock(ctx)()
+	wall, ok := adapter.client.walls.get(wallUUID)
+	if !ok {
+		return adapter.client.walls.notFound(wallUUID)
+	}
+	wall.Name = coalesce(updates.Name, wall.Name)
+	wall.Description = coalesce(updates.Description, wall.Description)
+	adapter.client.walls.set(wallUUID, wall)
+	return nil
+}
+
+// FindAll retrieves the walls matching the options, oldest first unless sorted otherwise,
+// along with the number of walls matching the filters.
+func (adapter *wallAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Wall, int, error) {
+	defer adapter.client.rlock(ctx)()
+	walls, total := page(adapter.client.walls.listed(opts), opts, func(wall model.Wall) columns {
+		return columns{name: wall.Name, createdAt: wall.CreatedAt, deletedAt: wall.DeletedAt}
+	})
+	return pointers(walls), total, nil
+}
+
+// Find retrieves a wall by its UUID.
+// It returns model.ErrNotFound when the wall does not exist.
+func (adapter *wallAdapter) Find(ctx context.Context, wallUUID string) (*model.Wall, error) {
+	defer adapter.client.rlock(ctx)()
+	wall, ok := adapter.client.walls.get(wallUUID)
+	if !ok {
+		return nil, adapter.client.walls.notFound(wallUUID)
+	}
+	return &wall, nil
+}
+
+// FindByIDs retrieves the walls with the given UUIDs, skipping unknown ones.
+func (adapter *wallAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Wall, error) {
+	defer adapter.client.rlock(ctx)()
+	return pointers(adapter.client.walls.getAll(ids)), nil
+}
@@ -0,0 +1,109 @@
+// Package memory provides in-memory implementations of the persistence interfaces.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// wallBlockAdapter is a struct that acts as an adapter for interacting with
+// the wall_block data kept in memory.
+type wallBlockAdapter struct {
+	client *client
+}
+
+// NewWallBlockAdapter creates a new wallBlock adapter with the provided in-memory client.
+// It returns an implementation of the WallBlockPersister interface.
+func NewWallBlockAdapter(client *client) port.WallBlockPersister {
+	return &wallBlockAdapter{
+		client: client,
+	}
+}
+
+// Create stores a new wallBlock association.
+// It returns an error if the ID is already used or the block is already placed on the wall.
+func (adapter *wallBlockAdapter) Create(ctx context.Context, wallBlock model.WallBlock) error {
+	defer adapter.client.lock(ctx)()
+	if err := adapter.checkUniquePair(wallBlock); err != nil {
+		return err
+	}
+	return adapter.client.wallBlocks.insert(wallBlock.ID, wallBlock)
+}
+
+// Delete removes a wallBlock association by its UUID. Deleting an unknown association is not an error.
+func (adapter *wallBlockAdapter) Delete(ctx context.Context, wallBlockUUID string) error {
+	defer adapter.client.lock(ctx)()
+	adapter.client.wallBlocks.remove(wallBlockUUID)
+	return nil
+}
+
+// Update replaces every field of an existing wallBlock association.
+func (adapter *wallBlockAdapter) Update(ctx context.Context, wallBlockUUID string, updates model.WallBlock) error {
+	defer adapter.client.lock(ctx)()
+	if _, ok := adapter.client.wallBlocks.get(wallBlockUUID); !ok {
+		return adapter.client.wallBlocks.notFound(wallBlockUUID)
+	}
+	updates.ID = wallBlockUUID
+	if err := adapter.checkUniquePair(updates); err != nil {
+		return err
+	}
+	adapter.client.wallBlocks.set(wallBlockUUID, updates)
+	return nil
+}
+
+// Find retrieves a wallBlock association by its UUID.
+// It returns model.ErrNotFound when the association does not exist.
+func (adapter *wallBlockAdapter) Find(ctx context.Context, wallBlockUUID string) (*model.WallBlock, error) {
+	defer adapter.client.rlock(ctx)()
+	wallBlock, ok := adapter.client.wallBlocks.get(wallBlockUUID)
+	if !ok {
+		return nil, adapter.client.wallBlocks.notFound(wallBlockUUID)
+	}
+	return &wallBlock, nil
+}
+
+// FindByWallID retrieves the wallBlock associations of a wall, ordered by position.
+func (adapter *wallBlockAdapter) FindByWallID(ctx context.Context, wallID string) ([]*model.WallBlock, error) {
+	return adapter.findBy(ctx, func(wallBlock model.WallBlock) bool {
+		return wallBlock.WallID == wallID
+	}), nil
+}
+
+// FindByBlockID retrieves the wallBlock associations of a block, ordered by position.
+func (adapter *wallBlockAdapter) FindByBlockID(ctx context.Context, blockID string) ([]*model.WallBlock, error) {
+	return adapter.findBy(ctx, func(wallBlock model.WallBlock) bool {
+		return wallBlock.BlockID == blockID
+	}), nil
+}
+
+// FindByWallIDAndBlockID retrieves the wallBlock associations for a given wall ID and block ID.
+func (adapter *wallBlockAdapter) FindByWallIDAndBlockID(ctx context.Context, wallID, blockID string) ([]*model.WallBlock, error) {
+	return adapter.findBy(ctx, func(wallBlock model.WallBlock) bool {
+		return wallBlock.WallID == wallID && wallBlock.BlockID == blockID
+	}), nil
+}
+
+// findBy returns the associations accepted by match, ordered by position.
+func (adapter *wallBlockAdapter) findBy(ctx context.Context, match func(model.WallBlock) bool) []*model.WallBlock {
+	defer adapter.client.rlock(ctx)()
+	wallBlocks := adapter.client.wallBlocks.filter(match)
+	sort.SliceStable(wallBlocks, func(i, j int) bool {
+		return wallBlocks[i].Position < wallBlocks[j].Position
+	})
+	return pointers(wallBlocks)
+}
+
+// checkUniquePair enforces the unique (wallUUID, blockUUID) constraint of the wall_block table.
+func (adapter *wallBlockAdapter) checkUniquePair(wallBlock model.WallBlock) error {
+	taken := adapter.client.wallBlocks.exists(func(existing model.WallBlock) bool {
+		return existing.WallID == wallBlock.WallID && existing.BlockID == wallBlock.BlockID && existing.ID != wallBlock.ID
+	})
+	if taken {
+		return fmt.Errorf("%w: duplicate entry '%s-%s' for key 'wall_block.uq_wall_block'", model.ErrConflict, wallBlock.WallID, wallBlock.BlockID)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Package mysql provides MySQL implementations of the persistence interfaces.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// auditAdapter is a struct that acts as an adapter for interacting with
+// the audit log in the MySQL database.
+type auditAdapter struct {
+	client *client
+}
+
+// NewAuditAdapter creates a new audit adapter with the provided MySQL client.
+// It returns an implementation of the AuditPersister interface.
+func NewAuditAdapter(client *client) port.AuditPersister {
+	return &auditAdapter{
+		client: client,
+	}
+}
+
+// Create inserts a new audit entry into the database.
+// It takes a context and a model.AuditEntry, and returns an error if the operation fails.
+func (adapter *auditAdapter) Create(ctx context.Context, entry model.AuditEntry) error {
+	const query = `
+        INSERT INTO audit_log (UUID, actor, action, entity, entityUUID, field, valuesBefore, valuesAfter, requestID)
+        VALUES (UUID_TO_BIN(:UUID), :actor, :action, :entity, UUID_TO_BIN(:entityUUID), :field, :valuesBefore, :valuesAfter, :requestID)
+    `
+	var entryDB AuditEntryDB
+	entryDB.FromDomainModel(entry)
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, entryDB)
+	return translateError(err)
+}
+
+// FindAll retrieves the audit entries matching the filter from the database, most recent first.
+// It takes a context and the filter, and returns a slice of model.AuditEntry, the number of matching entries
+// and an error if the operation fails.
+func (adapter *auditAdapter) FindAll(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, int, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Entity != "" {
+		conditions = append(conditions, "entity = ?")
+		args = append(args, filter.Entity)
+	}
+	if filter.EntityID != "" {
+		conditions = append(conditions, "entityUUID = UUID_TO_BIN(?)")
+		args = append(args, filter.EntityID)
+	}
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = ?")
+		args = append(args, filter.Actor)
+	}
+	var where string
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	selectQuery := "SELECT * FROM audit_log" + where + " ORDER BY createdAt DESC, UUID DESC"
+	switch {
+	case filter.Limit > 0:
+		selectQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", filter.Limit, filter.Offset)
+	case filter.Offset > 0:
+		selectQuery += fmt.Sprintf(" LIMIT 18446744073709551615 OFFSET %d", filter.Offset)
+	}
+	var entriesDB []*AuditEntryDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &entriesDB, selectQuery, args...); err != nil {
+		return nil, 0, translateError(err)
+	}
+	var total int
+	if err := adapter.client.conn(ctx).GetContext(ctx, &total, "SELECT COUNT(*) FROM audit_log"+where, args...); err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	var entries []*model.AuditEntry
+	for _, entryDB := range entriesDB {
+		mappedEntry := entryDB.ToDomainModel()
+		entries = append(entries, &mappedEntry)
+	}
+	return entries, total, nil
+}
+
+// AuditEntryDB represents the database model for an audit entry.
+type AuditEntryDB struct {
+	UUID         uuid.UUID      `db:"UUID"`
+	Actor        string         `db:"actor"`
+	Action       string         `db:"action"`
+	Entity       string         `db:"entity"`
+	EntityUUID   uuid.UUID      `db:"entityUUID"`
+	Field        string         `db:"field"`
+	ValuesBefore sql.NullString `db:"valuesBefore"`
+	ValuesAfter  sql.NullString `db:"valuesAfter"`
+	RequestID    string         `db:"requestID"`
+	CreatedAt    sql.NullTime   `db:"createdAt"`
+}
+
+// ToDomainModel converts an AuditEntryDB database model to a model.AuditEntry domain model.
+// It returns the corresponding model.AuditEntry.
+func (db *AuditEntryDB) ToDomainModel() model.AuditEntry {
+	return model.AuditEntry{
+		ID:        db.UUID.String(),
+		Actor:     db.Actor,
+		Action:    db.Action,
+		Entity:    db.Entity,
+		EntityID:  db.EntityUUID.String(),
+		Field:     db.Field,
+		Before:    db.ValuesBefore.String,
+		After:     db.ValuesAfter.String,
+		RequestID: db.RequestID,
+		CreatedAt: db.CreatedAt.Time,
+	}
+}
+
+// FromDomainModel converts a model.AuditEntry domain model to an AuditEntryDB database model.
+// It sets the fields of the AuditEntryDB based on the given model.AuditEntry.
+func (db *AuditEntryDB) FromDomainModel(domain model.AuditEntry) {
+	db.UUID = uuid.MustParse(domain.ID)
+	db.Actor = domain.Actor
+	db.Action = domain.Action
+	db.Entity = domain.Entity
+	db.EntityUUID = uuid.MustParse(domain.EntityID)
+	db.Field = domain.Field
+	db.ValuesBefore = sql.NullString{String: domain.Before, Valid: domain.Before != ""}
+	db.ValuesAfter = sql.NullString{String: domain.After, Valid: domain.After != ""}
+	db.RequestID = domain.RequestID
+}
@@ -0,0 +1,27 @@
+// Package mysql provides MySQL implementations of the persistence interfaces.
+package mysql
+
+import "strings"
+
+// uuidList returns the placeholders and arguments of an IN clause matching the given UUIDs,
+// each converted with UUID_TO_BIN, so that a batch of rows is read in a single query.
+func uuidList(ids []string) (string, []interface{}) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "UUID_TO_BIN(?)"
+		args[i] = id
+	}
+	return strings.Join(placeholders, ", "), args
+}
+
+// valueList returns the placeholders and arguments of an IN clause matching the given plain values.
+func valueList(values []string) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		placeholders[i] = "?"
+		args[i] = value
+	}
+	return strings.Join(placeholders, ", "), args
+}
@@ -4,9 +4,11 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"time"
 )
 
 // blockAdapter is a struct that acts as an adapter for interacting with
@@ -32,72 +34,127 @@ func (adapter *blockAdapter) Create(ctx context.Context, block model.Block) erro
     `
 	var blockDB BlockDB
 	blockDB.FromDomainModel(block)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, blockDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, blockDB)
+	return translateError(err)
 }
 
-// Delete removes a block record from the database based on its UUID.
+// Delete moves a block record to the trash based on its UUID.
 // It takes a context and the block's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no live block has the given UUID.
 func (adapter *blockAdapter) Delete(ctx context.Context, blockUUID string) error {
 	const query = `
-        DELETE FROM block WHERE UUID = UUID_TO_BIN(?)
+        UPDATE block SET deletedAt = UTC_TIMESTAMP(6) WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, blockUUID)
-	return err
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, blockUUID)
+	return checkAffected(result, err, "block", blockUUID)
+}
+
+// Restore brings back a block record from the trash based on its UUID.
+// It takes a context and the block's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no block in the trash has the given UUID.
+func (adapter *blockAdapter) Restore(ctx context.Context, blockUUID string) error {
+	const query = `
+        UPDATE block SET deletedAt = NULL WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NOT NULL
+    `
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, blockUUID)
+	return checkAffected(result, err, "block", blockUUID)
+}
+
+// Purge permanently removes the block records moved to the trash before the given time from the database.
+// It takes a context and the time, and returns the number of removed records and an error if the operation fails.
+func (adapter *blockAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	const query = `
+        DELETE FROM block WHERE deletedAt < ?
+    `
+	return purged(adapter.client.conn(ctx).ExecContext(ctx, query, before))
 }
 
 // Update updates an existing block record in the database.
 // It takes a context, the block's UUID, and the updated model.Block, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no block has the given UUID.
 func (adapter *blockAdapter) Update(ctx context.Context, blockUUID string, updates model.Block) error {
 	const query = `
         UPDATE block SET 
                              name = COALESCE(:name, name), 
                              description = COALESCE(:description, description), 
                              kind = COALESCE(:kind, kind) 
-                        WHERE UUID = UUID_TO_BIN(:UUID)
+                        WHERE UUID = UUID_TO_BIN(:UUID) AND deletedAt IS NULL
     `
 	updates.ID = blockUUID
 	var blockDB BlockDB
 	blockDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, blockDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, blockDB)
+	return checkAffected(result, err, "block", blockUUID)
 }
 
-// FindAll retrieves all block records from the database.
-// It takes a context and returns a slice of model.Block and an error if the operation fails.
-func (adapter *blockAdapter) FindAll(ctx context.Context) ([]*model.Block, error) {
-	const query = `
-        SELECT * FROM block;
-    `
+// blockListing describes how blocks can be filtered and sorted.
+var blockListing = listing{
+	table:      "block",
+	nameColumn: "name",
+	sortable: map[string]string{
+		model.SortByName:      "name",
+		model.SortByCreatedAt: "createdAt",
+		model.SortByKind:      "kind",
+	},
+}
+
+// FindAll retrieves the block records matching the options from the database.
+// It takes a context and the list options, and returns a slice of model.Block, the number of matching records
+// and an error if the operation fails.
+func (adapter *blockAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Block, int, error) {
 	var blocksDB []*BlockDB
-	if err := adapter.client.db.SelectContext(ctx, &blocksDB, query); err != nil {
-		return nil, err
+	total, err := adapter.client.selectPage(ctx, &blocksDB, blockListing, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 	var blocks []*model.Block
 	for _, blockDB := range blocksDB {
 		mappedBlock := blockDB.ToDomainModel()
 		blocks = append(blocks, &mappedBlock)
 	}
-	return blocks, nil
+	return blocks, total, nil
 }
 
 // Find retrieves a block record from the database by its UUID.
 // It takes a context and the block's UUID, and returns a model.Block and an error if the operation fails.
 func (adapter *blockAdapter) Find(ctx context.Context, blockUUID string) (*model.Block, error) {
 	const query = `
-        SELECT * FROM block WHERE UUID = UUID_TO_BIN(?);
+        SELECT * FROM block WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
 	var blocksDB []*BlockDB
-	if err := adapter.client.db.SelectContext(ctx, &blocksDB, query, blockUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &blocksDB, query, blockUUID); err != nil {
+		return nil, translateError(err)
 	}
 	if len(blocksDB) == 0 {
-		return nil, nil
+		return nil, notFound("block", blockUUID)
 	}
 	result := blocksDB[0].ToDomainModel()
 	return &result, nil
 }
 
+// FindByIDs retrieves the block records from the database matching the given UUIDs.
+// It takes a context and the blocks' UUIDs, and returns a slice of model.Block and an error if the operation fails.
+// No query is run when ids is empty.
+func (adapter *blockAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Block, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT * FROM block WHERE UUID IN (%s) AND deletedAt IS NULL;
+    `, placeholders)
+	var blocksDB []*BlockDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &blocksDB, query, args...); err != nil {
+		return nil, err
+	}
+	var blocks []*model.Block
+	for _, blockDB := range blocksDB {
+		mappedBlock := blockDB.ToDomainModel()
+		blocks = append(blocks, &mappedBlock)
+	}
+	return blocks, nil
+}
+
 // BlockDB is a struct representing the block database model.
 type BlockDB struct {
 	UUID        uuid.UUID      `db:"UUID"`
@@ -106,6 +163,7 @@ type BlockDB struct {
 	Kind        sql.NullString `db:"kind"`
 	CreatedAt   sql.NullTime   `db:"createdAt"`
 	UpdatedAt   sql.NullTime   `db:"updatedAt"`
+	DeletedAt   sql.NullTime   `db:"deletedAt"`
 }
 
 // ToDomainModel converts a BlockDB database model to a model.Block domain model.
@@ -116,6 +174,8 @@ func (db *BlockDB) ToDomainModel() model.Block {
 		Name:        db.Name.String,
 		Description: db.Description.String,
 		Kind:        db.Kind.String,
+		CreatedAt:   db.CreatedAt.Time,
+		DeletedAt:   db.DeletedAt.Time,
 	}
 }
 
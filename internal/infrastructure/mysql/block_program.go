@@ -3,6 +3,8 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
@@ -32,8 +34,8 @@ func (adapter *blockProgramAdapter) Create(ctx context.Context, blockProgram mod
     `
 	var blockProgramDB BlockProgramDB
 	blockProgramDB.FromDomainModel(blockProgram)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, blockProgramDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, blockProgramDB)
+	return translateError(err)
 }
 
 // Delete removes a blockProgram record from the database based on its UUID.
@@ -42,12 +44,13 @@ func (adapter *blockProgramAdapter) Delete(ctx context.Context, blockProgramUUID
 	const query = `
         DELETE FROM block_program WHERE UUID = UUID_TO_BIN(?)
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, blockProgramUUID)
-	return err
+	_, err := adapter.client.conn(ctx).ExecContext(ctx, query, blockProgramUUID)
+	return translateError(err)
 }
 
 // Update updates an existing blockProgram record in the database.
 // It takes a context, the blockProgram's UUID, and the updated model.BlockProgram, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no block_program has the given UUID.
 func (adapter *blockProgramAdapter) Update(ctx context.Context, blockProgramUUID string, updates model.BlockProgram) error {
 	const query = `
         UPDATE block_program SET 
@@ -59,8 +62,8 @@ func (adapter *blockProgramAdapter) Update(ctx context.Context, blockProgramUUID
 	updates.ID = blockProgramUUID
 	var blockProgramDB BlockProgramDB
 	blockProgramDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, blockProgramDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, blockProgramDB)
+	return checkAffected(result, err, "block_program", blockProgramUUID)
 }
 
 // Find retrieves a blockProgram record from the database by its UUID.
@@ -70,11 +73,14 @@ func (adapter *blockProgramAdapter) Find(ctx context.Context, blockProgramUUID s
         SELECT * FROM block_program WHERE UUID = UUID_TO_BIN(?)
     `
 	var blockProgramDB BlockProgramDB
-	if err := adapter.client.db.GetContext(ctx, &blockProgramDB, query, blockProgramUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).GetContext(ctx, &blockProgramDB, query, blockProgramUUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFound("block_program", blockProgramUUID)
+		}
+		return nil, translateError(err)
 	}
 	if blockProgramDB.UUID == uuid.Nil {
-		return nil, fmt.Errorf("blockProgram with ID %s not found", blockProgramUUID)
+		return nil, notFound("block_program", blockProgramUUID)
 	}
 	result := blockProgramDB.ToDomainModel()
 	return &result, nil
@@ -84,10 +90,10 @@ func (adapter *blockProgramAdapter) Find(ctx context.Context, blockProgramUUID s
 // It takes a context and the block's ID, and returns a slice of model.BlockProgram and an error if the operation fails.
 func (adapter *blockProgramAdapter) FindByBlockID(ctx context.Context, blockID string) ([]*model.BlockProgram, error) {
 	const query = `
-        SELECT * FROM block_program WHERE blockUUID = UUID_TO_BIN(?)
+        SELECT * FROM block_program WHERE blockUUID = UUID_TO_BIN(?) ORDER BY position
     `
 	var blockProgramsDB []*BlockProgramDB
-	if err := adapter.client.db.SelectContext(ctx, &blockProgramsDB, query, blockID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &blockProgramsDB, query, blockID); err != nil {
 		return nil, err
 	}
 	var blockPrograms []*model.BlockProgram
@@ -102,10 +108,10 @@ func (adapter *blockProgramAdapter) FindByBlockID(ctx context.Context, blockID s
 // It takes a context and the program's ID, and returns a slice of model.BlockProgram and an error if the operation fails.
 func (adapter *blockProgramAdapter) FindByProgramID(ctx context.Context, programID string) ([]*model.BlockProgram, error) {
 	const query = `
-        SELECT * FROM block_program WHERE programUUID = UUID_TO_BIN(?)
+        SELECT * FROM block_program WHERE programUUID = UUID_TO_BIN(?) ORDER BY position
     `
 	var blockProgramsDB []*BlockProgramDB
-	if err := adapter.client.db.SelectContext(ctx, &blockProgramsDB, query, programID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &blockProgramsDB, query, programID); err != nil {
 		return nil, err
 	}
 	var blockPrograms []*model.BlockProgram
@@ -120,10 +126,33 @@ func (adapter *blockProgramAdapter) FindByProgramID(ctx context.Context, program
 // It takes a context, the block's ID, and the program's ID, and returns a slice of model.BlockProgram and an error if the operation fails.
 func (adapter *blockProgramAdapter) FindByBlockIDAndProgramID(ctx context.Context, blockID, programID string) ([]*model.BlockProgram, error) {
 	const query = `
-        SELECT * FROM block_program WHERE blockUUID = UUID_TO_BIN(?) AND programUUID = UUID_TO_BIN(?)
+        SELECT * FROM block_program WHERE blockUUID = UUID_TO_BIN(?) AND programUUID = UUID_TO_BIN(?) ORDER BY position
     `
 	var blockProgramsDB []*BlockProgramDB
-	if err := adapter.client.db.SelectContext(ctx, &blockProgramsDB, query, blockID, programID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &blockProgramsDB, query, blockID, programID); err != nil {
+		return nil, err
+	}
+	var blockPrograms []*model.BlockProgram
+	for _, blockProgramDB := range blockProgramsDB {
+		mappedBlockProgram := blockProgramDB.ToDomainModel()
+		blockPrograms = append(blockPrograms, &mappedBlockProgram)
+	}
+	return blockPrograms, nil
+}
+
+// FindByBlockIDs retrieves the blockProgram records from the database for the given block IDs, ordered by position.
+// It takes a context and the blocks' IDs, and returns a slice of model.BlockProgram and an error if the operation fails.
+// No query is run when ids is empty.
+func (adapter *blockProgramAdapter) FindByBlockIDs(ctx context.Context, ids []string) ([]*model.BlockProgram, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT * FROM block_program WHERE blockUUID IN (%s) ORDER BY position;
+    `, placeholders)
+	var blockProgramsDB []*BlockProgramDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &blockProgramsDB, query, args...); err != nil {
 		return nil, err
 	}
 	var blockPrograms []*model.BlockProgram
@@ -4,9 +4,12 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"time"
 )
 
 // categoryAdapter is a struct that acts as an adapter for interacting with
@@ -32,69 +35,190 @@ func (adapter *categoryAdapter) Create(ctx context.Context, category model.Categ
     `
 	var categoryDB CategoryDB
 	categoryDB.FromDomainModel(category)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, categoryDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, categoryDB)
+	return translateError(err)
 }
 
-// Delete removes a category record from the database based on its UUID.
+// Delete moves a category record to the trash based on its UUID.
 // It takes a context and the category's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no live category has the given UUID.
 func (adapter *categoryAdapter) Delete(ctx context.Context, categoryUUID string) error {
 	const query = `
-        DELETE FROM category WHERE UUID = UUID_TO_BIN(?);
+        UPDATE category SET deletedAt = UTC_TIMESTAMP(6) WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, categoryUUID)
-	return err
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, categoryUUID)
+	return checkAffected(result, err, "category", categoryUUID)
+}
+
+// Restore brings back a category record from the trash based on its UUID.
+// It takes a context and the category's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no category in the trash has the given UUID.
+func (adapter *categoryAdapter) Restore(ctx context.Context, categoryUUID string) error {
+	const query = `
+        UPDATE category SET deletedAt = NULL WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NOT NULL
+    `
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, categoryUUID)
+	return checkAffected(result, err, "category", categoryUUID)
+}
+
+// Purge permanently removes the category records moved to the trash before the given time from the database.
+// It takes a context and the time, and returns the number of removed records and an error if the operation fails.
+func (adapter *categoryAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	const query = `
+        DELETE FROM category WHERE deletedAt < ?
+    `
+	return purged(adapter.client.conn(ctx).ExecContext(ctx, query, before))
 }
 
 // Update updates an existing category record in the database.
 // It takes a context, the category's UUID, and the updated model.Category, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no category has the given UUID.
 func (adapter *categoryAdapter) Update(ctx context.Context, categoryUUID string, updates model.Category) error {
 	const query = `
         UPDATE category SET 
                              name = COALESCE(:name, name), 
                              description = COALESCE(:description, description),
                              parentUUID = COALESCE(NULLIF(UUID_TO_BIN(:parentUUID), UUID_TO_BIN('00000000-0000-0000-0000-000000000000')), parentUUID)
-                        WHERE UUID = UUID_TO_BIN(:UUID);
+                        WHERE UUID = UUID_TO_BIN(:UUID) AND deletedAt IS NULL
     `
 	updates.ID = categoryUUID
 	var categoryDB CategoryDB
 	categoryDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, categoryDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, categoryDB)
+	return checkAffected(result, err, "category", categoryUUID)
 }
 
-// FindAll retrieves all category records from the database.
-// It takes a context and returns a slice of model.Category and an error if the operation fails.
-func (adapter *categoryAdapter) FindAll(ctx context.Context) ([]*model.Category, error) {
+// Move sets the parent of a category record in the database, storing the nil UUID as parent of a root category.
+// It takes a context, the category's UUID and the parent's UUID, empty for a root category, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no live category has the given UUID.
+func (adapter *categoryAdapter) Move(ctx context.Context, categoryUUID string, parentUUID string) error {
 	const query = `
-        SELECT * FROM category;
+        UPDATE category SET parentUUID = UUID_TO_BIN(?) WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
+	if parentUUID == "" {
+		parentUUID = uuid.Nil.String()
+	}
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, parentUUID, categoryUUID)
+	return checkAffected(result, err, "category", categoryUUID)
+}
+
+// categoryListing describes how categories can be filtered and sorted.
+var categoryListing = listing{
+	table:      "category",
+	nameColumn: "name",
+	sortable: map[string]string{
+		model.SortByName:      "name",
+		model.SortByCreatedAt: "createdAt",
+	},
+}
+
+// FindAll retrieves the category records matching the options from the database.
+// It takes a context and the list options, and returns a slice of model.Category, the number of matching records
+// and an error if the operation fails.
+func (adapter *categoryAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Category, int, error) {
 	var categoriesDB []*CategoryDB
-	if err := adapter.client.db.SelectContext(ctx, &categoriesDB, query); err != nil {
-		return nil, err
+	total, err := adapter.client.selectPage(ctx, &categoriesDB, categoryListing, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 	var categories []*model.Category
 	for _, categoryDB := range categoriesDB {
 		mappedCategory := categoryDB.ToDomainModel()
 		categories = append(categories, &mappedCategory)
 	}
-	return categories, nil
+	return categories, total, nil
 }
 
 // Find retrieves a category record from the database by its UUID.
 // It takes a context and the category's UUID, and returns a model.Category and an error if the operation fails.
 func (adapter *categoryAdapter) Find(ctx context.Context, categoryUUID string) (*model.Category, error) {
 	const query = `
-        SELECT * FROM category WHERE UUID = UUID_TO_BIN(?);
+        SELECT * FROM category WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
 	var categoryDB CategoryDB
-	if err := adapter.client.db.GetContext(ctx, &categoryDB, query, categoryUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).GetContext(ctx, &categoryDB, query, categoryUUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFound("category", categoryUUID)
+		}
+		return nil, translateError(err)
 	}
 	result := categoryDB.ToDomainModel()
 	return &result, nil
 }
 
+// FindByIDs retrieves the category records from the database matching the given UUIDs.
+// It takes a context and the categories' UUIDs, and returns a slice of model.Category and an error if the operation fails.
+// No query is run when ids is empty.
+func (adapter *categoryAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Category, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT * FROM category WHERE UUID IN (%s) AND deletedAt IS NULL;
+    `, placeholders)
+	var categoriesDB []*CategoryDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &categoriesDB, query, args...); err != nil {
+		return nil, err
+	}
+	var categories []*model.Category
+	for _, categoryDB := range categoriesDB {
+		mappedCategory := categoryDB.ToDomainModel()
+		categories = append(categories, &mappedCategory)
+	}
+	return categories, nil
+}
+
+// FindByProgramID retrieves the category records associated with a program from the database, ordered by name.
+// It joins the program_category association table, so that a single query is run however many categories are associated.
+// It takes a context and the program's ID, and returns a slice of model.Category and an error if the operation fails.
+func (adapter *categoryAdapter) FindByProgramID(ctx context.Context, id string) ([]*model.Category, error) {
+	const query = `
+        SELECT c.* FROM category c
+        JOIN program_category a ON a.categoryUUID = c.UUID
+        WHERE a.programUUID = UUID_TO_BIN(?) AND c.deletedAt IS NULL
+        ORDER BY c.name, c.UUID;
+    `
+	var categoriesDB []*CategoryDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &categoriesDB, query, id); err != nil {
+		return nil, err
+	}
+	var categories []*model.Category
+	for _, categoryDB := range categoriesDB {
+		mappedCategory := categoryDB.ToDomainModel()
+		categories = append(categories, &mappedCategory)
+	}
+	return categories, nil
+}
+
+// LockHierarchy locks every category record of the database until the end of the ongoing transaction.
+// It takes a context, and returns an error if the operation fails.
+func (adapter *categoryAdapter) LockHierarchy(ctx context.Context) error {
+	const query = `
+        SELECT COUNT(*) FROM category FOR UPDATE;
+    `
+	var count int
+	return adapter.client.conn(ctx).GetContext(ctx, &count, query)
+}
+
+// FindHierarchy retrieves every live category record from the database, ordered by name.
+// It takes a context, and returns a slice of model.Category and an error if the operation fails.
+func (adapter *categoryAdapter) FindHierarchy(ctx context.Context) ([]*model.Category, error) {
+	const query = `
+        SELECT * FROM category WHERE deletedAt IS NULL ORDER BY name, UUID;
+    `
+	var categoriesDB []*CategoryDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &categoriesDB, query); err != nil {
+		return nil, err
+	}
+	var categories []*model.Category
+	for _, categoryDB := range categoriesDB {
+		mappedCategory := categoryDB.ToDomainModel()
+		categories = append(categories, &mappedCategory)
+	}
+	return categories, nil
+}
+
 // CategoryDB is a struct representing the category database model.
 type CategoryDB struct {
 	UUID        uuid.UUID      `db:"UUID"`
@@ -103,6 +227,7 @@ type CategoryDB struct {
 	ParentID    uuid.UUID      `db:"parentUUID"`
 	CreatedAt   sql.NullTime   `db:"createdAt"`
 	UpdatedAt   sql.NullTime   `db:"updatedAt"`
+	DeletedAt   sql.NullTime   `db:"deletedAt"`
 }
 
 // ToDomainModel converts a CategoryDB database model to a model.Category domain model.
@@ -115,6 +240,9 @@ func (db *CategoryDB) ToDomainModel() model.Category {
 		Parent: &model.Category{
 			ID: db.ParentID.String(),
 		},
+		CreatedAt: db.CreatedAt.Time,
+		UpdatedAt: db.UpdatedAt.Time,
+		DeletedAt: db.DeletedAt.Time,
 	}
 }
 
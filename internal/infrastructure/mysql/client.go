@@ -2,7 +2,9 @@
 package mysql
 
 import (
-	_ "github.com/go-sql-driver/mysql" // Import MySQL driver
+	"context"
+	"database/sql"
+	driver "github.com/go-sql-driver/mysql" // Import MySQL driver
 	"github.com/jmoiron/sqlx"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
 	"log"
@@ -13,6 +15,23 @@ type client struct {
 	db *sqlx.DB
 }
 
+// executor is the subset of the sqlx.DB and sqlx.Tx methods used by the adapters,
+// so that they run their queries the same way inside and outside a transaction.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// conn returns the transaction started by the txManager for ctx, if any, and the connection pool otherwise.
+func (c *client) conn(ctx context.Context) executor {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx
+	}
+	return c.db
+}
+
 // NewClient creates a new MySQL client using the provided configuration.
 // It initializes the database connection and sets connection pool settings based on the provided AppConfig.
 func NewClient(config *configuration.AppConfig) *client {
@@ -25,8 +44,16 @@ func NewClient(config *configuration.AppConfig) *client {
 
 // openDB opens a new database connection using the provided DSN and database configuration.
 // It sets the maximum number of open connections, idle connections, and the maximum lifetime of connections.
+// Updates report matched rather than changed rows, so that updating a missing row can be told apart
+// from an update that leaves the row unchanged, and DATETIME columns are scanned into time.Time.
 func openDB(dsn string, config configuration.DatabaseConfig) (*sqlx.DB, error) {
-	conn, err := sqlx.Open(config.Driver, dsn)
+	driverConfig, err := driver.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	driverConfig.ClientFoundRows = true
+	driverConfig.ParseTime = true
+	conn, err := sqlx.Open(config.Driver, driverConfig.FormatDSN())
 	if err != nil {
 		return nil, err
 	}
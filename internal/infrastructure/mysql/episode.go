@@ -4,9 +4,11 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"time"
 )
 
 // episodeAdapter is a struct that acts as an adapter for interacting with
@@ -27,10 +29,10 @@ func NewEpisodeAdapter(client *client) port.EpisodePersister {
 // It takes a context and the program's ID, and returns a slice of model.Episode and an error if the operation fails.
 func (adapter *episodeAdapter) FindByProgramID(ctx context.Context, id string) ([]*model.Episode, error) {
 	const query = `
-        SELECT * FROM episode WHERE programUUID = UUID_TO_BIN(?);
+        SELECT * FROM episode WHERE programUUID = UUID_TO_BIN(?) AND deletedAt IS NULL ORDER BY position;
     `
 	var episodesDB []*EpisodeDB
-	if err := adapter.client.db.SelectContext(ctx, &episodesDB, query, id); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &episodesDB, query, id); err != nil {
 		return nil, err
 	}
 	var episodes []*model.Episode
@@ -45,27 +47,72 @@ func (adapter *episodeAdapter) FindByProgramID(ctx context.Context, id string) (
 // It takes a context and a model.Episode, and returns an error if the operation fails.
 func (adapter *episodeAdapter) Create(ctx context.Context, episode model.Episode) error {
 	const query = `
-        INSERT INTO episode (UUID, name, description, position, programUUID)
-        VALUES (UUID_TO_BIN(:UUID), :name, :description, :position, UUID_TO_BIN(:programUUID));
+        INSERT INTO episode (UUID, name, description, position, programUUID, source_guid, status, publishAt)
+        VALUES (UUID_TO_BIN(:UUID), :name, :description, :position, UUID_TO_BIN(:programUUID), :source_guid,
+                COALESCE(:status, 'draft'), :publishAt);
     `
 	var episodeDB EpisodeDB
 	episodeDB.FromDomainModel(episode)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, episodeDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, episodeDB)
+	return translateError(err)
 }
 
-// Delete removes an episode record from the database based on its UUID.
+// Delete moves an episode record to the trash based on its UUID, along with its medias.
+// A single statement stamps them all with the same deletion time, which tells them apart from the medias
+// deleted on their own when the episode is restored.
 // It takes a context and the episode's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no live episode has the given UUID.
 func (adapter *episodeAdapter) Delete(ctx context.Context, episodeUUID string) error {
 	const query = `
-        DELETE FROM episode WHERE UUID = UUID_TO_BIN(?);
+        UPDATE episode e
+        LEFT JOIN media m ON m.episodeUUID = e.UUID AND m.deletedAt IS NULL
+        SET e.deletedAt = UTC_TIMESTAMP(6), m.deletedAt = UTC_TIMESTAMP(6)
+        WHERE e.UUID = UUID_TO_BIN(?) AND e.deletedAt IS NULL
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, episodeUUID)
-	return err
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, episodeUUID)
+	return checkAffected(result, err, "episode", episodeUUID)
+}
+
+// Restore brings back an episode record from the trash based on its UUID, along with the medias deleted with it.
+// It takes a context and the episode's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no episode in the trash has the given UUID,
+// and model.ErrConflict while its program is in the trash.
+func (adapter *episodeAdapter) Restore(ctx context.Context, episodeUUID string) error {
+	const query = `
+        UPDATE episode e
+        JOIN program p ON p.UUID = e.programUUID AND p.deletedAt IS NULL
+        LEFT JOIN media m ON m.episodeUUID = e.UUID AND m.deletedAt = ?
+        SET e.deletedAt = NULL, m.deletedAt = NULL
+        WHERE e.UUID = UUID_TO_BIN(?) AND e.deletedAt = ?
+    `
+	deletedAt, err := adapter.client.deletedAt(ctx, "episode", episodeUUID)
+	if err != nil {
+		return err
+	}
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, deletedAt, episodeUUID, deletedAt)
+	if err != nil {
+		return translateError(err)
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+		return err
+	}
+	return fmt.Errorf("%w: the program of episode %s is in the trash", model.ErrConflict, episodeUUID)
+}
+
+// Purge permanently removes the episode records moved to the trash before the given time from the database.
+// Episodes whose medias have not been purged yet are kept, as the medias still reference them.
+// It takes a context and the time, and returns the number of removed records and an error if the operation fails.
+func (adapter *episodeAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	const query = `
+        DELETE FROM episode
+        WHERE deletedAt < ? AND NOT EXISTS (SELECT 1 FROM media m WHERE m.episodeUUID = episode.UUID)
+    `
+	return purged(adapter.client.conn(ctx).ExecContext(ctx, query, before))
 }
 
 // Update updates an existing episode record in the database.
 // It takes a context, the episode's UUID, and the updated model.Episode, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no episode has the given UUID.
 func (adapter *episodeAdapter) Update(ctx context.Context, episodeUUID string, updates model.Episode) error {
 	const query = `
         UPDATE episode SET 
@@ -73,23 +120,43 @@ func (adapter *episodeAdapter) Update(ctx context.Context, episodeUUID string, u
                              description = COALESCE(:description, description), 
                              position = COALESCE(NULLIF(:position, 0), position),
                              programUUID = COALESCE(NULLIF(UUID_TO_BIN(:programUUID), UUID_TO_BIN('00000000-0000-0000-0000-000000000000')), programUUID)
-                        WHERE UUID = UUID_TO_BIN(:UUID);
+                        WHERE UUID = UUID_TO_BIN(:UUID) AND deletedAt IS NULL
     `
 	updates.ID = episodeUUID
 	var episodeDB EpisodeDB
 	episodeDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, episodeDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, episodeDB)
+	return checkAffected(result, err, "episode", episodeUUID)
 }
 
-// FindAll retrieves all episode records from the database.
-// It takes a context and returns a slice of model.Episode and an error if the operation fails.
-func (adapter *episodeAdapter) FindAll(ctx context.Context) ([]*model.Episode, error) {
+// UpdateStatus moves an episode record from one publication status to another, along with its publication time.
+// A zero publishAt clears the publication time.
+// It takes a context, the episode's UUID, the current and new statuses and the publication time, and returns an error
+// if the operation fails.
+// It returns model.ErrConflict when no live episode with the given UUID has the from status.
+func (adapter *episodeAdapter) UpdateStatus(ctx context.Context, episodeUUID string, from, to model.EpisodeStatus, publishAt time.Time) error {
 	const query = `
-        SELECT * FROM episode;
+        UPDATE episode SET status = ?, publishAt = ?
+        WHERE UUID = UUID_TO_BIN(?) AND status = ? AND deletedAt IS NULL
+    `
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, to, sql.NullTime{Time: publishAt, Valid: !publishAt.IsZero()}, episodeUUID, from)
+	if err != nil {
+		return translateError(err)
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+		return err
+	}
+	return fmt.Errorf("%w: episode %s is no longer %s", model.ErrConflict, episodeUUID, from)
+}
+
+// FindDue retrieves the scheduled episode records whose publication time is at or before now, oldest first.
+// It takes a context and the time, and returns a slice of model.Episode and an error if the operation fails.
+func (adapter *episodeAdapter) FindDue(ctx context.Context, now time.Time) ([]*model.Episode, error) {
+	const query = `
+        SELECT * FROM episode WHERE status = ? AND publishAt <= ? AND deletedAt IS NULL ORDER BY publishAt, UUID;
     `
 	var episodesDB []*EpisodeDB
-	if err := adapter.client.db.SelectContext(ctx, &episodesDB, query); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &episodesDB, query, model.EpisodeStatusScheduled, now); err != nil {
 		return nil, err
 	}
 	var episodes []*model.Episode
@@ -100,23 +167,97 @@ func (adapter *episodeAdapter) FindAll(ctx context.Context) ([]*model.Episode, e
 	return episodes, nil
 }
 
+// episodeListing describes how episodes can be filtered and sorted.
+var episodeListing = listing{
+	table:      "episode",
+	nameColumn: "name",
+	sortable: map[string]string{
+		model.SortByName:      "name",
+		model.SortByCreatedAt: "createdAt",
+		model.SortByPosition:  "position",
+	},
+}
+
+// FindAll retrieves the episode records matching the options from the database.
+// It takes a context and the list options, and returns a slice of model.Episode, the number of matching records
+// and an error if the operation fails.
+func (adapter *episodeAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Episode, int, error) {
+	var episodesDB []*EpisodeDB
+	total, err := adapter.client.selectPage(ctx, &episodesDB, episodeListing, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	var episodes []*model.Episode
+	for _, episodeDB := range episodesDB {
+		mappedEpisode := episodeDB.ToDomainModel()
+		episodes = append(episodes, &mappedEpisode)
+	}
+	return episodes, total, nil
+}
+
 // Find retrieves an episode record from the database by its UUID.
 // It takes a context and the episode's UUID, and returns a model.Episode and an error if the operation fails.
 func (adapter *episodeAdapter) Find(ctx context.Context, episodeUUID string) (*model.Episode, error) {
 	const query = `
-        SELECT * FROM episode WHERE UUID = UUID_TO_BIN(?)
+        SELECT * FROM episode WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
 	var episodesDB []*EpisodeDB
-	if err := adapter.client.db.SelectContext(ctx, &episodesDB, query, episodeUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &episodesDB, query, episodeUUID); err != nil {
+		return nil, translateError(err)
 	}
 	if len(episodesDB) == 0 {
-		return nil, nil
+		return nil, notFound("episode", episodeUUID)
 	}
 	result := episodesDB[0].ToDomainModel()
 	return &result, nil
 }
 
+// FindByProgramIDs retrieves the episode records from the database for the given program IDs, ordered by position.
+// It takes a context and the programs' IDs, and returns a slice of model.Episode and an error if the operation fails.
+// No query is run when ids is empty.
+func (adapter *episodeAdapter) FindByProgramIDs(ctx context.Context, ids []string) ([]*model.Episode, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT * FROM episode WHERE programUUID IN (%s) AND deletedAt IS NULL ORDER BY position;
+    `, placeholders)
+	var episodesDB []*EpisodeDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &episodesDB, query, args...); err != nil {
+		return nil, err
+	}
+	var episodes []*model.Episode
+	for _, episodeDB := range episodesDB {
+		mappedEpisode := episodeDB.ToDomainModel()
+		episodes = append(episodes, &mappedEpisode)
+	}
+	return episodes, nil
+}
+
+// FindByIDs retrieves the episode records from the database matching the given UUIDs.
+// It takes a context and the episodes' UUIDs, and returns a slice of model.Episode and an error if the operation fails.
+// No query is run when ids is empty.
+func (adapter *episodeAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Episode, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT * FROM episode WHERE UUID IN (%s) AND deletedAt IS NULL;
+    `, placeholders)
+	var episodesDB []*EpisodeDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &episodesDB, query, args...); err != nil {
+		return nil, err
+	}
+	var episodes []*model.Episode
+	for _, episodeDB := range episodesDB {
+		mappedEpisode := episodeDB.ToDomainModel()
+		episodes = append(episodes, &mappedEpisode)
+	}
+	return episodes, nil
+}
+
 // EpisodeDB is a struct representing the episode database model.
 type EpisodeDB struct {
 	UUID        uuid.UUID      `db:"UUID"`
@@ -124,8 +265,12 @@ type EpisodeDB struct {
 	Description sql.NullString `db:"description"`
 	Position    int            `db:"position"`
 	ProgramID   uuid.UUID      `db:"programUUID"`
+	SourceGUID  sql.NullString `db:"source_guid"`
+	Status      sql.NullString `db:"status"`
+	PublishAt   sql.NullTime   `db:"publishAt"`
 	CreatedAt   sql.NullTime   `db:"createdAt"`
 	UpdatedAt   sql.NullTime   `db:"updatedAt"`
+	DeletedAt   sql.NullTime   `db:"deletedAt"`
 }
 
 // ToDomainModel converts an EpisodeDB database model to a model.Episode domain model.
@@ -137,6 +282,12 @@ func (db *EpisodeDB) ToDomainModel() model.Episode {
 		Description: db.Description.String,
 		Position:    db.Position,
 		ProgramID:   db.ProgramID.String(),
+		SourceGUID:  db.SourceGUID.String,
+		Status:      model.EpisodeStatus(db.Status.String),
+		PublishAt:   db.PublishAt.Time,
+		CreatedAt:   db.CreatedAt.Time,
+		UpdatedAt:   db.UpdatedAt.Time,
+		DeletedAt:   db.DeletedAt.Time,
 	}
 }
 
@@ -147,6 +298,9 @@ func (db *EpisodeDB) FromDomainModel(domain model.Episode) {
 	db.Name = sql.NullString{String: domain.Name, Valid: domain.Name != ""}
 	db.Description = sql.NullString{String: domain.Description, Valid: domain.Description != ""}
 	db.Position = domain.Position
+	db.SourceGUID = sql.NullString{String: domain.SourceGUID, Valid: domain.SourceGUID != ""}
+	db.Status = sql.NullString{String: string(domain.Status), Valid: domain.Status != ""}
+	db.PublishAt = sql.NullTime{Time: domain.PublishAt, Valid: !domain.PublishAt.IsZero()}
 	db.ProgramID = uuid.Nil
 	if domain.ProgramID != "" {
 		db.ProgramID = uuid.MustParse(domain.ProgramID)
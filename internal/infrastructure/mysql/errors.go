@@ -0,0 +1,56 @@
+// Package mysql provides MySQL implementations of the persistence interfaces.
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// MySQL server error numbers translated into domain errors.
+const (
+	errDuplicateEntry    = 1062 // ER_DUP_ENTRY
+	errRowIsReferenced   = 1451 // ER_ROW_IS_REFERENCED_2
+	errNoReferencedRow   = 1452 // ER_NO_REFERENCED_ROW_2
+	errWrongValueForType = 1411 // ER_WRONG_VALUE_FOR_TYPE, raised by UUID_TO_BIN on malformed UUIDs
+)
+
+// translateError maps the MySQL errors the domain cares about onto model.ErrConflict and model.ErrNotFound,
+// keeping the original message. Other errors are returned unchanged.
+func translateError(err error) error {
+	var mysqlErr *driver.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return err
+	}
+	switch mysqlErr.Number {
+	case errDuplicateEntry, errRowIsReferenced:
+		return fmt.Errorf("%w: %s", model.ErrConflict, mysqlErr.Message)
+	case errNoReferencedRow, errWrongValueForType:
+		return fmt.Errorf("%w: %s", model.ErrNotFound, mysqlErr.Message)
+	}
+	return err
+}
+
+// checkAffected translates the outcome of a statement targeting the row of table identified by id.
+// It returns model.ErrNotFound when the statement matched no row.
+func checkAffected(result sql.Result, err error, table, id string) error {
+	if err != nil {
+		return translateError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound(table, id)
+	}
+	return nil
+}
+
+// notFound returns the model.ErrNotFound error reported when no row of table has the given UUID.
+func notFound(table, id string) error {
+	return fmt.Errorf("%s %s: %w", table, id, model.ErrNotFound)
+}
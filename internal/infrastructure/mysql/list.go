@@ -0,0 +1,79 @@
+// Package mysql provides MySQL implementations of the persistence interfaces.
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// likeEscaper escapes the LIKE wildcards of a user provided search text.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// listing describes how the FindAll query of a table can be filtered and sorted.
+type listing struct {
+	table      string            // Table to read from
+	nameColumn string            // Column matched by model.ListOptions.NameContains, empty when the table has none
+	sortable   map[string]string // Columns of the table by model.SortBy field
+}
+
+// queries builds the SELECT returning the page described by opts and the COUNT of every row matching its filters.
+// Only the live rows are listed, or only the rows in the trash when opts.Deleted is set.
+// Rows sharing the same sort value are ordered by UUID so that pages do not overlap.
+func (l listing) queries(opts model.ListOptions) (selectQuery string, countQuery string, args []interface{}) {
+	conditions := []string{"deletedAt IS NULL"}
+	if opts.Deleted {
+		conditions = []string{"deletedAt IS NOT NULL"}
+	}
+	if opts.NameContains != "" && l.nameColumn != "" {
+		conditions = append(conditions, l.nameColumn+" LIKE ?")
+		args = append(args, "%"+likeEscaper.Replace(opts.NameContains)+"%")
+	}
+	if !opts.CreatedFrom.IsZero() {
+		conditions = append(conditions, "createdAt >= ?")
+		args = append(args, opts.CreatedFrom)
+	}
+	if !opts.CreatedTo.IsZero() {
+		conditions = append(conditions, "createdAt <= ?")
+		args = append(args, opts.CreatedTo)
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	column, ok := l.sortable[opts.Sort]
+	switch {
+	case opts.Sort == model.SortByDeletedAt:
+		// Every listed table can be moved to the trash, so every one of them can be sorted on the deletion time.
+		column = "deletedAt"
+	case !ok:
+		column = "createdAt"
+	}
+	direction := "ASC"
+	if opts.Descending {
+		direction = "DESC"
+	}
+	selectQuery = fmt.Sprintf("SELECT * FROM %s%s ORDER BY %s %s, UUID %s", l.table, where, column, direction, direction)
+	switch {
+	case opts.Limit > 0:
+		selectQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", opts.Limit, opts.Offset)
+	case opts.Offset > 0:
+		// MySQL has no OFFSET without LIMIT, the largest limit it accepts stands for "no limit".
+		selectQuery += fmt.Sprintf(" LIMIT 18446744073709551615 OFFSET %d", opts.Offset)
+	}
+	countQuery = fmt.Sprintf("SELECT COUNT(*) FROM %s%s", l.table, where)
+	return selectQuery, countQuery, args
+}
+
+// selectPage scans the rows of the page described by opts into dest and returns the number of rows matching its filters.
+func (c *client) selectPage(ctx context.Context, dest interface{}, l listing, opts model.ListOptions) (int, error) {
+	selectQuery, countQuery, args := l.queries(opts)
+	if err := c.conn(ctx).SelectContext(ctx, dest, selectQuery, args...); err != nil {
+		return 0, err
+	}
+	var total int
+	if err := c.conn(ctx).GetContext(ctx, &total, countQuery, args...); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -4,9 +4,12 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"time"
 )
 
 // mediaAdapter is a struct that acts as an adapter for interacting with
@@ -27,90 +30,247 @@ func NewMediaAdapter(client *client) port.MediaPersister {
 // It takes a context and a model.Media, and returns an error if the operation fails.
 func (adapter *mediaAdapter) Create(ctx context.Context, media model.Media) error {
 	const query = `
-        INSERT INTO media (UUID, direct_link, kind, episodeUUID)
-        VALUES (UUID_TO_BIN(:UUID), :direct_link, :kind, UUID_TO_BIN(:episodeUUID))
+        INSERT INTO media (UUID, direct_link, kind, episodeUUID, storage_key, size, checksum, mime_type,
+                           codec, duration_ms, bitrate, channels, sample_rate, title, artwork_key, artwork_link)
+        VALUES (UUID_TO_BIN(:UUID), :direct_link, :kind, UUID_TO_BIN(:episodeUUID), :storage_key, :size, :checksum, :mime_type,
+                :codec, :duration_ms, :bitrate, :channels, :sample_rate, :title, :artwork_key, :artwork_link)
     `
 	var mediaDB MediaDB
 	mediaDB.FromDomainModel(media)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, mediaDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, mediaDB)
+	return translateError(err)
 }
 
-// Delete removes a media record from the database based on its UUID.
+// Delete moves a media record to the trash based on its UUID.
 // It takes a context and the media's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no live media has the given UUID.
 func (adapter *mediaAdapter) Delete(ctx context.Context, mediaUUID string) error {
 	const query = `
-        DELETE FROM media WHERE UUID = UUID_TO_BIN(?);
+        UPDATE media SET deletedAt = UTC_TIMESTAMP(6) WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, mediaUUID)
-	return err
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, mediaUUID)
+	return checkAffected(result, err, "media", mediaUUID)
+}
+
+// Restore brings back a media record from the trash based on its UUID.
+// It takes a context and the media's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no media in the trash has the given UUID,
+// and model.ErrConflict while its episode is in the trash.
+func (adapter *mediaAdapter) Restore(ctx context.Context, mediaUUID string) error {
+	const query = `
+        UPDATE media m
+        JOIN episode e ON e.UUID = m.episodeUUID AND e.deletedAt IS NULL
+        SET m.deletedAt = NULL
+        WHERE m.UUID = UUID_TO_BIN(?) AND m.deletedAt IS NOT NULL
+    `
+	if _, err := adapter.client.deletedAt(ctx, "media", mediaUUID); err != nil {
+		return err
+	}
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, mediaUUID)
+	if err != nil {
+		return translateError(err)
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+		return err
+	}
+	return fmt.Errorf("%w: the episode of media %s is in the trash", model.ErrConflict, mediaUUID)
+}
+
+// Purge permanently removes the media records moved to the trash before the given time from the database.
+// The removed records are locked while they are read, so that they are the ones deleted when the call runs in a transaction.
+// It takes a context and the time, and returns the removed medias and an error if the operation fails.
+func (adapter *mediaAdapter) Purge(ctx context.Context, before time.Time) ([]*model.Media, error) {
+	const selectQuery = `
+        SELECT * FROM media WHERE deletedAt < ? FOR UPDATE
+    `
+	const deleteQuery = `
+        DELETE FROM media WHERE deletedAt < ?
+    `
+	var mediasDB []*MediaDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &mediasDB, selectQuery, before); err != nil {
+		return nil, err
+	}
+	if len(mediasDB) == 0 {
+		return nil, nil
+	}
+	if _, err := adapter.client.conn(ctx).ExecContext(ctx, deleteQuery, before); err != nil {
+		return nil, translateError(err)
+	}
+	var medias []*model.Media
+	for _, mediaDB := range mediasDB {
+		mappedMedia := mediaDB.ToDomainModel()
+		medias = append(medias, &mappedMedia)
+	}
+	return medias, nil
 }
 
 // Update updates an existing media record in the database.
+// A new direct link replaces every column describing the file, which is cleared when empty in updates.
 // It takes a context, the media's UUID, and the updated model.Media, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no media has the given UUID.
 func (adapter *mediaAdapter) Update(ctx context.Context, mediaUUID string, updates model.Media) error {
 	const query = `
         UPDATE media SET 
+                             storage_key = IF(:direct_link IS NULL, storage_key, :storage_key),
+                             size = IF(:direct_link IS NULL, size, :size),
+                             checksum = IF(:direct_link IS NULL, checksum, :checksum),
+                             mime_type = IF(:direct_link IS NULL, mime_type, :mime_type),
+                             codec = IF(:direct_link IS NULL, codec, :codec),
+                             duration_ms = IF(:direct_link IS NULL, duration_ms, :duration_ms),
+                             bitrate = IF(:direct_link IS NULL, bitrate, :bitrate),
+                             channels = IF(:direct_link IS NULL, channels, :channels),
+                             sample_rate = IF(:direct_link IS NULL, sample_rate, :sample_rate),
+                             title = IF(:direct_link IS NULL, title, :title),
+                             artwork_key = IF(:direct_link IS NULL, artwork_key, :artwork_key),
+                             artwork_link = IF(:direct_link IS NULL, artwork_link, :artwork_link),
                              direct_link = COALESCE(:direct_link, direct_link), 
                              kind = COALESCE(:kind, kind), 
                              episodeUUID = COALESCE(NULLIF(UUID_TO_BIN(:episodeUUID), UUID_TO_BIN('00000000-0000-0000-0000-000000000000')), episodeUUID)
-                        WHERE UUID = UUID_TO_BIN(:UUID)
+                        WHERE UUID = UUID_TO_BIN(:UUID) AND deletedAt IS NULL
     `
 	updates.ID = mediaUUID
 	var mediaDB MediaDB
 	mediaDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, mediaDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, mediaDB)
+	return checkAffected(result, err, "media", mediaUUID)
 }
 
-// FindAll retrieves all media records from the database.
-// It takes a context and returns a slice of model.Media and an error if the operation fails.
-func (adapter *mediaAdapter) FindAll(ctx context.Context) ([]*model.Media, error) {
-	const query = `
-        SELECT * FROM media;
-    `
+// mediaListing describes how medias can be filtered and sorted.
+var mediaListing = listing{
+	table:      "media",
+	nameColumn: "",
+	sortable: map[string]string{
+		model.SortByCreatedAt: "createdAt",
+		model.SortByKind:      "kind",
+	},
+}
+
+// FindAll retrieves the media records matching the options from the database.
+// It takes a context and the list options, and returns a slice of model.Media, the number of matching records
+// and an error if the operation fails.
+func (adapter *mediaAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Media, int, error) {
 	var mediaDB []*MediaDB
-	if err := adapter.client.db.SelectContext(ctx, &mediaDB, query); err != nil {
-		return nil, err
+	total, err := adapter.client.selectPage(ctx, &mediaDB, mediaListing, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 	var media []*model.Media
 	for _, mediaEntry := range mediaDB {
 		mappedMedia := mediaEntry.ToDomainModel()
 		media = append(media, &mappedMedia)
 	}
-	return media, nil
+	return media, total, nil
 }
 
 // Find retrieves a media record from the database by its UUID.
 // It takes a context and the media's UUID, and returns a model.Media and an error if the operation fails.
 func (adapter *mediaAdapter) Find(ctx context.Context, mediaUUID string) (*model.Media, error) {
 	const query = `
-        SELECT * FROM media WHERE UUID = UUID_TO_BIN(?);
+        SELECT * FROM media WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
 	var mediaDB MediaDB
-	if err := adapter.client.db.GetContext(ctx, &mediaDB, query, mediaUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).GetContext(ctx, &mediaDB, query, mediaUUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFound("media", mediaUUID)
+		}
+		return nil, translateError(err)
 	}
 	result := mediaDB.ToDomainModel()
 	return &result, nil
 }
 
+// FindByEpisodeIDs retrieves the media records from the database for the given episode IDs, oldest first.
+// It takes a context and the episodes' IDs, and returns a slice of model.Media and an error if the operation fails.
+// No query is run when ids is empty.
+func (adapter *mediaAdapter) FindByEpisodeIDs(ctx context.Context, ids []string) ([]*model.Media, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT * FROM media WHERE episodeUUID IN (%s) AND deletedAt IS NULL ORDER BY createdAt, UUID;
+    `, placeholders)
+	var mediasDB []*MediaDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &mediasDB, query, args...); err != nil {
+		return nil, err
+	}
+	var medias []*model.Media
+	for _, mediaDB := range mediasDB {
+		mappedMedia := mediaDB.ToDomainModel()
+		medias = append(medias, &mappedMedia)
+	}
+	return medias, nil
+}
+
+// FindByIDs retrieves the media records from the database matching the given UUIDs.
+// It takes a context and the medias' UUIDs, and returns a slice of model.Media and an error if the operation fails.
+// No query is run when ids is empty.
+func (adapter *mediaAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Media, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT * FROM media WHERE UUID IN (%s) AND deletedAt IS NULL;
+    `, placeholders)
+	var mediasDB []*MediaDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &mediasDB, query, args...); err != nil {
+		return nil, err
+	}
+	var medias []*model.Media
+	for _, mediaDB := range mediasDB {
+		mappedMedia := mediaDB.ToDomainModel()
+		medias = append(medias, &mappedMedia)
+	}
+	return medias, nil
+}
+
 // MediaDB is a struct representing the media database model.
 type MediaDB struct {
-	UUID       uuid.UUID      `db:"UUID"`
-	DirectLink sql.NullString `db:"direct_link"`
-	Kind       sql.NullString `db:"kind"`
-	EpisodeID  uuid.UUID      `db:"episodeUUID"`
+	UUID        uuid.UUID      `db:"UUID"`
+	DirectLink  sql.NullString `db:"direct_link"`
+	Kind        sql.NullString `db:"kind"`
+	EpisodeID   uuid.UUID      `db:"episodeUUID"`
+	StorageKey  sql.NullString `db:"storage_key"`
+	Size        sql.NullInt64  `db:"size"`
+	Checksum    sql.NullString `db:"checksum"`
+	MimeType    sql.NullString `db:"mime_type"`
+	Codec       sql.NullString `db:"codec"`
+	DurationMs  sql.NullInt64  `db:"duration_ms"`
+	Bitrate     sql.NullInt64  `db:"bitrate"`
+	Channels    sql.NullInt64  `db:"channels"`
+	SampleRate  sql.NullInt64  `db:"sample_rate"`
+	Title       sql.NullString `db:"title"`
+	ArtworkKey  sql.NullString `db:"artwork_key"`
+	ArtworkLink sql.NullString `db:"artwork_link"`
+	CreatedAt   sql.NullTime   `db:"createdAt"`
+	UpdatedAt   sql.NullTime   `db:"updatedAt"`
+	DeletedAt   sql.NullTime   `db:"deletedAt"`
 }
 
 // ToDomainModel converts a MediaDB database model to a model.Media domain model.
 // It returns the corresponding model.Media.
 func (db *MediaDB) ToDomainModel() model.Media {
 	return model.Media{
-		ID:         db.UUID.String(),
-		DirectLink: db.DirectLink.String,
-		Kind:       db.Kind.String,
-		EpisodeID:  db.EpisodeID.String(),
+		ID:          db.UUID.String(),
+		DirectLink:  db.DirectLink.String,
+		Kind:        db.Kind.String,
+		EpisodeID:   db.EpisodeID.String(),
+		StorageKey:  db.StorageKey.String,
+		Size:        db.Size.Int64,
+		Checksum:    db.Checksum.String,
+		MimeType:    db.MimeType.String,
+		Codec:       db.Codec.String,
+		Duration:    time.Duration(db.DurationMs.Int64) * time.Millisecond,
+		Bitrate:     int(db.Bitrate.Int64),
+		Channels:    int(db.Channels.Int64),
+		SampleRate:  int(db.SampleRate.Int64),
+		Title:       db.Title.String,
+		ArtworkKey:  db.ArtworkKey.String,
+		ArtworkLink: db.ArtworkLink.String,
+		CreatedAt:   db.CreatedAt.Time,
+		UpdatedAt:   db.UpdatedAt.Time,
+		DeletedAt:   db.DeletedAt.Time,
 	}
 }
 
@@ -120,6 +280,18 @@ func (db *MediaDB) FromDomainModel(domain model.Media) {
 	db.UUID = uuid.MustParse(domain.ID)
 	db.DirectLink = sql.NullString{String: domain.DirectLink, Valid: domain.DirectLink != ""}
 	db.Kind = sql.NullString{String: domain.Kind, Valid: domain.Kind != ""}
+	db.StorageKey = sql.NullString{String: domain.StorageKey, Valid: domain.StorageKey != ""}
+	db.Size = sql.NullInt64{Int64: domain.Size, Valid: domain.StorageKey != ""}
+	db.Checksum = sql.NullString{String: domain.Checksum, Valid: domain.Checksum != ""}
+	db.MimeType = sql.NullString{String: domain.MimeType, Valid: domain.MimeType != ""}
+	db.Codec = sql.NullString{String: domain.Codec, Valid: domain.Codec != ""}
+	db.DurationMs = sql.NullInt64{Int64: domain.Duration.Milliseconds(), Valid: domain.Codec != ""}
+	db.Bitrate = sql.NullInt64{Int64: int64(domain.Bitrate), Valid: domain.Codec != ""}
+	db.Channels = sql.NullInt64{Int64: int64(domain.Channels), Valid: domain.Codec != ""}
+	db.SampleRate = sql.NullInt64{Int64: int64(domain.SampleRate), Valid: domain.Codec != ""}
+	db.Title = sql.NullString{String: domain.Title, Valid: domain.Title != ""}
+	db.ArtworkKey = sql.NullString{String: domain.ArtworkKey, Valid: domain.ArtworkKey != ""}
+	db.ArtworkLink = sql.NullString{String: domain.ArtworkLink, Valid: domain.ArtworkLink != ""}
 	db.EpisodeID = uuid.Nil
 	if domain.EpisodeID != "" {
 		db.EpisodeID = uuid.MustParse(domain.EpisodeID)
@@ -0,0 +1,288 @@
+// Package mysql provides MySQL implementations of the persistence interfaces.
+package mysql
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// migrationFiles embeds the versioned schema migrations shipped with the service.
+// Files are named <version>_<name>.up.sql and <version>_<name>.down.sql.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration represents a single versioned schema change with its up and down scripts.
+type Migration struct {
+	Version int64  // Version number parsed from the file name prefix
+	Name    string // Human readable name parsed from the file name
+	Up      string // SQL applied when migrating up
+	Down    string // SQL applied when migrating down
+}
+
+// MigrationStatus describes whether a known migration has been applied to the database.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time // Time the migration was applied, nil when pending
+}
+
+// Migrator applies and reverts the versioned schema migrations of the database.
+type Migrator interface {
+	Up(ctx context.Context) (int, error)
+	Down(ctx context.Context, steps int) (int, error)
+	Status(ctx context.Context) ([]MigrationStatus, error)
+}
+
+// migrator applies the embedded migrations and records them in the schema_migrations table.
+type migrator struct {
+	client     *client
+	migrations []Migration
+}
+
+// NewMigrator creates a new migrator with the provided MySQL client.
+// It returns an implementation of the Migrator interface, and panics if the embedded migration files are malformed,
+// since that is a build defect.
+func NewMigrator(client *client) Migrator {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		panic(fmt.Sprintf("could not load embedded migrations: %s", err.Error()))
+	}
+	return &migrator{
+		client:     client,
+		migrations: migrations,
+	}
+}
+
+// Up applies every pending migration in ascending version order.
+// It returns the number of migrations applied and an error if one of them fails.
+func (m *migrator) Up(ctx context.Context) (int, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.exec(ctx, migration.Up); err != nil {
+			return count, fmt.Errorf("error applying migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		const query = `
+            INSERT INTO schema_migrations (version, name) VALUES (?, ?)
+        `
+		if _, err := m.client.db.ExecContext(ctx, query, migration.Version, migration.Name); err != nil {
+			return count, fmt.Errorf("error recording migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		log.Info().Int64("version", migration.Version).Str("name", migration.Name).Msg("migration applied")
+		count++
+	}
+	return count, nil
+}
+
+// Down rolls back the given number of applied migrations, most recent first.
+// It returns the number of migrations rolled back and an error if one of them fails.
+func (m *migrator) Down(ctx context.Context, steps int) (int, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if err := m.exec(ctx, migration.Down); err != nil {
+			return count, fmt.Errorf("error reverting migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		const query = `
+            DELETE FROM schema_migrations WHERE version = ?
+        `
+		if _, err := m.client.db.ExecContext(ctx, query, migration.Version); err != nil {
+			return count, fmt.Errorf("error unrecording migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		log.Info().Int64("version", migration.Version).Str("name", migration.Name).Msg("migration reverted")
+		count++
+	}
+	return count, nil
+}
+
+// Status lists every embedded migration along with the time it was applied, if any.
+func (m *migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []MigrationStatus
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// appliedVersions ensures the schema_migrations table exists and returns the applied versions.
+func (m *migrator) appliedVersions(ctx context.Context) (map[int64]time.Time, error) {
+	const createQuery = `
+        CREATE TABLE IF NOT EXISTS schema_migrations
+        (
+            version   BIGINT       NOT NULL,
+            name      VARCHAR(255) NOT NULL,
+            appliedAt DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            PRIMARY KEY (version)
+        ) ENGINE = InnoDB
+          DEFAULT CHARSET = utf8mb4
+    `
+	if _, err := m.client.db.ExecContext(ctx, createQuery); err != nil {
+		return nil, fmt.Errorf("error creating schema_migrations table: %w", err)
+	}
+
+	const selectQuery = `
+        SELECT version, appliedAt FROM schema_migrations
+    `
+	var rows []struct {
+		Version   int64     `db:"version"`
+		AppliedAt time.Time `db:"appliedAt"`
+	}
+	if err := m.client.db.SelectContext(ctx, &rows, selectQuery); err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
+
+// exec runs every statement of a migration script one after the other.
+// Statements are split on their semicolons, so the DSN does not need multiStatements.
+func (m *migrator) exec(ctx context.Context, script string) error {
+	for _, statement := range splitStatements(script) {
+		if _, err := m.client.db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadMigrations reads and pairs the up and down scripts found in dir, sorted by version.
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(fileName, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(fileName, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(fileName, "."+direction+".sql")
+		versionPart, name, found := strings.Cut(base, "_")
+		if !found {
+			return nil, fmt.Errorf("migration file %s is not named <version>_<name>", fileName)
+		}
+		version, err := strconv.ParseInt(versionPart, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration file %s has an invalid version: %w", fileName, err)
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, name)
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	var migrations []Migration
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must provide both up and down scripts", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// splitStatements splits a SQL script into individual statements on the semicolons found outside of string
+// literals and quoted identifiers, dropping the -- comments and the empty statements.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	var quote byte // Quote of the literal or identifier being read, zero outside of them
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case quote != 0:
+			current.WriteByte(c)
+			if c == '\\' && quote != '`' && i+1 < len(script) {
+				i++
+				current.WriteByte(script[i])
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			current.WriteByte(c)
+		case strings.HasPrefix(script[i:], "--") && (i+2 == len(script) || strings.ContainsRune(" \t\r\n", rune(script[i+2]))):
+			// Skip the comment up to the end of its line
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				end = len(script) - i
+			}
+			i += end - 1
+		case c == ';':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return statements
+}
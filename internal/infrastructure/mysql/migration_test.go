@@ -0,0 +1,168 @@
+package mysql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(content string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(content)} }
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []Migration
+		wantErr string
+	}{
+		{
+			name: "pairs the scripts by version, in version order",
+			files: fstest.MapFS{
+				"migrations/0010_add_index.down.sql":     file("DROP INDEX idx;"),
+				"migrations/0002_create_table.up.sql":    file("CREATE TABLE t (id INT);"),
+				"migrations/0010_add_index.up.sql":       file("CREATE INDEX idx ON t (id);"),
+				"migrations/0002_create_table.down.sql":  file("DROP TABLE t;"),
+				"migrations/README.md":                   file("not a migration"),
+				"migrations/0003_not_sql_either.up.txt":  file("ignored"),
+				"other/0001_outside_of_the_dir.up.sql":   file("ignored"),
+				"other/0001_outside_of_the_dir.down.sql": file("ignored"),
+			},
+			want: []Migration{
+				{Version: 2, Name: "create_table", Up: "CREATE TABLE t (id INT);", Down: "DROP TABLE t;"},
+				{Version: 10, Name: "add_index", Up: "CREATE INDEX idx ON t (id);", Down: "DROP INDEX idx;"},
+			},
+		},
+		{
+			name: "refuses a migration without a down script",
+			files: fstest.MapFS{
+				"migrations/0001_create_table.up.sql": file("CREATE TABLE t (id INT);"),
+			},
+			wantErr: "must provide both up and down scripts",
+		},
+		{
+			name: "refuses a version used by two migrations",
+			files: fstest.MapFS{
+				"migrations/0001_create_table.up.sql":   file("CREATE TABLE t (id INT);"),
+				"migrations/0001_create_other.down.sql": file("DROP TABLE other;"),
+			},
+			wantErr: "is used by both",
+		},
+		{
+			name: "refuses a file without a name",
+			files: fstest.MapFS{
+				"migrations/0001.up.sql": file("CREATE TABLE t (id INT);"),
+			},
+			wantErr: "is not named <version>_<name>",
+		},
+		{
+			name: "refuses a file with an invalid version",
+			files: fstest.MapFS{
+				"migrations/v1_create_table.up.sql": file("CREATE TABLE t (id INT);"),
+			},
+			wantErr: "has an invalid version",
+		},
+		{
+			name:    "fails on a missing directory",
+			files:   fstest.MapFS{},
+			wantErr: "file does not exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadMigrations(tt.files, "migrations")
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got migrations %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, migration := range migrations {
+		for _, script := range []string{migration.Up, migration.Down} {
+			statements := splitStatements(script)
+			if len(statements) == 0 {
+				t.Fatalf("got no statement in a script of migration %d_%s", migration.Version, migration.Name)
+			}
+			for _, statement := range statements {
+				if strings.HasPrefix(statement, "--") || strings.HasSuffix(statement, ";") {
+					t.Fatalf("got statement %q in migration %d_%s, want it stripped of comments and semicolons", statement, migration.Version, migration.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "splits statements spanning several lines",
+			script: "CREATE TABLE t (\n    id INT\n);\n\nDROP TABLE other;\n",
+			want:   []string{"CREATE TABLE t (\n    id INT\n)", "DROP TABLE other"},
+		},
+		{
+			name:   "splits statements sharing a line",
+			script: "SELECT 1; SELECT 2;",
+			want:   []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:   "keeps a last statement without a semicolon",
+			script: "SELECT 1;\nSELECT 2\n",
+			want:   []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:   "drops comments and empty statements",
+			script: "-- Create the table;\nCREATE TABLE t (id INT); -- trailing comment; still a comment\n;\n--\nDROP TABLE t;",
+			want:   []string{"CREATE TABLE t (id INT)", "DROP TABLE t"},
+		},
+		{
+			name:   "keeps decrements, which are not comments",
+			script: "UPDATE t SET n = n --1;",
+			want:   []string{"UPDATE t SET n = n --1"},
+		},
+		{
+			name:   "keeps semicolons and dashes in string literals",
+			script: "INSERT INTO t VALUES ('a;\n-- b;', \"c;\");\nSELECT 1;",
+			want:   []string{"INSERT INTO t VALUES ('a;\n-- b;', \"c;\")", "SELECT 1"},
+		},
+		{
+			name:   "keeps escaped and doubled quotes in string literals",
+			script: "INSERT INTO t VALUES ('it''s; ok', 'it\\'s; ok');\nSELECT 1;",
+			want:   []string{"INSERT INTO t VALUES ('it''s; ok', 'it\\'s; ok')", "SELECT 1"},
+		},
+		{
+			name:   "keeps semicolons in quoted identifiers",
+			script: "CREATE TABLE `a;b` (id INT);",
+			want:   []string{"CREATE TABLE `a;b` (id INT)"},
+		},
+		{
+			name:   "returns nothing for a script of comments",
+			script: "-- nothing to do\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitStatements(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got statements %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
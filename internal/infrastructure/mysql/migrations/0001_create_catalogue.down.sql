@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS program_category;
+DROP TABLE IF EXISTS program_tag;
+DROP TABLE IF EXISTS block_program;
+DROP TABLE IF EXISTS wall_block;
+DROP TABLE IF EXISTS category;
+DROP TABLE IF EXISTS tag;
+DROP TABLE IF EXISTS media;
+DROP TABLE IF EXISTS episode;
+DROP TABLE IF EXISTS program;
+DROP TABLE IF EXISTS block;
+DROP TABLE IF EXISTS wall;
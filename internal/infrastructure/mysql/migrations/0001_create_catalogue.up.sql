@@ -0,0 +1,144 @@
+-- Catalogue entities. UUIDs are stored as BINARY(16) through UUID_TO_BIN / BIN_TO_UUID.
+
+CREATE TABLE IF NOT EXISTS wall
+(
+    UUID        BINARY(16)   NOT NULL,
+    name        VARCHAR(255) NULL,
+    description TEXT         NULL,
+    createdAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updatedAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (UUID)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS block
+(
+    UUID        BINARY(16)   NOT NULL,
+    name        VARCHAR(255) NULL,
+    description TEXT         NULL,
+    kind        VARCHAR(64)  NULL,
+    createdAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updatedAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (UUID)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS program
+(
+    UUID        BINARY(16)   NOT NULL,
+    name        VARCHAR(255) NULL,
+    description TEXT         NULL,
+    createdAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updatedAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (UUID)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS episode
+(
+    UUID        BINARY(16)   NOT NULL,
+    name        VARCHAR(255) NULL,
+    description TEXT         NULL,
+    position    INT          NOT NULL DEFAULT 0,
+    programUUID BINARY(16)   NOT NULL,
+    createdAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updatedAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (UUID),
+    KEY idx_episode_program (programUUID, position),
+    CONSTRAINT fk_episode_program FOREIGN KEY (programUUID) REFERENCES program (UUID)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS media
+(
+    UUID        BINARY(16)   NOT NULL,
+    direct_link VARCHAR(2048) NULL,
+    kind        VARCHAR(64)  NULL,
+    episodeUUID BINARY(16)   NOT NULL,
+    PRIMARY KEY (UUID),
+    KEY idx_media_episode (episodeUUID),
+    CONSTRAINT fk_media_episode FOREIGN KEY (episodeUUID) REFERENCES episode (UUID)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS tag
+(
+    UUID        BINARY(16)   NOT NULL,
+    name        VARCHAR(255) NULL,
+    description TEXT         NULL,
+    createdAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updatedAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (UUID),
+    UNIQUE KEY uq_tag_name (name)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+-- Root categories are stored with the nil UUID as parentUUID, so the column carries no foreign key.
+CREATE TABLE IF NOT EXISTS category
+(
+    UUID        BINARY(16)   NOT NULL,
+    name        VARCHAR(255) NULL,
+    description TEXT         NULL,
+    parentUUID  BINARY(16)   NULL,
+    createdAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updatedAt   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (UUID),
+    KEY idx_category_parent (parentUUID)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+-- Associations. Rows are meaningless without both ends, so they follow their owners on delete.
+
+CREATE TABLE IF NOT EXISTS wall_block
+(
+    UUID      BINARY(16) NOT NULL,
+    wallUUID  BINARY(16) NOT NULL,
+    blockUUID BINARY(16) NOT NULL,
+    position  INT        NOT NULL DEFAULT 0,
+    PRIMARY KEY (UUID),
+    UNIQUE KEY uq_wall_block (wallUUID, blockUUID),
+    KEY idx_wall_block_block (blockUUID),
+    CONSTRAINT fk_wall_block_wall FOREIGN KEY (wallUUID) REFERENCES wall (UUID) ON DELETE CASCADE,
+    CONSTRAINT fk_wall_block_block FOREIGN KEY (blockUUID) REFERENCES block (UUID) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS block_program
+(
+    UUID        BINARY(16) NOT NULL,
+    blockUUID   BINARY(16) NOT NULL,
+    programUUID BINARY(16) NOT NULL,
+    position    INT        NOT NULL DEFAULT 0,
+    PRIMARY KEY (UUID),
+    UNIQUE KEY uq_block_program (blockUUID, programUUID),
+    KEY idx_block_program_program (programUUID),
+    CONSTRAINT fk_block_program_block FOREIGN KEY (blockUUID) REFERENCES block (UUID) ON DELETE CASCADE,
+    CONSTRAINT fk_block_program_program FOREIGN KEY (programUUID) REFERENCES program (UUID) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS program_tag
+(
+    UUID        BINARY(16) NOT NULL,
+    programUUID BINARY(16) NOT NULL,
+    tagUUID     BINARY(16) NOT NULL,
+    PRIMARY KEY (UUID),
+    UNIQUE KEY uq_program_tag (programUUID, tagUUID),
+    KEY idx_program_tag_tag (tagUUID),
+    CONSTRAINT fk_program_tag_program FOREIGN KEY (programUUID) REFERENCES program (UUID) ON DELETE CASCADE,
+    CONSTRAINT fk_program_tag_tag FOREIGN KEY (tagUUID) REFERENCES tag (UUID) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS program_category
+(
+    UUID         BINARY(16) NOT NULL,
+    programUUID  BINARY(16) NOT NULL,
+    categoryUUID BINARY(16) NOT NULL,
+    PRIMARY KEY (UUID),
+    UNIQUE KEY uq_program_category (programUUID, categoryUUID),
+    KEY idx_program_category_category (categoryUUID),
+    CONSTRAINT fk_program_category_program FOREIGN KEY (programUUID) REFERENCES program (UUID) ON DELETE CASCADE,
+    CONSTRAINT fk_program_category_category FOREIGN KEY (categoryUUID) REFERENCES category (UUID) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,27 @@
+ALTER TABLE category
+    DROP KEY idx_category_name,
+    DROP KEY idx_category_created;
+
+ALTER TABLE tag
+    DROP KEY idx_tag_created;
+
+ALTER TABLE episode
+    DROP KEY idx_episode_name,
+    DROP KEY idx_episode_created;
+
+ALTER TABLE program
+    DROP KEY idx_program_name,
+    DROP KEY idx_program_created;
+
+ALTER TABLE block
+    DROP KEY idx_block_name,
+    DROP KEY idx_block_created;
+
+ALTER TABLE wall
+    DROP KEY idx_wall_name,
+    DROP KEY idx_wall_created;
+
+ALTER TABLE media
+    DROP KEY idx_media_created,
+    DROP COLUMN updatedAt,
+    DROP COLUMN createdAt;
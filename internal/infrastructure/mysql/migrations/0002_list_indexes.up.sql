@@ -0,0 +1,30 @@
+-- Collections are listed by creation time by default and may be sorted by name, so both get an index.
+-- Media had no timestamps yet; they are added so every collection pages the same way.
+
+ALTER TABLE media
+    ADD COLUMN createdAt DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN updatedAt DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    ADD KEY idx_media_created (createdAt);
+
+ALTER TABLE wall
+    ADD KEY idx_wall_created (createdAt),
+    ADD KEY idx_wall_name (name);
+
+ALTER TABLE block
+    ADD KEY idx_block_created (createdAt),
+    ADD KEY idx_block_name (name);
+
+ALTER TABLE program
+    ADD KEY idx_program_created (createdAt),
+    ADD KEY idx_program_name (name);
+
+ALTER TABLE episode
+    ADD KEY idx_episode_created (createdAt),
+    ADD KEY idx_episode_name (name);
+
+ALTER TABLE tag
+    ADD KEY idx_tag_created (createdAt);
+
+ALTER TABLE category
+    ADD KEY idx_category_created (createdAt),
+    ADD KEY idx_category_name (name);
@@ -0,0 +1,11 @@
+ALTER TABLE category
+    DROP KEY ft_category_search;
+
+ALTER TABLE tag
+    DROP KEY ft_tag_search;
+
+ALTER TABLE episode
+    DROP KEY ft_episode_search;
+
+ALTER TABLE program
+    DROP KEY ft_program_search;
@@ -0,0 +1,13 @@
+-- Full-text indexes backing the search endpoint. Tags and categories are only searched by name.
+
+ALTER TABLE program
+    ADD FULLTEXT KEY ft_program_search (name, description);
+
+ALTER TABLE episode
+    ADD FULLTEXT KEY ft_episode_search (name, description);
+
+ALTER TABLE tag
+    ADD FULLTEXT KEY ft_tag_search (name);
+
+ALTER TABLE category
+    ADD FULLTEXT KEY ft_category_search (name);
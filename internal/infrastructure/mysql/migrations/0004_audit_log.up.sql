@@ -0,0 +1,21 @@
+-- Audit log of the mutating operations of the back-office. Entries are never updated nor deleted.
+-- Only the fields changed by an operation are kept, as JSON objects of their values before and after it.
+
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    UUID         BINARY(16)   NOT NULL,
+    actor        VARCHAR(255) NOT NULL DEFAULT '',
+    action       VARCHAR(32)  NOT NULL,
+    entity       VARCHAR(32)  NOT NULL,
+    entityUUID   BINARY(16)   NOT NULL,
+    field        VARCHAR(64)  NOT NULL DEFAULT '',
+    valuesBefore JSON         NULL,
+    valuesAfter  JSON         NULL,
+    requestID    VARCHAR(128) NOT NULL DEFAULT '',
+    createdAt    DATETIME(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (UUID),
+    KEY idx_audit_log_created (createdAt),
+    KEY idx_audit_log_entity (entity, entityUUID, createdAt),
+    KEY idx_audit_log_actor (actor, createdAt)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,27 @@
+ALTER TABLE category
+    DROP KEY idx_category_deleted,
+    DROP COLUMN deletedAt;
+
+ALTER TABLE tag
+    DROP KEY idx_tag_deleted,
+    DROP COLUMN deletedAt;
+
+ALTER TABLE media
+    DROP KEY idx_media_deleted,
+    DROP COLUMN deletedAt;
+
+ALTER TABLE episode
+    DROP KEY idx_episode_deleted,
+    DROP COLUMN deletedAt;
+
+ALTER TABLE program
+    DROP KEY idx_program_deleted,
+    DROP COLUMN deletedAt;
+
+ALTER TABLE block
+    DROP KEY idx_block_deleted,
+    DROP COLUMN deletedAt;
+
+ALTER TABLE wall
+    DROP KEY idx_wall_deleted,
+    DROP COLUMN deletedAt;
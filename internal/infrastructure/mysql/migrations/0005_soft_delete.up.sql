@@ -0,0 +1,31 @@
+-- Soft deletion of the catalogue entities. Deleted rows keep their associations and are hidden from every lookup
+-- until they are restored, or purged once the retention of the trash is over.
+-- Episodes and medias deleted along with their owner share its deletedAt, which tells them apart on restore.
+
+ALTER TABLE wall
+    ADD COLUMN deletedAt DATETIME(6) NULL,
+    ADD KEY idx_wall_deleted (deletedAt);
+
+ALTER TABLE block
+    ADD COLUMN deletedAt DATETIME(6) NULL,
+    ADD KEY idx_block_deleted (deletedAt);
+
+ALTER TABLE program
+    ADD COLUMN deletedAt DATETIME(6) NULL,
+    ADD KEY idx_program_deleted (deletedAt);
+
+ALTER TABLE episode
+    ADD COLUMN deletedAt DATETIME(6) NULL,
+    ADD KEY idx_episode_deleted (deletedAt);
+
+ALTER TABLE media
+    ADD COLUMN deletedAt DATETIME(6) NULL,
+    ADD KEY idx_media_deleted (deletedAt);
+
+ALTER TABLE tag
+    ADD COLUMN deletedAt DATETIME(6) NULL,
+    ADD KEY idx_tag_deleted (deletedAt);
+
+ALTER TABLE category
+    ADD COLUMN deletedAt DATETIME(6) NULL,
+    ADD KEY idx_category_deleted (deletedAt);
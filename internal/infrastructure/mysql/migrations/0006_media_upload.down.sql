@@ -0,0 +1,5 @@
+ALTER TABLE media
+    DROP COLUMN mime_type,
+    DROP COLUMN checksum,
+    DROP COLUMN size,
+    DROP COLUMN storage_key;
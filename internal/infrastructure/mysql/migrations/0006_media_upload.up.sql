@@ -0,0 +1,8 @@
+-- Files uploaded for the medias. The direct link of an uploaded media points to the object stored under storage_key;
+-- the columns stay NULL for medias whose link is set by hand.
+
+ALTER TABLE media
+    ADD COLUMN storage_key VARCHAR(512) NULL,
+    ADD COLUMN size        BIGINT       NULL,
+    ADD COLUMN checksum    CHAR(64)     NULL,
+    ADD COLUMN mime_type   VARCHAR(255) NULL;
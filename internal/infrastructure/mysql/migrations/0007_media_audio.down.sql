@@ -0,0 +1,9 @@
+ALTER TABLE media
+    DROP COLUMN artwork_link,
+    DROP COLUMN artwork_key,
+    DROP COLUMN title,
+    DROP COLUMN sample_rate,
+    DROP COLUMN channels,
+    DROP COLUMN bitrate,
+    DROP COLUMN duration_ms,
+    DROP COLUMN codec;
@@ -0,0 +1,12 @@
+-- Description of the audio stream of the media files, probed when a media is registered or uploaded.
+-- The columns stay NULL for medias whose file could not be probed.
+
+ALTER TABLE media
+    ADD COLUMN codec        VARCHAR(32)   NULL,
+    ADD COLUMN duration_ms  BIGINT        NULL,
+    ADD COLUMN bitrate      INT           NULL,
+    ADD COLUMN channels     SMALLINT      NULL,
+    ADD COLUMN sample_rate  INT           NULL,
+    ADD COLUMN title        VARCHAR(512)  NULL,
+    ADD COLUMN artwork_key  VARCHAR(512)  NULL,
+    ADD COLUMN artwork_link VARCHAR(2048) NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE episode
+    DROP KEY idx_episode_source_guid,
+    DROP COLUMN source_guid;
+
+ALTER TABLE program
+    DROP KEY idx_program_source_guid,
+    DROP COLUMN source_guid;
@@ -0,0 +1,11 @@
+-- Identifiers of the feeds and feed items the programs and episodes were imported from,
+-- so that importing a feed again updates them instead of duplicating them.
+-- The columns stay NULL for the programs and episodes created by hand.
+
+ALTER TABLE program
+    ADD COLUMN source_guid VARCHAR(512) NULL,
+    ADD KEY idx_program_source_guid (source_guid);
+
+ALTER TABLE episode
+    ADD COLUMN source_guid VARCHAR(512) NULL,
+    ADD KEY idx_episode_source_guid (programUUID, source_guid);
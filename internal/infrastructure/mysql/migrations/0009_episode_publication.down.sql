@@ -0,0 +1,4 @@
+ALTER TABLE episode
+    DROP KEY idx_episode_publication,
+    DROP COLUMN publishAt,
+    DROP COLUMN status;
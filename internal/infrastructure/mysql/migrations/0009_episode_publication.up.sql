@@ -0,0 +1,12 @@
+-- Publication workflow of the episodes. The episodes created before it were live as soon as they were created,
+-- so they start published at their creation time; the episodes created afterwards start as drafts.
+
+ALTER TABLE episode
+    ADD COLUMN status    VARCHAR(16) NOT NULL DEFAULT 'published',
+    ADD COLUMN publishAt DATETIME(6) NULL,
+    ADD KEY idx_episode_publication (status, publishAt);
+
+UPDATE episode SET publishAt = createdAt;
+
+ALTER TABLE episode
+    ALTER COLUMN status SET DEFAULT 'draft';
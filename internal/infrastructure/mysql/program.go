@@ -4,9 +4,11 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"time"
 )
 
 // programAdapter is a struct that acts as an adapter for interacting with
@@ -27,83 +29,246 @@ func NewProgramAdapter(client *client) port.ProgramPersister {
 // It takes a context and a model.Program, and returns an error if the operation fails.
 func (adapter *programAdapter) Create(ctx context.Context, program model.Program) error {
 	const query = `
-        INSERT INTO program (UUID, name, description)
-        VALUES (UUID_TO_BIN(:UUID), :name, :description)
+        INSERT INTO program (UUID, name, description, source_guid)
+        VALUES (UUID_TO_BIN(:UUID), :name, :description, :source_guid)
     `
 	var programDB ProgramDB
 	programDB.FromDomainModel(program)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, programDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, programDB)
+	return translateError(err)
 }
 
-// Delete removes a program record from the database based on its UUID.
+// Delete moves a program record to the trash based on its UUID, along with its episodes and their medias.
+// A single statement stamps them all with the same deletion time, which tells them apart from the episodes and medias
+// deleted on their own when the program is restored.
 // It takes a context and the program's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no live program has the given UUID.
 func (adapter *programAdapter) Delete(ctx context.Context, programUUID string) error {
 	const query = `
-        DELETE FROM program WHERE UUID = UUID_TO_BIN(?)
+        UPDATE program p
+        LEFT JOIN episode e ON e.programUUID = p.UUID AND e.deletedAt IS NULL
+        LEFT JOIN media m ON m.episodeUUID = e.UUID AND m.deletedAt IS NULL
+        SET p.deletedAt = UTC_TIMESTAMP(6), e.deletedAt = UTC_TIMESTAMP(6), m.deletedAt = UTC_TIMESTAMP(6)
+        WHERE p.UUID = UUID_TO_BIN(?) AND p.deletedAt IS NULL
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, programUUID)
-	return err
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, programUUID)
+	return checkAffected(result, err, "program", programUUID)
+}
+
+// Restore brings back a program record from the trash based on its UUID,
+// along with the episodes and medias deleted with it.
+// It takes a context and the program's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no program in the trash has the given UUID.
+func (adapter *programAdapter) Restore(ctx context.Context, programUUID string) error {
+	const query = `
+        UPDATE program p
+        LEFT JOIN episode e ON e.programUUID = p.UUID AND e.deletedAt = ?
+        LEFT JOIN media m ON m.episodeUUID = e.UUID AND m.deletedAt = ?
+        SET p.deletedAt = NULL, e.deletedAt = NULL, m.deletedAt = NULL
+        WHERE p.UUID = UUID_TO_BIN(?) AND p.deletedAt = ?
+    `
+	deletedAt, err := adapter.client.deletedAt(ctx, "program", programUUID)
+	if err != nil {
+		return err
+	}
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, deletedAt, deletedAt, programUUID, deletedAt)
+	return checkAffected(result, err, "program", programUUID)
+}
+
+// Purge permanently removes the program records moved to the trash before the given time from the database.
+// Programs whose episodes have not been purged yet are kept, as the episodes still reference them.
+// It takes a context and the time, and returns the number of removed records and an error if the operation fails.
+func (adapter *programAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	const query = `
+        DELETE FROM program
+        WHERE deletedAt < ? AND NOT EXISTS (SELECT 1 FROM episode e WHERE e.programUUID = program.UUID)
+    `
+	return purged(adapter.client.conn(ctx).ExecContext(ctx, query, before))
 }
 
 // Update updates an existing program record in the database.
 // It takes a context, the program's UUID, and the updated model.Program, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no program has the given UUID.
 func (adapter *programAdapter) Update(ctx context.Context, programUUID string, updates model.Program) error {
 	const query = `
         UPDATE program SET 
                              name = COALESCE(:name, name), 
                              description = COALESCE(:description, description) 
-                        WHERE UUID = UUID_TO_BIN(:UUID)
+                        WHERE UUID = UUID_TO_BIN(:UUID) AND deletedAt IS NULL
     `
 	updates.ID = programUUID
 	var programDB ProgramDB
 	programDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, programDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, programDB)
+	return checkAffected(result, err, "program", programUUID)
 }
 
-// FindAll retrieves all program records from the database.
-// It takes a context and returns a slice of model.Program and an error if the operation fails.
-func (adapter *programAdapter) FindAll(ctx context.Context) ([]*model.Program, error) {
-	const query = `
-        SELECT * FROM program
-    `
+// programListing describes how programs can be filtered and sorted.
+var programListing = listing{
+	table:      "program",
+	nameColumn: "name",
+	sortable: map[string]string{
+		model.SortByName:      "name",
+		model.SortByCreatedAt: "createdAt",
+	},
+}
+
+// FindAll retrieves the program records matching the options from the database.
+// It takes a context and the list options, and returns a slice of model.Program, the number of matching records
+// and an error if the operation fails.
+func (adapter *programAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Program, int, error) {
 	var programsDB []*ProgramDB
-	if err := adapter.client.db.SelectContext(ctx, &programsDB, query); err != nil {
-		return nil, err
+	total, err := adapter.client.selectPage(ctx, &programsDB, programListing, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 	var programs []*model.Program
 	for _, programDB := range programsDB {
 		mappedProgram := programDB.ToDomainModel()
 		programs = append(programs, &mappedProgram)
 	}
-	return programs, nil
+	return programs, total, nil
 }
 
 // Find retrieves a program record from the database by its UUID.
 // It takes a context and the program's UUID, and returns a model.Program and an error if the operation fails.
 func (adapter *programAdapter) Find(ctx context.Context, programUUID string) (*model.Program, error) {
 	const query = `
-        SELECT * FROM program WHERE UUID = UUID_TO_BIN(?)
+        SELECT * FROM program WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
 	var programsDB []*ProgramDB
-	if err := adapter.client.db.SelectContext(ctx, &programsDB, query, programUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programsDB, query, programUUID); err != nil {
+		return nil, translateError(err)
 	}
 	if len(programsDB) == 0 {
-		return nil, nil
+		return nil, notFound("program", programUUID)
+	}
+	result := programsDB[0].ToDomainModel()
+	return &result, nil
+}
+
+// FindBySourceGUID retrieves the live program record imported from the feed with the given identifier, the oldest
+// one should several have been imported from it.
+// It takes a context and the identifier of the feed, and returns a model.Program and an error if the operation fails.
+// It returns model.ErrNotFound when no live program was imported from the feed.
+func (adapter *programAdapter) FindBySourceGUID(ctx context.Context, guid string) (*model.Program, error) {
+	const query = `
+        SELECT * FROM program WHERE source_guid = ? AND deletedAt IS NULL ORDER BY createdAt, UUID LIMIT 1
+    `
+	var programsDB []*ProgramDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programsDB, query, guid); err != nil {
+		return nil, translateError(err)
+	}
+	if len(programsDB) == 0 {
+		return nil, notFound("program", guid)
 	}
 	result := programsDB[0].ToDomainModel()
 	return &result, nil
 }
 
+// FindByIDs retrieves the program records from the database matching the given UUIDs.
+// It takes a context and the programs' UUIDs, and returns a slice of model.Program and an error if the operation fails.
+// No query is run when ids is empty.
+func (adapter *programAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Program, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT * FROM program WHERE UUID IN (%s) AND deletedAt IS NULL;
+    `, placeholders)
+	var programsDB []*ProgramDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programsDB, query, args...); err != nil {
+		return nil, err
+	}
+	var programs []*model.Program
+	for _, programDB := range programsDB {
+		mappedProgram := programDB.ToDomainModel()
+		programs = append(programs, &mappedProgram)
+	}
+	return programs, nil
+}
+
+// FindByTagID retrieves the program records associated with a tag from the database, ordered by name.
+// It joins the program_tag association table, so that a single query is run however many programs are associated.
+// It takes a context and the tag's ID, and returns a slice of model.Program and an error if the operation fails.
+func (adapter *programAdapter) FindByTagID(ctx context.Context, id string) ([]*model.Program, error) {
+	const query = `
+        SELECT p.* FROM program p
+        JOIN program_tag a ON a.programUUID = p.UUID
+        WHERE a.tagUUID = UUID_TO_BIN(?) AND p.deletedAt IS NULL
+        ORDER BY p.name, p.UUID;
+    `
+	var programsDB []*ProgramDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programsDB, query, id); err != nil {
+		return nil, err
+	}
+	var programs []*model.Program
+	for _, programDB := range programsDB {
+		mappedProgram := programDB.ToDomainModel()
+		programs = append(programs, &mappedProgram)
+	}
+	return programs, nil
+}
+
+// FindByCategoryID retrieves the program records associated with a category from the database, ordered by name.
+// It joins the program_category association table, so that a single query is run however many programs are associated.
+// It takes a context and the category's ID, and returns a slice of model.Program and an error if the operation fails.
+func (adapter *programAdapter) FindByCategoryID(ctx context.Context, id string) ([]*model.Program, error) {
+	const query = `
+        SELECT p.* FROM program p
+        JOIN program_category a ON a.programUUID = p.UUID
+        WHERE a.categoryUUID = UUID_TO_BIN(?) AND p.deletedAt IS NULL
+        ORDER BY p.name, p.UUID;
+    `
+	var programsDB []*ProgramDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programsDB, query, id); err != nil {
+		return nil, err
+	}
+	var programs []*model.Program
+	for _, programDB := range programsDB {
+		mappedProgram := programDB.ToDomainModel()
+		programs = append(programs, &mappedProgram)
+	}
+	return programs, nil
+}
+
+// FindByCategoryIDs retrieves the program records associated with any of the given categories from the database,
+// each program once, ordered by name. No query is run when ids is empty.
+// It takes a context and the categories' IDs, and returns a slice of model.Program and an error if the operation fails.
+func (adapter *programAdapter) FindByCategoryIDs(ctx context.Context, ids []string) ([]*model.Program, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT p.* FROM program p
+        WHERE p.deletedAt IS NULL AND EXISTS (
+            SELECT 1 FROM program_category a WHERE a.programUUID = p.UUID AND a.categoryUUID IN (%s)
+        )
+        ORDER BY p.name, p.UUID;
+    `, placeholders)
+	var programsDB []*ProgramDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programsDB, query, args...); err != nil {
+		return nil, err
+	}
+	var programs []*model.Program
+	for _, programDB := range programsDB {
+		mappedProgram := programDB.ToDomainModel()
+		programs = append(programs, &mappedProgram)
+	}
+	return programs, nil
+}
+
 // ProgramDB is a struct representing the program database model.
 type ProgramDB struct {
 	UUID        uuid.UUID      `db:"UUID"`
 	Name        sql.NullString `db:"name"`
 	Description sql.NullString `db:"description"`
+	SourceGUID  sql.NullString `db:"source_guid"`
 	CreatedAt   sql.NullTime   `db:"createdAt"`
 	UpdatedAt   sql.NullTime   `db:"updatedAt"`
+	DeletedAt   sql.NullTime   `db:"deletedAt"`
 }
 
 // ToDomainModel converts a ProgramDB database model to a model.Program domain model.
@@ -113,6 +278,10 @@ func (db *ProgramDB) ToDomainModel() model.Program {
 		ID:          db.UUID.String(),
 		Name:        db.Name.String,
 		Description: db.Description.String,
+		SourceGUID:  db.SourceGUID.String,
+		CreatedAt:   db.CreatedAt.Time,
+		UpdatedAt:   db.UpdatedAt.Time,
+		DeletedAt:   db.DeletedAt.Time,
 	}
 }
 
@@ -122,4 +291,5 @@ func (db *ProgramDB) FromDomainModel(domain model.Program) {
 	db.UUID = uuid.MustParse(domain.ID)
 	db.Name = sql.NullString{String: domain.Name, Valid: domain.Name != ""}
 	db.Description = sql.NullString{String: domain.Description, Valid: domain.Description != ""}
+	db.SourceGUID = sql.NullString{String: domain.SourceGUID, Valid: domain.SourceGUID != ""}
 }
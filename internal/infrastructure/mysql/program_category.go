@@ -3,7 +3,8 @@ package mysql
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
@@ -32,8 +33,8 @@ func (adapter *programCategoryAdapter) Create(ctx context.Context, programCatego
     `
 	var programCategoryDB ProgramCategoryDB
 	programCategoryDB.FromDomainModel(programCategory)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, programCategoryDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, programCategoryDB)
+	return translateError(err)
 }
 
 // Delete removes a programCategory record from the database based on its UUID.
@@ -42,12 +43,13 @@ func (adapter *programCategoryAdapter) Delete(ctx context.Context, programCatego
 	const query = `
         DELETE FROM program_category WHERE UUID = UUID_TO_BIN(?)
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, programCategoryUUID)
-	return err
+	_, err := adapter.client.conn(ctx).ExecContext(ctx, query, programCategoryUUID)
+	return translateError(err)
 }
 
 // Update updates an existing programCategory record in the database.
 // It takes a context, the programCategory's UUID, and the updated model.ProgramCategory, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no program_category has the given UUID.
 func (adapter *programCategoryAdapter) Update(ctx context.Context, programCategoryUUID string, updates model.ProgramCategory) error {
 	const query = `
         UPDATE program_category SET 
@@ -58,8 +60,8 @@ func (adapter *programCategoryAdapter) Update(ctx context.Context, programCatego
 	updates.ID = programCategoryUUID
 	var programCategoryDB ProgramCategoryDB
 	programCategoryDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, programCategoryDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, programCategoryDB)
+	return checkAffected(result, err, "program_category", programCategoryUUID)
 }
 
 // Find retrieves a programCategory record from the database by its UUID.
@@ -69,11 +71,14 @@ func (adapter *programCategoryAdapter) Find(ctx context.Context, programCategory
         SELECT * FROM program_category WHERE UUID = UUID_TO_BIN(?)
     `
 	var programCategoryDB ProgramCategoryDB
-	if err := adapter.client.db.GetContext(ctx, &programCategoryDB, query, programCategoryUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).GetContext(ctx, &programCategoryDB, query, programCategoryUUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFound("program_category", programCategoryUUID)
+		}
+		return nil, translateError(err)
 	}
 	if programCategoryDB.UUID == uuid.Nil {
-		return nil, fmt.Errorf("programCategory with ID %s not found", programCategoryUUID)
+		return nil, notFound("program_category", programCategoryUUID)
 	}
 	result := programCategoryDB.ToDomainModel()
 	return &result, nil
@@ -86,7 +91,7 @@ func (adapter *programCategoryAdapter) FindByProgramID(ctx context.Context, prog
         SELECT * FROM program_category WHERE programUUID = UUID_TO_BIN(?)
     `
 	var programCategoriesDB []*ProgramCategoryDB
-	if err := adapter.client.db.SelectContext(ctx, &programCategoriesDB, query, programID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programCategoriesDB, query, programID); err != nil {
 		return nil, err
 	}
 	var programCategories []*model.ProgramCategory
@@ -105,7 +110,7 @@ func (adapter *programCategoryAdapter) FindByCategoryIDAndProgramID(ctx context.
         SELECT * FROM program_category WHERE categoryUUID = UUID_TO_BIN(?) AND programUUID = UUID_TO_BIN(?)
     `
 	var programCategoriesDB []*ProgramCategoryDB
-	if err := adapter.client.db.SelectContext(ctx, &programCategoriesDB, query, categoryID, programID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programCategoriesDB, query, categoryID, programID); err != nil {
 		return nil, err
 	}
 	var programCategories []*model.ProgramCategory
@@ -123,7 +128,7 @@ func (adapter *programCategoryAdapter) FindByCategoryID(ctx context.Context, cat
         SELECT * FROM program_category WHERE categoryUUID = UUID_TO_BIN(?)
     `
 	var programCategoriesDB []*ProgramCategoryDB
-	if err := adapter.client.db.SelectContext(ctx, &programCategoriesDB, query, categoryID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programCategoriesDB, query, categoryID); err != nil {
 		return nil, err
 	}
 	var programCategories []*model.ProgramCategory
@@ -3,7 +3,8 @@ package mysql
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 )
@@ -31,8 +32,8 @@ func (adapter *programTagAdapter) Create(ctx context.Context, programTag model.P
     `
 	var programTagDB ProgramTagDB
 	programTagDB.FromDomainModel(programTag)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, programTagDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, programTagDB)
+	return translateError(err)
 }
 
 // Delete removes a programTag record from the database based on its UUID.
@@ -41,12 +42,13 @@ func (adapter *programTagAdapter) Delete(ctx context.Context, programTagUUID str
 	const query = `
         DELETE FROM program_tag WHERE UUID = UUID_TO_BIN(?)
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, programTagUUID)
-	return err
+	_, err := adapter.client.conn(ctx).ExecContext(ctx, query, programTagUUID)
+	return translateError(err)
 }
 
 // Update updates an existing programTag record in the database.
 // It takes a context, the programTag's UUID, and the updated model.ProgramTag, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no program_tag has the given UUID.
 func (adapter *programTagAdapter) Update(ctx context.Context, programTagUUID string, updates model.ProgramTag) error {
 	const query = `
         UPDATE program_tag SET 
@@ -57,8 +59,8 @@ func (adapter *programTagAdapter) Update(ctx context.Context, programTagUUID str
 	updates.ID = programTagUUID
 	var programTagDB ProgramTagDB
 	programTagDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, programTagDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, programTagDB)
+	return checkAffected(result, err, "program_tag", programTagUUID)
 }
 
 // Find retrieves a programTag record from the database by its UUID.
@@ -68,11 +70,14 @@ func (adapter *programTagAdapter) Find(ctx context.Context, programTagUUID strin
         SELECT * FROM program_tag WHERE UUID = UUID_TO_BIN(?)
     `
 	var programTagDB ProgramTagDB
-	if err := adapter.client.db.GetContext(ctx, &programTagDB, query, programTagUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).GetContext(ctx, &programTagDB, query, programTagUUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFound("program_tag", programTagUUID)
+		}
+		return nil, translateError(err)
 	}
 	if programTagDB.UUID == uuid.Nil {
-		return nil, fmt.Errorf("programTag with ID %s not found", programTagUUID)
+		return nil, notFound("program_tag", programTagUUID)
 	}
 	result := programTagDB.ToDomainModel()
 	return &result, nil
@@ -85,7 +90,7 @@ func (adapter *programTagAdapter) FindByProgramID(ctx context.Context, programID
         SELECT * FROM program_tag WHERE programUUID = UUID_TO_BIN(?)
     `
 	var programTagsDB []*ProgramTagDB
-	if err := adapter.client.db.SelectContext(ctx, &programTagsDB, query, programID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programTagsDB, query, programID); err != nil {
 		return nil, err
 	}
 	var programTags []*model.ProgramTag
@@ -104,7 +109,7 @@ func (adapter *programTagAdapter) FindByTagIDAndProgramID(ctx context.Context, t
         SELECT * FROM program_tag WHERE tagUUID = UUID_TO_BIN(?) AND programUUID = UUID_TO_BIN(?)
     `
 	var programTagsDB []*ProgramTagDB
-	if err := adapter.client.db.SelectContext(ctx, &programTagsDB, query, tagID, programID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programTagsDB, query, tagID, programID); err != nil {
 		return nil, err
 	}
 	var programTags []*model.ProgramTag
@@ -122,7 +127,7 @@ func (adapter *programTagAdapter) FindByTagID(ctx context.Context, tagID string)
         SELECT * FROM program_tag WHERE tagUUID = UUID_TO_BIN(?)
     `
 	var programTagsDB []*ProgramTagDB
-	if err := adapter.client.db.SelectContext(ctx, &programTagsDB, query, tagID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &programTagsDB, query, tagID); err != nil {
 		return nil, err
 	}
 	var programTags []*model.ProgramTag
@@ -0,0 +1,84 @@
+// Package mysql provides MySQL implementations of the persistence interfaces.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// searchedTables lists, for every kind of search hit, the table and the FULLTEXT indexed columns to match.
+var searchedTables = []struct {
+	kind    string
+	table   string
+	columns string
+}{
+	{kind: model.SearchKindProgram, table: "program", columns: "name, description"},
+	{kind: model.SearchKindEpisode, table: "episode", columns: "name, description"},
+	{kind: model.SearchKindTag, table: "tag", columns: "name"},
+	{kind: model.SearchKindCategory, table: "category", columns: "name"},
+}
+
+// searchAdapter is a struct that acts as an adapter for searching
+// the catalogue through the FULLTEXT indexes of the MySQL database.
+type searchAdapter struct {
+	client *client
+}
+
+// NewSearchAdapter creates a new search adapter with the provided MySQL client.
+// It returns an implementation of the Searcher interface.
+func NewSearchAdapter(client *client) port.Searcher {
+	return &searchAdapter{
+		client: client,
+	}
+}
+
+// Search runs the query in natural language mode against every searched table.
+// It takes a context, the query and the maximum number of hits per kind, and returns the hits
+// of every kind, ranked by relevance within their kind, and an error if the operation fails.
+func (adapter *searchAdapter) Search(ctx context.Context, query string, limit int) ([]*model.SearchHit, error) {
+	var hits []*model.SearchHit
+	for _, searched := range searchedTables {
+		match := fmt.Sprintf("MATCH (%s) AGAINST (? IN NATURAL LANGUAGE MODE)", searched.columns)
+		statement := fmt.Sprintf(`
+        SELECT UUID, name, description, %s AS score
+        FROM %s
+        WHERE %s AND deletedAt IS NULL
+        ORDER BY score DESC
+        LIMIT ?
+    `, match, searched.table, match)
+		var hitsDB []*SearchHitDB
+		if err := adapter.client.conn(ctx).SelectContext(ctx, &hitsDB, statement, query, query, limit); err != nil {
+			return nil, err
+		}
+		for _, hitDB := range hitsDB {
+			hit := hitDB.ToDomainModel(searched.kind)
+			hits = append(hits, &hit)
+		}
+	}
+	return hits, nil
+}
+
+// SearchHitDB is a struct representing a row matching a full-text search.
+type SearchHitDB struct {
+	UUID        uuid.UUID      `db:"UUID"`
+	Name        sql.NullString `db:"name"`
+	Description sql.NullString `db:"description"`
+	Score       float64        `db:"score"`
+}
+
+// ToDomainModel converts a SearchHitDB database model to a model.SearchHit domain model of the given kind.
+// It returns the corresponding model.SearchHit.
+func (db *SearchHitDB) ToDomainModel(kind string) model.SearchHit {
+	return model.SearchHit{
+		Kind:        kind,
+		ID:          db.UUID.String(),
+		Name:        db.Name.String,
+		Description: db.Description.String,
+		Score:       db.Score,
+	}
+}
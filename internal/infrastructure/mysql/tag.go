@@ -4,9 +4,11 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"time"
 )
 
 // tagAdapter is a struct that acts as an adapter for interacting with
@@ -32,71 +34,170 @@ func (adapter *tagAdapter) Create(ctx context.Context, tag model.Tag) error {
     `
 	var tagDB TagDB
 	tagDB.FromDomainModel(tag)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, tagDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, tagDB)
+	return translateError(err)
 }
 
-// Delete removes a tag record from the database based on its UUID.
+// Delete moves a tag record to the trash based on its UUID.
 // It takes a context and the tag's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no live tag has the given UUID.
 func (adapter *tagAdapter) Delete(ctx context.Context, tagUUID string) error {
 	const query = `
-        DELETE FROM tag WHERE UUID = UUID_TO_BIN(?)
+        UPDATE tag SET deletedAt = UTC_TIMESTAMP(6) WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, tagUUID)
-	return err
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, tagUUID)
+	return checkAffected(result, err, "tag", tagUUID)
+}
+
+// Restore brings back a tag record from the trash based on its UUID.
+// It takes a context and the tag's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no tag in the trash has the given UUID.
+func (adapter *tagAdapter) Restore(ctx context.Context, tagUUID string) error {
+	const query = `
+        UPDATE tag SET deletedAt = NULL WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NOT NULL
+    `
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, tagUUID)
+	return checkAffected(result, err, "tag", tagUUID)
+}
+
+// Purge permanently removes the tag records moved to the trash before the given time from the database.
+// It takes a context and the time, and returns the number of removed records and an error if the operation fails.
+func (adapter *tagAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	const query = `
+        DELETE FROM tag WHERE deletedAt < ?
+    `
+	return purged(adapter.client.conn(ctx).ExecContext(ctx, query, before))
 }
 
 // Update updates an existing tag record in the database.
 // It takes a context, the tag's UUID, and the updated model.Tag, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no tag has the given UUID.
 func (adapter *tagAdapter) Update(ctx context.Context, tagUUID string, updates model.Tag) error {
 	const query = `
         UPDATE tag SET 
                              name = COALESCE(:name, name), 
                              description = COALESCE(:description, description) 
-                        WHERE UUID = UUID_TO_BIN(:UUID)
+                        WHERE UUID = UUID_TO_BIN(:UUID) AND deletedAt IS NULL
     `
 	updates.ID = tagUUID
 	var tagDB TagDB
 	tagDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, tagDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, tagDB)
+	return checkAffected(result, err, "tag", tagUUID)
 }
 
-// FindAll retrieves all tag records from the database.
-// It takes a context and returns a slice of model.Tag and an error if the operation fails.
-func (adapter *tagAdapter) FindAll(ctx context.Context) ([]*model.Tag, error) {
-	const query = `
-        SELECT * FROM tag
-    `
+// tagListing describes how tags can be filtered and sorted.
+var tagListing = listing{
+	table:      "tag",
+	nameColumn: "name",
+	sortable: map[string]string{
+		model.SortByName:      "name",
+		model.SortByCreatedAt: "createdAt",
+	},
+}
+
+// FindAll retrieves the tag records matching the options from the database.
+// It takes a context and the list options, and returns a slice of model.Tag, the number of matching records
+// and an error if the operation fails.
+func (adapter *tagAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Tag, int, error) {
 	var tagsDB []*TagDB
-	if err := adapter.client.db.SelectContext(ctx, &tagsDB, query); err != nil {
-		return nil, err
+	total, err := adapter.client.selectPage(ctx, &tagsDB, tagListing, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 	var tags []*model.Tag
 	for _, tagDB := range tagsDB {
 		mappedTag := tagDB.ToDomainModel()
 		tags = append(tags, &mappedTag)
 	}
-	return tags, nil
+	return tags, total, nil
 }
 
 // Find retrieves a tag record from the database by its UUID.
 // It takes a context and the tag's UUID, and returns a model.Tag and an error if the operation fails.
 func (adapter *tagAdapter) Find(ctx context.Context, tagUUID string) (*model.Tag, error) {
 	const query = `
-        SELECT * FROM tag WHERE UUID = UUID_TO_BIN(?)
+        SELECT * FROM tag WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
 	var tagsDB []*TagDB
-	if err := adapter.client.db.SelectContext(ctx, &tagsDB, query, tagUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &tagsDB, query, tagUUID); err != nil {
+		return nil, translateError(err)
 	}
 	if len(tagsDB) == 0 {
-		return nil, nil
+		return nil, notFound("tag", tagUUID)
 	}
 	result := tagsDB[0].ToDomainModel()
 	return &result, nil
 }
 
+// FindByIDs retrieves the tag records from the database matching the given UUIDs.
+// It takes a context and the tags' UUIDs, and returns a slice of model.Tag and an error if the operation fails.
+// No query is run when ids is empty.
+func (adapter *tagAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Tag, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT * FROM tag WHERE UUID IN (%s) AND deletedAt IS NULL;
+    `, placeholders)
+	var tagsDB []*TagDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &tagsDB, query, args...); err != nil {
+		return nil, err
+	}
+	var tags []*model.Tag
+	for _, tagDB := range tagsDB {
+		mappedTag := tagDB.ToDomainModel()
+		tags = append(tags, &mappedTag)
+	}
+	return tags, nil
+}
+
+// FindByNames retrieves the tag records from the database matching the given names, including the records in the trash.
+// It takes a context and the tags' names, and returns a slice of model.Tag and an error if the operation fails.
+// No query is run when names is empty.
+func (adapter *tagAdapter) FindByNames(ctx context.Context, names []string) ([]*model.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	placeholders, args := valueList(names)
+	query := fmt.Sprintf(`
+        SELECT * FROM tag WHERE name IN (%s);
+    `, placeholders)
+	var tagsDB []*TagDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &tagsDB, query, args...); err != nil {
+		return nil, err
+	}
+	var tags []*model.Tag
+	for _, tagDB := range tagsDB {
+		mappedTag := tagDB.ToDomainModel()
+		tags = append(tags, &mappedTag)
+	}
+	return tags, nil
+}
+
+// FindByProgramID retrieves the tag records associated with a program from the database, ordered by name.
+// It joins the program_tag association table, so that a single query is run however many tags are associated.
+// It takes a context and the program's ID, and returns a slice of model.Tag and an error if the operation fails.
+func (adapter *tagAdapter) FindByProgramID(ctx context.Context, id string) ([]*model.Tag, error) {
+	const query = `
+        SELECT t.* FROM tag t
+        JOIN program_tag a ON a.tagUUID = t.UUID
+        WHERE a.programUUID = UUID_TO_BIN(?) AND t.deletedAt IS NULL
+        ORDER BY t.name, t.UUID;
+    `
+	var tagsDB []*TagDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &tagsDB, query, id); err != nil {
+		return nil, err
+	}
+	var tags []*model.Tag
+	for _, tagDB := range tagsDB {
+		mappedTag := tagDB.ToDomainModel()
+		tags = append(tags, &mappedTag)
+	}
+	return tags, nil
+}
+
 // TagDB is a struct representing the tag database model.
 type TagDB struct {
 	UUID        uuid.UUID      `db:"UUID"`
@@ -104,6 +205,7 @@ type TagDB struct {
 	Description sql.NullString `db:"description"`
 	CreatedAt   sql.NullTime   `db:"createdAt"`
 	UpdatedAt   sql.NullTime   `db:"updatedAt"`
+	DeletedAt   sql.NullTime   `db:"deletedAt"`
 }
 
 // ToDomainModel converts a TagDB database model to a model.Tag domain model.
@@ -113,6 +215,8 @@ func (db *TagDB) ToDomainModel() model.Tag {
 		ID:          db.UUID.String(),
 		Name:        db.Name.String,
 		Description: db.Description.String,
+		CreatedAt:   db.CreatedAt.Time,
+		DeletedAt:   db.DeletedAt.Time,
 	}
 }
 
@@ -0,0 +1,38 @@
+// Package mysql provides MySQL implementations of the persistence interfaces.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// deletedAt returns the time the row of table identified by id was moved to the trash.
+// It returns model.ErrNotFound when no row of table with this UUID is in the trash.
+func (c *client) deletedAt(ctx context.Context, table, id string) (time.Time, error) {
+	query := fmt.Sprintf(`
+        SELECT deletedAt FROM %s WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NOT NULL
+    `, table)
+	var deletedAt time.Time
+	if err := c.conn(ctx).GetContext(ctx, &deletedAt, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, notFound(table, id)
+		}
+		return time.Time{}, translateError(err)
+	}
+	return deletedAt, nil
+}
+
+// purged translates the outcome of a statement purging rows from the trash into the number of rows removed.
+func purged(result sql.Result, err error) (int, error) {
+	if err != nil {
+		return 0, translateError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
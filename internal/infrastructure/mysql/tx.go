@@ -0,0 +1,60 @@
+// Package mysql provides MySQL implementations of the persistence interfaces.
+package mysql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/rs/zerolog/log"
+)
+
+// txKey is the context key under which the transaction of a unit of work is stored.
+type txKey struct{}
+
+// txFromContext returns the transaction stored in ctx by the txManager, if any.
+func txFromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok
+}
+
+// txManager runs units of work in MySQL transactions.
+type txManager struct {
+	client *client
+}
+
+// NewTxManager creates a new transaction manager with the provided MySQL client.
+// It returns an implementation of the TxManager interface.
+func NewTxManager(client *client) port.TxManager {
+	return &txManager{
+		client: client,
+	}
+}
+
+// WithinTx runs fn in a transaction, committing it when fn returns nil and rolling it back otherwise.
+// The adapters called with the context given to fn run their queries in the transaction.
+// A call made within an ongoing transaction joins it instead of starting a new one.
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.client.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Ctx(ctx).Error().Err(rollbackErr).Msg("error while rolling back transaction")
+		}
+		return err
+	}
+	return tx.Commit()
+}
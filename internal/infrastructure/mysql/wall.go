@@ -4,9 +4,11 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"time"
 )
 
 // wallAdapter is a struct that acts as an adapter for interacting with
@@ -32,71 +34,125 @@ func (adapter wallAdapter) Create(ctx context.Context, wall model.Wall) error {
     `
 	var wallDB WallDB
 	wallDB.FromDomainModel(wall)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, wallDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, wallDB)
+	return translateError(err)
 }
 
-// Delete removes a wall record from the database based on its UUID.
+// Delete moves a wall record to the trash based on its UUID.
 // It takes a context and the wall's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no live wall has the given UUID.
 func (adapter wallAdapter) Delete(ctx context.Context, wallUUID string) error {
 	const query = `
-        DELETE FROM wall WHERE UUID = UUID_TO_BIN(?)
+        UPDATE wall SET deletedAt = UTC_TIMESTAMP(6) WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, wallUUID)
-	return err
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, wallUUID)
+	return checkAffected(result, err, "wall", wallUUID)
+}
+
+// Restore brings back a wall record from the trash based on its UUID.
+// It takes a context and the wall's UUID, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no wall in the trash has the given UUID.
+func (adapter wallAdapter) Restore(ctx context.Context, wallUUID string) error {
+	const query = `
+        UPDATE wall SET deletedAt = NULL WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NOT NULL
+    `
+	result, err := adapter.client.conn(ctx).ExecContext(ctx, query, wallUUID)
+	return checkAffected(result, err, "wall", wallUUID)
+}
+
+// Purge permanently removes the wall records moved to the trash before the given time from the database.
+// It takes a context and the time, and returns the number of removed records and an error if the operation fails.
+func (adapter wallAdapter) Purge(ctx context.Context, before time.Time) (int, error) {
+	const query = `
+        DELETE FROM wall WHERE deletedAt < ?
+    `
+	return purged(adapter.client.conn(ctx).ExecContext(ctx, query, before))
 }
 
 // Update updates an existing wall record in the database.
 // It takes a context, the wall's UUID, and the updated model.Wall, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no wall has the given UUID.
 func (adapter wallAdapter) Update(ctx context.Context, wallUUID string, updates model.Wall) error {
 	const query = `
         UPDATE wall SET 
                              name = COALESCE(:name, name), 
                              description = COALESCE(:description, description)
-                        WHERE UUID = UUID_TO_BIN(:UUID)
+                        WHERE UUID = UUID_TO_BIN(:UUID) AND deletedAt IS NULL
     `
 	updates.ID = wallUUID
 	var wallDB WallDB
 	wallDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, wallDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, wallDB)
+	return checkAffected(result, err, "wall", wallUUID)
 }
 
-// FindAll retrieves all wall records from the database.
-// It takes a context and returns a slice of model.Wall and an error if the operation fails.
-func (adapter wallAdapter) FindAll(ctx context.Context) ([]*model.Wall, error) {
-	const query = `
-        SELECT * FROM wall
-    `
+// wallListing describes how walls can be filtered and sorted.
+var wallListing = listing{
+	table:      "wall",
+	nameColumn: "name",
+	sortable: map[string]string{
+		model.SortByName:      "name",
+		model.SortByCreatedAt: "createdAt",
+	},
+}
+
+// FindAll retrieves the wall records matching the options from the database.
+// It takes a context and the list options, and returns a slice of model.Wall, the number of matching records
+// and an error if the operation fails.
+func (adapter wallAdapter) FindAll(ctx context.Context, opts model.ListOptions) ([]*model.Wall, int, error) {
 	var wallsDB []*WallDB
-	if err := adapter.client.db.SelectContext(ctx, &wallsDB, query); err != nil {
-		return nil, err
+	total, err := adapter.client.selectPage(ctx, &wallsDB, wallListing, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 	var walls []*model.Wall
 	for _, wallDB := range wallsDB {
 		mappedWall := wallDB.ToDomainModel()
 		walls = append(walls, &mappedWall)
 	}
-	return walls, nil
+	return walls, total, nil
 }
 
 // Find retrieves a wall record from the database by its UUID.
 // It takes a context and the wall's UUID, and returns a model.Wall and an error if the operation fails.
 func (adapter wallAdapter) Find(ctx context.Context, wallUUID string) (*model.Wall, error) {
 	const query = `
-        SELECT * FROM wall WHERE UUID = UUID_TO_BIN(?)
+        SELECT * FROM wall WHERE UUID = UUID_TO_BIN(?) AND deletedAt IS NULL
     `
 	var wallsDB []*WallDB
-	if err := adapter.client.db.SelectContext(ctx, &wallsDB, query, wallUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &wallsDB, query, wallUUID); err != nil {
+		return nil, translateError(err)
 	}
 	if len(wallsDB) == 0 {
-		return nil, nil
+		return nil, notFound("wall", wallUUID)
 	}
 	result := wallsDB[0].ToDomainModel()
 	return &result, nil
 }
 
+// FindByIDs retrieves the wall records from the database matching the given UUIDs.
+// It takes a context and the walls' UUIDs, and returns a slice of model.Wall and an error if the operation fails.
+// No query is run when ids is empty.
+func (adapter wallAdapter) FindByIDs(ctx context.Context, ids []string) ([]*model.Wall, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders, args := uuidList(ids)
+	query := fmt.Sprintf(`
+        SELECT * FROM wall WHERE UUID IN (%s) AND deletedAt IS NULL;
+    `, placeholders)
+	var wallsDB []*WallDB
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &wallsDB, query, args...); err != nil {
+		return nil, err
+	}
+	var walls []*model.Wall
+	for _, wallDB := range wallsDB {
+		mappedWall := wallDB.ToDomainModel()
+		walls = append(walls, &mappedWall)
+	}
+	return walls, nil
+}
+
 // WallDB is a struct representing the wall database model.
 type WallDB struct {
 	UUID        uuid.UUID      `db:"UUID"`
@@ -104,6 +160,7 @@ type WallDB struct {
 	Description sql.NullString `db:"description"`
 	CreatedAt   sql.NullTime   `db:"createdAt"`
 	UpdatedAt   sql.NullTime   `db:"updatedAt"`
+	DeletedAt   sql.NullTime   `db:"deletedAt"`
 }
 
 // ToDomainModel converts a WallDB database model to a model.Wall domain model.
@@ -113,6 +170,8 @@ func (db *WallDB) ToDomainModel() model.Wall {
 		ID:          db.UUID.String(),
 		Name:        db.Name.String,
 		Description: db.Description.String,
+		CreatedAt:   db.CreatedAt.Time,
+		DeletedAt:   db.DeletedAt.Time,
 	}
 }
 
@@ -3,7 +3,8 @@ package mysql
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
@@ -32,8 +33,8 @@ func (adapter *wallBlockAdapter) Create(ctx context.Context, wallBlock model.Wal
     `
 	var wallBlockDB WallBlockDB
 	wallBlockDB.FromDomainModel(wallBlock)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, wallBlockDB)
-	return err
+	_, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, wallBlockDB)
+	return translateError(err)
 }
 
 // Delete removes a wallBlock record from the database based on its UUID.
@@ -42,12 +43,13 @@ func (adapter *wallBlockAdapter) Delete(ctx context.Context, wallBlockUUID strin
 	const query = `
         DELETE FROM wall_block WHERE UUID = UUID_TO_BIN(?)
     `
-	_, err := adapter.client.db.ExecContext(ctx, query, wallBlockUUID)
-	return err
+	_, err := adapter.client.conn(ctx).ExecContext(ctx, query, wallBlockUUID)
+	return translateError(err)
 }
 
 // Update updates an existing wallBlock record in the database.
 // It takes a context, the wallBlock's UUID, and the updated model.WallBlock, and returns an error if the operation fails.
+// It returns model.ErrNotFound when no wall_block has the given UUID.
 func (adapter *wallBlockAdapter) Update(ctx context.Context, wallBlockUUID string, updates model.WallBlock) error {
 	const query = `
         UPDATE wall_block SET 
@@ -59,8 +61,8 @@ func (adapter *wallBlockAdapter) Update(ctx context.Context, wallBlockUUID strin
 	updates.ID = wallBlockUUID
 	var wallBlockDB WallBlockDB
 	wallBlockDB.FromDomainModel(updates)
-	_, err := adapter.client.db.NamedExecContext(ctx, query, wallBlockDB)
-	return err
+	result, err := adapter.client.conn(ctx).NamedExecContext(ctx, query, wallBlockDB)
+	return checkAffected(result, err, "wall_block", wallBlockUUID)
 }
 
 // Find retrieves a wallBlock record from the database by its UUID.
@@ -70,11 +72,14 @@ func (adapter *wallBlockAdapter) Find(ctx context.Context, wallBlockUUID string)
         SELECT * FROM wall_block WHERE UUID = UUID_TO_BIN(?)
     `
 	var wallBlockDB WallBlockDB
-	if err := adapter.client.db.GetContext(ctx, &wallBlockDB, query, wallBlockUUID); err != nil {
-		return nil, err
+	if err := adapter.client.conn(ctx).GetContext(ctx, &wallBlockDB, query, wallBlockUUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFound("wall_block", wallBlockUUID)
+		}
+		return nil, translateError(err)
 	}
 	if wallBlockDB.UUID == uuid.Nil {
-		return nil, fmt.Errorf("wallBlock with ID %s not found", wallBlockUUID)
+		return nil, notFound("wall_block", wallBlockUUID)
 	}
 	result := wallBlockDB.ToDomainModel()
 	return &result, nil
@@ -84,10 +89,10 @@ func (adapter *wallBlockAdapter) Find(ctx context.Context, wallBlockUUID string)
 // It takes a context and the wall's ID, and returns a slice of model.WallBlock and an error if the operation fails.
 func (adapter *wallBlockAdapter) FindByWallID(ctx context.Context, wallID string) ([]*model.WallBlock, error) {
 	const query = `
-        SELECT * FROM wall_block WHERE wallUUID = UUID_TO_BIN(?)
+        SELECT * FROM wall_block WHERE wallUUID = UUID_TO_BIN(?) ORDER BY position
     `
 	var wallBlocksDB []*WallBlockDB
-	if err := adapter.client.db.SelectContext(ctx, &wallBlocksDB, query, wallID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &wallBlocksDB, query, wallID); err != nil {
 		return nil, err
 	}
 	var wallBlocks []*model.WallBlock
@@ -102,10 +107,10 @@ func (adapter *wallBlockAdapter) FindByWallID(ctx context.Context, wallID string
 // It takes a context and the block's ID, and returns a slice of model.WallBlock and an error if the operation fails.
 func (adapter *wallBlockAdapter) FindByBlockID(ctx context.Context, blockID string) ([]*model.WallBlock, error) {
 	const query = `
-        SELECT * FROM wall_block WHERE blockUUID = UUID_TO_BIN(?)
+        SELECT * FROM wall_block WHERE blockUUID = UUID_TO_BIN(?) ORDER BY position
     `
 	var wallBlocksDB []*WallBlockDB
-	if err := adapter.client.db.SelectContext(ctx, &wallBlocksDB, query, blockID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &wallBlocksDB, query, blockID); err != nil {
 		return nil, err
 	}
 	var wallBlocks []*model.WallBlock
@@ -120,10 +125,10 @@ func (adapter *wallBlockAdapter) FindByBlockID(ctx context.Context, blockID stri
 // It takes a context, the wall's ID, and the block's ID, and returns a slice of model.WallBlock and an error if the operation fails.
 func (adapter *wallBlockAdapter) FindByWallIDAndBlockID(ctx context.Context, wallID, blockID string) ([]*model.WallBlock, error) {
 	const query = `
-        SELECT * FROM wall_block WHERE wallUUID = UUID_TO_BIN(?) AND blockUUID = UUID_TO_BIN(?)
+        SELECT * FROM wall_block WHERE wallUUID = UUID_TO_BIN(?) AND blockUUID = UUID_TO_BIN(?) ORDER BY position
     `
 	var wallBlocksDB []*WallBlockDB
-	if err := adapter.client.db.SelectContext(ctx, &wallBlocksDB, query, wallID, blockID); err != nil {
+	if err := adapter.client.conn(ctx).SelectContext(ctx, &wallBlocksDB, query, wallID, blockID); err != nil {
 		return nil, err
 	}
 	var wallBlocks []*model.WallBlock
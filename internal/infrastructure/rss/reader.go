@@ -0,0 +1,287 @@
+// Package rss provides a reader of the RSS podcast feeds published by other hosts, used to import their shows.
+// It understands the RSS 2.0 elements along with the iTunes, Atom and Podcasting 2.0 extensions the importer needs.
+package rss
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// Namespaces of the extensions read from the feeds.
+const (
+	itunesNamespace  = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+	atomNamespace    = "http://www.w3.org/2005/Atom"
+	podcastNamespace = "https://podcastindex.org/namespace/1.0"
+)
+
+// maxFeedSize is the largest feed downloaded by Fetch, which guards against endless responses.
+const maxFeedSize = 32 << 20
+
+// pubDateLayouts are the layouts the publication dates of the items are parsed with, the RFC 822 dates of
+// RSS 2.0 first, followed by the variants commonly found in the wild.
+var pubDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04 MST",
+	time.RFC3339,
+}
+
+// reader is an implementation of the FeedReader interface.
+type reader struct {
+	client *http.Client
+}
+
+// NewReader creates a new instance of FeedReader.
+// It takes the timeout of the requests downloading the feeds.
+func NewReader(timeout time.Duration) port.FeedReader {
+	return &reader{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Read parses the RSS feed read from r.
+// It returns model.ErrInvalidFeed when the document is not a well-formed RSS feed.
+func (reader *reader) Read(_ context.Context, r io.Reader) (model.ImportedFeed, error) {
+	return parse(r, "")
+}
+
+// Fetch downloads and parses the RSS feed published at link.
+// The URL of the feed identifies the podcast when the feed does not carry its own identifier.
+// It returns model.ErrInvalidFeed when the server does not serve a well-formed RSS feed at link.
+func (reader *reader) Fetch(ctx context.Context, link string) (model.ImportedFeed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return model.ImportedFeed{}, err
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/xml;q=0.9, */*;q=0.8")
+	resp, err := reader.client.Do(req)
+	if err != nil {
+		return model.ImportedFeed{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return model.ImportedFeed{}, fmt.Errorf("%w: feed download answered with status %s", model.ErrInvalidFeed, resp.Status)
+	}
+	return parse(io.LimitReader(resp.Body, maxFeedSize), link)
+}
+
+// rssDocument is the root element of an RSS feed.
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// rssChannel is the channel of an RSS feed, its elements being looked up by namespace and name.
+type rssChannel struct {
+	Items    []rssItem    `xml:"item"`
+	Elements []rssElement `xml:",any"`
+}
+
+// rssItem is an item of an RSS feed, its elements being looked up by namespace and name.
+type rssItem struct {
+	Elements []rssElement `xml:",any"`
+}
+
+// rssElement is an element of a channel or an item, holding the attributes of the elements read by the importer.
+// Elements are kept along with their namespace, which tells the RSS elements from the elements of the extensions
+// sharing their name, such as link and atom:link.
+type rssElement struct {
+	XMLName xml.Name
+	Href    string `xml:"href,attr"`
+	Rel     string `xml:"rel,attr"`
+	URL     string `xml:"url,attr"`
+	Length  string `xml:"length,attr"`
+	Type    string `xml:"type,attr"`
+	Value   string `xml:",chardata"`
+}
+
+// elements is a list of elements looked up by namespace and name.
+type elements []rssElement
+
+// text returns the trimmed text of the first element with the given namespace and name holding some,
+// the namespace being empty for the RSS elements.
+func (e elements) text(space, local string) string {
+	for _, element := range e.all(space, local) {
+		if value := strings.TrimSpace(element.Value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// all returns the elements with the given namespace and name. Namespaces are compared regardless of case,
+// as older feeds declare the iTunes namespace with capitals.
+func (e elements) all(space, local string) []rssElement {
+	var found []rssElement
+	for _, element := range e {
+		if element.XMLName.Local == local && strings.EqualFold(element.XMLName.Space, space) {
+			found = append(found, element)
+		}
+	}
+	return found
+}
+
+// parse decodes the RSS feed read from r. link is the URL the feed was downloaded from, empty when unknown.
+func parse(r io.Reader, link string) (model.ImportedFeed, error) {
+	decoder := xml.NewDecoder(bufio.NewReader(r))
+	decoder.CharsetReader = charsetReader
+	decoder.Strict = false
+	var document rssDocument
+	if err := decoder.Decode(&document); err != nil {
+		var syntaxErr *xml.SyntaxError
+		var unmarshalErr xml.UnmarshalError
+		if errors.As(err, &syntaxErr) || errors.As(err, &unmarshalErr) || errors.Is(err, io.EOF) {
+			return model.ImportedFeed{}, fmt.Errorf("%w: %s", model.ErrInvalidFeed, err.Error())
+		}
+		return model.ImportedFeed{}, err
+	}
+
+	channel := elements(document.Channel.Elements)
+	feed := model.ImportedFeed{
+		GUID:        channel.text(podcastNamespace, "guid"),
+		Title:       channel.text("", "title"),
+		Description: firstOf(channel.text("", "description"), channel.text(itunesNamespace, "summary")),
+		Keywords:    keywords(channel.text(itunesNamespace, "keywords")),
+	}
+	if feed.GUID == "" {
+		for _, atomLink := range channel.all(atomNamespace, "link") {
+			if atomLink.Rel == "self" && atomLink.Href != "" {
+				feed.GUID = atomLink.Href
+				break
+			}
+		}
+	}
+	feed.GUID = firstOf(feed.GUID, link, channel.text("", "link"))
+
+	for _, rssItem := range document.Channel.Items {
+		if item, ok := parseItem(rssItem.Elements); ok {
+			feed.Items = append(feed.Items, item)
+		}
+	}
+	return feed, nil
+}
+
+// parseItem decodes an item, reporting false when it has neither a GUID nor an enclosure to be identified by.
+func parseItem(e elements) (model.ImportedItem, bool) {
+	item := model.ImportedItem{
+		GUID:        e.text("", "guid"),
+		Title:       firstOf(e.text("", "title"), e.text(itunesNamespace, "title")),
+		Description: firstOf(e.text("", "description"), e.text(itunesNamespace, "summary")),
+		PublishedAt: pubDate(e.text("", "pubDate")),
+		Duration:    duration(e.text(itunesNamespace, "duration")),
+	}
+	for _, enclosure := range e.all("", "enclosure") {
+		if enclosure.URL == "" {
+			continue
+		}
+		size, _ := strconv.ParseInt(strings.TrimSpace(enclosure.Length), 10, 64)
+		item.Enclosures = append(item.Enclosures, model.ImportedEnclosure{
+			URL:      strings.TrimSpace(enclosure.URL),
+			MimeType: strings.TrimSpace(enclosure.Type),
+			Size:     max(size, 0),
+		})
+	}
+	if item.GUID == "" && len(item.Enclosures) > 0 {
+		item.GUID = item.Enclosures[0].URL
+	}
+	return item, item.GUID != ""
+}
+
+// keywords splits comma separated iTunes keywords.
+func keywords(s string) []string {
+	var words []string
+	for _, word := range strings.Split(s, ",") {
+		if word = strings.TrimSpace(word); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// pubDate parses the publication date of an item, returning the zero time when it is malformed.
+func pubDate(s string) time.Time {
+	for _, layout := range pubDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// duration parses an iTunes duration, either a number of seconds or [[HH:]MM:]SS, returning 0 when it is malformed.
+func duration(s string) time.Duration {
+	var seconds float64
+	for _, part := range strings.Split(s, ":") {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value < 0 {
+			return 0
+		}
+		seconds = seconds*60 + value
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// firstOf returns the first non-empty string.
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// charsetReader converts the feeds declaring a Latin-1 encoding to UTF-8, Windows-1252 being read as Latin-1.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252", "cp1252", "us-ascii":
+		return &latin1Reader{r: bufio.NewReader(input)}, nil
+	}
+	return nil, fmt.Errorf("unsupported charset %q", charset)
+}
+
+// latin1Reader decodes Latin-1 text to UTF-8.
+type latin1Reader struct {
+	r       *bufio.Reader
+	pending []byte
+}
+
+// Read fills p with the UTF-8 encoding of the Latin-1 bytes read so far.
+func (l *latin1Reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(l.pending) > 0 {
+			copied := copy(p[n:], l.pending)
+			l.pending = l.pending[copied:]
+			n += copied
+			continue
+		}
+		b, err := l.r.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		l.pending = utf8.AppendRune(l.pending[:0], rune(b))
+	}
+	return n, nil
+}
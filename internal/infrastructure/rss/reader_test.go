@@ -0,0 +1,161 @@
+package rss
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+)
+
+// feed is an RSS feed whose items are listed newest first, declaring the iTunes namespace with the capitals of
+// older feeds.
+const feed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/DTDs/Podcast-1.0.dtd" xmlns:atom="http://www.w3.org/2005/Atom">
+  <channel>
+    <atom:link href="https://host.example.com/show.xml" rel="self" type="application/rss+xml"/>
+    <title>Morning show</title>
+    <link>https://show.example.com</link>
+    <description><![CDATA[The <b>morning</b> show]]></description>
+    <itunes:keywords>news, politics,, Culture </itunes:keywords>
+    <item>
+      <title>Second</title>
+      <itunes:title>Second episode</itunes:title>
+      <guid isPermaLink="false">ep-2</guid>
+      <pubDate>Thu, 2 May 2024 08:00:00 GMT</pubDate>
+      <itunes:duration>1:02:03</itunes:duration>
+      <enclosure url="https://cdn.example.com/ep-2.mp3" length="2048" type="audio/mpeg"/>
+    </item>
+    <item>
+      <title>First</title>
+      <itunes:summary>The first one</itunes:summary>
+      <pubDate>Wed, 01 May 2024 08:00:00 +0200</pubDate>
+      <itunes:duration>90</itunes:duration>
+      <enclosure url="https://cdn.example.com/ep-1.mp3" length="" type="audio/mpeg"/>
+    </item>
+    <item>
+      <title>Announcement</title>
+    </item>
+  </channel>
+</rss>`
+
+func TestReader_Read(t *testing.T) {
+	got, err := NewReader(time.Second).Read(context.Background(), strings.NewReader(feed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := model.ImportedFeed{
+		GUID:        "https://host.example.com/show.xml",
+		Title:       "Morning show",
+		Description: "The <b>morning</b> show",
+		Keywords:    []string{"news", "politics", "Culture"},
+		Items: []model.ImportedItem{
+			{
+				GUID:        "ep-2",
+				Title:       "Second",
+				PublishedAt: time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC),
+				Duration:    time.Hour + 2*time.Minute + 3*time.Second,
+				Enclosures:  []model.ImportedEnclosure{{URL: "https://cdn.example.com/ep-2.mp3", MimeType: "audio/mpeg", Size: 2048}},
+			},
+			{
+				GUID:        "https://cdn.example.com/ep-1.mp3",
+				Title:       "First",
+				Description: "The first one",
+				PublishedAt: time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC),
+				Duration:    90 * time.Second,
+				Enclosures:  []model.ImportedEnclosure{{URL: "https://cdn.example.com/ep-1.mp3", MimeType: "audio/mpeg"}},
+			},
+		},
+	}
+	for i := range got.Items {
+		got.Items[i].PublishedAt = got.Items[i].PublishedAt.UTC()
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestReader_Read_Identifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel string
+		want    string
+	}{
+		{
+			name:    "prefers the podcast GUID",
+			channel: `<podcast:guid>917393e3-1b1e-5cef-ace4-edaa54e1f810</podcast:guid><atom:link href="https://host.example.com/show.xml" rel="self"/><link>https://show.example.com</link>`,
+			want:    "917393e3-1b1e-5cef-ace4-edaa54e1f810",
+		},
+		{
+			name:    "ignores the Atom links other than self",
+			channel: `<atom:link href="https://hub.example.com" rel="hub"/><link>https://show.example.com</link>`,
+			want:    "https://show.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:podcast="https://podcastindex.org/namespace/1.0"><channel><title>Show</title>` + tt.channel + `</channel></rss>`
+
+			got, err := NewReader(time.Second).Read(context.Background(), strings.NewReader(document))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.GUID != tt.want {
+				t.Fatalf("got GUID %q, want %q", got.GUID, tt.want)
+			}
+		})
+	}
+}
+
+func TestReader_Read_Latin1(t *testing.T) {
+	document := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><rss version=\"2.0\"><channel><title>Caf\xe9</title></channel></rss>"
+
+	got, err := NewReader(time.Second).Read(context.Background(), strings.NewReader(document))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Title != "Café" {
+		t.Fatalf("got title %q, want %q", got.Title, "Café")
+	}
+}
+
+func TestReader_Read_Invalid(t *testing.T) {
+	for _, document := range []string{"", "<html><body>Not a feed</body></html>", "<rss><channel><title>Broken</channel>"} {
+		_, err := NewReader(time.Second).Read(context.Background(), strings.NewReader(document))
+
+		if !errors.Is(err, model.ErrInvalidFeed) {
+			t.Fatalf("got error %v for %q, want %v", err, document, model.ErrInvalidFeed)
+		}
+	}
+}
+
+func TestReader_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/show.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Show</title><link>https://show.example.com</link></channel></rss>`))
+	}))
+	defer server.Close()
+
+	got, err := NewReader(time.Second).Fetch(context.Background(), server.URL+"/show.xml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.GUID != server.URL+"/show.xml" {
+		t.Fatalf("got GUID %q, want the URL of the feed", got.GUID)
+	}
+
+	_, err = NewReader(time.Second).Fetch(context.Background(), server.URL+"/missing.xml")
+	if !errors.Is(err, model.ErrInvalidFeed) {
+		t.Fatalf("got error %v, want %v", err, model.ErrInvalidFeed)
+	}
+}
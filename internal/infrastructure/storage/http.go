@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// httpChunkSize is the number of bytes fetched by every range request.
+const httpChunkSize = 256 << 10
+
+// httpFetcher is an implementation of the MediaFetcher interface reading the files served over HTTP
+// chunk by chunk, with range requests.
+type httpFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher creates a new instance of MediaFetcher.
+// It takes the timeout of every request made to the servers of the medias.
+func NewHTTPFetcher(timeout time.Duration) port.MediaFetcher {
+	return &httpFetcher{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Open returns a reader of the file served at link. The first chunk of the file is fetched right away, which
+// fails when the server does not support range requests.
+func (fetcher *httpFetcher) Open(ctx context.Context, link string) (io.ReadSeekCloser, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	file := &httpFile{ctx: ctx, client: fetcher.client, link: link}
+	if err := file.fetch(0); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// httpFile reads a file served over HTTP, keeping the last fetched chunk.
+type httpFile struct {
+	ctx        context.Context
+	client     *http.Client
+	link       string
+	size       int64
+	offset     int64
+	chunk      []byte
+	chunkStart int64
+}
+
+// fetch fetches the chunk of the file starting at start, and learns the size of the file on the way.
+func (file *httpFile) fetch(start int64) error {
+	req, err := http.NewRequestWithContext(file.ctx, http.MethodGet, file.link, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, start+httpChunkSize-1))
+	resp, err := file.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent && resp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		return fmt.Errorf("range request answered with status %s", resp.Status)
+	}
+	_, total, _ := strings.Cut(resp.Header.Get("Content-Range"), "/")
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return fmt.Errorf("range request answered without the size of the file: %w", err)
+	}
+	chunk, err := io.ReadAll(io.LimitReader(resp.Body, httpChunkSize))
+	if err != nil {
+		return err
+	}
+	file.size, file.chunk, file.chunkStart = size, chunk, start
+	return nil
+}
+
+// Read reads from the current chunk, fetching the chunk at the current offset when it is not the current one.
+func (file *httpFile) Read(p []byte) (int, error) {
+	if file.offset >= file.size {
+		return 0, io.EOF
+	}
+	if file.offset < file.chunkStart || file.offset >= file.chunkStart+int64(len(file.chunk)) {
+		if err := file.fetch(file.offset); err != nil {
+			return 0, err
+		}
+		if len(file.chunk) == 0 {
+			return 0, io.ErrUnexpectedEOF
+		}
+	}
+	n := copy(p, file.chunk[file.offset-file.chunkStart:])
+	file.offset += int64(n)
+	return n, nil
+}
+
+// Seek sets the offset of the next Read.
+func (file *httpFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += file.offset
+	case io.SeekEnd:
+		offset += file.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative offset")
+	}
+	file.offset = offset
+	return offset, nil
+}
+
+// Close releases nothing, as every chunk is fetched with its own request.
+func (file *httpFile) Close() error {
+	return nil
+}
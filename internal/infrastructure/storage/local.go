@@ -0,0 +1,106 @@
+// Package storage provides implementations of the object store the catalogue files are kept in.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+)
+
+// localStore is an object store keeping every object as a file under a root directory.
+// Objects are served from publicURL, by the service itself or by a front web server sharing the directory.
+type localStore struct {
+	root      string
+	publicURL string
+}
+
+// NewLocalStore creates an object store writing to the directory root, created when missing.
+// It returns an implementation of the ObjectStore interface, whose objects are served from publicURL.
+func NewLocalStore(root, publicURL string) (port.ObjectStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("error occurred while creating the object store directory: %w", err)
+	}
+	return &localStore{
+		root:      root,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}, nil
+}
+
+// Put writes the content read from r to the file of key, and returns the URL it is served from.
+// The content is written to a temporary file first and renamed once complete, so that a failed upload
+// never leaves a truncated object behind.
+func (store *localStore) Put(_ context.Context, key string, r io.Reader, _ string) (string, error) {
+	name, err := store.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return "", err
+	}
+	return store.publicURL + "/" + (&url.URL{Path: key}).EscapedPath(), nil
+}
+
+// Open opens the file of key for reading.
+func (store *localStore) Open(_ context.Context, key string) (io.ReadSeekCloser, error) {
+	name, err := store.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, model.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Delete removes the file of key, if any.
+func (store *localStore) Delete(_ context.Context, key string) error {
+	name, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the file of key, refusing keys that would escape the root directory.
+func (store *localStore) path(key string) (string, error) {
+	if key == "" || !fs.ValidPath(key) || path.Clean(key) != key {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(store.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,72 @@
+// Package handlers provides HTTP request handlers for reading the audit log.
+package handlers
+
+import (
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/api"
+	"github.com/rs/zerolog/log"
+)
+
+// Audit represents the interface for reading the audit log.
+type Audit interface {
+	// FindAll returns a Gin handler function for finding a page of audit entries.
+	FindAll() gin.HandlerFunc
+}
+
+// auditHandler is an implementation of the Audit interface.
+type auditHandler struct {
+	api api.Audit
+}
+
+// NewAuditHandler creates a new instance of Audit interface.
+func NewAuditHandler(api api.Audit) Audit {
+	return &auditHandler{
+		api: api,
+	}
+}
+
+// FindAll returns a Gin handler function for finding a page of audit entries.
+//
+// @Summary Find audit entries
+// @Description Find a page of the audit log, most recent first. Each entry records who created, updated, deleted
+// @Description or overwrote the associations of an entity, with the changed fields before and after the operation.
+// @Tags audit
+// @ID find-all-audit-entries
+// @Produce json
+// @Param limit query int false "maximum number of entries, 20 by default and at most 100"
+// @Param offset query int false "number of entries to skip"
+// @Param entity query string false "only keep entries about this kind of entity" Enums(wall, block, program, episode, media, tag, category)
+// @Param entityID query string false "only keep entries about the entity with this UUID"
+// @Param actor query string false "only keep entries recorded for this actor"
+// @Success 200 {object} pkg.PageResponse[pkg.AuditEntryResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 403 {object} pkg.ErrorJSON "Forbidden"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/audit [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler auditHandler) FindAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var auditRequest pkg.AuditRequestJSON
+		if err := c.ShouldBindQuery(&auditRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of audit entries
+		entries, err := handler.api.FindAll(c, auditRequest)
+		if err != nil {
+			log.Error().Msg("error finding audit entries: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		paginate(c, entries)
+		c.JSON(http.StatusOK, entries)
+	}
+}
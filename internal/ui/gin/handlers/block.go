@@ -21,17 +21,32 @@ type Block interface {
 	// Find returns a Gin handler function for finding a block by its UUID.
 	Find() gin.HandlerFunc
 
-	// FindAll returns a Gin handler function for finding all blocks.
+	// FindAll returns a Gin handler function for finding a page of blocks.
 	FindAll() gin.HandlerFunc
 
 	// Delete returns a Gin handler function for deleting a block by its UUID.
 	Delete() gin.HandlerFunc
 
+	// Restore returns a Gin handler function for restoring a block from the trash by its UUID.
+	Restore() gin.HandlerFunc
+
+	// FindDeleted returns a Gin handler function for finding a page of the blocks in the trash.
+	FindDeleted() gin.HandlerFunc
+
 	// FindPrograms returns a Gin handler function for finding all programs of a block.
 	FindPrograms() gin.HandlerFunc
 
 	// OverwritePrograms returns a Gin handler function for overwriting the programs of a block.
 	OverwritePrograms() gin.HandlerFunc
+
+	// ReorderPrograms returns a Gin handler function for reordering the programs of a block.
+	ReorderPrograms() gin.HandlerFunc
+
+	// AddProgram returns a Gin handler function for adding a program to a block.
+	AddProgram() gin.HandlerFunc
+
+	// RemoveProgram returns a Gin handler function for removing a program from a block.
+	RemoveProgram() gin.HandlerFunc
 }
 
 // blockHandler is an implementation of the Block interface.
@@ -55,6 +70,8 @@ func NewBlockHandler(api api.Block) Block {
 // @Param request body pkg.CreateBlockRequestJSON true "create request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/blocks [post]
 //
@@ -70,9 +87,9 @@ func (handler blockHandler) Create() gin.HandlerFunc {
 		}
 
 		// Call API to create block
-		if err := handler.api.Create(c, jsonRequest); err != nil {
+		if _, err := handler.api.Create(c, jsonRequest); err != nil {
 			log.Error().Msg("error creating block: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -89,6 +106,9 @@ func (handler blockHandler) Create() gin.HandlerFunc {
 // @Param request body pkg.UpdateBlockRequestJSON true "update request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/blocks/{uuid} [put]
 //
@@ -109,7 +129,7 @@ func (handler blockHandler) Update() gin.HandlerFunc {
 		// Call API to update block
 		if err := handler.api.Update(c, blockUUID, jsonRequest); err != nil {
 			log.Error().Msg("error updating block: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -125,6 +145,7 @@ func (handler blockHandler) Update() gin.HandlerFunc {
 // @Param uuid path string true "uuid"
 // @Produce json
 // @Success 200 {object} pkg.BlockResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/blocks/{uuid} [get]
 //
@@ -138,7 +159,7 @@ func (handler blockHandler) Find() gin.HandlerFunc {
 		block, err := handler.api.Find(c, blockUUID)
 		if err != nil {
 			log.Error().Msg("error finding block: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -147,29 +168,44 @@ func (handler blockHandler) Find() gin.HandlerFunc {
 	}
 }
 
-// FindAll returns a Gin handler function for finding all blocks.
+// FindAll returns a Gin handler function for finding a page of blocks.
 //
 // @Summary Find all blocks
-// @Description Find all blocks
+// @Description Find a page of blocks, filtered and sorted by the query parameters
 // @Tags blocks
 // @ID find-all-blocks
 // @Produce json
-// @Success 200 {array} pkg.BlockResponse
+// @Param limit query int false "maximum number of blocks, 20 by default and at most 100"
+// @Param offset query int false "number of blocks to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, createdAt by default" Enums(name, -name, createdAt, -createdAt, kind, -kind)
+// @Param name query string false "only keep blocks whose name contains this text"
+// @Param createdFrom query string false "only keep blocks created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep blocks created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.BlockResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/blocks [get]
 //
 // @Security Bearer-APIKey || Bearer-JWT
 func (handler blockHandler) FindAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Call API to find all blocks
-		blocks, err := handler.api.FindAll(c)
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of blocks
+		blocks, err := handler.api.FindAll(c, listRequest)
 		if err != nil {
 			log.Error().Msg("error finding all blocks: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
 		// Return response
+		paginate(c, blocks)
 		c.JSON(http.StatusOK, blocks)
 	}
 }
@@ -177,12 +213,15 @@ func (handler blockHandler) FindAll() gin.HandlerFunc {
 // Delete returns a Gin handler function for deleting a block by its UUID.
 //
 // @Summary Delete a block
-// @Description Delete a block
+// @Description Move a block to the trash, refused while other entities still reference it unless cascade is set
 // @Tags blocks
 // @ID delete-block
 // @Param uuid path string true "uuid"
+// @Param cascade query bool false "removes the block from the walls it is placed on instead of refusing the deletion"
 // @Produce json
 // @Success 200 {string} string "deleted"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the dependents of the block"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/blocks/{uuid} [delete]
 func (handler blockHandler) Delete() gin.HandlerFunc {
@@ -190,10 +229,18 @@ func (handler blockHandler) Delete() gin.HandlerFunc {
 		// Extract block UUID from path
 		blockUUID := c.Param("uuid")
 
+		// Extract query parameters
+		var deleteRequest pkg.DeleteRequestJSON
+		if err := c.ShouldBindQuery(&deleteRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
 		// Call API to delete block
-		if err := handler.api.Delete(c, blockUUID); err != nil {
+		if err := handler.api.Delete(c, blockUUID, deleteRequest); err != nil {
 			log.Error().Msg("error deleting block: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -202,6 +249,80 @@ func (handler blockHandler) Delete() gin.HandlerFunc {
 	}
 }
 
+// Restore returns a Gin handler function for restoring a block from the trash by its UUID.
+//
+// @Summary Restore a block
+// @Description Restore a block from the trash
+// @Tags blocks
+// @ID restore-block
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {string} string "restored"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/blocks/{uuid}/restore [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler blockHandler) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract block UUID from path
+		blockUUID := c.Param("uuid")
+
+		// Call API to restore block
+		if err := handler.api.Restore(c, blockUUID); err != nil {
+			log.Error().Msg("error restoring block: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "restored")
+	}
+}
+
+// FindDeleted returns a Gin handler function for finding a page of the blocks in the trash.
+//
+// @Summary Find deleted blocks
+// @Description Find a page of the blocks in the trash, filtered and sorted by the query parameters
+// @Tags blocks
+// @ID find-deleted-blocks
+// @Produce json
+// @Param limit query int false "maximum number of blocks, 20 by default and at most 100"
+// @Param offset query int false "number of blocks to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, -deletedAt by default" Enums(name, -name, createdAt, -createdAt, kind, -kind, deletedAt, -deletedAt)
+// @Param name query string false "only keep blocks whose name contains this text"
+// @Param createdFrom query string false "only keep blocks created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep blocks created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.BlockResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/blocks/trash [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler blockHandler) FindDeleted() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of the blocks in the trash
+		blocks, err := handler.api.FindDeleted(c, listRequest)
+		if err != nil {
+			log.Error().Msg("error finding deleted blocks: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		paginate(c, blocks)
+		c.JSON(http.StatusOK, blocks)
+	}
+}
+
 // FindPrograms returns a Gin handler function for finding all programs of a block.
 //
 // @Summary Find all programs of a block
@@ -224,7 +345,7 @@ func (handler blockHandler) FindPrograms() gin.HandlerFunc {
 		programs, err := handler.api.FindPrograms(c, blockUUID)
 		if err != nil {
 			log.Error().Msg("error finding all programs of the block: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -243,6 +364,8 @@ func (handler blockHandler) FindPrograms() gin.HandlerFunc {
 // @Param request body pkg.OverwriteProgramsRequestJSON true "List of programs' UUIDs to set"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
 // @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
 // @Router /private/blocks/{uuid}/programs/overwrite [put]
@@ -264,7 +387,115 @@ func (handler blockHandler) OverwritePrograms() gin.HandlerFunc {
 		// Call API to overwrite programs
 		if err := handler.api.OverwritePrograms(c, blockUUID, jsonRequest); err != nil {
 			log.Error().Msg("error overwriting programs: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// ReorderPrograms returns a Gin handler function for reordering the programs of a block.
+//
+// @Summary Reorder programs of a block
+// @Description Reorder the programs of a block, identified by their program ID, renumbering their positions from 1
+// @Tags blocks
+// @ID reorder-block-programs
+// @Param uuid path string true "UUID of the block"
+// @Param request body pkg.ReorderRequestJSON true "new order, as a list of IDs or a move between two positions"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, when the IDs do not list every program exactly once or a position is out of range"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/blocks/{uuid}/programs/reorder [patch]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler blockHandler) ReorderPrograms() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract block UUID from path
+		blockUUID := c.Param("uuid")
+
+		// Extract body request
+		var jsonRequest pkg.ReorderRequestJSON
+		if err := c.ShouldBindJSON(&jsonRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to reorder the programs of the block
+		if err := handler.api.ReorderPrograms(c, blockUUID, jsonRequest); err != nil {
+			log.Error().Msg("error reordering block programs: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// AddProgram returns a Gin handler function for add a program to a block.
+//
+// @Summary Add a program to a block
+// @Description Append a program to a block, after its last program; adding a program the block already holds does nothing
+// @Tags blocks
+// @ID add-block-program
+// @Param uuid path string true "UUID of the block"
+// @Param programUUID path string true "UUID of the program"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, when the block or the program does not exist"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/blocks/{uuid}/programs/{programUUID} [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler blockHandler) AddProgram() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract block and program UUIDs from path
+		blockUUID := c.Param("uuid")
+		programUUID := c.Param("programUUID")
+
+		// Call API to add block program
+		if err := handler.api.AddProgram(c, blockUUID, programUUID); err != nil {
+			log.Error().Msg("error adding block program: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// RemoveProgram returns a Gin handler function for remove a program from a block.
+//
+// @Summary Remove a program from a block
+// @Description Remove a program from a block, renumbering the positions of the remaining programs from 1; removing a program the block does not hold does nothing
+// @Tags blocks
+// @ID remove-block-program
+// @Param uuid path string true "UUID of the block"
+// @Param programUUID path string true "UUID of the program"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, when the block does not exist"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/blocks/{uuid}/programs/{programUUID} [delete]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler blockHandler) RemoveProgram() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract block and program UUIDs from path
+		blockUUID := c.Param("uuid")
+		programUUID := c.Param("programUUID")
+
+		// Call API to remove block program
+		if err := handler.api.RemoveProgram(c, blockUUID, programUUID); err != nil {
+			log.Error().Msg("error removing block program: " + err.Error())
+			renderError(c, err)
 			return
 		}
 
@@ -0,0 +1,552 @@
+// Package handlers provides HTTP request handlers for creating, updating and deleting catalogue entities in bulk.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/api"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"github.com/rs/zerolog/log"
+)
+
+// Bulk represents the interface for creating, updating and deleting catalogue entities in bulk.
+type Bulk interface {
+	// CreatePrograms returns a Gin handler function for creating several programs at once.
+	CreatePrograms() gin.HandlerFunc
+
+	// UpdatePrograms returns a Gin handler function for updating several programs at once.
+	UpdatePrograms() gin.HandlerFunc
+
+	// DeletePrograms returns a Gin handler function for deleting several programs at once.
+	DeletePrograms() gin.HandlerFunc
+
+	// CreateEpisodes returns a Gin handler function for creating several episodes at once.
+	CreateEpisodes() gin.HandlerFunc
+
+	// UpdateEpisodes returns a Gin handler function for updating several episodes at once.
+	UpdateEpisodes() gin.HandlerFunc
+
+	// DeleteEpisodes returns a Gin handler function for deleting several episodes at once.
+	DeleteEpisodes() gin.HandlerFunc
+
+	// CreateMedias returns a Gin handler function for creating several medias at once.
+	CreateMedias() gin.HandlerFunc
+
+	// UpdateMedias returns a Gin handler function for updating several medias at once.
+	UpdateMedias() gin.HandlerFunc
+
+	// DeleteMedias returns a Gin handler function for deleting several medias at once.
+	DeleteMedias() gin.HandlerFunc
+
+	// CreateTags returns a Gin handler function for creating several tags at once.
+	CreateTags() gin.HandlerFunc
+
+	// UpdateTags returns a Gin handler function for updating several tags at once.
+	UpdateTags() gin.HandlerFunc
+
+	// DeleteTags returns a Gin handler function for deleting several tags at once.
+	DeleteTags() gin.HandlerFunc
+}
+
+// bulkHandler is an implementation of the Bulk interface.
+type bulkHandler struct {
+	api api.Bulk
+}
+
+// NewBulkHandler creates a new instance of Bulk interface.
+func NewBulkHandler(api api.Bulk) Bulk {
+	return &bulkHandler{
+		api: api,
+	}
+}
+
+// CreatePrograms returns a Gin handler function for creating several programs at once.
+//
+// @Summary Create programs in bulk
+// @Description Create up to 100 programs in a single transaction: when an item fails, none is created and the failed items are listed by index
+// @Tags programs
+// @ID bulk-create-programs
+// @Param request body []pkg.CreateProgramRequestJSON true "create requests"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/programs/bulk [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) CreatePrograms() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract body request
+		reqs, ok := bindBulk(c, func(req pkg.CreateProgramRequestJSON) api.CreateProgramRequest { return req })
+		if !ok {
+			return
+		}
+
+		// Call API to create the programs
+		response, err := handler.api.CreatePrograms(c, reqs)
+		if err != nil {
+			log.Error().Msg("error creating programs: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// UpdatePrograms returns a Gin handler function for updating several programs at once.
+//
+// @Summary Update programs in bulk
+// @Description Update up to 100 programs, identified by their ID, in a single transaction: when an item fails, none is updated and the failed items are listed by index
+// @Tags programs
+// @ID bulk-update-programs
+// @Param request body []pkg.BulkUpdateProgramRequestJSON true "update requests"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/programs/bulk [put]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) UpdatePrograms() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract body request
+		reqs, ok := bindBulk(c, func(req pkg.BulkUpdateProgramRequestJSON) api.BulkUpdateProgramRequest { return req })
+		if !ok {
+			return
+		}
+
+		// Call API to update the programs
+		response, err := handler.api.UpdatePrograms(c, reqs)
+		if err != nil {
+			log.Error().Msg("error updating programs: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// DeletePrograms returns a Gin handler function for deleting several programs at once.
+//
+// @Summary Delete programs in bulk
+// @Description Move up to 100 programs to the trash in a single transaction: when an item fails, none is deleted and the failed items are listed by index
+// @Tags programs
+// @ID bulk-delete-programs
+// @Param request body []string true "IDs of the programs"
+// @Param cascade query bool false "moves the episodes of the programs and their medias to the trash too instead of refusing the deletion"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/programs/bulk [delete]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) DeletePrograms() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract query parameters
+		var deleteRequest pkg.DeleteRequestJSON
+		if err := c.ShouldBindQuery(&deleteRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Extract body request
+		ids, ok := bindBulk(c, func(id string) string { return id })
+		if !ok {
+			return
+		}
+
+		// Call API to delete the programs
+		response, err := handler.api.DeletePrograms(c, ids, deleteRequest)
+		if err != nil {
+			log.Error().Msg("error deleting programs: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// CreateEpisodes returns a Gin handler function for creating several episodes at once.
+//
+// @Summary Create episodes in bulk
+// @Description Create up to 100 episodes in a single transaction: when an item fails, none is created and the failed items are listed by index
+// @Tags episodes
+// @ID bulk-create-episodes
+// @Param request body []pkg.CreateEpisodeRequestJSON true "create requests"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/episodes/bulk [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) CreateEpisodes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract body request
+		reqs, ok := bindBulk(c, func(req pkg.CreateEpisodeRequestJSON) api.CreateEpisodeRequest { return req })
+		if !ok {
+			return
+		}
+
+		// Call API to create the episodes
+		response, err := handler.api.CreateEpisodes(c, reqs)
+		if err != nil {
+			log.Error().Msg("error creating episodes: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// UpdateEpisodes returns a Gin handler function for updating several episodes at once.
+//
+// @Summary Update episodes in bulk
+// @Description Update up to 100 episodes, identified by their ID, in a single transaction: when an item fails, none is updated and the failed items are listed by index
+// @Tags episodes
+// @ID bulk-update-episodes
+// @Param request body []pkg.BulkUpdateEpisodeRequestJSON true "update requests"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/episodes/bulk [put]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) UpdateEpisodes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract body request
+		reqs, ok := bindBulk(c, func(req pkg.BulkUpdateEpisodeRequestJSON) api.BulkUpdateEpisodeRequest { return req })
+		if !ok {
+			return
+		}
+
+		// Call API to update the episodes
+		response, err := handler.api.UpdateEpisodes(c, reqs)
+		if err != nil {
+			log.Error().Msg("error updating episodes: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// DeleteEpisodes returns a Gin handler function for deleting several episodes at once.
+//
+// @Summary Delete episodes in bulk
+// @Description Move up to 100 episodes to the trash in a single transaction: when an item fails, none is deleted and the failed items are listed by index
+// @Tags episodes
+// @ID bulk-delete-episodes
+// @Param request body []string true "IDs of the episodes"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/episodes/bulk [delete]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) DeleteEpisodes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract body request
+		ids, ok := bindBulk(c, func(id string) string { return id })
+		if !ok {
+			return
+		}
+
+		// Call API to delete the episodes
+		response, err := handler.api.DeleteEpisodes(c, ids)
+		if err != nil {
+			log.Error().Msg("error deleting episodes: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// CreateMedias returns a Gin handler function for creating several medias at once.
+//
+// @Summary Create medias in bulk
+// @Description Create up to 100 medias in a single transaction: when an item fails, none is created and the failed items are listed by index
+// @Tags medias
+// @ID bulk-create-medias
+// @Param request body []pkg.CreateMediaRequestJSON true "create requests"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/medias/bulk [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) CreateMedias() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract body request
+		reqs, ok := bindBulk(c, func(req pkg.CreateMediaRequestJSON) api.CreateMediaRequest { return req })
+		if !ok {
+			return
+		}
+
+		// Call API to create the medias
+		response, err := handler.api.CreateMedias(c, reqs)
+		if err != nil {
+			log.Error().Msg("error creating medias: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// UpdateMedias returns a Gin handler function for updating several medias at once.
+//
+// @Summary Update medias in bulk
+// @Description Update up to 100 medias, identified by their ID, in a single transaction: when an item fails, none is updated and the failed items are listed by index
+// @Tags medias
+// @ID bulk-update-medias
+// @Param request body []pkg.BulkUpdateMediaRequestJSON true "update requests"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/medias/bulk [put]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) UpdateMedias() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract body request
+		reqs, ok := bindBulk(c, func(req pkg.BulkUpdateMediaRequestJSON) api.BulkUpdateMediaRequest { return req })
+		if !ok {
+			return
+		}
+
+		// Call API to update the medias
+		response, err := handler.api.UpdateMedias(c, reqs)
+		if err != nil {
+			log.Error().Msg("error updating medias: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// DeleteMedias returns a Gin handler function for deleting several medias at once.
+//
+// @Summary Delete medias in bulk
+// @Description Move up to 100 medias to the trash in a single transaction: when an item fails, none is deleted and the failed items are listed by index
+// @Tags medias
+// @ID bulk-delete-medias
+// @Param request body []string true "IDs of the medias"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/medias/bulk [delete]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) DeleteMedias() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract body request
+		ids, ok := bindBulk(c, func(id string) string { return id })
+		if !ok {
+			return
+		}
+
+		// Call API to delete the medias
+		response, err := handler.api.DeleteMedias(c, ids)
+		if err != nil {
+			log.Error().Msg("error deleting medias: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// CreateTags returns a Gin handler function for creating several tags at once.
+//
+// @Summary Create tags in bulk
+// @Description Create up to 100 tags in a single transaction: when an item fails, none is created and the failed items are listed by index
+// @Tags tags
+// @ID bulk-create-tags
+// @Param request body []pkg.CreateTagRequestJSON true "create requests"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/tags/bulk [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) CreateTags() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract body request
+		reqs, ok := bindBulk(c, func(req pkg.CreateTagRequestJSON) api.CreateTagRequest { return req })
+		if !ok {
+			return
+		}
+
+		// Call API to create the tags
+		response, err := handler.api.CreateTags(c, reqs)
+		if err != nil {
+			log.Error().Msg("error creating tags: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// UpdateTags returns a Gin handler function for updating several tags at once.
+//
+// @Summary Update tags in bulk
+// @Description Update up to 100 tags, identified by their ID, in a single transaction: when an item fails, none is updated and the failed items are listed by index
+// @Tags tags
+// @ID bulk-update-tags
+// @Param request body []pkg.BulkUpdateTagRequestJSON true "update requests"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/tags/bulk [put]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) UpdateTags() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract body request
+		reqs, ok := bindBulk(c, func(req pkg.BulkUpdateTagRequestJSON) api.BulkUpdateTagRequest { return req })
+		if !ok {
+			return
+		}
+
+		// Call API to update the tags
+		response, err := handler.api.UpdateTags(c, reqs)
+		if err != nil {
+			log.Error().Msg("error updating tags: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// DeleteTags returns a Gin handler function for deleting several tags at once.
+//
+// @Summary Delete tags in bulk
+// @Description Move up to 100 tags to the trash in a single transaction: when an item fails, none is deleted and the failed items are listed by index
+// @Tags tags
+// @ID bulk-delete-tags
+// @Param request body []string true "IDs of the tags"
+// @Param cascade query bool false "detaches the tags from the programs they are attached to instead of refusing the deletion"
+// @Produce json
+// @Success 200 {object} pkg.BulkResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, listing the items that failed validation"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, listing the items referencing an entity that does not exist"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the items conflicting with the stored data"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/tags/bulk [delete]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler bulkHandler) DeleteTags() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract query parameters
+		var deleteRequest pkg.DeleteRequestJSON
+		if err := c.ShouldBindQuery(&deleteRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Extract body request
+		ids, ok := bindBulk(c, func(id string) string { return id })
+		if !ok {
+			return
+		}
+
+		// Call API to delete the tags
+		response, err := handler.api.DeleteTags(c, ids, deleteRequest)
+		if err != nil {
+			log.Error().Msg("error deleting tags: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// bindBulk binds the JSON array of the request body and returns its items as the requests of the domain api.
+// It renders a 422 response and returns false when the body is not an array of T.
+func bindBulk[T any, R any](c *gin.Context, as func(T) R) ([]R, bool) {
+	var items []T
+	if err := c.ShouldBindJSON(&items); err != nil {
+		log.Ctx(c).Error().Err(err).Msg("error binding request")
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	reqs := make([]R, 0, len(items))
+	for _, item := range items {
+		reqs = append(reqs, as(item))
+	}
+	return reqs, true
+}
@@ -18,17 +18,35 @@ type Category interface {
 	// Update returns a Gin handler function for updating a category.
 	Update() gin.HandlerFunc
 
+	// Move returns a Gin handler function for moving a category under another parent.
+	Move() gin.HandlerFunc
+
 	// Find returns a Gin handler function for finding a category by its UUID.
 	Find() gin.HandlerFunc
 
-	// FindAll returns a Gin handler function for finding all categories.
+	// FindAll returns a Gin handler function for finding a page of categories.
 	FindAll() gin.HandlerFunc
 
 	// Delete returns a Gin handler function for deleting a category by its UUID.
 	Delete() gin.HandlerFunc
 
+	// Restore returns a Gin handler function for restoring a category from the trash by its UUID.
+	Restore() gin.HandlerFunc
+
+	// FindDeleted returns a Gin handler function for finding a page of the categories in the trash.
+	FindDeleted() gin.HandlerFunc
+
 	// FindPrograms returns a Gin handler function for finding all programs associated with a category.
 	FindPrograms() gin.HandlerFunc
+
+	// FindTree returns a Gin handler function for finding the whole category hierarchy.
+	FindTree() gin.HandlerFunc
+
+	// FindAncestors returns a Gin handler function for finding the ancestors of a category.
+	FindAncestors() gin.HandlerFunc
+
+	// FindDescendants returns a Gin handler function for finding the nested subcategories of a category.
+	FindDescendants() gin.HandlerFunc
 }
 
 // categoryHandler is an implementation of the Category interface.
@@ -52,6 +70,8 @@ func NewCategoryHandler(api api.Category) Category {
 // @Param request body pkg.CreateCategoryRequestJSON true "create request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/categories [post]
 //
@@ -67,9 +87,9 @@ func (handler categoryHandler) Create() gin.HandlerFunc {
 		}
 
 		// Call API to create category
-		if err := handler.api.Create(c, jsonRequest); err != nil {
+		if _, err := handler.api.Create(c, jsonRequest); err != nil {
 			log.Error().Msg("error creating category: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -86,6 +106,9 @@ func (handler categoryHandler) Create() gin.HandlerFunc {
 // @Param request body pkg.UpdateCategoryRequestJSON true "update request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/categories/{uuid} [put]
 //
@@ -106,7 +129,46 @@ func (handler categoryHandler) Update() gin.HandlerFunc {
 		// Call API to update category
 		if err := handler.api.Update(c, categoryUUID, jsonRequest); err != nil {
 			log.Error().Msg("error updating category: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// Move returns a Gin handler function for moving a category under another parent.
+//
+// @Summary Move a category
+// @Description Move a category, along with its subcategories, under another parent, or to the root when the parent is empty
+// @Tags categories
+// @ID move-category
+// @Param uuid path string true "uuid"
+// @Param request body pkg.MoveCategoryRequestJSON true "move request"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, when the parent does not exist, would create a cycle or nest categories too deep"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/categories/{uuid}/move [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler categoryHandler) Move() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract category UUID from path
+		categoryUUID := c.Param("uuid")
+
+		// Extract body request
+		var jsonRequest pkg.MoveCategoryRequestJSON
+		if err := c.ShouldBindJSON(&jsonRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to move category
+		if err := handler.api.Move(c, categoryUUID, jsonRequest); err != nil {
+			log.Error().Msg("error moving category: " + err.Error())
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -122,6 +184,7 @@ func (handler categoryHandler) Update() gin.HandlerFunc {
 // @Param uuid path string true "uuid"
 // @Produce json
 // @Success 200 {object} pkg.CategoryResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/categories/{uuid} [get]
 //
@@ -135,7 +198,7 @@ func (handler categoryHandler) Find() gin.HandlerFunc {
 		category, err := handler.api.Find(c, categoryUUID)
 		if err != nil {
 			log.Error().Msg("error finding category: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -144,29 +207,44 @@ func (handler categoryHandler) Find() gin.HandlerFunc {
 	}
 }
 
-// FindAll returns a Gin handler function for finding all categories.
+// FindAll returns a Gin handler function for finding a page of categories.
 //
 // @Summary Find all categories
-// @Description Find all categories
+// @Description Find a page of categories, filtered and sorted by the query parameters
 // @Tags categories
 // @ID find-all-categories
 // @Produce json
-// @Success 200 {array} pkg.CategoryResponse
+// @Param limit query int false "maximum number of categories, 20 by default and at most 100"
+// @Param offset query int false "number of categories to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, createdAt by default" Enums(name, -name, createdAt, -createdAt)
+// @Param name query string false "only keep categories whose name contains this text"
+// @Param createdFrom query string false "only keep categories created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep categories created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.CategoryResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/categories [get]
 //
 // @Security Bearer-APIKey || Bearer-JWT
 func (handler categoryHandler) FindAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Call API to find all categories
-		categories, err := handler.api.FindAll(c)
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of categories
+		categories, err := handler.api.FindAll(c, listRequest)
 		if err != nil {
 			log.Error().Msg("error finding all categories: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
 		// Return response
+		paginate(c, categories)
 		c.JSON(http.StatusOK, categories)
 	}
 }
@@ -174,12 +252,15 @@ func (handler categoryHandler) FindAll() gin.HandlerFunc {
 // Delete returns a Gin handler function for deleting a category by its UUID.
 //
 // @Summary Delete a category
-// @Description Delete a category
+// @Description Move a category to the trash, refused while other entities still reference it unless cascade is set
 // @Tags categories
 // @ID delete-category
 // @Param uuid path string true "uuid"
+// @Param cascade query bool false "detaches the category from the programs it is attached to instead of refusing the deletion"
 // @Produce json
 // @Success 200 {string} string "deleted"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the dependents of the category"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/categories/{uuid} [delete]
 //
@@ -189,10 +270,18 @@ func (handler categoryHandler) Delete() gin.HandlerFunc {
 		// Extract category UUID from path
 		categoryUUID := c.Param("uuid")
 
+		// Extract query parameters
+		var deleteRequest pkg.DeleteRequestJSON
+		if err := c.ShouldBindQuery(&deleteRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
 		// Call API to delete category
-		if err := handler.api.Delete(c, categoryUUID); err != nil {
+		if err := handler.api.Delete(c, categoryUUID, deleteRequest); err != nil {
 			log.Error().Msg("error deleting category: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -201,15 +290,92 @@ func (handler categoryHandler) Delete() gin.HandlerFunc {
 	}
 }
 
+// Restore returns a Gin handler function for restoring a category from the trash by its UUID.
+//
+// @Summary Restore a category
+// @Description Restore a category from the trash
+// @Tags categories
+// @ID restore-category
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {string} string "restored"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, when it would create a cycle or nest categories too deep"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/categories/{uuid}/restore [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler categoryHandler) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract category UUID from path
+		categoryUUID := c.Param("uuid")
+
+		// Call API to restore category
+		if err := handler.api.Restore(c, categoryUUID); err != nil {
+			log.Error().Msg("error restoring category: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "restored")
+	}
+}
+
+// FindDeleted returns a Gin handler function for finding a page of the categories in the trash.
+//
+// @Summary Find deleted categories
+// @Description Find a page of the categories in the trash, filtered and sorted by the query parameters
+// @Tags categories
+// @ID find-deleted-categories
+// @Produce json
+// @Param limit query int false "maximum number of categories, 20 by default and at most 100"
+// @Param offset query int false "number of categories to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, -deletedAt by default" Enums(name, -name, createdAt, -createdAt, deletedAt, -deletedAt)
+// @Param name query string false "only keep categories whose name contains this text"
+// @Param createdFrom query string false "only keep categories created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep categories created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.CategoryResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/categories/trash [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler categoryHandler) FindDeleted() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of the categories in the trash
+		categories, err := handler.api.FindDeleted(c, listRequest)
+		if err != nil {
+			log.Error().Msg("error finding deleted categories: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		paginate(c, categories)
+		c.JSON(http.StatusOK, categories)
+	}
+}
+
 // FindPrograms returns a Gin handler function for finding all programs associated with a category.
 //
 // @Summary Find all category's programs
-// @Description Find all category's programs
+// @Description Find all category's programs, optionally including the programs of every subcategory
 // @Tags categories
 // @ID find-category-programs
 // @Param uuid path string true "uuid"
+// @Param descendants query bool false "includes the programs of the subcategories at any depth"
 // @Produce json
 // @Success 200 {array} pkg.ProgramResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/categories/{uuid}/programs [get]
 //
@@ -219,11 +385,19 @@ func (handler categoryHandler) FindPrograms() gin.HandlerFunc {
 		// Extract category UUID from path
 		categoryUUID := c.Param("uuid")
 
+		// Extract query parameters
+		var findRequest pkg.FindCategoryProgramsRequestJSON
+		if err := c.ShouldBindQuery(&findRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
 		// Call API to find all programs associated with the category
-		programs, err := handler.api.FindPrograms(c, categoryUUID)
+		programs, err := handler.api.FindPrograms(c, categoryUUID, findRequest)
 		if err != nil {
 			log.Error().Msg("error finding all category's programs: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -231,3 +405,94 @@ func (handler categoryHandler) FindPrograms() gin.HandlerFunc {
 		c.JSON(http.StatusOK, programs)
 	}
 }
+
+// FindTree returns a Gin handler function for finding the whole category hierarchy.
+//
+// @Summary Find the category tree
+// @Description Find the live categories nested under their parent, root categories first and ordered by name at every level
+// @Tags categories
+// @ID find-category-tree
+// @Produce json
+// @Success 200 {array} pkg.CategoryTreeResponse
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/categories/tree [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler categoryHandler) FindTree() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Call API to find the category tree
+		tree, err := handler.api.FindTree(c)
+		if err != nil {
+			log.Error().Msg("error finding category tree: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, tree)
+	}
+}
+
+// FindAncestors returns a Gin handler function for finding the ancestors of a category.
+//
+// @Summary Find a category's ancestors
+// @Description Find the ancestors of a category, from its root category down to its parent
+// @Tags categories
+// @ID find-category-ancestors
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {array} pkg.CategoryResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/categories/{uuid}/ancestors [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler categoryHandler) FindAncestors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract category UUID from path
+		categoryUUID := c.Param("uuid")
+
+		// Call API to find the ancestors of the category
+		ancestors, err := handler.api.FindAncestors(c, categoryUUID)
+		if err != nil {
+			log.Error().Msg("error finding category ancestors: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, ancestors)
+	}
+}
+
+// FindDescendants returns a Gin handler function for finding the nested subcategories of a category.
+//
+// @Summary Find a category's descendants
+// @Description Find the subcategories of a category at any depth, nested under their parent and ordered by name
+// @Tags categories
+// @ID find-category-descendants
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {array} pkg.CategoryTreeResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/categories/{uuid}/descendants [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler categoryHandler) FindDescendants() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract category UUID from path
+		categoryUUID := c.Param("uuid")
+
+		// Call API to find the descendants of the category
+		descendants, err := handler.api.FindDescendants(c, categoryUUID)
+		if err != nil {
+			log.Error().Msg("error finding category descendants: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, descendants)
+	}
+}
@@ -21,11 +21,29 @@ type Episode interface {
 	// Find returns a Gin handler function for finding an episode by its UUID.
 	Find() gin.HandlerFunc
 
-	// FindAll returns a Gin handler function for finding all episodes.
+	// FindAll returns a Gin handler function for finding a page of episodes.
 	FindAll() gin.HandlerFunc
 
 	// Delete returns a Gin handler function for deleting an episode by its UUID.
 	Delete() gin.HandlerFunc
+
+	// Restore returns a Gin handler function for restoring an episode from the trash by its UUID.
+	Restore() gin.HandlerFunc
+
+	// FindDeleted returns a Gin handler function for finding a page of the episodes in the trash.
+	FindDeleted() gin.HandlerFunc
+
+	// Publish returns a Gin handler function for publishing an episode by its UUID.
+	Publish() gin.HandlerFunc
+
+	// Schedule returns a Gin handler function for scheduling the publication of an episode by its UUID.
+	Schedule() gin.HandlerFunc
+
+	// Unpublish returns a Gin handler function for unpublishing an episode by its UUID.
+	Unpublish() gin.HandlerFunc
+
+	// Draft returns a Gin handler function for moving an episode back to draft by its UUID.
+	Draft() gin.HandlerFunc
 }
 
 // episodeHandler is an implementation of the Episode interface.
@@ -49,6 +67,9 @@ func NewEpisodeHandler(api api.Episode) Episode {
 // @Param request body pkg.CreateEpisodeRequestJSON true "create request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/episodes [post]
 //
@@ -64,9 +85,9 @@ func (handler episodeHandler) Create() gin.HandlerFunc {
 		}
 
 		// Call API to create episode
-		if err := handler.api.Create(c, jsonRequest); err != nil {
+		if _, err := handler.api.Create(c, jsonRequest); err != nil {
 			log.Error().Msg("error creating episode: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -83,6 +104,9 @@ func (handler episodeHandler) Create() gin.HandlerFunc {
 // @Param request body pkg.UpdateEpisodeRequestJSON true "update request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/episodes/{uuid} [put]
 //
@@ -103,7 +127,7 @@ func (handler episodeHandler) Update() gin.HandlerFunc {
 		// Call API to update episode
 		if err := handler.api.Update(c, episodeUUID, jsonRequest); err != nil {
 			log.Error().Msg("error updating episode: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -119,6 +143,7 @@ func (handler episodeHandler) Update() gin.HandlerFunc {
 // @Param uuid path string true "uuid"
 // @Produce json
 // @Success 200 {object} pkg.EpisodeResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/episodes/{uuid} [get]
 //
@@ -132,7 +157,7 @@ func (handler episodeHandler) Find() gin.HandlerFunc {
 		episode, err := handler.api.Find(c, episodeUUID)
 		if err != nil {
 			log.Error().Msg("error finding episode: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -141,29 +166,44 @@ func (handler episodeHandler) Find() gin.HandlerFunc {
 	}
 }
 
-// FindAll returns a Gin handler function for finding all episodes.
+// FindAll returns a Gin handler function for finding a page of episodes.
 //
 // @Summary Find all episodes
-// @Description Find all episodes
+// @Description Find a page of episodes, filtered and sorted by the query parameters
 // @Tags episodes
 // @ID find-all-episodes
 // @Produce json
-// @Success 200 {array} pkg.EpisodeResponse
+// @Param limit query int false "maximum number of episodes, 20 by default and at most 100"
+// @Param offset query int false "number of episodes to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, createdAt by default" Enums(name, -name, createdAt, -createdAt, position, -position)
+// @Param name query string false "only keep episodes whose name contains this text"
+// @Param createdFrom query string false "only keep episodes created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep episodes created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.EpisodeResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/episodes [get]
 //
 // @Security Bearer-APIKey || Bearer-JWT
 func (handler episodeHandler) FindAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Call API to find all episodes
-		episodes, err := handler.api.FindAll(c)
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of episodes
+		episodes, err := handler.api.FindAll(c, listRequest)
 		if err != nil {
 			log.Error().Msg("error finding all episodes: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
 		// Return response
+		paginate(c, episodes)
 		c.JSON(http.StatusOK, episodes)
 	}
 }
@@ -177,6 +217,8 @@ func (handler episodeHandler) FindAll() gin.HandlerFunc {
 // @Param uuid path string true "uuid"
 // @Produce json
 // @Success 200 {string} string "deleted"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/episodes/{uuid} [delete]
 //
@@ -189,7 +231,7 @@ func (handler episodeHandler) Delete() gin.HandlerFunc {
 		// Call API to delete episode
 		if err := handler.api.Delete(c, episodeUUID); err != nil {
 			log.Error().Msg("error deleting episode: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -197,3 +239,216 @@ func (handler episodeHandler) Delete() gin.HandlerFunc {
 		c.JSON(http.StatusOK, "deleted")
 	}
 }
+
+// Restore returns a Gin handler function for restoring an episode from the trash by its UUID.
+//
+// @Summary Restore an episode
+// @Description Restore an episode from the trash, along with the medias deleted with it. Fails with 409 while its program is in the trash
+// @Tags episodes
+// @ID restore-episode
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {string} string "restored"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/episodes/{uuid}/restore [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler episodeHandler) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract episode UUID from path
+		episodeUUID := c.Param("uuid")
+
+		// Call API to restore episode
+		if err := handler.api.Restore(c, episodeUUID); err != nil {
+			log.Error().Msg("error restoring episode: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "restored")
+	}
+}
+
+// FindDeleted returns a Gin handler function for finding a page of the episodes in the trash.
+//
+// @Summary Find deleted episodes
+// @Description Find a page of the episodes in the trash, filtered and sorted by the query parameters
+// @Tags episodes
+// @ID find-deleted-episodes
+// @Produce json
+// @Param limit query int false "maximum number of episodes, 20 by default and at most 100"
+// @Param offset query int false "number of episodes to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, -deletedAt by default" Enums(name, -name, createdAt, -createdAt, position, -position, deletedAt, -deletedAt)
+// @Param name query string false "only keep episodes whose name contains this text"
+// @Param createdFrom query string false "only keep episodes created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep episodes created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.EpisodeResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/episodes/trash [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler episodeHandler) FindDeleted() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of the episodes in the trash
+		episodes, err := handler.api.FindDeleted(c, listRequest)
+		if err != nil {
+			log.Error().Msg("error finding deleted episodes: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		paginate(c, episodes)
+		c.JSON(http.StatusOK, episodes)
+	}
+}
+
+// Publish returns a Gin handler function for publishing an episode by its UUID.
+//
+// @Summary Publish an episode
+// @Description Publish a draft, scheduled or unpublished episode right away. Fails with 400 when the episode holds no media, and with 409 when it is already published
+// @Tags episodes
+// @ID publish-episode
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {string} string "published"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/episodes/{uuid}/publish [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler episodeHandler) Publish() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract episode UUID from path
+		episodeUUID := c.Param("uuid")
+
+		// Call API to publish episode
+		if err := handler.api.Publish(c, episodeUUID); err != nil {
+			log.Error().Msg("error publishing episode: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "published")
+	}
+}
+
+// Schedule returns a Gin handler function for scheduling the publication of an episode by its UUID.
+//
+// @Summary Schedule an episode
+// @Description Schedule the publication of a draft or unpublished episode at a time in the future, or move the publication of a scheduled episode. Fails with 400 when the episode holds no media, and with 409 when it is published
+// @Tags episodes
+// @ID schedule-episode
+// @Param uuid path string true "uuid"
+// @Param request body pkg.ScheduleEpisodeRequestJSON true "schedule request"
+// @Produce json
+// @Success 200 {string} string "scheduled"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/episodes/{uuid}/schedule [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler episodeHandler) Schedule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract episode UUID from path
+		episodeUUID := c.Param("uuid")
+
+		// Extract body request
+		var jsonRequest pkg.ScheduleEpisodeRequestJSON
+		if err := c.ShouldBindJSON(&jsonRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to schedule episode
+		if err := handler.api.Schedule(c, episodeUUID, jsonRequest); err != nil {
+			log.Error().Msg("error scheduling episode: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "scheduled")
+	}
+}
+
+// Unpublish returns a Gin handler function for unpublishing an episode by its UUID.
+//
+// @Summary Unpublish an episode
+// @Description Withdraw a published episode from the listeners. Fails with 409 when the episode is not published
+// @Tags episodes
+// @ID unpublish-episode
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {string} string "unpublished"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/episodes/{uuid}/unpublish [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler episodeHandler) Unpublish() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract episode UUID from path
+		episodeUUID := c.Param("uuid")
+
+		// Call API to unpublish episode
+		if err := handler.api.Unpublish(c, episodeUUID); err != nil {
+			log.Error().Msg("error unpublishing episode: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "unpublished")
+	}
+}
+
+// Draft returns a Gin handler function for moving an episode back to draft by its UUID.
+//
+// @Summary Move an episode back to draft
+// @Description Move a scheduled or unpublished episode back to draft, cancelling its scheduled publication. Fails with 409 when the episode is published or already a draft
+// @Tags episodes
+// @ID draft-episode
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {string} string "draft"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/episodes/{uuid}/draft [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler episodeHandler) Draft() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract episode UUID from path
+		episodeUUID := c.Param("uuid")
+
+		// Call API to move episode back to draft
+		if err := handler.api.Draft(c, episodeUUID); err != nil {
+			log.Error().Msg("error moving episode to draft: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "draft")
+	}
+}
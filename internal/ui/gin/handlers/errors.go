@@ -0,0 +1,69 @@
+// Package handlers provides HTTP request handlers for the backoffice resources.
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+// renderError writes the JSON error response matching the class of an error returned by the domain api:
+// 400 with per-field details for validation errors, 404 when an entity does not exist,
+// 409 when the operation conflicts with the stored data, listing the dependents of an entity that could not be deleted,
+// and 500 for anything else. The failed items of a bulk operation are listed with their own error,
+// the status matching the error of the first of them.
+func renderError(c *gin.Context, err error) {
+	if bErr, ok := model.AsBulkError(err); ok {
+		failed := make([]pkg.BulkItemResponse, 0, len(bErr.Items))
+		for _, item := range bErr.Items {
+			failed = append(failed, pkg.BulkItemResponse{Index: item.Index, Error: item.Err.Error(), Details: fieldErrors(item.Err)})
+		}
+		c.JSON(errorStatus(bErr.Items[0].Err), pkg.ErrorJSON{Error: err.Error(), Failed: failed})
+		return
+	}
+
+	if details := fieldErrors(err); details != nil {
+		c.JSON(http.StatusBadRequest, pkg.ErrorJSON{Error: err.Error(), Details: details})
+		return
+	}
+
+	if dErr, ok := model.AsDependentsError(err); ok {
+		dependents := make([]pkg.DependentJSON, 0, len(dErr.Dependents))
+		for _, dependent := range dErr.Dependents {
+			dependents = append(dependents, pkg.DependentJSON{Entity: dependent.Entity, ID: dependent.ID, Name: dependent.Name})
+		}
+		c.JSON(http.StatusConflict, pkg.ErrorJSON{Error: err.Error(), Dependents: dependents})
+		return
+	}
+
+	c.JSON(errorStatus(err), pkg.ErrorJSON{Error: err.Error()})
+}
+
+// errorStatus returns the HTTP status matching the class of an error returned by the domain api.
+func errorStatus(err error) int {
+	switch {
+	case errors.As(err, new(model.ValidationErrors)):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrConflict):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// fieldErrors returns the per-field details of the validation errors wrapped by err, or nil when it wraps none.
+func fieldErrors(err error) []pkg.FieldErrorJSON {
+	vErrs, ok := model.AsValidationErrors(err)
+	if !ok {
+		return nil
+	}
+	details := make([]pkg.FieldErrorJSON, 0, len(vErrs))
+	for _, vErr := range vErrs {
+		details = append(details, pkg.FieldErrorJSON{Field: vErr.Field, Message: vErr.Message})
+	}
+	return details
+}
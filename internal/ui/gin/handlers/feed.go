@@ -0,0 +1,77 @@
+// Package handlers provides HTTP request handlers for serving the podcast feeds of programs.
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/api"
+	"github.com/rs/zerolog/log"
+)
+
+// feedMaxAge is the number of seconds shared caches may serve a podcast feed without revalidating it.
+const feedMaxAge = "300"
+
+// Feed represents the interface for serving the podcast feeds of programs.
+type Feed interface {
+	// FindByProgram returns a Gin handler function for serving the podcast feed of a program.
+	FindByProgram() gin.HandlerFunc
+}
+
+// feedHandler is an implementation of the Feed interface.
+type feedHandler struct {
+	api api.Feed
+}
+
+// NewFeedHandler creates a new instance of Feed interface.
+func NewFeedHandler(api api.Feed) Feed {
+	return &feedHandler{
+		api: api,
+	}
+}
+
+// FindByProgram returns a Gin handler function for serving the podcast feed of a program.
+// The feed carries an ETag and a Last-Modified date, so that podcast clients can poll it with conditional requests.
+//
+// @Summary Find the podcast feed of a program
+// @Description Render a program as an RSS 2.0 feed with the iTunes and Podcasting 2.0 extensions, with an item per episode holding a media, in the order of the episodes.
+// @Description This read-only endpoint requires no authentication and answers conditional requests with 304 Not Modified.
+// @Tags public
+// @ID find-program-feed
+// @Param uuid path string true "UUID of the program"
+// @Produce xml
+// @Success 200 {string} string "RSS feed"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /public/programs/{uuid}/feed.xml [get]
+func (handler feedHandler) FindByProgram() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		programUUID := c.Param("uuid")
+
+		feed, err := handler.api.FindByProgram(c, programUUID)
+		if err != nil {
+			log.Error().Msg("error finding program feed: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		body, err := xml.MarshalIndent(feed, "", "  ")
+		if err != nil {
+			log.Error().Msg("error rendering program feed: " + err.Error())
+			renderError(c, err)
+			return
+		}
+		body = append([]byte(xml.Header), body...)
+		digest := sha256.Sum256(body)
+
+		c.Header("Content-Type", "application/rss+xml; charset=utf-8")
+		c.Header("Cache-Control", "public, max-age="+feedMaxAge)
+		c.Header("ETag", `"`+hex.EncodeToString(digest[:])+`"`)
+		http.ServeContent(c.Writer, c.Request, "", feed.LastModified, bytes.NewReader(body))
+	}
+}
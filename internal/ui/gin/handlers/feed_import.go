@@ -0,0 +1,68 @@
+// Package handlers provides HTTP request handlers for importing the podcast feeds published by other hosts.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/api"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"github.com/rs/zerolog/log"
+)
+
+// FeedImport represents the interface for importing the podcast feeds published by other hosts.
+type FeedImport interface {
+	// Import returns a Gin handler function for importing a podcast feed as a program.
+	Import() gin.HandlerFunc
+}
+
+// feedImportHandler is an implementation of the FeedImport interface.
+type feedImportHandler struct {
+	api api.FeedImport
+}
+
+// NewFeedImportHandler creates a new instance of FeedImport interface.
+func NewFeedImportHandler(api api.FeedImport) FeedImport {
+	return &feedImportHandler{
+		api: api,
+	}
+}
+
+// Import returns a Gin handler function for importing a podcast feed as a program.
+//
+// @Summary Import a podcast feed
+// @Description Import an RSS feed, downloaded from a URL or uploaded, as a program: its items become episodes positioned in publication order, their enclosures medias and its iTunes keywords tags.
+// @Description Importing a feed again updates the program and episodes imported from it, matched by GUID, instead of duplicating them.
+// @Tags programs
+// @ID import-program-feed
+// @Accept multipart/form-data
+// @Param url formData string false "URL of the feed, when no file is sent"
+// @Param file formData file false "feed file, when no URL is sent"
+// @Produce json
+// @Success 200 {object} pkg.ImportResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/programs/import [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler feedImportHandler) Import() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract multipart form request
+		var formRequest pkg.ImportFeedRequestJSON
+		if err := c.ShouldBind(&formRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to import feed
+		result, err := handler.api.Import(c, formRequest)
+		if err != nil {
+			log.Error().Msg("error importing feed: " + err.Error())
+			renderError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
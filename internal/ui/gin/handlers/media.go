@@ -2,7 +2,9 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -15,17 +17,26 @@ type Media interface {
 	// Create returns a Gin handler function for creating a media.
 	Create() gin.HandlerFunc
 
+	// Upload returns a Gin handler function for creating a media from an uploaded file.
+	Upload() gin.HandlerFunc
+
 	// Update returns a Gin handler function for updating a media.
 	Update() gin.HandlerFunc
 
 	// Find returns a Gin handler function for finding a media by its UUID.
 	Find() gin.HandlerFunc
 
-	// FindAll returns a Gin handler function for finding all medias.
+	// FindAll returns a Gin handler function for finding a page of medias.
 	FindAll() gin.HandlerFunc
 
 	// Delete returns a Gin handler function for deleting a media by its UUID.
 	Delete() gin.HandlerFunc
+
+	// Restore returns a Gin handler function for restoring a media from the trash by its UUID.
+	Restore() gin.HandlerFunc
+
+	// FindDeleted returns a Gin handler function for finding a page of the medias in the trash.
+	FindDeleted() gin.HandlerFunc
 }
 
 // mediaHandler is an implementation of the Media interface.
@@ -49,6 +60,9 @@ func NewMediaHandler(api api.Media) Media {
 // @Param request body pkg.CreateMediaRequestJSON true "create request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/medias [post]
 func (handler mediaHandler) Create() gin.HandlerFunc {
@@ -62,15 +76,105 @@ func (handler mediaHandler) Create() gin.HandlerFunc {
 		}
 
 		// Call API to create media
-		if err := handler.api.Create(c, jsonRequest); err != nil {
+		if _, err := handler.api.Create(c, jsonRequest); err != nil {
 			log.Error().Msg("error creating media: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
 	}
 }
 
+// Upload returns a Gin handler function for creating a media from an uploaded file.
+//
+// @Summary Upload a new media
+// @Description Create a new media from a file streamed to the object store; its direct link, size, checksum and MIME type are filled in from the stored file
+// @Description The kind and episodeID fields must be sent before the file, which is stored as it is received.
+// @Description The new media is returned, with its UUID and the link of the stored file.
+// @Tags medias
+// @ID upload-media
+// @Accept multipart/form-data
+// @Param kind formData string true "kind of the media"
+// @Param episodeID formData string true "UUID of the episode of the media"
+// @Param file formData file true "media file"
+// @Produce json
+// @Success 200 {object} pkg.MediaResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/medias/upload [post]
+func (handler mediaHandler) Upload() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract multipart form request, up to the file part streamed to the API
+		formRequest, err := readUploadForm(c)
+		if err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to upload media
+		mediaUUID, err := handler.api.Upload(c, formRequest)
+		if err != nil {
+			log.Error().Msg("error uploading media: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Call API to find the new media, described from the stored file
+		media, err := handler.api.Find(c, mediaUUID)
+		if err != nil {
+			log.Error().Msg("error finding uploaded media: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, media)
+	}
+}
+
+// maxUploadFieldSize is the largest value read from a text field of an upload form.
+const maxUploadFieldSize = 1 << 10
+
+// readUploadForm reads the parts of an upload form up to the file part, which is left unread in the
+// returned request so that the API streams it. Parts after the file are ignored, and the request has no
+// file when the form holds none.
+func readUploadForm(c *gin.Context) (pkg.UploadMediaRequestJSON, error) {
+	var req pkg.UploadMediaRequestJSON
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return req, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return req, nil
+		}
+		if err != nil {
+			return req, err
+		}
+		switch part.FormName() {
+		case "file":
+			req.FileNameJSON = part.FileName()
+			req.ContentTypeJSON = part.Header.Get("Content-Type")
+			req.FileJSON = part
+			return req, nil
+		case "kind", "episodeID":
+			value, err := io.ReadAll(io.LimitReader(part, maxUploadFieldSize))
+			if err != nil {
+				return req, err
+			}
+			if part.FormName() == "kind" {
+				req.KindJSON = string(value)
+			} else {
+				req.EpisodeIDJSON = string(value)
+			}
+		}
+	}
+}
+
 // Update returns a Gin handler function for updating a media.
 //
 // @Summary Update media
@@ -81,6 +185,9 @@ func (handler mediaHandler) Create() gin.HandlerFunc {
 // @Param request body pkg.UpdateMediaRequestJSON true "update request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/medias/{uuid} [put]
 func (handler mediaHandler) Update() gin.HandlerFunc {
@@ -99,7 +206,7 @@ func (handler mediaHandler) Update() gin.HandlerFunc {
 		// Call API to update media
 		if err := handler.api.Update(c, mediaUUID, jsonRequest); err != nil {
 			log.Error().Msg("error updating media: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -115,6 +222,7 @@ func (handler mediaHandler) Update() gin.HandlerFunc {
 // @Param uuid path string true "uuid"
 // @Produce json
 // @Success 200 {object} pkg.MediaResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/medias/{uuid} [get]
 func (handler mediaHandler) Find() gin.HandlerFunc {
@@ -126,7 +234,7 @@ func (handler mediaHandler) Find() gin.HandlerFunc {
 		media, err := handler.api.Find(c, mediaUUID)
 		if err != nil {
 			log.Error().Msg("error finding media: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -135,27 +243,41 @@ func (handler mediaHandler) Find() gin.HandlerFunc {
 	}
 }
 
-// FindAll returns a Gin handler function for finding all medias.
+// FindAll returns a Gin handler function for finding a page of medias.
 //
 // @Summary Find all medias
-// @Description Find all medias
+// @Description Find a page of medias, filtered and sorted by the query parameters
 // @Tags medias
 // @ID find-all-medias
 // @Produce json
-// @Success 200 {array} pkg.MediaResponse
+// @Param limit query int false "maximum number of medias, 20 by default and at most 100"
+// @Param offset query int false "number of medias to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, createdAt by default" Enums(createdAt, -createdAt, kind, -kind)
+// @Param createdFrom query string false "only keep medias created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep medias created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.MediaResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/medias [get]
 func (handler mediaHandler) FindAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Call API to find all medias
-		medias, err := handler.api.FindAll(c)
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of medias
+		medias, err := handler.api.FindAll(c, listRequest)
 		if err != nil {
 			log.Error().Msg("error finding all medias: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
 		// Return response
+		paginate(c, medias)
 		c.JSON(http.StatusOK, medias)
 	}
 }
@@ -169,6 +291,8 @@ func (handler mediaHandler) FindAll() gin.HandlerFunc {
 // @Param uuid path string true "uuid"
 // @Produce json
 // @Success 200 {string} string "deleted"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/medias/{uuid} [delete]
 //
@@ -181,7 +305,7 @@ func (handler mediaHandler) Delete() gin.HandlerFunc {
 		// Call API to delete media
 		if err := handler.api.Delete(c, mediaUUID); err != nil {
 			log.Error().Msg("error deleting media: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -189,3 +313,76 @@ func (handler mediaHandler) Delete() gin.HandlerFunc {
 		c.JSON(http.StatusOK, "deleted")
 	}
 }
+
+// Restore returns a Gin handler function for restoring a media from the trash by its UUID.
+//
+// @Summary Restore a media
+// @Description Restore a media from the trash. Fails with 409 while its episode is in the trash
+// @Tags medias
+// @ID restore-media
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {string} string "restored"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/medias/{uuid}/restore [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler mediaHandler) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract media UUID from path
+		mediaUUID := c.Param("uuid")
+
+		// Call API to restore media
+		if err := handler.api.Restore(c, mediaUUID); err != nil {
+			log.Error().Msg("error restoring media: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "restored")
+	}
+}
+
+// FindDeleted returns a Gin handler function for finding a page of the medias in the trash.
+//
+// @Summary Find deleted medias
+// @Description Find a page of the medias in the trash, filtered and sorted by the query parameters
+// @Tags medias
+// @ID find-deleted-medias
+// @Produce json
+// @Param limit query int false "maximum number of medias, 20 by default and at most 100"
+// @Param offset query int false "number of medias to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, -deletedAt by default" Enums(createdAt, -createdAt, kind, -kind, deletedAt, -deletedAt)
+// @Param createdFrom query string false "only keep medias created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep medias created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.MediaResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/medias/trash [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler mediaHandler) FindDeleted() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of the medias in the trash
+		medias, err := handler.api.FindDeleted(c, listRequest)
+		if err != nil {
+			log.Error().Msg("error finding deleted medias: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		paginate(c, medias)
+		c.JSON(http.StatusOK, medias)
+	}
+}
@@ -0,0 +1,28 @@
+// Package handlers provides HTTP request handlers for the backoffice resources.
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+)
+
+// paginate sets the links to the pages surrounding page. They are built from the request URL,
+// so the filters and sort of the request carry over to the other pages.
+func paginate[T any](c *gin.Context, page *pkg.PageResponse[T]) {
+	link := func(offset int) string {
+		url := *c.Request.URL
+		query := url.Query()
+		query.Set("limit", strconv.Itoa(page.Limit))
+		query.Set("offset", strconv.Itoa(offset))
+		url.RawQuery = query.Encode()
+		return url.RequestURI()
+	}
+	if page.Offset+page.Limit < page.Total {
+		page.Next = link(page.Offset + page.Limit)
+	}
+	if page.Offset > 0 {
+		page.Previous = link(max(page.Offset-page.Limit, 0))
+	}
+}
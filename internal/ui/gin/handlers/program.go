@@ -21,12 +21,18 @@ type Program interface {
 	// Find returns a Gin handler function for finding a program by its UUID.
 	Find() gin.HandlerFunc
 
-	// FindAll returns a Gin handler function for finding all programs.
+	// FindAll returns a Gin handler function for finding a page of programs.
 	FindAll() gin.HandlerFunc
 
 	// Delete returns a Gin handler function for deleting a program by its UUID.
 	Delete() gin.HandlerFunc
 
+	// Restore returns a Gin handler function for restoring a program from the trash by its UUID.
+	Restore() gin.HandlerFunc
+
+	// FindDeleted returns a Gin handler function for finding a page of the programs in the trash.
+	FindDeleted() gin.HandlerFunc
+
 	// FindEpisodes returns a Gin handler function for finding a program's episodes.
 	FindEpisodes() gin.HandlerFunc
 
@@ -41,6 +47,21 @@ type Program interface {
 
 	// OverwriteTags returns a Gin handler function for overwriting tags of a program.
 	OverwriteTags() gin.HandlerFunc
+
+	// ReorderEpisodes returns a Gin handler function for reordering the episodes of a program.
+	ReorderEpisodes() gin.HandlerFunc
+
+	// AddTag returns a Gin handler function for adding a tag to a program.
+	AddTag() gin.HandlerFunc
+
+	// RemoveTag returns a Gin handler function for removing a tag from a program.
+	RemoveTag() gin.HandlerFunc
+
+	// AddCategory returns a Gin handler function for adding a category to a program.
+	AddCategory() gin.HandlerFunc
+
+	// RemoveCategory returns a Gin handler function for removing a category from a program.
+	RemoveCategory() gin.HandlerFunc
 }
 
 type programHandler struct {
@@ -63,6 +84,8 @@ func NewProgramHandler(api api.Program) Program {
 // @Param request body pkg.CreateProgramRequestJSON true "create request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/programs [post]
 //
@@ -78,9 +101,9 @@ func (handler programHandler) Create() gin.HandlerFunc {
 		}
 
 		// Call API to create program
-		if err := handler.api.Create(c, jsonRequest); err != nil {
+		if _, err := handler.api.Create(c, jsonRequest); err != nil {
 			log.Error().Msg("error creating program: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -97,6 +120,9 @@ func (handler programHandler) Create() gin.HandlerFunc {
 // @Param request body pkg.UpdateProgramRequestJSON true "update request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/programs/{uuid} [put]
 //
@@ -117,7 +143,7 @@ func (handler programHandler) Update() gin.HandlerFunc {
 		// Call API to update program
 		if err := handler.api.Update(c, programUUID, jsonRequest); err != nil {
 			log.Error().Msg("error updating program: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -133,6 +159,7 @@ func (handler programHandler) Update() gin.HandlerFunc {
 // @Param uuid path string true "uuid"
 // @Produce json
 // @Success 200 {object} pkg.ProgramResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/programs/{uuid} [get]
 //
@@ -146,7 +173,7 @@ func (handler programHandler) Find() gin.HandlerFunc {
 		program, err := handler.api.Find(c, programUUID)
 		if err != nil {
 			log.Error().Msg("error finding program: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -155,29 +182,44 @@ func (handler programHandler) Find() gin.HandlerFunc {
 	}
 }
 
-// FindAll returns a Gin handler function for finding all programs.
+// FindAll returns a Gin handler function for finding a page of programs.
 //
 // @Summary Find all programs
-// @Description Find all programs
+// @Description Find a page of programs, filtered and sorted by the query parameters
 // @Tags programs
 // @ID find-all-programs
 // @Produce json
-// @Success 200 {array} pkg.ProgramResponse
+// @Param limit query int false "maximum number of programs, 20 by default and at most 100"
+// @Param offset query int false "number of programs to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, createdAt by default" Enums(name, -name, createdAt, -createdAt)
+// @Param name query string false "only keep programs whose name contains this text"
+// @Param createdFrom query string false "only keep programs created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep programs created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.ProgramResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/programs [get]
 //
 // @Security Bearer-APIKey || Bearer-JWT
 func (handler programHandler) FindAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Call API to find all programs
-		programs, err := handler.api.FindAll(c)
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of programs
+		programs, err := handler.api.FindAll(c, listRequest)
 		if err != nil {
 			log.Error().Msg("error finding all programs: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
 		// Return response
+		paginate(c, programs)
 		c.JSON(http.StatusOK, programs)
 	}
 }
@@ -185,12 +227,15 @@ func (handler programHandler) FindAll() gin.HandlerFunc {
 // Delete returns a Gin handler function for deleting a program by its UUID.
 //
 // @Summary Delete a program
-// @Description Delete a program
+// @Description Move a program to the trash, refused while other entities still reference it unless cascade is set
 // @Tags programs
 // @ID delete-program
 // @Param uuid path string true "uuid"
+// @Param cascade query bool false "moves the episodes of the program and their medias to the trash too instead of refusing the deletion"
 // @Produce json
 // @Success 200 {string} string "deleted"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the dependents of the program"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/programs/{uuid} [delete]
 //
@@ -200,10 +245,18 @@ func (handler programHandler) Delete() gin.HandlerFunc {
 		// Extract program UUID from path
 		programUUID := c.Param("uuid")
 
+		// Extract query parameters
+		var deleteRequest pkg.DeleteRequestJSON
+		if err := c.ShouldBindQuery(&deleteRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
 		// Call API to delete program
-		if err := handler.api.Delete(c, programUUID); err != nil {
+		if err := handler.api.Delete(c, programUUID, deleteRequest); err != nil {
 			log.Error().Msg("error deleting program: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -212,6 +265,80 @@ func (handler programHandler) Delete() gin.HandlerFunc {
 	}
 }
 
+// Restore returns a Gin handler function for restoring a program from the trash by its UUID.
+//
+// @Summary Restore a program
+// @Description Restore a program from the trash, along with the episodes and medias deleted with it
+// @Tags programs
+// @ID restore-program
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {string} string "restored"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/programs/{uuid}/restore [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler programHandler) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract program UUID from path
+		programUUID := c.Param("uuid")
+
+		// Call API to restore program
+		if err := handler.api.Restore(c, programUUID); err != nil {
+			log.Error().Msg("error restoring program: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "restored")
+	}
+}
+
+// FindDeleted returns a Gin handler function for finding a page of the programs in the trash.
+//
+// @Summary Find deleted programs
+// @Description Find a page of the programs in the trash, filtered and sorted by the query parameters
+// @Tags programs
+// @ID find-deleted-programs
+// @Produce json
+// @Param limit query int false "maximum number of programs, 20 by default and at most 100"
+// @Param offset query int false "number of programs to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, -deletedAt by default" Enums(name, -name, createdAt, -createdAt, deletedAt, -deletedAt)
+// @Param name query string false "only keep programs whose name contains this text"
+// @Param createdFrom query string false "only keep programs created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep programs created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.ProgramResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/programs/trash [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler programHandler) FindDeleted() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of the programs in the trash
+		programs, err := handler.api.FindDeleted(c, listRequest)
+		if err != nil {
+			log.Error().Msg("error finding deleted programs: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		paginate(c, programs)
+		c.JSON(http.StatusOK, programs)
+	}
+}
+
 // FindEpisodes returns a Gin handler function for finding a program's episodes.
 //
 // @Summary Find a program's episodes
@@ -234,7 +361,7 @@ func (handler programHandler) FindEpisodes() gin.HandlerFunc {
 		episodes, err := handler.api.FindEpisodes(c, programUUID)
 		if err != nil {
 			log.Error().Msg("error finding program's episodes: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -265,7 +392,7 @@ func (handler programHandler) FindTags() gin.HandlerFunc {
 		tags, err := handler.api.FindTags(c, programUUID)
 		if err != nil {
 			log.Error().Msg("error finding program's tags: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -296,7 +423,7 @@ func (handler programHandler) FindCategories() gin.HandlerFunc {
 		categories, err := handler.api.FindCats(c, programUUID)
 		if err != nil {
 			log.Error().Msg("error finding program's categories: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -315,6 +442,8 @@ func (handler programHandler) FindCategories() gin.HandlerFunc {
 // @Param request body []string true "List of categories' UUIDs to set"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
 // @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
 // @Router /private/programs/{uuid}/categories/overwrite [put]
@@ -336,7 +465,7 @@ func (handler programHandler) OverwriteCategories() gin.HandlerFunc {
 		// Call API to overwrite categories
 		if err := handler.api.OverwriteCategories(c, programUUID, jsonRequest); err != nil {
 			log.Error().Msg("error overwriting program's categories: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -355,6 +484,8 @@ func (handler programHandler) OverwriteCategories() gin.HandlerFunc {
 // @Param request body []string true "List of tags UUIDs to set"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
 // @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
 // @Router /private/programs/{uuid}/tags/overwrite [put]
@@ -376,7 +507,181 @@ func (handler programHandler) OverwriteTags() gin.HandlerFunc {
 		// Call API to overwrite tags
 		if err := handler.api.OverwriteTags(c, programUUID, jsonRequest); err != nil {
 			log.Error().Msg("error overwriting program's tags: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// ReorderEpisodes returns a Gin handler function for reordering the episodes of a program.
+//
+// @Summary Reorder episodes of a program
+// @Description Reorder the episodes of a program, renumbering their positions from 1
+// @Tags programs
+// @ID reorder-program-episodes
+// @Param uuid path string true "UUID of the program"
+// @Param request body pkg.ReorderRequestJSON true "new order, as a list of IDs or a move between two positions"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, when the IDs do not list every episode exactly once or a position is out of range"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/programs/{uuid}/episodes/reorder [patch]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler programHandler) ReorderEpisodes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract program UUID from path
+		programUUID := c.Param("uuid")
+
+		// Extract body request
+		var jsonRequest pkg.ReorderRequestJSON
+		if err := c.ShouldBindJSON(&jsonRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to reorder the episodes of the program
+		if err := handler.api.ReorderEpisodes(c, programUUID, jsonRequest); err != nil {
+			log.Error().Msg("error reordering program episodes: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// AddTag returns a Gin handler function for add a tag to a program.
+//
+// @Summary Add a tag to a program
+// @Description Associate a tag with a program; adding a tag the program already has does nothing
+// @Tags programs
+// @ID add-program-tag
+// @Param uuid path string true "UUID of the program"
+// @Param tagUUID path string true "UUID of the tag"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, when the program or the tag does not exist"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/programs/{uuid}/tags/{tagUUID} [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler programHandler) AddTag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract program and tag UUIDs from path
+		programUUID := c.Param("uuid")
+		tagUUID := c.Param("tagUUID")
+
+		// Call API to add program tag
+		if err := handler.api.AddTag(c, programUUID, tagUUID); err != nil {
+			log.Error().Msg("error adding program tag: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// RemoveTag returns a Gin handler function for remove a tag from a program.
+//
+// @Summary Remove a tag from a program
+// @Description Dissociate a tag from a program; removing a tag the program does not have does nothing
+// @Tags programs
+// @ID remove-program-tag
+// @Param uuid path string true "UUID of the program"
+// @Param tagUUID path string true "UUID of the tag"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, when the program does not exist"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/programs/{uuid}/tags/{tagUUID} [delete]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler programHandler) RemoveTag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract program and tag UUIDs from path
+		programUUID := c.Param("uuid")
+		tagUUID := c.Param("tagUUID")
+
+		// Call API to remove program tag
+		if err := handler.api.RemoveTag(c, programUUID, tagUUID); err != nil {
+			log.Error().Msg("error removing program tag: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// AddCategory returns a Gin handler function for add a category to a program.
+//
+// @Summary Add a category to a program
+// @Description Associate a category with a program; adding a category the program already has does nothing
+// @Tags programs
+// @ID add-program-category
+// @Param uuid path string true "UUID of the program"
+// @Param categoryUUID path string true "UUID of the category"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, when the program or the category does not exist"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/programs/{uuid}/categories/{categoryUUID} [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler programHandler) AddCategory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract program and category UUIDs from path
+		programUUID := c.Param("uuid")
+		categoryUUID := c.Param("categoryUUID")
+
+		// Call API to add program category
+		if err := handler.api.AddCategory(c, programUUID, categoryUUID); err != nil {
+			log.Error().Msg("error adding program category: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// RemoveCategory returns a Gin handler function for remove a category from a program.
+//
+// @Summary Remove a category from a program
+// @Description Dissociate a category from a program; removing a category the program does not have does nothing
+// @Tags programs
+// @ID remove-program-category
+// @Param uuid path string true "UUID of the program"
+// @Param categoryUUID path string true "UUID of the category"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, when the program does not exist"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/programs/{uuid}/categories/{categoryUUID} [delete]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler programHandler) RemoveCategory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract program and category UUIDs from path
+		programUUID := c.Param("uuid")
+		categoryUUID := c.Param("categoryUUID")
+
+		// Call API to remove program category
+		if err := handler.api.RemoveCategory(c, programUUID, categoryUUID); err != nil {
+			log.Error().Msg("error removing program category: " + err.Error())
+			renderError(c, err)
 			return
 		}
 
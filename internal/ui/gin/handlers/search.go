@@ -0,0 +1,67 @@
+// Package handlers provides HTTP request handlers for searching the catalogue.
+package handlers
+
+import (
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/api"
+	"github.com/rs/zerolog/log"
+)
+
+// Search represents the interface for searching the catalogue.
+type Search interface {
+	// Search returns a Gin handler function for searching programs, episodes, tags and categories.
+	Search() gin.HandlerFunc
+}
+
+// searchHandler is an implementation of the Search interface.
+type searchHandler struct {
+	api api.Search
+}
+
+// NewSearchHandler creates a new instance of Search interface.
+func NewSearchHandler(api api.Search) Search {
+	return &searchHandler{
+		api: api,
+	}
+}
+
+// Search returns a Gin handler function for searching programs, episodes, tags and categories.
+//
+// @Summary Search the catalogue
+// @Description Full-text search over program and episode names and descriptions, and tag and category names.
+// @Description Hits are grouped by kind and ranked from the most to the least relevant.
+// @Tags search
+// @ID search
+// @Produce json
+// @Param q query string true "text to search for"
+// @Param limit query int false "maximum number of hits of each kind, 20 by default and at most 100"
+// @Success 200 {object} pkg.SearchResponse
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/search [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler searchHandler) Search() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var searchRequest pkg.SearchRequestJSON
+		if err := c.ShouldBindQuery(&searchRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to search the catalogue
+		response, err := handler.api.Search(c, searchRequest)
+		if err != nil {
+			log.Error().Msg("error searching: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, response)
+	}
+}
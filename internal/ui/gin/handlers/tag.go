@@ -21,12 +21,18 @@ type Tag interface {
 	// Find returns a Gin handler function for finding a tag by its UUID.
 	Find() gin.HandlerFunc
 
-	// FindAll returns a Gin handler function for finding all tags.
+	// FindAll returns a Gin handler function for finding a page of tags.
 	FindAll() gin.HandlerFunc
 
 	// Delete returns a Gin handler function for deleting a tag by its UUID.
 	Delete() gin.HandlerFunc
 
+	// Restore returns a Gin handler function for restoring a tag from the trash by its UUID.
+	Restore() gin.HandlerFunc
+
+	// FindDeleted returns a Gin handler function for finding a page of the tags in the trash.
+	FindDeleted() gin.HandlerFunc
+
 	// FindPrograms returns a Gin handler function for finding all programs associated with a tag.
 	FindPrograms() gin.HandlerFunc
 }
@@ -52,6 +58,8 @@ func NewTagHandler(api api.Tag) Tag {
 // @Param request body pkg.CreateTagRequestJSON true "create request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/tags [post]
 //
@@ -67,9 +75,9 @@ func (handler tagHandler) Create() gin.HandlerFunc {
 		}
 
 		// Call API to create tag
-		if err := handler.api.Create(c, jsonRequest); err != nil {
+		if _, err := handler.api.Create(c, jsonRequest); err != nil {
 			log.Error().Msg("error creating tag: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -86,6 +94,9 @@ func (handler tagHandler) Create() gin.HandlerFunc {
 // @Param request body pkg.UpdateTagRequestJSON true "update request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/tags/{uuid} [put]
 //
@@ -106,7 +117,7 @@ func (handler tagHandler) Update() gin.HandlerFunc {
 		// Call API to update tag
 		if err := handler.api.Update(c, tagUUID, jsonRequest); err != nil {
 			log.Error().Msg("error updating tag: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, "ok")
@@ -122,6 +133,7 @@ func (handler tagHandler) Update() gin.HandlerFunc {
 // @Param uuid path string true "uuid"
 // @Produce json
 // @Success 200 {object} pkg.TagResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/tags/{uuid} [get]
 //
@@ -135,7 +147,7 @@ func (handler tagHandler) Find() gin.HandlerFunc {
 		tag, err := handler.api.Find(c, tagUUID)
 		if err != nil {
 			log.Error().Msg("error finding tag: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -144,29 +156,44 @@ func (handler tagHandler) Find() gin.HandlerFunc {
 	}
 }
 
-// FindAll returns a Gin handler function for finding all tags.
+// FindAll returns a Gin handler function for finding a page of tags.
 //
 // @Summary Find all tags
-// @Description Find all tags
+// @Description Find a page of tags, filtered and sorted by the query parameters
 // @Tags tags
 // @ID find-all-tags
 // @Produce json
-// @Success 200 {array} pkg.TagResponse
+// @Param limit query int false "maximum number of tags, 20 by default and at most 100"
+// @Param offset query int false "number of tags to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, createdAt by default" Enums(name, -name, createdAt, -createdAt)
+// @Param name query string false "only keep tags whose name contains this text"
+// @Param createdFrom query string false "only keep tags created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep tags created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.TagResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/tags [get]
 //
 // @Security Bearer-APIKey || Bearer-JWT
 func (handler tagHandler) FindAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Call API to find all tags
-		tags, err := handler.api.FindAll(c)
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of tags
+		tags, err := handler.api.FindAll(c, listRequest)
 		if err != nil {
 			log.Error().Msg("error finding all tags: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
 		// Return response
+		paginate(c, tags)
 		c.JSON(http.StatusOK, tags)
 	}
 }
@@ -174,12 +201,15 @@ func (handler tagHandler) FindAll() gin.HandlerFunc {
 // Delete returns a Gin handler function for deleting a tag by its UUID.
 //
 // @Summary Delete a tag
-// @Description Delete a tag
+// @Description Move a tag to the trash, refused while other entities still reference it unless cascade is set
 // @Tags tags
 // @ID delete-tag
 // @Param uuid path string true "uuid"
+// @Param cascade query bool false "detaches the tag from the programs it is attached to instead of refusing the deletion"
 // @Produce json
 // @Success 200 {string} string "deleted"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict, listing the dependents of the tag"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/tags/{uuid} [delete]
 //
@@ -189,10 +219,18 @@ func (handler tagHandler) Delete() gin.HandlerFunc {
 		// Extract tag UUID from path
 		tagUUID := c.Param("uuid")
 
+		// Extract query parameters
+		var deleteRequest pkg.DeleteRequestJSON
+		if err := c.ShouldBindQuery(&deleteRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
 		// Call API to delete tag
-		if err := handler.api.Delete(c, tagUUID); err != nil {
+		if err := handler.api.Delete(c, tagUUID, deleteRequest); err != nil {
 			log.Error().Msg("error deleting tag: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -201,6 +239,80 @@ func (handler tagHandler) Delete() gin.HandlerFunc {
 	}
 }
 
+// Restore returns a Gin handler function for restoring a tag from the trash by its UUID.
+//
+// @Summary Restore a tag
+// @Description Restore a tag from the trash
+// @Tags tags
+// @ID restore-tag
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {string} string "restored"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/tags/{uuid}/restore [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler tagHandler) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract tag UUID from path
+		tagUUID := c.Param("uuid")
+
+		// Call API to restore tag
+		if err := handler.api.Restore(c, tagUUID); err != nil {
+			log.Error().Msg("error restoring tag: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "restored")
+	}
+}
+
+// FindDeleted returns a Gin handler function for finding a page of the tags in the trash.
+//
+// @Summary Find deleted tags
+// @Description Find a page of the tags in the trash, filtered and sorted by the query parameters
+// @Tags tags
+// @ID find-deleted-tags
+// @Produce json
+// @Param limit query int false "maximum number of tags, 20 by default and at most 100"
+// @Param offset query int false "number of tags to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, -deletedAt by default" Enums(name, -name, createdAt, -createdAt, deletedAt, -deletedAt)
+// @Param name query string false "only keep tags whose name contains this text"
+// @Param createdFrom query string false "only keep tags created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep tags created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.TagResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/tags/trash [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler tagHandler) FindDeleted() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to find a page of the tags in the trash
+		tags, err := handler.api.FindDeleted(c, listRequest)
+		if err != nil {
+			log.Error().Msg("error finding deleted tags: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		paginate(c, tags)
+		c.JSON(http.StatusOK, tags)
+	}
+}
+
 // FindPrograms returns a Gin handler function for finding all programs associated with a tag.
 //
 // @Summary Find all tag's programs
@@ -223,7 +335,7 @@ func (handler tagHandler) FindPrograms() gin.HandlerFunc {
 		programs, err := handler.api.FindPrograms(c, tagUUID)
 		if err != nil {
 			log.Error().Msg("error finding all tag's programs: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
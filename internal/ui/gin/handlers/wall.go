@@ -21,17 +21,32 @@ type Wall interface {
 	// Find returns a Gin handler function for finding a wall by its UUID.
 	Find() gin.HandlerFunc
 
-	// FindAll returns a Gin handler function for finding all walls.
+	// FindAll returns a Gin handler function for finding a page of walls.
 	FindAll() gin.HandlerFunc
 
 	// Delete returns a Gin handler function for deleting a wall by its UUID.
 	Delete() gin.HandlerFunc
 
+	// Restore returns a Gin handler function for restoring a wall from the trash by its UUID.
+	Restore() gin.HandlerFunc
+
+	// FindDeleted returns a Gin handler function for finding a page of the walls in the trash.
+	FindDeleted() gin.HandlerFunc
+
 	// FindBlocks returns a Gin handler function for finding all blocks associated with a wall.
 	FindBlocks() gin.HandlerFunc
 
 	// OverwriteBlocks returns a Gin handler function for overwriting the blocks of a wall.
 	OverwriteBlocks() gin.HandlerFunc
+
+	// ReorderBlocks returns a Gin handler function for reordering the blocks of a wall.
+	ReorderBlocks() gin.HandlerFunc
+
+	// AddBlock returns a Gin handler function for adding a block to a wall.
+	AddBlock() gin.HandlerFunc
+
+	// RemoveBlock returns a Gin handler function for removing a block from a wall.
+	RemoveBlock() gin.HandlerFunc
 }
 
 type wallHandler struct {
@@ -54,6 +69,8 @@ func NewWallHandler(api api.Wall) Wall {
 // @Param request body pkg.CreateWallRequestJSON true "create request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/walls [post]
 //
@@ -67,9 +84,9 @@ func (handler wallHandler) Create() gin.HandlerFunc {
 			return
 		}
 
-		if err := handler.api.Create(c, jsonRequest); err != nil {
+		if _, err := handler.api.Create(c, jsonRequest); err != nil {
 			log.Error().Msg("error creating wall: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -87,6 +104,9 @@ func (handler wallHandler) Create() gin.HandlerFunc {
 // @Param request body pkg.UpdateWallRequestJSON true "update request"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/walls/{uuid} [put]
 //
@@ -104,7 +124,7 @@ func (handler wallHandler) Update() gin.HandlerFunc {
 
 		if err := handler.api.Update(c, wallUUID, jsonRequest); err != nil {
 			log.Error().Msg("error updating wall: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -121,6 +141,7 @@ func (handler wallHandler) Update() gin.HandlerFunc {
 // @Param uuid path string true "uuid"
 // @Produce json
 // @Success 200 {object} pkg.WallResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/walls/{uuid} [get]
 //
@@ -132,7 +153,7 @@ func (handler wallHandler) Find() gin.HandlerFunc {
 		wall, err := handler.api.Find(c, wallUUID)
 		if err != nil {
 			log.Error().Msg("error finding wall: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -140,27 +161,42 @@ func (handler wallHandler) Find() gin.HandlerFunc {
 	}
 }
 
-// FindAll returns a Gin handler function for finding all walls.
+// FindAll returns a Gin handler function for finding a page of walls.
 //
 // @Summary Find all walls
-// @Description Find all walls
+// @Description Find a page of walls, filtered and sorted by the query parameters
 // @Tags walls
 // @ID find-all-walls
 // @Produce json
-// @Success 200 {array} pkg.WallResponse
+// @Param limit query int false "maximum number of walls, 20 by default and at most 100"
+// @Param offset query int false "number of walls to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, createdAt by default" Enums(name, -name, createdAt, -createdAt)
+// @Param name query string false "only keep walls whose name contains this text"
+// @Param createdFrom query string false "only keep walls created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep walls created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.WallResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/walls [get]
 //
 // @Security Bearer-APIKey || Bearer-JWT
 func (handler wallHandler) FindAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		walls, err := handler.api.FindAll(c)
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		walls, err := handler.api.FindAll(c, listRequest)
 		if err != nil {
 			log.Error().Msg("error finding all walls: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
+		paginate(c, walls)
 		c.JSON(http.StatusOK, walls)
 	}
 }
@@ -174,6 +210,8 @@ func (handler wallHandler) FindAll() gin.HandlerFunc {
 // @Param uuid path string true "uuid"
 // @Produce json
 // @Success 200 {string} string "deleted"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 500 {object} pkg.ErrorJSON
 // @Router /private/walls/{uuid} [delete]
 //
@@ -184,7 +222,7 @@ func (handler wallHandler) Delete() gin.HandlerFunc {
 
 		if err := handler.api.Delete(c, wallUUID); err != nil {
 			log.Error().Msg("error deleting wall: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -192,6 +230,78 @@ func (handler wallHandler) Delete() gin.HandlerFunc {
 	}
 }
 
+// Restore returns a Gin handler function for restoring a wall from the trash by its UUID.
+//
+// @Summary Restore a wall
+// @Description Restore a wall from the trash
+// @Tags walls
+// @ID restore-wall
+// @Param uuid path string true "uuid"
+// @Produce json
+// @Success 200 {string} string "restored"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/walls/{uuid}/restore [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler wallHandler) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract wall UUID from path
+		wallUUID := c.Param("uuid")
+
+		// Call API to restore wall
+		if err := handler.api.Restore(c, wallUUID); err != nil {
+			log.Error().Msg("error restoring wall: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "restored")
+	}
+}
+
+// FindDeleted returns a Gin handler function for finding a page of the walls in the trash.
+//
+// @Summary Find deleted walls
+// @Description Find a page of the walls in the trash, filtered and sorted by the query parameters
+// @Tags walls
+// @ID find-deleted-walls
+// @Produce json
+// @Param limit query int false "maximum number of walls, 20 by default and at most 100"
+// @Param offset query int false "number of walls to skip"
+// @Param sort query string false "field to sort on, prefixed with - for descending order, -deletedAt by default" Enums(name, -name, createdAt, -createdAt, deletedAt, -deletedAt)
+// @Param name query string false "only keep walls whose name contains this text"
+// @Param createdFrom query string false "only keep walls created at or after this RFC 3339 time"
+// @Param createdTo query string false "only keep walls created at or before this RFC 3339 time"
+// @Success 200 {object} pkg.PageResponse[pkg.WallResponse]
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/walls/trash [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler wallHandler) FindDeleted() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var listRequest pkg.ListRequestJSON
+		if err := c.ShouldBindQuery(&listRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		walls, err := handler.api.FindDeleted(c, listRequest)
+		if err != nil {
+			log.Error().Msg("error finding deleted walls: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		paginate(c, walls)
+		c.JSON(http.StatusOK, walls)
+	}
+}
+
 // FindBlocks returns a Gin handler function for finding all blocks associated with a wall.
 //
 // @Summary Find all wall's blocks
@@ -212,7 +322,7 @@ func (handler wallHandler) FindBlocks() gin.HandlerFunc {
 		blocks, err := handler.api.FindBlocks(c, wallUUID)
 		if err != nil {
 			log.Error().Msg("error finding all wall's blocks: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
 			return
 		}
 
@@ -230,6 +340,8 @@ func (handler wallHandler) FindBlocks() gin.HandlerFunc {
 // @Param request body pkg.OverwriteBlocksRequestJSON true "List of blocks' UUIDs to set"
 // @Produce json
 // @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 409 {object} pkg.ErrorJSON "Conflict"
 // @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
 // @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
 // @Router /private/walls/{uuid}/blocks/overwrite [put]
@@ -248,10 +360,118 @@ func (handler wallHandler) OverwriteBlocks() gin.HandlerFunc {
 
 		if err := handler.api.OverwriteBlocks(c, wallUUID, jsonRequest); err != nil {
 			log.Error().Msg("error overwriting blocks: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			renderError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// ReorderBlocks returns a Gin handler function for reordering the blocks of a wall.
+//
+// @Summary Reorder blocks of a wall
+// @Description Reorder the blocks of a wall, identified by their block ID, renumbering their positions from 1
+// @Tags walls
+// @ID reorder-wall-blocks
+// @Param uuid path string true "UUID of the wall"
+// @Param request body pkg.ReorderRequestJSON true "new order, as a list of IDs or a move between two positions"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 400 {object} pkg.ErrorJSON "Bad Request, when the IDs do not list every block exactly once or a position is out of range"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 422 {object} pkg.ErrorJSON "Unprocessable Entity"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/walls/{uuid}/blocks/reorder [patch]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler wallHandler) ReorderBlocks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract wall UUID from path
+		wallUUID := c.Param("uuid")
+
+		// Extract body request
+		var jsonRequest pkg.ReorderRequestJSON
+		if err := c.ShouldBindJSON(&jsonRequest); err != nil {
+			log.Ctx(c).Error().Err(err).Msg("error binding request")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call API to reorder the blocks of the wall
+		if err := handler.api.ReorderBlocks(c, wallUUID, jsonRequest); err != nil {
+			log.Error().Msg("error reordering wall blocks: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// AddBlock returns a Gin handler function for add a block to a wall.
+//
+// @Summary Add a block to a wall
+// @Description Append a block to a wall, after its last block; adding a block the wall already holds does nothing
+// @Tags walls
+// @ID add-wall-block
+// @Param uuid path string true "UUID of the wall"
+// @Param blockUUID path string true "UUID of the block"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, when the wall or the block does not exist"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/walls/{uuid}/blocks/{blockUUID} [post]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler wallHandler) AddBlock() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract wall and block UUIDs from path
+		wallUUID := c.Param("uuid")
+		blockUUID := c.Param("blockUUID")
+
+		// Call API to add wall block
+		if err := handler.api.AddBlock(c, wallUUID, blockUUID); err != nil {
+			log.Error().Msg("error adding wall block: " + err.Error())
+			renderError(c, err)
+			return
+		}
+
+		// Return response
+		c.JSON(http.StatusOK, "ok")
+	}
+}
+
+// RemoveBlock returns a Gin handler function for remove a block from a wall.
+//
+// @Summary Remove a block from a wall
+// @Description Remove a block from a wall, renumbering the positions of the remaining blocks from 1; removing a block the wall does not hold does nothing
+// @Tags walls
+// @ID remove-wall-block
+// @Param uuid path string true "UUID of the wall"
+// @Param blockUUID path string true "UUID of the block"
+// @Produce json
+// @Success 200 {string} string "ok"
+// @Failure 404 {object} pkg.ErrorJSON "Not Found, when the wall does not exist"
+// @Failure 500 {object} pkg.ErrorJSON "Internal Server Error"
+// @Router /private/walls/{uuid}/blocks/{blockUUID} [delete]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler wallHandler) RemoveBlock() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Extract wall and block UUIDs from path
+		wallUUID := c.Param("uuid")
+		blockUUID := c.Param("blockUUID")
+
+		// Call API to remove wall block
+		if err := handler.api.RemoveBlock(c, wallUUID, blockUUID); err != nil {
+			log.Error().Msg("error removing wall block: " + err.Error())
+			renderError(c, err)
 			return
 		}
 
+		// Return response
 		c.JSON(http.StatusOK, "ok")
 	}
 }
@@ -0,0 +1,95 @@
+// Package handlers provides HTTP request handlers for rendering walls with their whole content.
+package handlers
+
+import (
+	"context"
+	"github.com/khedhrije/podcaster-backoffice-api/pkg"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/api"
+	"github.com/rs/zerolog/log"
+)
+
+// publicTreeMaxAge is the number of seconds shared caches may serve a public wall tree without revalidating it.
+const publicTreeMaxAge = "60"
+
+// WallTree represents the interface for rendering walls with their whole content.
+type WallTree interface {
+	// Find returns a Gin handler function for finding the tree of a wall.
+	Find() gin.HandlerFunc
+
+	// FindPublic returns a Gin handler function for finding the tree of a wall without authentication.
+	FindPublic() gin.HandlerFunc
+}
+
+// wallTreeHandler is an implementation of the WallTree interface.
+type wallTreeHandler struct {
+	api api.WallTree
+}
+
+// NewWallTreeHandler creates a new instance of WallTree interface.
+func NewWallTreeHandler(api api.WallTree) WallTree {
+	return &wallTreeHandler{
+		api: api,
+	}
+}
+
+// Find returns a Gin handler function for finding the tree of a wall.
+//
+// @Summary Find the tree of a wall
+// @Description Find a wall along with its ordered blocks, their ordered programs, the episodes of these programs and their medias
+// @Tags walls
+// @ID find-wall-tree
+// @Param uuid path string true "UUID of the wall"
+// @Produce json
+// @Success 200 {object} pkg.WallTreeResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /private/walls/{uuid}/tree [get]
+//
+// @Security Bearer-APIKey || Bearer-JWT
+func (handler wallTreeHandler) Find() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tree, ok := handler.find(c, handler.api.FindTree); ok {
+			c.JSON(http.StatusOK, tree)
+		}
+	}
+}
+
+// FindPublic returns a Gin handler function for finding the tree of a wall without authentication.
+// The response may be kept by shared caches for a short while.
+//
+// @Summary Find the tree of a wall for the front-office
+// @Description Find a wall along with its ordered blocks, their ordered programs, the published episodes of these programs and their medias.
+// @Description This read-only endpoint requires no authentication.
+// @Tags public
+// @ID find-public-wall-tree
+// @Param uuid path string true "UUID of the wall"
+// @Produce json
+// @Success 200 {object} pkg.WallTreeResponse
+// @Failure 404 {object} pkg.ErrorJSON "Not Found"
+// @Failure 500 {object} pkg.ErrorJSON
+// @Router /public/walls/{uuid}/tree [get]
+func (handler wallTreeHandler) FindPublic() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tree, ok := handler.find(c, handler.api.FindPublishedTree); ok {
+			c.Header("Cache-Control", "public, max-age="+publicTreeMaxAge)
+			c.JSON(http.StatusOK, tree)
+		}
+	}
+}
+
+// find finds the tree of the wall identified by the uuid path parameter with findTree.
+// It renders the error and returns false when the tree cannot be found.
+func (handler wallTreeHandler) find(c *gin.Context, findTree func(ctx context.Context, uuid string) (*pkg.WallTreeResponse, error)) (*pkg.WallTreeResponse, bool) {
+	wallUUID := c.Param("uuid")
+
+	tree, err := findTree(c, wallUUID)
+	if err != nil {
+		log.Error().Msg("error finding wall tree: " + err.Error())
+		renderError(c, err)
+		return nil, false
+	}
+	return tree, true
+}
@@ -0,0 +1,162 @@
+package router
+
+import (
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route identifies a route by its method and full path, as registered in the router.
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Policy maps routes to the minimum role required to call them.
+// Routes missing from a policy are reserved to administrators, so that a new route is never open by mistake.
+type Policy map[Route]model.Role
+
+// privatePolicy is the policy of the private routes.
+var privatePolicy = Policy{
+	// Walls
+	{http.MethodPost, "/private/walls"}:                           model.RoleEditor,
+	{http.MethodPut, "/private/walls/:uuid"}:                      model.RoleEditor,
+	{http.MethodGet, "/private/walls/:uuid"}:                      model.RoleViewer,
+	{http.MethodGet, "/private/walls"}:                            model.RoleViewer,
+	{http.MethodDelete, "/private/walls/:uuid"}:                   model.RoleEditor,
+	{http.MethodPost, "/private/walls/:uuid/restore"}:             model.RoleEditor,
+	{http.MethodGet, "/private/walls/trash"}:                      model.RoleViewer,
+	{http.MethodGet, "/private/walls/:uuid/blocks"}:               model.RoleViewer,
+	{http.MethodGet, "/private/walls/:uuid/tree"}:                 model.RoleViewer,
+	{http.MethodPut, "/private/walls/:uuid/blocks/overwrite"}:     model.RoleEditor,
+	{http.MethodPatch, "/private/walls/:uuid/blocks/reorder"}:     model.RoleEditor,
+	{http.MethodPost, "/private/walls/:uuid/blocks/:blockUUID"}:   model.RoleEditor,
+	{http.MethodDelete, "/private/walls/:uuid/blocks/:blockUUID"}: model.RoleEditor,
+
+	// Blocks
+	{http.MethodPost, "/private/blocks"}:                               model.RoleEditor,
+	{http.MethodPut, "/private/blocks/:uuid"}:                          model.RoleEditor,
+	{http.MethodGet, "/private/blocks/:uuid"}:                          model.RoleViewer,
+	{http.MethodGet, "/private/blocks"}:                                model.RoleViewer,
+	{http.MethodDelete, "/private/blocks/:uuid"}:                       model.RoleEditor,
+	{http.MethodPost, "/private/blocks/:uuid/restore"}:                 model.RoleEditor,
+	{http.MethodGet, "/private/blocks/trash"}:                          model.RoleViewer,
+	{http.MethodGet, "/private/blocks/:uuid/programs"}:                 model.RoleViewer,
+	{http.MethodPut, "/private/blocks/:uuid/programs/overwrite"}:       model.RoleEditor,
+	{http.MethodPatch, "/private/blocks/:uuid/programs/reorder"}:       model.RoleEditor,
+	{http.MethodPost, "/private/blocks/:uuid/programs/:programUUID"}:   model.RoleEditor,
+	{http.MethodDelete, "/private/blocks/:uuid/programs/:programUUID"}: model.RoleEditor,
+
+	// Programs
+	{http.MethodPost, "/private/programs"}:                                  model.RoleEditor,
+	{http.MethodPost, "/private/programs/import"}:                           model.RoleEditor,
+	{http.MethodPut, "/private/programs/:uuid"}:                             model.RoleEditor,
+	{http.MethodGet, "/private/programs/:uuid"}:                             model.RoleViewer,
+	{http.MethodGet, "/private/programs"}:                                   model.RoleViewer,
+	{http.MethodDelete, "/private/programs/:uuid"}:                          model.RoleEditor,
+	{http.MethodPost, "/private/programs/:uuid/restore"}:                    model.RoleEditor,
+	{http.MethodGet, "/private/programs/trash"}:                             model.RoleViewer,
+	{http.MethodGet, "/private/programs/:uuid/episodes"}:                    model.RoleViewer,
+	{http.MethodGet, "/private/programs/:uuid/tags"}:                        model.RoleViewer,
+	{http.MethodGet, "/private/programs/:uuid/categories"}:                  model.RoleViewer,
+	{http.MethodPut, "/private/programs/:uuid/tags/overwrite"}:              model.RoleEditor,
+	{http.MethodPut, "/private/programs/:uuid/categories/overwrite"}:        model.RoleEditor,
+	{http.MethodPatch, "/private/programs/:uuid/episodes/reorder"}:          model.RoleEditor,
+	{http.MethodPost, "/private/programs/:uuid/tags/:tagUUID"}:              model.RoleEditor,
+	{http.MethodDelete, "/private/programs/:uuid/tags/:tagUUID"}:            model.RoleEditor,
+	{http.MethodPost, "/private/programs/:uuid/categories/:categoryUUID"}:   model.RoleEditor,
+	{http.MethodDelete, "/private/programs/:uuid/categories/:categoryUUID"}: model.RoleEditor,
+	{http.MethodPost, "/private/programs/bulk"}:                             model.RoleEditor,
+	{http.MethodPut, "/private/programs/bulk"}:                              model.RoleEditor,
+	{http.MethodDelete, "/private/programs/bulk"}:                           model.RoleEditor,
+
+	// Episodes
+	{http.MethodPost, "/private/episodes"}:                 model.RoleEditor,
+	{http.MethodPut, "/private/episodes/:uuid"}:            model.RoleEditor,
+	{http.MethodGet, "/private/episodes/:uuid"}:            model.RoleViewer,
+	{http.MethodGet, "/private/episodes"}:                  model.RoleViewer,
+	{http.MethodDelete, "/private/episodes/:uuid"}:         model.RoleEditor,
+	{http.MethodPost, "/private/episodes/:uuid/restore"}:   model.RoleEditor,
+	{http.MethodGet, "/private/episodes/trash"}:            model.RoleViewer,
+	{http.MethodPost, "/private/episodes/:uuid/publish"}:   model.RolePublisher,
+	{http.MethodPost, "/private/episodes/:uuid/schedule"}:  model.RolePublisher,
+	{http.MethodPost, "/private/episodes/:uuid/unpublish"}: model.RolePublisher,
+	{http.MethodPost, "/private/episodes/:uuid/draft"}:     model.RolePublisher,
+	{http.MethodPost, "/private/episodes/bulk"}:            model.RoleEditor,
+	{http.MethodPut, "/private/episodes/bulk"}:             model.RoleEditor,
+	{http.MethodDelete, "/private/episodes/bulk"}:          model.RoleEditor,
+
+	// Medias
+	{http.MethodPost, "/private/medias"}:               model.RoleEditor,
+	{http.MethodPost, "/private/medias/upload"}:        model.RoleEditor,
+	{http.MethodPut, "/private/medias/:uuid"}:          model.RoleEditor,
+	{http.MethodGet, "/private/medias/:uuid"}:          model.RoleViewer,
+	{http.MethodGet, "/private/medias"}:                model.RoleViewer,
+	{http.MethodDelete, "/private/medias/:uuid"}:       model.RoleEditor,
+	{http.MethodPost, "/private/medias/:uuid/restore"}: model.RoleEditor,
+	{http.MethodGet, "/private/medias/trash"}:          model.RoleViewer,
+	{http.MethodPost, "/private/medias/bulk"}:          model.RoleEditor,
+	{http.MethodPut, "/private/medias/bulk"}:           model.RoleEditor,
+	{http.MethodDelete, "/private/medias/bulk"}:        model.RoleEditor,
+
+	// Tags
+	{http.MethodPost, "/private/tags"}:               model.RoleEditor,
+	{http.MethodPut, "/private/tags/:uuid"}:          model.RoleEditor,
+	{http.MethodGet, "/private/tags/:uuid"}:          model.RoleViewer,
+	{http.MethodGet, "/private/tags"}:                model.RoleViewer,
+	{http.MethodDelete, "/private/tags/:uuid"}:       model.RoleEditor,
+	{http.MethodPost, "/private/tags/:uuid/restore"}: model.RoleEditor,
+	{http.MethodGet, "/private/tags/trash"}:          model.RoleViewer,
+	{http.MethodGet, "/private/tags/:uuid/programs"}: model.RoleViewer,
+	{http.MethodPost, "/private/tags/bulk"}:          model.RoleEditor,
+	{http.MethodPut, "/private/tags/bulk"}:           model.RoleEditor,
+	{http.MethodDelete, "/private/tags/bulk"}:        model.RoleEditor,
+
+	// Categories
+	{http.MethodPost, "/private/categories"}:                  model.RoleEditor,
+	{http.MethodPost, "/private/categories/:uuid/move"}:       model.RoleEditor,
+	{http.MethodPut, "/private/categories/:uuid"}:             model.RoleEditor,
+	{http.MethodGet, "/private/categories/:uuid"}:             model.RoleViewer,
+	{http.MethodGet, "/private/categories"}:                   model.RoleViewer,
+	{http.MethodDelete, "/private/categories/:uuid"}:          model.RoleEditor,
+	{http.MethodPost, "/private/categories/:uuid/restore"}:    model.RoleEditor,
+	{http.MethodGet, "/private/categories/trash"}:             model.RoleViewer,
+	{http.MethodGet, "/private/categories/:uuid/programs"}:    model.RoleViewer,
+	{http.MethodGet, "/private/categories/tree"}:              model.RoleViewer,
+	{http.MethodGet, "/private/categories/:uuid/ancestors"}:   model.RoleViewer,
+	{http.MethodGet, "/private/categories/:uuid/descendants"}: model.RoleViewer,
+
+	// Search
+	{http.MethodGet, "/private/search"}: model.RoleViewer,
+
+	// Audit log
+	{http.MethodGet, "/private/audit"}: model.RoleAdmin,
+}
+
+// AuthorizationMiddleware creates a Gin middleware that rejects with 403 the callers whose role is below
+// the role policy requires for the matched route.
+// It must run after TokenValidatorMiddleware, which stores the claims of the caller.
+func AuthorizationMiddleware(policy Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		required, ok := policy[Route{Method: c.Request.Method, Path: c.FullPath()}]
+		if !ok {
+			required = model.RoleAdmin
+		}
+
+		var role model.Role
+		if value, exists := c.Get(claimsKey); exists {
+			if claims, ok := value.(*model.Claims); ok {
+				role = claims.Role()
+			}
+		}
+		if role < required {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient role", "required": required.String()})
+			c.Abort()
+			return
+		}
+
+		// Caller is allowed, proceed to the next handler
+		c.Next()
+	}
+}
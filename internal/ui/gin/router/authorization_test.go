@@ -0,0 +1,142 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/ui/gin/handlers"
+)
+
+func TestAuthorizationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		roles      []string
+		wantStatus int
+	}{
+		{
+			name:       "lets a viewer read",
+			method:     http.MethodGet,
+			path:       "/private/programs/p1",
+			roles:      []string{"viewer"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "forbids a viewer to delete a program",
+			method:     http.MethodDelete,
+			path:       "/private/programs/p1",
+			roles:      []string{"viewer"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "lets an editor delete a program",
+			method:     http.MethodDelete,
+			path:       "/private/programs/p1",
+			roles:      []string{"editor"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "lets higher roles overwrite wall blocks",
+			method:     http.MethodPut,
+			path:       "/private/walls/w1/blocks/overwrite",
+			roles:      []string{"viewer", "Publisher"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "forbids an editor to publish an episode",
+			method:     http.MethodPost,
+			path:       "/private/episodes/e1/publish",
+			roles:      []string{"editor"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "lets a publisher publish an episode",
+			method:     http.MethodPost,
+			path:       "/private/episodes/e1/publish",
+			roles:      []string{"publisher"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "forbids a caller without back-office role",
+			method:     http.MethodGet,
+			path:       "/private/programs/p1",
+			roles:      []string{"listener"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "reserves routes missing from the policy to administrators",
+			method:     http.MethodPost,
+			path:       "/private/unlisted",
+			roles:      []string{"publisher"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "lets an administrator call routes missing from the policy",
+			method:     http.MethodPost,
+			path:       "/private/unlisted",
+			roles:      []string{"admin"},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			private := r.Group("/private")
+			private.Use(func(c *gin.Context) {
+				c.Set(claimsKey, &model.Claims{Subject: "user-1", Roles: tt.roles})
+			}, AuthorizationMiddleware(privatePolicy))
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+			private.GET("/programs/:uuid", ok)
+			private.DELETE("/programs/:uuid", ok)
+			private.PUT("/walls/:uuid/blocks/overwrite", ok)
+			private.POST("/episodes/:uuid/publish", ok)
+			private.POST("/unlisted", ok)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestPrivatePolicy_CoversEveryRoute guards against private routes silently falling back to the administrator role.
+func TestPrivatePolicy_CoversEveryRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	configuration.Config = &configuration.AppConfig{}
+	r := CreateRouter(
+		handlers.NewWallHandler(nil),
+		handlers.NewBlockHandler(nil),
+		handlers.NewProgramHandler(nil),
+		handlers.NewEpisodeHandler(nil),
+		handlers.NewMediaHandler(nil),
+		handlers.NewTagHandler(nil),
+		handlers.NewCategoryHandler(nil),
+		handlers.NewSearchHandler(nil),
+		handlers.NewWallTreeHandler(nil),
+		handlers.NewAuditHandler(nil),
+		handlers.NewFeedHandler(nil),
+		handlers.NewFeedImportHandler(nil),
+		handlers.NewBulkHandler(nil),
+		nil,
+		nil,
+	)
+
+	for _, info := range r.Routes() {
+		if !strings.HasPrefix(info.Path, "/private/") {
+			continue
+		}
+		if _, ok := privatePolicy[Route{Method: info.Method, Path: info.Path}]; !ok {
+			t.Errorf("route %s %s is missing from the private policy", info.Method, info.Path)
+		}
+	}
+}
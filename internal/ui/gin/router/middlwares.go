@@ -1,9 +1,11 @@
 package router
 
 import (
-	"fmt"
-	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
-	"io/ioutil"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/rs/zerolog/log"
 	"net/http"
 	"strings"
 
@@ -11,12 +13,42 @@ import (
 )
 
 const (
-	validationEndpoint = "/private/token/validate"
-	refreshEndpoint    = "/private/token/refresh"
+	// claimsKey is the gin context key under which the claims of the authenticated caller are stored.
+	claimsKey = "claims"
+	// accessTokenHeader is the response header carrying the new access token after a refresh.
+	accessTokenHeader = "access_token"
+	// refreshTokenHeader is the request header carrying the refresh token of the caller,
+	// and the response header carrying the new refresh token after a refresh.
+	refreshTokenHeader = "refresh_token"
+	// requestIDHeader is the request header carrying the identifier the caller gives to the request,
+	// and the response header carrying the identifier the request was handled with.
+	requestIDHeader = "X-Request-ID"
+	// maxRequestIDLength is the longest request identifier accepted from the caller.
+	maxRequestIDLength = 128
 )
 
-// TokenValidatorMiddleware creates a Gin middleware that validates a token by calling an external endpoint.
-func TokenValidatorMiddleware() gin.HandlerFunc {
+// RequestIDMiddleware creates a Gin middleware that identifies every request, with the X-Request-ID header
+// given by the caller or else a new UUID. The identifier is returned in the X-Request-ID response header and stored
+// in the request context, so that APIs can read it with model.RequestIDFromContext.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.New().String()
+		}
+		c.Header(requestIDHeader, requestID)
+		c.Request = c.Request.WithContext(model.ContextWithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
+
+// TokenValidatorMiddleware creates a Gin middleware that validates the bearer token of the request with verifier.
+// When the access token has expired and the request carries a refresh token, the tokens are refreshed with refresher,
+// which may be nil when tokens cannot be refreshed, and the new tokens are returned in the access_token and
+// refresh_token response headers.
+// The claims of a valid token are stored in the gin context and in the request context, so that handlers and APIs
+// can read them with model.ClaimsFromContext.
+func TokenValidatorMiddleware(verifier port.TokenVerifier, refresher port.TokenRefresher) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Extract the token from the Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -25,121 +57,67 @@ func TokenValidatorMiddleware() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
-
-		// Split the header to get the token part
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+		token, ok := bearer(authHeader)
+		if !ok {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
 			c.Abort()
 			return
 		}
 
-		token := tokenParts[1]
-
-		url := fmt.Sprintf("%s%s", configuration.Config.AccountApi.BaseURL, validationEndpoint)
-		// Create a new HTTP request
-		req, err := http.NewRequest("POST", url, nil)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create request"})
-			c.Abort()
-			return
+		// Verify the token, refreshing it when it has expired
+		claims, err := verifier.Verify(c.Request.Context(), token)
+		if errors.Is(err, model.ErrTokenExpired) && refresher != nil && c.GetHeader(refreshTokenHeader) != "" {
+			claims, err = refresh(c, verifier, refresher)
 		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Authorization", "Bearer "+token)
-
-		// Send the request
-		client := &http.Client{}
-		resp, err := client.Do(req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate token"})
+			switch {
+			case errors.Is(err, model.ErrTokenExpired):
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token expired"})
+			case errors.Is(err, model.ErrInvalidToken):
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			default:
+				log.Ctx(c.Request.Context()).Error().Err(err).Msg("error while validating token")
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Failed to validate token"})
+			}
 			c.Abort()
 			return
 		}
-		defer resp.Body.Close()
 
-		// Read the response body
-		_, err = ioutil.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read response body"})
-			c.Abort()
-			return
-		}
-
-		// Check the response status code
-		if resp.StatusCode != http.StatusOK {
-			TokenRefresherMiddleware()
-			return
-		}
-
-		// Token is valid, proceed to the next handler
+		// Token is valid, expose the claims and proceed to the next handler
+		c.Set(claimsKey, claims)
+		c.Request = c.Request.WithContext(model.ContextWithClaims(c.Request.Context(), claims))
 		c.Next()
 	}
 }
 
-// TokenRefresherMiddleware creates a Gin middleware that refreshes a token by calling an external endpoint.
-func TokenRefresherMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Extract the token from the Authorization header
-		refreshHeader := c.GetHeader("refresh_token")
-		if refreshHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "refresh header is required"})
-			c.Abort()
-			return
-		}
-
-		// Split the header to get the token part
-		tokenParts := strings.Split(refreshHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh header format"})
-			c.Abort()
-			return
-		}
-
-		token := tokenParts[1]
-
-		// Create a new HTTP request
-		url := fmt.Sprintf("%s%s", configuration.Config.AccountApi.BaseURL, refreshEndpoint)
-		req, err := http.NewRequest("POST", url, nil)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create request"})
-			c.Abort()
-			return
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("refresh_token", "Bearer "+token)
-
-		// Send the request
-		client := &http.Client{}
-		resp, err := client.Do(req)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
-			c.Abort()
-			return
-		}
-		defer resp.Body.Close()
-
-		// Read the response body
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read response body"})
-			c.Abort()
-			return
-		}
-
-		// Check the response status code
-		if resp.StatusCode != http.StatusOK {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to refresh token", "details": string(body)})
-			c.Abort()
-			return
-		}
-
-		// Parse the new token from the response
-		newToken := string(body) // Assuming the body contains the new token as plain text, adjust as needed
-
-		// Set the new token in the refresh_token header for the response
-		c.Header("refresh_token", "Bearer "+newToken)
+// refresh exchanges the refresh token of the request for new tokens, verifies the new access token
+// and sets the new tokens in the response headers.
+// It returns the claims of the new access token, or model.ErrInvalidToken when the refresh token is malformed or rejected.
+func refresh(c *gin.Context, verifier port.TokenVerifier, refresher port.TokenRefresher) (*model.Claims, error) {
+	refreshToken, ok := bearer(c.GetHeader(refreshTokenHeader))
+	if !ok {
+		return nil, model.ErrInvalidToken
+	}
+	tokens, err := refresher.Refresh(c.Request.Context(), refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := verifier.Verify(c.Request.Context(), tokens.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	c.Header(accessTokenHeader, "Bearer "+tokens.AccessToken)
+	if tokens.RefreshToken != "" {
+		c.Header(refreshTokenHeader, "Bearer "+tokens.RefreshToken)
+	}
+	return claims, nil
+}
 
-		// Proceed to the next handler
-		c.Next()
+// bearer extracts the token of a header value using the Bearer scheme.
+func bearer(header string) (string, bool) {
+	parts := strings.Split(header, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" || parts[1] == "" {
+		return "", false
 	}
+	return parts[1], true
 }
@@ -0,0 +1,350 @@
+package router
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/model"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/infrastructure/auth"
+)
+
+// tokenIssuer signs the access tokens of the tests, standing in for the account API signing key.
+type tokenIssuer struct {
+	key *ecdsa.PrivateKey
+}
+
+func newTokenIssuer(t *testing.T) tokenIssuer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return tokenIssuer{key: key}
+}
+
+// publicKey returns the PEM encoded public key of the issuer.
+func (i tokenIssuer) publicKey(t *testing.T) string {
+	der, err := x509.MarshalPKIXPublicKey(&i.key.PublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// issue signs an access token for subject expiring after expiresIn.
+func (i tokenIssuer) issue(t *testing.T, subject string, expiresIn time.Duration) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"sub": subject,
+		"exp": time.Now().Add(expiresIn).Unix(),
+	}).SignedString(i.key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return token
+}
+
+// newAccountApi starts a stand-in of the account API refresh endpoint.
+// Refresh token r1 is exchanged for a JSON token pair, r2 for a plain text access token,
+// forged for a token signed with another key and broken fails; any other refresh token is rejected.
+func newAccountApi(t *testing.T, issuer tokenIssuer, calls *atomic.Int32) *httptest.Server {
+	forger := newTokenIssuer(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Method != http.MethodPost || r.URL.Path != "/private/token/refresh" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Header.Get("refresh_token") {
+		case "Bearer r1":
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"access_token":  issuer.issue(t, "refreshed", time.Hour),
+				"refresh_token": "r1-rotated",
+			})
+		case "Bearer r2":
+			_, _ = w.Write([]byte(issuer.issue(t, "refreshed", time.Hour)))
+		case "Bearer forged":
+			_, _ = w.Write([]byte(forger.issue(t, "refreshed", time.Hour)))
+		case "Bearer broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTokenValidatorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	issuer := newTokenIssuer(t)
+	tests := []struct {
+		name             string
+		access           func(t *testing.T) string
+		refresh          string
+		withoutRefresher bool
+		wantStatus       int
+		wantSubject      string
+		wantAccess       bool
+		wantRefresh      string
+		wantCalls        int32
+	}{
+		{
+			name:        "accepts a valid token without refreshing it",
+			access:      func(t *testing.T) string { return "Bearer " + issuer.issue(t, "user-1", time.Hour) },
+			refresh:     "Bearer r1",
+			wantStatus:  http.StatusOK,
+			wantSubject: "user-1",
+		},
+		{
+			name:       "requires the authorization header",
+			access:     func(t *testing.T) string { return "" },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "rejects an authorization header without bearer scheme",
+			access:     func(t *testing.T) string { return "Basic dXNlcjpwYXNz" },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "rejects an invalid token without refreshing it",
+			access:     func(t *testing.T) string { return "Bearer " + newTokenIssuer(t).issue(t, "user-1", time.Hour) },
+			refresh:    "Bearer r1",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "refreshes an expired token and rotates the refresh token",
+			access:      func(t *testing.T) string { return "Bearer " + issuer.issue(t, "user-1", -time.Hour) },
+			refresh:     "Bearer r1",
+			wantStatus:  http.StatusOK,
+			wantSubject: "refreshed",
+			wantAccess:  true,
+			wantRefresh: "Bearer r1-rotated",
+			wantCalls:   1,
+		},
+		{
+			name:        "refreshes an expired token with a plain text response",
+			access:      func(t *testing.T) string { return "Bearer " + issuer.issue(t, "user-1", -time.Hour) },
+			refresh:     "Bearer r2",
+			wantStatus:  http.StatusOK,
+			wantSubject: "refreshed",
+			wantAccess:  true,
+			wantCalls:   1,
+		},
+		{
+			name:       "rejects an expired token without refresh token",
+			access:     func(t *testing.T) string { return "Bearer " + issuer.issue(t, "user-1", -time.Hour) },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "rejects a malformed refresh header",
+			access:     func(t *testing.T) string { return "Bearer " + issuer.issue(t, "user-1", -time.Hour) },
+			refresh:    "r1",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "rejects a refresh token refused by the account api",
+			access:     func(t *testing.T) string { return "Bearer " + issuer.issue(t, "user-1", -time.Hour) },
+			refresh:    "Bearer revoked",
+			wantStatus: http.StatusUnauthorized,
+			wantCalls:  1,
+		},
+		{
+			name:       "rejects a refreshed token that does not verify",
+			access:     func(t *testing.T) string { return "Bearer " + issuer.issue(t, "user-1", -time.Hour) },
+			refresh:    "Bearer forged",
+			wantStatus: http.StatusUnauthorized,
+			wantCalls:  1,
+		},
+		{
+			name:       "reports an account api failure",
+			access:     func(t *testing.T) string { return "Bearer " + issuer.issue(t, "user-1", -time.Hour) },
+			refresh:    "Bearer broken",
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  1,
+		},
+		{
+			name:             "rejects an expired token when refreshing is not configured",
+			access:           func(t *testing.T) string { return "Bearer " + issuer.issue(t, "user-1", -time.Hour) },
+			refresh:          "Bearer r1",
+			withoutRefresher: true,
+			wantStatus:       http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			config := configuration.AccountApi{
+				BaseURL:   newAccountApi(t, issuer, &calls).URL,
+				PublicKey: issuer.publicKey(t),
+				Timeout:   time.Second,
+			}
+			verifier, err := auth.NewVerifier(config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var refresher port.TokenRefresher
+			if !tt.withoutRefresher {
+				if refresher, err = auth.NewRefresher(config); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			r := gin.New()
+			r.ContextWithFallback = true
+			r.GET("/private/walls", TokenValidatorMiddleware(verifier, refresher), func(c *gin.Context) {
+				claims, ok := model.ClaimsFromContext(c)
+				if !ok {
+					c.Status(http.StatusInternalServerError)
+					return
+				}
+				c.String(http.StatusOK, claims.Subject)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/private/walls", nil)
+			if access := tt.access(t); access != "" {
+				req.Header.Set("Authorization", access)
+			}
+			if tt.refresh != "" {
+				req.Header.Set("refresh_token", tt.refresh)
+			}
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != tt.wantSubject {
+				t.Fatalf("got subject %q, want %q", w.Body.String(), tt.wantSubject)
+			}
+			if got := w.Header().Get("access_token"); (got != "") != tt.wantAccess {
+				t.Fatalf("got access token header %q, want one: %v", got, tt.wantAccess)
+			}
+			if got := w.Header().Get("refresh_token"); got != tt.wantRefresh {
+				t.Fatalf("got refresh token header %q, want %q", got, tt.wantRefresh)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Fatalf("got %d calls to the account api, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// newRemoteAccountApi starts a stand-in of the account API validation and refresh endpoints, for deployments
+// that only configure its base URL. Tokens valid and refreshed are accepted, denied is forbidden and any other
+// token is unauthorized. Refresh token r1 is exchanged for the refreshed token; any other is rejected.
+func newRemoteAccountApi(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/private/token/validate":
+			switch r.Header.Get("Authorization") {
+			case "Bearer valid":
+				_ = json.NewEncoder(w).Encode(map[string]string{"sub": "user-1"})
+			case "Bearer refreshed":
+				_ = json.NewEncoder(w).Encode(map[string]string{"sub": "refreshed"})
+			case "Bearer denied":
+				w.WriteHeader(http.StatusForbidden)
+			default:
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		case "/private/token/refresh":
+			if r.Header.Get("refresh_token") != "Bearer r1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "refreshed"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTokenValidatorMiddleware_RemoteOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name        string
+		access      string
+		refresh     string
+		wantStatus  int
+		wantSubject string
+		wantAccess  bool
+	}{
+		{
+			name:        "accepts a token validated by the account api",
+			access:      "Bearer valid",
+			refresh:     "Bearer r1",
+			wantStatus:  http.StatusOK,
+			wantSubject: "user-1",
+		},
+		{
+			name:        "refreshes a token the account api does not authorize",
+			access:      "Bearer expired",
+			refresh:     "Bearer r1",
+			wantStatus:  http.StatusOK,
+			wantSubject: "refreshed",
+			wantAccess:  true,
+		},
+		{
+			name:       "rejects a token the account api does not authorize without refresh token",
+			access:     "Bearer expired",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "rejects a token forbidden by the account api without refreshing it",
+			access:     "Bearer denied",
+			refresh:    "Bearer r1",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := configuration.AccountApi{BaseURL: newRemoteAccountApi(t).URL, Timeout: time.Second}
+			verifier, err := auth.NewVerifier(config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			refresher, err := auth.NewRefresher(config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			r := gin.New()
+			r.ContextWithFallback = true
+			r.GET("/private/walls", TokenValidatorMiddleware(verifier, refresher), func(c *gin.Context) {
+				claims, _ := model.ClaimsFromContext(c)
+				c.String(http.StatusOK, claims.Subject)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/private/walls", nil)
+			req.Header.Set("Authorization", tt.access)
+			if tt.refresh != "" {
+				req.Header.Set("refresh_token", tt.refresh)
+			}
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != tt.wantSubject {
+				t.Fatalf("got subject %q, want %q", w.Body.String(), tt.wantSubject)
+			}
+			if got := w.Header().Get("access_token"); (got != "") != tt.wantAccess {
+				t.Fatalf("got access token header %q, want one: %v", got, tt.wantAccess)
+			}
+		})
+	}
+}
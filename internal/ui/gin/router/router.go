@@ -9,22 +9,26 @@ import (
 	"github.com/gin-gonic/gin"
 	spec "github.com/khedhrije/podcaster-backoffice-api/deployments/swagger"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/port"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/ui/gin/handlers"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 // CreateRouter sets up and returns a new Gin router with the defined routes.
-func CreateRouter(wall handlers.Wall, block handlers.Block, program handlers.Program, episode handlers.Episode, media handlers.Media, tag handlers.Tag, category handlers.Category) *gin.Engine {
+func CreateRouter(wall handlers.Wall, block handlers.Block, program handlers.Program, episode handlers.Episode, media handlers.Media, tag handlers.Tag, category handlers.Category, search handlers.Search, wallTree handlers.WallTree, audit handlers.Audit, feed handlers.Feed, feedImport handlers.FeedImport, bulk handlers.Bulk, verifier port.TokenVerifier, refresher port.TokenRefresher) *gin.Engine {
 	// Initialize a new Gin router without any middleware by default.
 	r := gin.New()
 
+	// Let handlers read the values of the request context, such as the claims of the caller, from the gin context.
+	r.ContextWithFallback = true
+
 	// Customize CORS configuration if needed
 	corsConfig := cors.Config{
 		AllowOrigins:     []string{"*"}, // Change this to specific domains if needed
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", refreshTokenHeader, requestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", accessTokenHeader, refreshTokenHeader, requestIDHeader},
 		AllowCredentials: true,
 		AllowOriginFunc: func(origin string) bool {
 			return true
@@ -35,6 +39,9 @@ func CreateRouter(wall handlers.Wall, block handlers.Block, program handlers.Pro
 	// Apply the CORS middleware with the custom configuration
 	r.Use(cors.New(corsConfig))
 
+	// Identify every request, so that the audit log can tell which request performed an operation.
+	r.Use(RequestIDMiddleware())
+
 	// Health check route.
 	r.GET("/health", health())
 
@@ -44,9 +51,16 @@ func CreateRouter(wall handlers.Wall, block handlers.Block, program handlers.Pro
 	// Set up the route for Swagger documentation.
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
-	// Define private routes that require authentication.
+	// Define public, read-only routes serving the front-office.
+	public := r.Group("/public")
+	{
+		public.GET("/walls/:uuid/tree", wallTree.FindPublic())
+		public.GET("/programs/:uuid/feed.xml", feed.FindByProgram())
+	}
+
+	// Define private routes that require authentication, and a role allowed by the private policy.
 	private := r.Group("/private")
-	private.Use(TokenValidatorMiddleware())
+	private.Use(TokenValidatorMiddleware(verifier, refresher), AuthorizationMiddleware(privatePolicy))
 	{
 		// Routes for managing walls.
 		walls := private.Group("/walls")
@@ -56,8 +70,14 @@ func CreateRouter(wall handlers.Wall, block handlers.Block, program handlers.Pro
 			walls.GET("/:uuid", wall.Find())
 			walls.GET("", wall.FindAll())
 			walls.DELETE("/:uuid", wall.Delete())
+			walls.POST("/:uuid/restore", wall.Restore())
+			walls.GET("/trash", wall.FindDeleted())
 			walls.GET("/:uuid/blocks", wall.FindBlocks())
+			walls.GET("/:uuid/tree", wallTree.Find())
 			walls.PUT("/:uuid/blocks/overwrite", wall.OverwriteBlocks())
+			walls.PATCH("/:uuid/blocks/reorder", wall.ReorderBlocks())
+			walls.POST("/:uuid/blocks/:blockUUID", wall.AddBlock())
+			walls.DELETE("/:uuid/blocks/:blockUUID", wall.RemoveBlock())
 		}
 
 		// Routes for managing blocks.
@@ -68,23 +88,39 @@ func CreateRouter(wall handlers.Wall, block handlers.Block, program handlers.Pro
 			blocks.GET("/:uuid", block.Find())
 			blocks.GET("", block.FindAll())
 			blocks.DELETE("/:uuid", block.Delete())
+			blocks.POST("/:uuid/restore", block.Restore())
+			blocks.GET("/trash", block.FindDeleted())
 			blocks.GET("/:uuid/programs", block.FindPrograms())
 			blocks.PUT("/:uuid/programs/overwrite", block.OverwritePrograms())
+			blocks.PATCH("/:uuid/programs/reorder", block.ReorderPrograms())
+			blocks.POST("/:uuid/programs/:programUUID", block.AddProgram())
+			blocks.DELETE("/:uuid/programs/:programUUID", block.RemoveProgram())
 		}
 
 		// Routes for managing programs.
 		programs := private.Group("/programs")
 		{
 			programs.POST("", program.Create())
+			programs.POST("/import", feedImport.Import())
 			programs.PUT("/:uuid", program.Update())
 			programs.GET("/:uuid", program.Find())
 			programs.GET("", program.FindAll())
 			programs.DELETE("/:uuid", program.Delete())
+			programs.POST("/:uuid/restore", program.Restore())
+			programs.GET("/trash", program.FindDeleted())
 			programs.GET("/:uuid/episodes", program.FindEpisodes())
 			programs.GET("/:uuid/tags", program.FindTags())
 			programs.GET("/:uuid/categories", program.FindCategories())
 			programs.PUT("/:uuid/tags/overwrite", program.OverwriteTags())
 			programs.PUT("/:uuid/categories/overwrite", program.OverwriteCategories())
+			programs.PATCH("/:uuid/episodes/reorder", program.ReorderEpisodes())
+			programs.POST("/:uuid/tags/:tagUUID", program.AddTag())
+			programs.DELETE("/:uuid/tags/:tagUUID", program.RemoveTag())
+			programs.POST("/:uuid/categories/:categoryUUID", program.AddCategory())
+			programs.DELETE("/:uuid/categories/:categoryUUID", program.RemoveCategory())
+			programs.POST("/bulk", bulk.CreatePrograms())
+			programs.PUT("/bulk", bulk.UpdatePrograms())
+			programs.DELETE("/bulk", bulk.DeletePrograms())
 		}
 
 		// Routes for managing episodes.
@@ -95,16 +131,31 @@ func CreateRouter(wall handlers.Wall, block handlers.Block, program handlers.Pro
 			episodes.GET("/:uuid", episode.Find())
 			episodes.GET("", episode.FindAll())
 			episodes.DELETE("/:uuid", episode.Delete())
+			episodes.POST("/:uuid/restore", episode.Restore())
+			episodes.GET("/trash", episode.FindDeleted())
+			episodes.POST("/:uuid/publish", episode.Publish())
+			episodes.POST("/:uuid/schedule", episode.Schedule())
+			episodes.POST("/:uuid/unpublish", episode.Unpublish())
+			episodes.POST("/:uuid/draft", episode.Draft())
+			episodes.POST("/bulk", bulk.CreateEpisodes())
+			episodes.PUT("/bulk", bulk.UpdateEpisodes())
+			episodes.DELETE("/bulk", bulk.DeleteEpisodes())
 		}
 
 		// Routes for managing media.
 		mediaRoutes := private.Group("/medias")
 		{
 			mediaRoutes.POST("", media.Create())
+			mediaRoutes.POST("/upload", media.Upload())
 			mediaRoutes.PUT("/:uuid", media.Update())
 			mediaRoutes.GET("/:uuid", media.Find())
 			mediaRoutes.GET("", media.FindAll())
 			mediaRoutes.DELETE("/:uuid", media.Delete())
+			mediaRoutes.POST("/:uuid/restore", media.Restore())
+			mediaRoutes.GET("/trash", media.FindDeleted())
+			mediaRoutes.POST("/bulk", bulk.CreateMedias())
+			mediaRoutes.PUT("/bulk", bulk.UpdateMedias())
+			mediaRoutes.DELETE("/bulk", bulk.DeleteMedias())
 		}
 
 		// Routes for managing tags.
@@ -115,7 +166,12 @@ func CreateRouter(wall handlers.Wall, block handlers.Block, program handlers.Pro
 			tags.GET("/:uuid", tag.Find())
 			tags.GET("", tag.FindAll())
 			tags.DELETE("/:uuid", tag.Delete())
+			tags.POST("/:uuid/restore", tag.Restore())
+			tags.GET("/trash", tag.FindDeleted())
 			tags.GET("/:uuid/programs", tag.FindPrograms())
+			tags.POST("/bulk", bulk.CreateTags())
+			tags.PUT("/bulk", bulk.UpdateTags())
+			tags.DELETE("/bulk", bulk.DeleteTags())
 		}
 
 		// Routes for managing categories.
@@ -123,11 +179,23 @@ func CreateRouter(wall handlers.Wall, block handlers.Block, program handlers.Pro
 		{
 			categories.POST("", category.Create())
 			categories.PUT("/:uuid", category.Update())
+			categories.POST("/:uuid/move", category.Move())
 			categories.GET("/:uuid", category.Find())
 			categories.GET("", category.FindAll())
 			categories.DELETE("/:uuid", category.Delete())
+			categories.POST("/:uuid/restore", category.Restore())
+			categories.GET("/trash", category.FindDeleted())
 			categories.GET("/:uuid/programs", category.FindPrograms())
+			categories.GET("/tree", category.FindTree())
+			categories.GET("/:uuid/ancestors", category.FindAncestors())
+			categories.GET("/:uuid/descendants", category.FindDescendants())
 		}
+
+		// Route for searching the catalogue.
+		private.GET("/search", search.Search())
+
+		// Route for reading the audit log.
+		private.GET("/audit", audit.FindAll())
 	}
 
 	// Return the configured router.
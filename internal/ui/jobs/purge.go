@@ -0,0 +1,56 @@
+// Package jobs provides the background jobs running alongside the HTTP server.
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/api"
+	"github.com/rs/zerolog/log"
+)
+
+// Purge periodically removes the catalogue entities that stayed in the trash longer than the retention.
+type Purge struct {
+	trash  api.Trash
+	config configuration.Trash
+}
+
+// NewPurge creates a new instance of Purge.
+// It takes the Trash API and the retention settings, and returns nil when the purge job is disabled.
+func NewPurge(trash api.Trash, config configuration.Trash) *Purge {
+	if config.PurgeInterval <= 0 {
+		return nil
+	}
+	return &Purge{
+		trash:  trash,
+		config: config,
+	}
+}
+
+// Run purges the trash right away, then once per interval until the context is done.
+func (p *Purge) Run(ctx context.Context) {
+	ctx = log.Logger.WithContext(ctx)
+	ticker := time.NewTicker(p.config.PurgeInterval)
+	defer ticker.Stop()
+	for {
+		p.purge(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge removes the entities moved to the trash longer than the retention before now.
+// Failures are logged and left to the next run.
+func (p *Purge) purge(ctx context.Context, now time.Time) {
+	before := now.Add(-p.config.Retention)
+	count, err := p.trash.Purge(ctx, before)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("purged", count).Msg("error while purging the trash")
+		return
+	}
+	log.Ctx(ctx).Info().Int("purged", count).Time("before", before).Msg("trash purged")
+}
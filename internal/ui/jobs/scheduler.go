@@ -0,0 +1,58 @@
+// Package jobs provides the background jobs running alongside the HTTP server.
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
+	"github.com/khedhrije/podcaster-backoffice-api/internal/domain/api"
+	"github.com/rs/zerolog/log"
+)
+
+// Scheduler periodically publishes the scheduled episodes whose publication time is reached.
+type Scheduler struct {
+	episode api.Episode
+	config  configuration.Publication
+}
+
+// NewScheduler creates a new instance of Scheduler.
+// It takes the Episode API and the publication settings, and returns nil when the scheduler is disabled.
+func NewScheduler(episode api.Episode, config configuration.Publication) *Scheduler {
+	if config.ScheduleInterval <= 0 {
+		return nil
+	}
+	return &Scheduler{
+		episode: episode,
+		config:  config,
+	}
+}
+
+// Run publishes the due episodes right away, then once per interval until the context is done.
+// Episodes are thus published at most one interval after their publication time.
+func (s *Scheduler) Run(ctx context.Context) {
+	ctx = log.Logger.WithContext(ctx)
+	ticker := time.NewTicker(s.config.ScheduleInterval)
+	defer ticker.Stop()
+	for {
+		s.publish(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish publishes the episodes scheduled at or before now.
+// Failures are logged and left to the next run.
+func (s *Scheduler) publish(ctx context.Context, now time.Time) {
+	changed, err := s.episode.PublishDue(ctx, now)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("changed", len(changed)).Msg("error while publishing scheduled episodes")
+		return
+	}
+	if len(changed) > 0 {
+		log.Ctx(ctx).Info().Int("changed", len(changed)).Time("now", now).Msg("scheduled episodes published")
+	}
+}
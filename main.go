@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/bootstrap"
 	"github.com/khedhrije/podcaster-backoffice-api/internal/configuration"
 	"github.com/rs/zerolog/log"
+	"os"
 )
 
 // @title           podcaster-backoffice-api
@@ -25,6 +27,23 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and a valid API key.
 func main() {
+	// Run the schema migration subcommand instead of the server when requested:
+	// `main migrate [up|down [steps]|status]`
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := bootstrap.Migrate(context.Background(), os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("migration failed")
+		}
+		return
+	}
+
+	// Run the feed import subcommand instead of the server when requested: `main import <url|path>`
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := bootstrap.Import(context.Background(), os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("import failed")
+		}
+		return
+	}
+
 	// Initialize the bootstrap process, which sets up the application
 	log.Info().
 		Interface("app", configuration.Config.Name).
@@ -0,0 +1,118 @@
+// Package pkg provides the structures of the podcast feeds rendered by the API.
+package pkg
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Namespaces of the extensions used by the podcast feeds.
+const (
+	FeedItunesNamespace  = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+	FeedPodcastNamespace = "https://podcastindex.org/namespace/1.0"
+	FeedAtomNamespace    = "http://www.w3.org/2005/Atom"
+)
+
+// FeedResponse represents the RSS 2.0 podcast feed of a program, with the iTunes and Podcasting 2.0 extensions.
+type FeedResponse struct {
+	XMLName      xml.Name    `xml:"rss"`
+	Version      string      `xml:"version,attr"`
+	ItunesNS     string      `xml:"xmlns:itunes,attr"`
+	PodcastNS    string      `xml:"xmlns:podcast,attr"`
+	AtomNS       string      `xml:"xmlns:atom,attr"`
+	Channel      FeedChannel `xml:"channel"`
+	LastModified time.Time   `xml:"-"` // Last time the program, its episodes, their medias or its categories changed
+}
+
+// FeedChannel represents the channel of a podcast feed, describing the program.
+type FeedChannel struct {
+	AtomLink         *FeedAtomLink        `xml:"atom:link,omitempty"`
+	Title            string               `xml:"title"`
+	Link             string               `xml:"link"`
+	Description      string               `xml:"description"`
+	Language         string               `xml:"language,omitempty"`
+	LastBuildDate    string               `xml:"lastBuildDate,omitempty"`
+	Categories       []string             `xml:"category"`
+	Image            *FeedImage           `xml:"image,omitempty"`
+	ItunesAuthor     string               `xml:"itunes:author,omitempty"`
+	ItunesOwner      *FeedOwner           `xml:"itunes:owner,omitempty"`
+	ItunesImage      *FeedItunesImage     `xml:"itunes:image,omitempty"`
+	ItunesCategories []FeedItunesCategory `xml:"itunes:category"`
+	ItunesExplicit   bool                 `xml:"itunes:explicit"`
+	ItunesType       string               `xml:"itunes:type"`
+	PodcastGUID      string               `xml:"podcast:guid,omitempty"`
+	Items            []FeedItem           `xml:"item"`
+}
+
+// FeedAtomLink represents the link of a feed to itself.
+type FeedAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// FeedImage represents the RSS image of a channel.
+type FeedImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+// FeedOwner represents the contact of the owner of a podcast.
+type FeedOwner struct {
+	Name  string `xml:"itunes:name,omitempty"`
+	Email string `xml:"itunes:email,omitempty"`
+}
+
+// FeedItunesImage represents the artwork of a podcast or of an episode.
+type FeedItunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+// FeedItunesCategory represents an iTunes category, optionally refined by a subcategory.
+type FeedItunesCategory struct {
+	Text        string              `xml:"text,attr"`
+	Subcategory *FeedItunesCategory `xml:"itunes:category,omitempty"`
+}
+
+// FeedItem represents an episode of a podcast feed.
+type FeedItem struct {
+	Title               string                   `xml:"title"`
+	Description         string                   `xml:"description,omitempty"`
+	GUID                FeedGUID                 `xml:"guid"`
+	PubDate             string                   `xml:"pubDate"`
+	Enclosure           FeedEnclosure            `xml:"enclosure"`
+	ItunesTitle         string                   `xml:"itunes:title"`
+	ItunesDuration      int64                    `xml:"itunes:duration,omitempty"`
+	ItunesEpisode       int                      `xml:"itunes:episode,omitempty"`
+	ItunesImage         *FeedItunesImage         `xml:"itunes:image,omitempty"`
+	AlternateEnclosures []FeedAlternateEnclosure `xml:"podcast:alternateEnclosure"`
+}
+
+// FeedGUID represents the globally unique identifier of an episode.
+type FeedGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// FeedEnclosure represents the media file of an episode.
+type FeedEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// FeedAlternateEnclosure represents one of the media files of an episode offering several.
+type FeedAlternateEnclosure struct {
+	Type    string     `xml:"type,attr"`
+	Length  int64      `xml:"length,attr,omitempty"`
+	Bitrate int        `xml:"bitrate,attr,omitempty"`
+	Title   string     `xml:"title,attr,omitempty"`
+	Default bool       `xml:"default,attr,omitempty"`
+	Source  FeedSource `xml:"podcast:source"`
+}
+
+// FeedSource represents a location an alternate enclosure is served from.
+type FeedSource struct {
+	URI string `xml:"uri,attr"`
+}
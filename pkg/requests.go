@@ -1,6 +1,12 @@
 // Package pkg provides the request structs for handling JSON requests.
 package pkg
 
+import (
+	"io"
+	"mime/multipart"
+	"time"
+)
+
 // CreateWallRequestJSON represents a JSON request for creating walls.
 type CreateWallRequestJSON struct {
 	NameJSON        string `json:"name"`
@@ -141,6 +147,66 @@ func (req UpdateMediaRequestJSON) EpisodeID() string {
 	return req.EpisodeIDJSON
 }
 
+// UploadMediaRequestJSON represents a multipart form request for creating medias from an uploaded file,
+// whose content is read from the file part as it is received.
+type UploadMediaRequestJSON struct {
+	KindJSON        string
+	EpisodeIDJSON   string
+	FileNameJSON    string
+	ContentTypeJSON string
+	FileJSON        io.Reader
+}
+
+// Kind returns the kind of the uploaded media.
+func (req UploadMediaRequestJSON) Kind() string {
+	return req.KindJSON
+}
+
+// EpisodeID returns the episode ID of the uploaded media.
+func (req UploadMediaRequestJSON) EpisodeID() string {
+	return req.EpisodeIDJSON
+}
+
+// FileName returns the name of the uploaded file, empty when no file was sent.
+func (req UploadMediaRequestJSON) FileName() string {
+	return req.FileNameJSON
+}
+
+// ContentType returns the content type the client declared for the uploaded file.
+func (req UploadMediaRequestJSON) ContentType() string {
+	return req.ContentTypeJSON
+}
+
+// File returns the reader of the uploaded file.
+func (req UploadMediaRequestJSON) File() io.Reader {
+	return req.FileJSON
+}
+
+// ImportFeedRequestJSON represents a multipart form request for importing a podcast feed,
+// either downloaded from a URL or uploaded as a file.
+type ImportFeedRequestJSON struct {
+	URLJSON  string                `form:"url"`
+	FileJSON *multipart.FileHeader `form:"file" swaggerignore:"true"`
+}
+
+// URL returns the URL the feed is downloaded from, empty when the feed is uploaded.
+func (req ImportFeedRequestJSON) URL() string {
+	return req.URLJSON
+}
+
+// FileName returns the name of the uploaded feed, empty when no file was sent.
+func (req ImportFeedRequestJSON) FileName() string {
+	if req.FileJSON == nil {
+		return ""
+	}
+	return req.FileJSON.Filename
+}
+
+// Open opens the uploaded feed for reading.
+func (req ImportFeedRequestJSON) Open() (io.ReadCloser, error) {
+	return req.FileJSON.Open()
+}
+
 // CreateCategoryRequestJSON represents a JSON request for creating categories.
 type CreateCategoryRequestJSON struct {
 	NameJSON        string `json:"name"`
@@ -285,6 +351,16 @@ func (req UpdateEpisodeRequestJSON) Position() int {
 	return req.PositionJSON
 }
 
+// ScheduleEpisodeRequestJSON represents a JSON request for scheduling the publication of episodes.
+type ScheduleEpisodeRequestJSON struct {
+	PublishAtJSON time.Time `json:"publishAt" description:"RFC 3339 time the episode is to be published at, in the future"`
+}
+
+// PublishAt returns the time the episode is to be published at.
+func (req ScheduleEpisodeRequestJSON) PublishAt() time.Time {
+	return req.PublishAtJSON
+}
+
 // OverwriteBlocksRequestJSON represents a JSON request for overwriting blocks in a wall.
 type OverwriteBlocksRequestJSON struct {
 	WallIDJSON        string         `json:"wallID"`
@@ -316,3 +392,190 @@ func (req OverwriteProgramsRequestJSON) BlockID() string {
 func (req OverwriteProgramsRequestJSON) OrderedPrograms() map[string]int {
 	return req.OrderedProgramsJSON
 }
+
+// ReorderRequestJSON represents a JSON request for reordering the items of a collection, either by listing
+// every item in its new order, or by moving the item at a position to another.
+type ReorderRequestJSON struct {
+	IDsJSON  []string `json:"ids,omitempty" description:"IDs of every item of the collection in their new order"`
+	FromJSON int      `json:"from,omitempty" description:"position of the item to move, starting at 1, when ids is not given"`
+	ToJSON   int      `json:"to,omitempty" description:"position to move the item to, starting at 1, when ids is not given"`
+}
+
+// IDs returns the IDs of the items in their new order.
+func (req ReorderRequestJSON) IDs() []string {
+	return req.IDsJSON
+}
+
+// From returns the position of the item to move.
+func (req ReorderRequestJSON) From() int {
+	return req.FromJSON
+}
+
+// To returns the position to move the item to.
+func (req ReorderRequestJSON) To() int {
+	return req.ToJSON
+}
+
+// ListRequestJSON represents the query parameters for listing a collection one page at a time.
+type ListRequestJSON struct {
+	LimitJSON       int       `form:"limit"`
+	OffsetJSON      int       `form:"offset"`
+	SortJSON        string    `form:"sort"`
+	NameJSON        string    `form:"name"`
+	CreatedFromJSON time.Time `form:"createdFrom" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedToJSON   time.Time `form:"createdTo" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// Limit returns the maximum number of items of the list request.
+func (req ListRequestJSON) Limit() int {
+	return req.LimitJSON
+}
+
+// Offset returns the number of items to skip of the list request.
+func (req ListRequestJSON) Offset() int {
+	return req.OffsetJSON
+}
+
+// Sort returns the field to sort on of the list request, prefixed with "-" for descending order.
+func (req ListRequestJSON) Sort() string {
+	return req.SortJSON
+}
+
+// NameContains returns the text the names of the listed items must contain.
+func (req ListRequestJSON) NameContains() string {
+	return req.NameJSON
+}
+
+// CreatedFrom returns the time the listed items must be created at or after.
+func (req ListRequestJSON) CreatedFrom() time.Time {
+	return req.CreatedFromJSON
+}
+
+// CreatedTo returns the time the listed items must be created at or before.
+func (req ListRequestJSON) CreatedTo() time.Time {
+	return req.CreatedToJSON
+}
+
+// SearchRequestJSON represents the query parameters of a catalogue search.
+type SearchRequestJSON struct {
+	QueryJSON string `form:"q"`
+	LimitJSON int    `form:"limit"`
+}
+
+// Query returns the text to search for.
+func (req SearchRequestJSON) Query() string {
+	return req.QueryJSON
+}
+
+// Limit returns the maximum number of hits of each kind of the search request.
+func (req SearchRequestJSON) Limit() int {
+	return req.LimitJSON
+}
+
+// AuditRequestJSON represents the query parameters for listing the audit log one page at a time.
+type AuditRequestJSON struct {
+	LimitJSON    int    `form:"limit"`
+	OffsetJSON   int    `form:"offset"`
+	EntityJSON   string `form:"entity"`
+	EntityIDJSON string `form:"entityID"`
+	ActorJSON    string `form:"actor"`
+}
+
+// Limit returns the maximum number of entries of the audit request.
+func (req AuditRequestJSON) Limit() int {
+	return req.LimitJSON
+}
+
+// Offset returns the number of entries to skip of the audit request.
+func (req AuditRequestJSON) Offset() int {
+	return req.OffsetJSON
+}
+
+// Entity returns the kind of entity the listed entries must be about.
+func (req AuditRequestJSON) Entity() string {
+	return req.EntityJSON
+}
+
+// EntityID returns the identifier of the entity the listed entries must be about.
+func (req AuditRequestJSON) EntityID() string {
+	return req.EntityIDJSON
+}
+
+// Actor returns the actor the listed entries must be recorded for.
+func (req AuditRequestJSON) Actor() string {
+	return req.ActorJSON
+}
+
+// BulkUpdateProgramRequestJSON represents a JSON request for updating one program of a bulk update.
+type BulkUpdateProgramRequestJSON struct {
+	IDJSON string `json:"ID"`
+	UpdateProgramRequestJSON
+}
+
+// ID returns the ID of the program to update.
+func (req BulkUpdateProgramRequestJSON) ID() string {
+	return req.IDJSON
+}
+
+// BulkUpdateEpisodeRequestJSON represents a JSON request for updating one episode of a bulk update.
+type BulkUpdateEpisodeRequestJSON struct {
+	IDJSON string `json:"ID"`
+	UpdateEpisodeRequestJSON
+}
+
+// ID returns the ID of the episode to update.
+func (req BulkUpdateEpisodeRequestJSON) ID() string {
+	return req.IDJSON
+}
+
+// BulkUpdateMediaRequestJSON represents a JSON request for updating one media of a bulk update.
+type BulkUpdateMediaRequestJSON struct {
+	IDJSON string `json:"ID"`
+	UpdateMediaRequestJSON
+}
+
+// ID returns the ID of the media to update.
+func (req BulkUpdateMediaRequestJSON) ID() string {
+	return req.IDJSON
+}
+
+// BulkUpdateTagRequestJSON represents a JSON request for updating one tag of a bulk update.
+type BulkUpdateTagRequestJSON struct {
+	IDJSON string `json:"ID"`
+	UpdateTagRequestJSON
+}
+
+// ID returns the ID of the tag to update.
+func (req BulkUpdateTagRequestJSON) ID() string {
+	return req.IDJSON
+}
+
+// DeleteRequestJSON represents the query parameters for deleting an entity other entities may still reference.
+type DeleteRequestJSON struct {
+	CascadeJSON bool `form:"cascade"`
+}
+
+// Cascade returns whether the references to the deleted entity are removed along with it.
+func (req DeleteRequestJSON) Cascade() bool {
+	return req.CascadeJSON
+}
+
+// MoveCategoryRequestJSON represents a JSON request for moving a category under another parent.
+type MoveCategoryRequestJSON struct {
+	ParentIDJSON string `json:"parentID"`
+}
+
+// ParentID returns the ID of the new parent of the category, empty to make it a root category.
+func (req MoveCategoryRequestJSON) ParentID() string {
+	return req.ParentIDJSON
+}
+
+// FindCategoryProgramsRequestJSON represents the query parameters for finding the programs of a category.
+type FindCategoryProgramsRequestJSON struct {
+	DescendantsJSON bool `form:"descendants"`
+}
+
+// Descendants returns whether the programs of every descendant category are included.
+func (req FindCategoryProgramsRequestJSON) Descendants() bool {
+	return req.DescendantsJSON
+}
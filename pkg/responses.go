@@ -1,63 +1,132 @@
 // Package pkg provides the response structs for handling JSON responses.
 package pkg
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // ErrorJSON represents the structure for error messages in JSON responses.
 type ErrorJSON struct {
-	Error string `json:"error" description:"error message"`
+	Error      string             `json:"error" description:"error message"`
+	Details    []FieldErrorJSON   `json:"details,omitempty" description:"per-field validation errors"`
+	Dependents []DependentJSON    `json:"dependents,omitempty" description:"entities still referencing the entity that could not be deleted"`
+	Failed     []BulkItemResponse `json:"failed,omitempty" description:"items of a bulk operation that failed, none of the items being saved"`
+}
+
+// FieldErrorJSON represents the validation error of a single request field.
+type FieldErrorJSON struct {
+	Field   string `json:"field" description:"name of the invalid field"`
+	Message string `json:"message" description:"what is wrong with the field"`
+}
+
+// DependentJSON represents an entity still referencing the entity that could not be deleted.
+type DependentJSON struct {
+	Entity string `json:"entity" description:"kind of the dependent entity"`
+	ID     string `json:"ID"`
+	Name   string `json:"name"`
+}
+
+// BulkResponse represents the response structure for bulk operations.
+type BulkResponse struct {
+	Items []BulkItemResponse `json:"items" description:"result of each item, in the order of the request"`
+}
+
+// BulkItemResponse represents the result of a single item of a bulk operation.
+type BulkItemResponse struct {
+	Index   int              `json:"index" description:"position of the item in the request, starting at 0"`
+	ID      string           `json:"ID,omitempty" description:"ID of the created, updated or deleted entity"`
+	Error   string           `json:"error,omitempty" description:"why the item failed"`
+	Details []FieldErrorJSON `json:"details,omitempty" description:"per-field validation errors of the item"`
+}
+
+// PageResponse represents the envelope of a page of a collection.
+type PageResponse[T any] struct {
+	Items    []T    `json:"items" description:"items of the page"`
+	Total    int    `json:"total" description:"number of items matching the filters, across all pages"`
+	Limit    int    `json:"limit" description:"maximum number of items per page"`
+	Offset   int    `json:"offset" description:"number of items skipped before this page"`
+	Next     string `json:"next,omitempty" description:"link to the next page, absent on the last page"`
+	Previous string `json:"previous,omitempty" description:"link to the previous page, absent on the first page"`
 }
 
 // WallResponse represents the response structure for walls.
 type WallResponse struct {
-	ID          string `json:"ID"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID          string     `json:"ID"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty" description:"time the item was moved to the trash, only set in trash listings"`
 }
 
 // TagResponse represents the response structure for tags.
 type TagResponse struct {
-	ID          string `json:"ID"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID          string     `json:"ID"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty" description:"time the item was moved to the trash, only set in trash listings"`
 }
 
 // ProgramResponse represents the response structure for programs.
 type ProgramResponse struct {
-	ID          string `json:"ID"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID          string     `json:"ID"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty" description:"time the item was moved to the trash, only set in trash listings"`
 }
 
 // MediaResponse represents the response structure for medias.
 type MediaResponse struct {
-	ID         string `json:"ID"`
-	DirectLink string `json:"directLink"`
-	Kind       string `json:"kind"`
-	EpisodeID  string `json:"episodeID"`
+	ID         string     `json:"ID"`
+	DirectLink string     `json:"directLink"`
+	Kind       string     `json:"kind"`
+	EpisodeID  string     `json:"episodeID"`
+	Size       int64      `json:"size,omitempty" description:"size of the uploaded file in bytes"`
+	Checksum   string     `json:"checksum,omitempty" description:"hex encoded SHA-256 digest of the uploaded file"`
+	MimeType   string     `json:"mimeType,omitempty" description:"MIME type of the uploaded file"`
+	Codec      string     `json:"codec,omitempty" description:"codec of the audio stream, empty when the file could not be probed"`
+	DurationMs int64      `json:"durationMs,omitempty" description:"duration of the audio stream in milliseconds"`
+	Bitrate    int        `json:"bitrate,omitempty" description:"average bitrate of the audio stream in bits per second"`
+	Channels   int        `json:"channels,omitempty" description:"number of audio channels"`
+	SampleRate int        `json:"sampleRate,omitempty" description:"sample rate of the audio stream in hertz"`
+	Title      string     `json:"title,omitempty" description:"title embedded in the file tags"`
+	Artwork    string     `json:"artwork,omitempty" description:"direct link to the artwork embedded in the file tags"`
+	DeletedAt  *time.Time `json:"deletedAt,omitempty" description:"time the item was moved to the trash, only set in trash listings"`
 }
 
 // CategoryResponse represents the response structure for categories.
 type CategoryResponse struct {
-	ID          string `json:"ID"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	ParentID    string `json:"parentID"`
+	ID          string     `json:"ID"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	ParentID    string     `json:"parentID"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty" description:"time the item was moved to the trash, only set in trash listings"`
+}
+
+// CategoryTreeResponse represents the response structure for a category along with its nested subcategories.
+type CategoryTreeResponse struct {
+	CategoryResponse
+	Children []*CategoryTreeResponse `json:"children"`
 }
 
 // BlockResponse represents the response structure for blocks.
 type BlockResponse struct {
-	ID          string `json:"ID"`
-	Name        string `json:"name"`
-	Kind        string `json:"kind"`
-	Description string `json:"description"`
+	ID          string     `json:"ID"`
+	Name        string     `json:"name"`
+	Kind        string     `json:"kind"`
+	Description string     `json:"description"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty" description:"time the item was moved to the trash, only set in trash listings"`
 }
 
 // EpisodeResponse represents the response structure for episodes.
 type EpisodeResponse struct {
-	ID          string `json:"ID"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	ProgramID   string `json:"programID"`
-	Position    int    `json:"position"`
+	ID          string     `json:"ID"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	ProgramID   string     `json:"programID"`
+	Position    int        `json:"position"`
+	Status      string     `json:"status" description:"stage of the episode in the publication workflow: draft, scheduled, published or unpublished"`
+	PublishAt   *time.Time `json:"publishAt,omitempty" description:"time the episode is scheduled for or was published at, unset for drafts"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty" description:"time the item was moved to the trash, only set in trash listings"`
 }
 
 // WallBlocksResponse represents the response structure for blocks within a wall,
@@ -73,3 +142,74 @@ type BlockProgramsResponse struct {
 	ProgramResponse
 	Position int `json:"position"`
 }
+
+// WallTreeResponse represents the response structure for a wall along with its whole content,
+// as rendered by the front-office.
+type WallTreeResponse struct {
+	WallResponse
+	Blocks []*WallTreeBlockResponse `json:"blocks"`
+}
+
+// WallTreeBlockResponse represents the response structure for a block within a wall tree,
+// including its ordered programs.
+type WallTreeBlockResponse struct {
+	WallBlocksResponse
+	Programs []*WallTreeProgramResponse `json:"programs"`
+}
+
+// WallTreeProgramResponse represents the response structure for a program within a wall tree,
+// including its ordered episodes.
+type WallTreeProgramResponse struct {
+	BlockProgramsResponse
+	Episodes []*WallTreeEpisodeResponse `json:"episodes"`
+}
+
+// WallTreeEpisodeResponse represents the response structure for an episode within a wall tree,
+// including its medias.
+type WallTreeEpisodeResponse struct {
+	EpisodeResponse
+	Medias []*MediaResponse `json:"medias"`
+}
+
+// SearchHitResponse represents the response structure for an entity matching a search.
+type SearchHitResponse struct {
+	ID          string  `json:"ID"`
+	Kind        string  `json:"kind"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Score       float64 `json:"score"`
+}
+
+// SearchResponse represents the response structure for a search, with hits grouped by kind
+// and ranked from the most to the least relevant.
+type SearchResponse struct {
+	Query      string               `json:"query"`
+	Programs   []*SearchHitResponse `json:"programs"`
+	Episodes   []*SearchHitResponse `json:"episodes"`
+	Tags       []*SearchHitResponse `json:"tags"`
+	Categories []*SearchHitResponse `json:"categories"`
+}
+
+// AuditEntryResponse represents the response structure for an entry of the audit log.
+type AuditEntryResponse struct {
+	ID        string          `json:"ID"`
+	Actor     string          `json:"actor" description:"subject of the access token of the caller"`
+	Action    string          `json:"action" description:"create, update, delete, restore, overwrite, import, publish, move, reorder, attach or detach"`
+	Entity    string          `json:"entity" description:"kind of the entity"`
+	EntityID  string          `json:"entityID"`
+	Field     string          `json:"field,omitempty" description:"associations overwritten by the overwrite action"`
+	Before    json.RawMessage `json:"before,omitempty" swaggertype:"object" description:"changed fields with their previous values, absent on creation"`
+	After     json.RawMessage `json:"after,omitempty" swaggertype:"object" description:"changed fields with their new values, absent on deletion"`
+	RequestID string          `json:"requestID"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// ImportResponse represents the response structure for the import of a podcast feed, summing up its changes.
+type ImportResponse struct {
+	ProgramID       string `json:"programID"`
+	ProgramCreated  bool   `json:"programCreated" description:"false when the feed was imported before and its program updated"`
+	EpisodesCreated int    `json:"episodesCreated"`
+	EpisodesUpdated int    `json:"episodesUpdated"`
+	MediasCreated   int    `json:"mediasCreated"`
+	TagsCreated     int    `json:"tagsCreated"`
+}